@@ -3,6 +3,9 @@ package abstract
 import (
 	"errors"
 	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
 	"strings"
 	"time"
 
@@ -27,6 +30,49 @@ type Asset struct {
 	Labels map[string]interface{} `yaml:"labels" json:"labels"`
 	// tags are flags used to simplify asset search
 	Tags []string `yaml:"tags" json:"tags"`
+	// users or groups accountable for the asset
+	Owners []string `yaml:"owners" json:"owners,omitempty"`
+	// user in charge of the quality and documentation of the asset
+	Steward string `yaml:"steward" json:"steward,omitempty"`
+	// business domain and team the asset belongs to
+	Domain string `yaml:"domain" json:"domain,omitempty"`
+	Team   string `yaml:"team" json:"team,omitempty"`
+	// contact for questions on the asset, an email address or a url (e.g. a chat channel)
+	Contact string `yaml:"contact" json:"contact,omitempty"`
+	// sensitivity of the asset, internal if empty, while columns are classified in the schema label
+	Classification Classification `yaml:"classification" json:"classification,omitempty"`
+	// number of schema columns hidden to the caller because above its clearance - only added by service
+	RedactedColumns int `yaml:"-" json:"redacted-columns,omitempty"`
+	// name of the crawler which last discovered the asset, empty if manually added
+	DiscoveredBy string `yaml:"-" json:"discovered-by,omitempty"`
+	// asset no longer found by the crawler which discovered it since - only added by service
+	StaleSince *time.Time `yaml:"-" json:"stale-since,omitempty"`
+	// asset soft-deleted at, i.e. a tombstone hidden from lists and searches - only added by service
+	DeletedAt *time.Time `yaml:"-" json:"deleted-at,omitempty"`
+}
+
+// ErrAssetNotFound ... returned when retrieving or deleting an asset which does not exist
+var ErrAssetNotFound = errors.New("Asset not found")
+
+// IsDeleted ... returns true if the asset was soft-deleted
+func (asset *Asset) IsDeleted() bool {
+	return asset.DeletedAt != nil
+}
+
+// StaleCandidates ... returns the assets discovered by the crawler which are not in seen, nor already stale or deleted,
+// for DAOs marking stale assets in memory
+func StaleCandidates(assets []Asset, discoveredBy string, seen []string) []Asset {
+	seenNames := make(map[string]bool)
+	for _, name := range seen {
+		seenNames[name] = true
+	}
+	var candidates []Asset
+	for _, asset := range assets {
+		if asset.DiscoveredBy == discoveredBy && !seenNames[asset.Name] && asset.StaleSince == nil && !asset.IsDeleted() {
+			candidates = append(candidates, asset)
+		}
+	}
+	return candidates
 }
 
 // AssetType ... Asset type information
@@ -108,10 +154,67 @@ func (asset *Asset) Validate() error {
 	}
 
 	// validate optional fields if any available
+	if err := asset.validateOwnership(); err != nil {
+		return err
+	}
+	if err := asset.validateClassifications(); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+// identifier ... domains and teams are lower case identifiers, e.g. sales or data-platform
+var identifier = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]*$`)
+
+// validateOwnership ... validate the ownership fields, all optional
+func (asset *Asset) validateOwnership() error {
+	owners := make(map[string]bool)
+	for _, owner := range asset.Owners {
+		if len(strings.TrimSpace(owner)) == 0 {
+			return errors.New("Owners should not be empty")
+		}
+		if owners[owner] {
+			return fmt.Errorf("Owner %s is repeated", owner)
+		}
+		owners[owner] = true
+	}
+	if asset.Steward != "" && len(strings.TrimSpace(asset.Steward)) == 0 {
+		return errors.New("Steward should not be blank")
+	}
+	if asset.Domain != "" && !identifier.MatchString(asset.Domain) {
+		return fmt.Errorf("Domain %s should be a lower case identifier", asset.Domain)
+	}
+	if asset.Team != "" && !identifier.MatchString(asset.Team) {
+		return fmt.Errorf("Team %s should be a lower case identifier", asset.Team)
+	}
+	if asset.Contact != "" {
+		if _, err := mail.ParseAddress(asset.Contact); err != nil {
+			if u, err := url.Parse(asset.Contact); err != nil || u.Scheme == "" || u.Host == "" {
+				return fmt.Errorf("Contact %s should be an email address or a url", asset.Contact)
+			}
+		}
+	}
+	return nil
+}
+
+// HasTags ... returns true if the asset is tagged with all the provided tags, without regard of the order
+func (asset *Asset) HasTags(tags []string) bool {
+	for _, tag := range tags {
+		found := false
+		for _, assetTag := range asset.Tags {
+			if assetTag == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
 // Label types
 
 const (
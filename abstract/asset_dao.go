@@ -1,14 +1,42 @@
 package abstract
 
-import "github.com/pilillo/mastro/utils/conf"
+import (
+	"time"
+
+	"github.com/pilillo/mastro/utils/conf"
+)
 
 // AssetDAOProvider ... The interface each dao must implement
 type AssetDAOProvider interface {
-	Init(*conf.DataSourceDefinition)
+	// Init ... connects to the backend, returning a permanent error (see retry.Permanent) if the definition is invalid
+	Init(*conf.DataSourceDefinition) error
 	Upsert(asset *Asset) error
+	// GetById and GetByName ... return the asset, wrapping ErrAssetNotFound if missing
 	GetById(id string) (*Asset, error)
 	GetByName(id string) (*Asset, error)
 	SearchAssetsByTags(tags []string) (*[]Asset, error)
 	ListAllAssets() (*[]Asset, error)
+	// ListAssets ... returns a page of assets, with options already validated
+	ListAssets(opts *ListOptions) (*AssetPage, error)
+	// SearchAssets ... returns the assets matching a free text query and filters, with facet counts
+	SearchAssets(search *AssetSearch) (*AssetSearchResult, error)
+	// Delete ... removes the asset, returns ErrAssetNotFound if missing
+	Delete(name string) error
+	// SoftDelete ... marks the asset as deleted at the given time, returns ErrAssetNotFound if missing
+	SoftDelete(name string, at time.Time) error
+	// MarkStale ... marks as stale at the given time the assets discovered by the crawler, not in seen and not already
+	// stale or deleted, returning the number of marked assets
+	MarkStale(discoveredBy string, seen []string, at time.Time) (int, error)
+	// AddRevision ... appends the revision, numbering it after the last one of the asset,
+	// returns ErrRevisionExists if the number was concurrently taken
+	AddRevision(rev *AssetRevision) error
+	// ListRevisions ... returns the revisions of the asset by increasing number, possibly none
+	ListRevisions(name string) (*[]AssetRevision, error)
+	// GetRevision ... returns the given revision of the asset, nil if missing
+	GetRevision(name string, revision int) (*AssetRevision, error)
+	// GetLatestRevision ... returns the revision of the asset with the highest number, nil if none
+	GetLatestRevision(name string) (*AssetRevision, error)
+	// Ping ... returns an error if the backend is not reachable
+	Ping() error
 	CloseConnection()
 }
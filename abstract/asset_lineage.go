@@ -0,0 +1,331 @@
+package abstract
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// lineage directions, relative to the root asset
+const (
+	LineageRoot       = "root"
+	LineageUpstream   = "upstream"
+	LineageDownstream = "downstream"
+	LineageBoth       = "both"
+)
+
+const (
+	// DefaultLineageDepth ... depth used when no depth is requested
+	DefaultLineageDepth = 3
+	// MaxLineageDepth ... maximum depth, as each level requires a lookup per asset
+	MaxLineageDepth = 10
+)
+
+// LineageGraph ... the assets the root depends on (upstream) and depending on it (downstream), up to a depth
+type LineageGraph struct {
+	Root  string        `json:"root"`
+	Depth int           `json:"depth"`
+	Nodes []LineageNode `json:"nodes"`
+	Edges []LineageEdge `json:"edges"`
+	// Dangling ... names referenced in depends-on which do not exist in the catalogue
+	Dangling []string `json:"dangling,omitempty"`
+	// Cycles ... groups of assets depending on each other, within the visited graph
+	Cycles [][]string `json:"cycles,omitempty"`
+}
+
+// LineageNode ... an asset of the graph, at a distance from the root in the direction it was first reached
+type LineageNode struct {
+	Name      string    `json:"name"`
+	Type      AssetType `json:"type,omitempty"`
+	Direction string    `json:"direction"`
+	Distance  int       `json:"distance"`
+	Dangling  bool      `json:"dangling,omitempty"`
+	// Classification ... of the asset, used to hide the nodes above the clearance of the caller
+	Classification Classification `json:"classification,omitempty"`
+}
+
+// LineageEdge ... data flows from the upstream asset to the downstream one, i.e. To depends on From
+type LineageEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// ValidateLineage ... validates the direction and depth of a lineage request, returning the depth to use
+func ValidateLineage(direction string, depth int) (int, error) {
+	switch direction {
+	case LineageUpstream, LineageDownstream, LineageBoth:
+	default:
+		return 0, fmt.Errorf("Direction %s is not supported, use one of %s, %s or %s", direction, LineageUpstream, LineageDownstream, LineageBoth)
+	}
+	if depth == 0 {
+		depth = DefaultLineageDepth
+	}
+	if depth < 0 || depth > MaxLineageDepth {
+		return 0, fmt.Errorf("Depth should be between 1 and %d", MaxLineageDepth)
+	}
+	return depth, nil
+}
+
+// lineageBuilder ... state of the visit of the graph
+type lineageBuilder struct {
+	graph  *LineageGraph
+	nodes  map[string]*LineageNode
+	assets map[string]*Asset
+	edges  map[LineageEdge]bool
+}
+
+func (b *lineageBuilder) addNode(node LineageNode, asset *Asset) bool {
+	if _, exist := b.nodes[node.Name]; exist {
+		return false
+	}
+	if asset != nil {
+		node.Type = asset.Type
+		node.Classification = asset.Classification
+		b.assets[node.Name] = asset
+	}
+	b.nodes[node.Name] = &node
+	return true
+}
+
+// BuildLineage ... visits the lineage of the root asset in the given direction up to depth levels,
+// getByName is used to resolve the upstream assets, ErrAssetNotFound being considered a dangling reference,
+// while getDependents returns the assets directly depending on the given one
+func BuildLineage(
+	root *Asset,
+	direction string,
+	depth int,
+	getByName func(name string) (*Asset, error),
+	getDependents func(name string) ([]Asset, error),
+) (*LineageGraph, error) {
+	b := &lineageBuilder{
+		graph:  &LineageGraph{Root: root.Name, Depth: depth},
+		nodes:  make(map[string]*LineageNode),
+		assets: make(map[string]*Asset),
+		edges:  make(map[LineageEdge]bool),
+	}
+	b.addNode(LineageNode{Name: root.Name, Direction: LineageRoot}, root)
+
+	if direction == LineageUpstream || direction == LineageBoth {
+		level := []string{root.Name}
+		for distance := 1; distance <= depth && len(level) > 0; distance++ {
+			var next []string
+			for _, name := range level {
+				for _, dependency := range b.assets[name].DependsOn {
+					b.edges[LineageEdge{From: dependency, To: name}] = true
+					if _, exist := b.nodes[dependency]; exist {
+						continue
+					}
+					asset, err := getByName(dependency)
+					if errors.Is(err, ErrAssetNotFound) {
+						b.addNode(LineageNode{Name: dependency, Direction: LineageUpstream, Distance: distance, Dangling: true}, nil)
+						continue
+					}
+					if err != nil {
+						return nil, err
+					}
+					b.addNode(LineageNode{Name: dependency, Direction: LineageUpstream, Distance: distance}, asset)
+					next = append(next, dependency)
+				}
+			}
+			level = next
+		}
+	}
+
+	if direction == LineageDownstream || direction == LineageBoth {
+		level := []string{root.Name}
+		for distance := 1; distance <= depth && len(level) > 0; distance++ {
+			var next []string
+			for _, name := range level {
+				dependents, err := getDependents(name)
+				if err != nil {
+					return nil, err
+				}
+				for i := range dependents {
+					dependent := &dependents[i]
+					b.edges[LineageEdge{From: name, To: dependent.Name}] = true
+					if b.addNode(LineageNode{Name: dependent.Name, Direction: LineageDownstream, Distance: distance}, dependent) {
+						next = append(next, dependent.Name)
+					}
+				}
+			}
+			level = next
+		}
+	}
+
+	b.collect()
+	return b.graph, nil
+}
+
+// lineageDirectionOrder ... order of the nodes in the graph, root first
+var lineageDirectionOrder = map[string]int{LineageRoot: 0, LineageUpstream: 1, LineageDownstream: 2}
+
+// collect ... fills the graph with the visited nodes and edges in a deterministic order and detects cycles
+func (b *lineageBuilder) collect() {
+	g := b.graph
+	g.Nodes = []LineageNode{}
+	for _, node := range b.nodes {
+		g.Nodes = append(g.Nodes, *node)
+		if node.Dangling {
+			g.Dangling = append(g.Dangling, node.Name)
+		}
+	}
+	sort.Slice(g.Nodes, func(i, j int) bool {
+		a, b := g.Nodes[i], g.Nodes[j]
+		if a.Direction != b.Direction {
+			return lineageDirectionOrder[a.Direction] < lineageDirectionOrder[b.Direction]
+		}
+		if a.Distance != b.Distance {
+			return a.Distance < b.Distance
+		}
+		return a.Name < b.Name
+	})
+	sort.Strings(g.Dangling)
+
+	g.Edges = []LineageEdge{}
+	for e := range b.edges {
+		g.Edges = append(g.Edges, e)
+	}
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		return g.Edges[i].To < g.Edges[j].To
+	})
+	g.Cycles = findCycles(g.Edges)
+}
+
+// findCycles ... returns the strongly connected components with more than one asset or a self dependency,
+// using Tarjan's algorithm over the edges sorted by source
+func findCycles(edges []LineageEdge) [][]string {
+	successors := make(map[string][]string)
+	var names []string
+	for _, e := range edges {
+		if _, exist := successors[e.From]; !exist {
+			names = append(names, e.From)
+		}
+		successors[e.From] = append(successors[e.From], e.To)
+	}
+
+	index := make(map[string]int)
+	lowlink := make(map[string]int)
+	onStack := make(map[string]bool)
+	var stack []string
+	var cycles [][]string
+
+	var visit func(name string)
+	visit = func(name string) {
+		index[name] = len(index)
+		lowlink[name] = index[name]
+		stack = append(stack, name)
+		onStack[name] = true
+
+		selfLoop := false
+		for _, next := range successors[name] {
+			if next == name {
+				selfLoop = true
+			}
+			if _, visited := index[next]; !visited {
+				visit(next)
+				if lowlink[next] < lowlink[name] {
+					lowlink[name] = lowlink[next]
+				}
+			} else if onStack[next] && index[next] < lowlink[name] {
+				lowlink[name] = index[next]
+			}
+		}
+
+		if lowlink[name] == index[name] {
+			var component []string
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				component = append(component, top)
+				if top == name {
+					break
+				}
+			}
+			if len(component) > 1 || selfLoop {
+				sort.Strings(component)
+				cycles = append(cycles, component)
+			}
+		}
+	}
+	for _, name := range names {
+		if _, visited := index[name]; !visited {
+			visit(name)
+		}
+	}
+
+	sort.Slice(cycles, func(i, j int) bool { return cycles[i][0] < cycles[j][0] })
+	return cycles
+}
+
+// Redact ... returns a copy of the graph without the nodes above the clearance, nor their edges and cycles
+func (g *LineageGraph) Redact(clearance Classification) *LineageGraph {
+	hidden := make(map[string]bool)
+	redacted := &LineageGraph{Root: g.Root, Depth: g.Depth, Nodes: []LineageNode{}, Edges: []LineageEdge{}, Dangling: g.Dangling}
+	for _, node := range g.Nodes {
+		// dangling nodes are not assets, hence have no classification
+		if !node.Dangling && !clearance.Allows(node.Classification) {
+			hidden[node.Name] = true
+			continue
+		}
+		redacted.Nodes = append(redacted.Nodes, node)
+	}
+	for _, e := range g.Edges {
+		if !hidden[e.From] && !hidden[e.To] {
+			redacted.Edges = append(redacted.Edges, e)
+		}
+	}
+	for _, cycle := range g.Cycles {
+		visible := true
+		for _, name := range cycle {
+			visible = visible && !hidden[name]
+		}
+		if visible {
+			redacted.Cycles = append(redacted.Cycles, cycle)
+		}
+	}
+	return redacted
+}
+
+// dotEscape ... escapes a string to be used within a quoted DOT identifier
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+// dotID ... quotes a name as a DOT identifier
+func dotID(name string) string {
+	return `"` + dotEscape(name) + `"`
+}
+
+// DOT ... exports the graph in the Graphviz DOT language, with data flowing from left to right,
+// the root in bold and dangling references dashed in red
+func (g *LineageGraph) DOT() string {
+	var sb strings.Builder
+	sb.WriteString("digraph lineage {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box];\n")
+	for _, node := range g.Nodes {
+		// the type is shown on a second line of the label
+		label := dotID(node.Name)
+		if node.Type != "" {
+			label = fmt.Sprintf(`"%s\n(%s)"`, dotEscape(node.Name), dotEscape(string(node.Type)))
+		}
+		attributes := []string{"label=" + label}
+		if node.Direction == LineageRoot {
+			attributes = append(attributes, "style=bold")
+		}
+		if node.Dangling {
+			attributes = append(attributes, "style=dashed", "color=red")
+		}
+		sb.WriteString(fmt.Sprintf("  %s [%s];\n", dotID(node.Name), strings.Join(attributes, ", ")))
+	}
+	for _, e := range g.Edges {
+		sb.WriteString(fmt.Sprintf("  %s -> %s;\n", dotID(e.From), dotID(e.To)))
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
@@ -0,0 +1,95 @@
+package abstract
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// lineageFixture ... raw <- staging <- report, with report <-> audit in a cycle and staging depending on a missing asset
+func lineageFixture() (func(string) (*Asset, error), func(string) ([]Asset, error)) {
+	assets := map[string]Asset{
+		"raw":     {Name: "raw", Type: "table"},
+		"staging": {Name: "staging", Type: "table", DependsOn: []string{"raw", "missing"}},
+		"report":  {Name: "report", Type: "report", DependsOn: []string{"staging", "audit"}},
+		"audit":   {Name: "audit", Type: "report", DependsOn: []string{"report"}},
+	}
+	getByName := func(name string) (*Asset, error) {
+		if a, exist := assets[name]; exist {
+			return &a, nil
+		}
+		return nil, fmt.Errorf("No document found for name %s :: %w", name, ErrAssetNotFound)
+	}
+	getDependents := func(name string) ([]Asset, error) {
+		var dependents []Asset
+		for _, a := range assets {
+			for _, d := range a.DependsOn {
+				if d == name {
+					dependents = append(dependents, a)
+				}
+			}
+		}
+		return dependents, nil
+	}
+	return getByName, getDependents
+}
+
+func TestBuildLineage(t *testing.T) {
+	getByName, getDependents := lineageFixture()
+	root, _ := getByName("staging")
+
+	graph, err := BuildLineage(root, LineageBoth, 3, getByName, getDependents)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var nodes []string
+	for _, n := range graph.Nodes {
+		nodes = append(nodes, fmt.Sprintf("%s:%s:%d", n.Name, n.Direction, n.Distance))
+	}
+	expected := "staging:root:0 missing:upstream:1 raw:upstream:1 report:downstream:1 audit:downstream:2"
+	if strings.Join(nodes, " ") != expected {
+		t.Errorf("expected nodes %s, got %s", expected, strings.Join(nodes, " "))
+	}
+	if len(graph.Dangling) != 1 || graph.Dangling[0] != "missing" {
+		t.Errorf("expected missing to be dangling, got %v", graph.Dangling)
+	}
+	if len(graph.Cycles) != 1 || strings.Join(graph.Cycles[0], ",") != "audit,report" {
+		t.Errorf("expected a cycle between audit and report, got %v", graph.Cycles)
+	}
+
+	// only missing assets are dangling, other errors are returned
+	failing := func(name string) (*Asset, error) {
+		return nil, fmt.Errorf("backend unavailable")
+	}
+	if _, err := BuildLineage(root, LineageUpstream, 3, failing, getDependents); err == nil {
+		t.Error("expected the error retrieving an upstream asset to be returned")
+	}
+
+	dot := graph.DOT()
+	for _, line := range []string{`"raw" -> "staging";`, `"staging" -> "report";`, `"missing" [label="missing", style=dashed, color=red];`} {
+		if !strings.Contains(dot, line) {
+			t.Errorf("expected %s in dot output:\n%s", line, dot)
+		}
+	}
+}
+
+func TestBuildLineageDepth(t *testing.T) {
+	getByName, getDependents := lineageFixture()
+	root, _ := getByName("raw")
+
+	graph, err := BuildLineage(root, LineageDownstream, 1, getByName, getDependents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(graph.Nodes) != 2 || graph.Nodes[1].Name != "staging" {
+		t.Errorf("expected only raw and staging at depth 1, got %v", graph.Nodes)
+	}
+
+	if _, err := ValidateLineage("sideways", 1); err == nil {
+		t.Error("expected an error on an unknown direction")
+	}
+	if _, err := ValidateLineage(LineageUpstream, MaxLineageDepth+1); err == nil {
+		t.Error("expected an error on a depth above the maximum")
+	}
+}
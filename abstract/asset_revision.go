@@ -0,0 +1,178 @@
+package abstract
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// ErrRevisionExists ... returned by DAOs when a revision number was concurrently taken, so that it can be retried
+var ErrRevisionExists = errors.New("Revision exists already")
+
+// AssetRevision ... a full snapshot of an asset, appended on every upsert
+type AssetRevision struct {
+	Name string `json:"name"`
+	// Revision ... incremental number of the revision for the asset, starting from 1
+	Revision  int       `json:"revision"`
+	CreatedAt time.Time `json:"created-at"`
+	// Author ... who upserted the asset, i.e. the crawler which discovered it or empty if unknown
+	Author string `json:"author,omitempty"`
+	Asset  Asset  `json:"asset"`
+	// SchemaChanges ... changes of the schema from the previous version of a table, if any
+	SchemaChanges []SchemaChange `json:"schema-changes,omitempty"`
+	// Impacted ... downstream assets depending on the table, when any of its schema changes is breaking
+	Impacted []string `json:"impacted,omitempty"`
+}
+
+// field changes
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// Change ... a field or schema column added, removed or changed between two revisions
+type Change struct {
+	Field  string      `json:"field"`
+	Change string      `json:"change"`
+	Old    interface{} `json:"old,omitempty"`
+	New    interface{} `json:"new,omitempty"`
+}
+
+// AssetDiff ... changes of the asset fields and of its schema columns from a revision to another
+type AssetDiff struct {
+	Name    string   `json:"name"`
+	From    int      `json:"from"`
+	To      int      `json:"to"`
+	Fields  []Change `json:"fields"`
+	Columns []Change `json:"columns"`
+	// SchemaChanges ... classification of the column changes, for tables
+	SchemaChanges []SchemaChange `json:"schema-changes,omitempty"`
+}
+
+// SchemaColumns ... returns the columns of the schema label of the asset, if any,
+// the label is a map of ColumnInfo when built by a crawler and a generic map once stored
+func SchemaColumns(asset *Asset) (map[string]ColumnInfo, error) {
+	columns := make(map[string]ColumnInfo)
+	schema, exist := asset.Labels[L_SCHEMA]
+	if !exist || schema == nil {
+		return columns, nil
+	}
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &columns); err != nil {
+		return nil, fmt.Errorf("Invalid schema label :: %v", err)
+	}
+	return columns, nil
+}
+
+// toFields ... returns the json fields of the asset, excluding name and discovery date which are not meaningful to diff,
+// and flattening labels as labels.<key> except the schema which is compared by column
+func toFields(asset *Asset) (map[string]interface{}, error) {
+	data, err := json.Marshal(asset)
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	delete(fields, "name")
+	delete(fields, "last-discovered-at")
+
+	if labels, isMap := fields["labels"].(map[string]interface{}); isMap {
+		for k, v := range labels {
+			if k != L_SCHEMA {
+				fields["labels."+k] = v
+			}
+		}
+	}
+	delete(fields, "labels")
+
+	// missing and empty values are equivalent
+	for k, v := range fields {
+		if isEmpty(v) {
+			delete(fields, k)
+		}
+	}
+	return fields, nil
+}
+
+func isEmpty(v interface{}) bool {
+	switch value := v.(type) {
+	case nil:
+		return true
+	case string:
+		return value == ""
+	case []interface{}:
+		return len(value) == 0
+	case map[string]interface{}:
+		return len(value) == 0
+	}
+	return false
+}
+
+// diffMaps ... returns the changes from before to after, sorted by field name
+func diffMaps(before map[string]interface{}, after map[string]interface{}) []Change {
+	changes := []Change{}
+	for k, o := range before {
+		n, exist := after[k]
+		switch {
+		case !exist:
+			changes = append(changes, Change{Field: k, Change: ChangeRemoved, Old: o})
+		case !reflect.DeepEqual(o, n):
+			changes = append(changes, Change{Field: k, Change: ChangeChanged, Old: o, New: n})
+		}
+	}
+	for k, n := range after {
+		if _, exist := before[k]; !exist {
+			changes = append(changes, Change{Field: k, Change: ChangeAdded, New: n})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Field < changes[j].Field
+	})
+	return changes
+}
+
+// DiffRevisions ... returns the changes of the asset fields and schema columns from a revision to another
+func DiffRevisions(from *AssetRevision, to *AssetRevision) (*AssetDiff, error) {
+	diff := &AssetDiff{Name: to.Name, From: from.Revision, To: to.Revision}
+
+	oldFields, err := toFields(&from.Asset)
+	if err != nil {
+		return nil, err
+	}
+	newFields, err := toFields(&to.Asset)
+	if err != nil {
+		return nil, err
+	}
+	diff.Fields = diffMaps(oldFields, newFields)
+
+	oldColumns, err := SchemaColumns(&from.Asset)
+	if err != nil {
+		return nil, err
+	}
+	newColumns, err := SchemaColumns(&to.Asset)
+	if err != nil {
+		return nil, err
+	}
+	before, after := make(map[string]interface{}), make(map[string]interface{})
+	for k, c := range oldColumns {
+		before[k] = c
+	}
+	for k, c := range newColumns {
+		after[k] = c
+	}
+	diff.Columns = diffMaps(before, after)
+
+	if diff.SchemaChanges, err = DetectSchemaChanges(&from.Asset, &to.Asset); err != nil {
+		return nil, err
+	}
+	return diff, nil
+}
@@ -0,0 +1,264 @@
+package abstract
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// AssetSearch ... a free text query and filters over the catalogue, all provided conditions shall be satisfied
+type AssetSearch struct {
+	// Query ... free text matched against the words of name and description, all words shall match
+	Query string `json:"query,omitempty"`
+	// Types ... assets of any of the given types
+	Types []AssetType `json:"types,omitempty"`
+	// Tags ... assets having all the given tags
+	Tags []string `json:"tags,omitempty"`
+	// Labels ... assets having all the given label values, see LabelMatches
+	Labels map[string]string `json:"labels,omitempty"`
+	// DependsOn ... assets depending on all the given assets
+	DependsOn []string `json:"depends-on,omitempty"`
+	// Owner ... assets having the given owner among their owners
+	Owner string `json:"owner,omitempty"`
+	// Domain ... assets of the given domain
+	Domain string `json:"domain,omitempty"`
+	// Classifications ... assets of any of the given classifications, unclassified assets being internal
+	Classifications []Classification `json:"classifications,omitempty"`
+	// PublishedFrom and PublishedTo ... inclusive range of the publication date
+	PublishedFrom *time.Time `json:"published-from,omitempty"`
+	PublishedTo   *time.Time `json:"published-to,omitempty"`
+	// Stale ... only stale assets if true, only fresh ones if false
+	Stale *bool `json:"stale,omitempty"`
+	// IncludeDeleted ... also return soft-deleted assets, which are excluded by default
+	IncludeDeleted bool `json:"include-deleted,omitempty"`
+	// Limit ... maximum number of assets returned, facets are computed on all matching assets
+	Limit int `json:"limit,omitempty"`
+	// Offset ... number of matching assets skipped before the returned ones
+	Offset int `json:"offset,omitempty"`
+}
+
+// AssetSearchResult ... assets matching a search, by relevance when a query is given and by name otherwise
+type AssetSearchResult struct {
+	Total  int         `json:"total"`
+	Assets []Asset     `json:"assets"`
+	Facets AssetFacets `json:"facets"`
+}
+
+// AssetFacets ... number of matching assets per type and per tag
+type AssetFacets struct {
+	Types map[string]int `json:"types"`
+	Tags  map[string]int `json:"tags"`
+}
+
+// NewAssetFacets ... returns empty facets
+func NewAssetFacets() AssetFacets {
+	return AssetFacets{Types: map[string]int{}, Tags: map[string]int{}}
+}
+
+// Validate ... validate the search and set the default limit
+func (s *AssetSearch) Validate() error {
+	if s.Limit == 0 {
+		s.Limit = DefaultLimit
+	}
+	if s.Limit < 0 || s.Limit > MaxLimit {
+		return fmt.Errorf("Limit should be between 1 and %d", MaxLimit)
+	}
+	if s.Offset < 0 {
+		return errors.New("Offset should not be negative")
+	}
+	for i := range s.Types {
+		if err := s.Types[i].Validate(); err != nil {
+			return err
+		}
+	}
+	// label keys are part of the field paths in the backends, e.g. labels.<key> in mongo, so operators and nested paths are rejected
+	for key := range s.Labels {
+		if key == "" || strings.ContainsAny(key, "$.") {
+			return fmt.Errorf("Label %q should neither be empty nor contain $ or .", key)
+		}
+	}
+	for _, c := range s.Classifications {
+		if err := c.Validate(); err != nil {
+			return err
+		}
+	}
+	if s.PublishedFrom != nil && s.PublishedTo != nil && s.PublishedFrom.After(*s.PublishedTo) {
+		return errors.New("published-from should not be after published-to")
+	}
+	return nil
+}
+
+// Terms ... the lower case words of the query
+func (s *AssetSearch) Terms() []string {
+	return words(s.Query)
+}
+
+// words ... splits the text on anything but letters and digits, in lower case
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// textScore ... number of occurrences of the terms in name and description, 0 if any term is missing
+func (s *AssetSearch) textScore(asset *Asset) int {
+	occurrences := make(map[string]int)
+	// the name counts twice, as in the weights of the backend indexes
+	for _, w := range words(asset.Name) {
+		occurrences[w] += 2
+	}
+	for _, w := range words(asset.Description) {
+		occurrences[w]++
+	}
+	score := 0
+	for _, term := range s.Terms() {
+		if occurrences[term] == 0 {
+			return 0
+		}
+		score += occurrences[term]
+	}
+	return score
+}
+
+// Matches ... returns true if the asset satisfies all filters of the search, ignoring the free text query
+func (s *AssetSearch) Matches(asset *Asset) bool {
+	if asset.IsDeleted() && !s.IncludeDeleted {
+		return false
+	}
+	if s.Stale != nil && *s.Stale != (asset.StaleSince != nil) {
+		return false
+	}
+	if len(s.Types) > 0 {
+		found := false
+		for _, t := range s.Types {
+			found = found || t == asset.Type
+		}
+		if !found {
+			return false
+		}
+	}
+	if !asset.HasTags(s.Tags) {
+		return false
+	}
+	for k, v := range s.Labels {
+		if value, exist := asset.Labels[k]; !exist || !LabelMatches(value, v) {
+			return false
+		}
+	}
+	for _, required := range s.DependsOn {
+		found := false
+		for _, dependency := range asset.DependsOn {
+			found = found || dependency == required
+		}
+		if !found {
+			return false
+		}
+	}
+	if s.Owner != "" {
+		found := false
+		for _, owner := range asset.Owners {
+			found = found || owner == s.Owner
+		}
+		if !found {
+			return false
+		}
+	}
+	if s.Domain != "" && asset.Domain != s.Domain {
+		return false
+	}
+	if len(s.Classifications) > 0 {
+		found := false
+		for _, c := range s.Classifications {
+			found = found || c.level() == asset.Classification.level()
+		}
+		if !found {
+			return false
+		}
+	}
+	if s.PublishedFrom != nil && asset.PublishedOn.Before(*s.PublishedFrom) {
+		return false
+	}
+	if s.PublishedTo != nil && asset.PublishedOn.After(*s.PublishedTo) {
+		return false
+	}
+	return true
+}
+
+// SearchAssets ... runs the search over the assets in memory, for DAOs without a search engine
+func SearchAssets(assets []Asset, s *AssetSearch) *AssetSearchResult {
+	result := &AssetSearchResult{Assets: []Asset{}, Facets: NewAssetFacets()}
+	hasQuery := len(s.Terms()) > 0
+	scores := make(map[string]int)
+	for _, asset := range assets {
+		if !s.Matches(&asset) {
+			continue
+		}
+		if hasQuery {
+			scores[asset.Name] = s.textScore(&asset)
+			if scores[asset.Name] == 0 {
+				continue
+			}
+		}
+		result.Assets = append(result.Assets, asset)
+		result.Facets.Types[string(asset.Type)]++
+		for _, tag := range asset.Tags {
+			result.Facets.Tags[tag]++
+		}
+	}
+
+	sort.Slice(result.Assets, func(i, j int) bool {
+		a, b := result.Assets[i].Name, result.Assets[j].Name
+		if scores[a] != scores[b] {
+			return scores[a] > scores[b]
+		}
+		return a < b
+	})
+	result.Total = len(result.Assets)
+	start, end := s.Offset, s.Offset+s.Limit
+	if start > result.Total {
+		start = result.Total
+	}
+	if end > result.Total {
+		end = result.Total
+	}
+	result.Assets = result.Assets[start:end]
+	return result
+}
+
+// labelText ... the text of a label value as compared with the searched ones, i.e. strings as they are, booleans and numbers
+// in json as indexed by the flattened labels of elastic search, false for other values which never match
+func labelText(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case bool, int, int32, int64, float32, float64:
+		data, err := json.Marshal(v)
+		return string(data), err == nil
+	}
+	return "", false
+}
+
+// LabelMatches ... returns true if the text of the label value is the searched one, so that e.g. the 1 and true values
+// match the searched "1" and "true" on all backends
+func LabelMatches(value interface{}, searched string) bool {
+	text, comparable := labelText(value)
+	return comparable && text == searched
+}
+
+// LabelValues ... returns the values of the labels matching the searched one, for backends comparing raw values,
+// i.e. the searched string itself and the boolean or number it is the text of, if any
+func LabelValues(searched string) []interface{} {
+	values := []interface{}{searched}
+	if b, err := strconv.ParseBool(searched); err == nil && LabelMatches(b, searched) {
+		values = append(values, b)
+	}
+	if n, err := strconv.ParseFloat(searched, 64); err == nil && LabelMatches(n, searched) {
+		values = append(values, n)
+	}
+	return values
+}
@@ -0,0 +1,45 @@
+package abstract
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLabelValues(t *testing.T) {
+	cases := map[string][]interface{}{
+		"sales": {"sales"},
+		"true":  {"true", true},
+		"1":     {"1", float64(1)},
+		"1.5":   {"1.5", 1.5},
+		// not the json text of the number
+		"1.0": {"1.0"},
+		"01":  {"01"},
+	}
+	for searched, expected := range cases {
+		values := LabelValues(searched)
+		if !reflect.DeepEqual(values, expected) {
+			t.Errorf("expected %v for %s, got %v", expected, searched, values)
+		}
+		for _, value := range values {
+			if !LabelMatches(value, searched) {
+				t.Errorf("expected %v to match %s", value, searched)
+			}
+		}
+	}
+	if LabelMatches(map[string]interface{}{}, "map[]") {
+		t.Error("expected labels other than strings, booleans and numbers not to match")
+	}
+}
+
+func TestAssetSearchValidateLabels(t *testing.T) {
+	for _, key := range []string{"", "$where", "schema.email", "a$b"} {
+		search := &AssetSearch{Labels: map[string]string{key: "x"}}
+		if err := search.Validate(); err == nil {
+			t.Errorf("expected the label %q to be rejected", key)
+		}
+	}
+	search := &AssetSearch{Labels: map[string]string{"owner-team": "sales"}}
+	if err := search.Validate(); err != nil {
+		t.Errorf("expected a valid label, got %v", err)
+	}
+}
@@ -28,3 +28,35 @@ func TestParsing(t *testing.T) {
 
   t.Log(asset)
 }
+
+func TestOwnershipValidation(t *testing.T) {
+	assert := assert.New(t)
+
+	asset := Asset{
+		Name:    "mydb.mytable",
+		Type:    "table",
+		Owners:  []string{"jane.doe", "data-platform"},
+		Steward: "john.doe",
+		Domain:  "sales",
+		Team:    "data-platform",
+		Contact: "data-platform@example.com",
+	}
+	assert.Nil(asset.Validate())
+
+	asset.Contact = "https://chat.example.com/channels/data-platform"
+	assert.Nil(asset.Validate())
+
+	invalid := []func(a *Asset){
+		func(a *Asset) { a.Contact = "not a contact" },
+		func(a *Asset) { a.Domain = "Sales Team" },
+		func(a *Asset) { a.Team = "-platform" },
+		func(a *Asset) { a.Owners = []string{"jane.doe", "jane.doe"} },
+		func(a *Asset) { a.Owners = []string{" "} },
+		func(a *Asset) { a.Steward = " " },
+	}
+	for _, invalidate := range invalid {
+		a := asset
+		invalidate(&a)
+		assert.NotNil(a.Validate())
+	}
+}
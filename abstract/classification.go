@@ -0,0 +1,165 @@
+package abstract
+
+import (
+	"fmt"
+)
+
+// Classification ... sensitivity of an asset or of a column, by increasing level
+type Classification string
+
+const (
+	// Public ... can be disclosed to anyone
+	Public Classification = "public"
+	// Internal ... restricted to the organization, the level of unclassified assets and columns
+	Internal Classification = "internal"
+	// Confidential ... restricted to those with a need to know
+	Confidential Classification = "confidential"
+	// PII ... personally identifiable information
+	PII Classification = "pii"
+)
+
+// classificationLevels ... level of each classification, higher is more sensitive
+var classificationLevels = map[Classification]int{
+	Public:       0,
+	Internal:     1,
+	Confidential: 2,
+	PII:          3,
+}
+
+// Classifications ... all classifications, by increasing level
+var Classifications = []Classification{Public, Internal, Confidential, PII}
+
+// Validate ... validates the classification, empty being allowed as internal
+func (c Classification) Validate() error {
+	if _, exist := classificationLevels[c]; !exist && c != "" {
+		return fmt.Errorf("invalid value %s for Classification, use one of %v", c, Classifications)
+	}
+	return nil
+}
+
+// level ... level of the classification, unclassified being internal
+func (c Classification) level() int {
+	if c == "" {
+		return classificationLevels[Internal]
+	}
+	return classificationLevels[c]
+}
+
+// Allows ... returns true if the clearance allows to access the given classification
+func (c Classification) Allows(classification Classification) bool {
+	return classification.level() <= c.level()
+}
+
+// Max ... returns the highest of the classifications, i.e. the clearance of a principal having many roles
+func Max(classifications ...Classification) Classification {
+	max := Public
+	for _, c := range classifications {
+		if c.level() > max.level() {
+			max = c
+		}
+	}
+	return max
+}
+
+// Cleared ... returns the classifications allowed by the clearance, e.g. to filter a search
+func (c Classification) Cleared() []Classification {
+	var cleared []Classification
+	for _, classification := range Classifications {
+		if c.Allows(classification) {
+			cleared = append(cleared, classification)
+		}
+	}
+	return cleared
+}
+
+// validateClassifications ... validates the classification of the asset and of its schema columns
+func (asset *Asset) validateClassifications() error {
+	if err := asset.Classification.Validate(); err != nil {
+		return err
+	}
+	columns, err := SchemaColumns(asset)
+	if err != nil {
+		return err
+	}
+	for name, column := range columns {
+		if err := column.Classification.Validate(); err != nil {
+			return fmt.Errorf("Column %s :: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// redactionFields ... json fields of an asset read by Redact, hence always retrieved by a projection
+var redactionFields = []string{"name", "classification", "labels"}
+
+// RedactableFields ... returns the fields to be retrieved from the backend so that the asset can still be redacted,
+// i.e. the requested ones and those read by Redact, or all fields if none are requested
+func RedactableFields(fields []string) []string {
+	if len(fields) == 0 {
+		return nil
+	}
+	retrieved := append([]string{}, fields...)
+	for _, f := range redactionFields {
+		found := false
+		for _, r := range fields {
+			found = found || r == f
+		}
+		if !found {
+			retrieved = append(retrieved, f)
+		}
+	}
+	return retrieved
+}
+
+// Redact ... returns a copy of the asset without the schema columns above the clearance,
+// and false if the asset itself is above the clearance
+func Redact(asset *Asset, clearance Classification) (*Asset, bool) {
+	if !clearance.Allows(asset.Classification) {
+		return nil, false
+	}
+	columns, err := SchemaColumns(asset)
+	if err != nil {
+		// an invalid schema cannot be redacted, hence it is hidden
+		redacted := *asset
+		redacted.Labels = copyLabels(asset.Labels)
+		delete(redacted.Labels, L_SCHEMA)
+		return &redacted, true
+	}
+
+	visible := make(map[string]ColumnInfo)
+	hidden := 0
+	for name, column := range columns {
+		if clearance.Allows(column.Classification) {
+			visible[name] = column
+		} else {
+			hidden++
+		}
+	}
+	if hidden == 0 {
+		return asset, true
+	}
+	redacted := *asset
+	redacted.Labels = copyLabels(asset.Labels)
+	redacted.Labels[L_SCHEMA] = visible
+	redacted.RedactedColumns = hidden
+	return &redacted, true
+}
+
+// RedactAll ... returns the assets within the clearance, redacted
+func RedactAll(assets []Asset, clearance Classification) []Asset {
+	redacted := []Asset{}
+	for i := range assets {
+		if asset, allowed := Redact(&assets[i], clearance); allowed {
+			redacted = append(redacted, *asset)
+		}
+	}
+	return redacted
+}
+
+func copyLabels(labels map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{})
+	for k, v := range labels {
+		copied[k] = v
+	}
+	return copied
+}
@@ -1,10 +1,17 @@
 package abstract
 
-import "github.com/pilillo/mastro/utils/conf"
+import (
+	"context"
+
+	"github.com/pilillo/mastro/utils/conf"
+)
 
 const DefaultManifestFilename string = "MANIFEST.yaml"
 
 type Crawler interface {
 	InitConnection(cfg *conf.Config) (Crawler, error)
-	WalkWithFilter(root string, filenameFilter string) ([]Asset, error)
+	// WalkWithFilter ... returns the assets found under root, tracing the walk within the context
+	WalkWithFilter(ctx context.Context, root string, filenameFilter string) ([]Asset, error)
+	// CloseConnection ... releases the connection to the data source, once no walk is running
+	CloseConnection()
 }
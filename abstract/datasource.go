@@ -5,6 +5,7 @@ import "github.com/pilillo/mastro/utils/conf"
 // ConnectorProvider ... The interface each connector must implement
 type ConnectorProvider interface {
 	ValidateDataSourceDefinition(*conf.DataSourceDefinition) error
-	InitConnection(*conf.DataSourceDefinition)
+	// InitConnection ... connects to the data source, returning a permanent error (see retry.Permanent) if the definition is invalid
+	InitConnection(*conf.DataSourceDefinition) error
 	CloseConnection()
 }
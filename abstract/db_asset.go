@@ -12,6 +12,8 @@ type DBInfo struct {
 type ColumnInfo struct {
 	Type    string
 	Comment string
+	// Classification ... sensitivity of the column, internal if empty
+	Classification Classification `json:",omitempty" yaml:"classification,omitempty"`
 }
 
 // TableInfo ... Name, schema and description for a table
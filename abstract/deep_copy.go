@@ -0,0 +1,122 @@
+package abstract
+
+import "time"
+
+// copyValue ... returns a copy of a value decoded from json or yaml, copying its maps and slices recursively
+func copyValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		copied := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			copied[key] = copyValue(item)
+		}
+		return copied
+	case map[interface{}]interface{}:
+		copied := make(map[interface{}]interface{}, len(v))
+		for key, item := range v {
+			copied[key] = copyValue(item)
+		}
+		return copied
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, item := range v {
+			copied[i] = copyValue(item)
+		}
+		return copied
+	case []string:
+		return copyStrings(v)
+	case map[string]string:
+		return copyStringMap(v)
+	}
+	return value
+}
+
+func copyStrings(values []string) []string {
+	if values == nil {
+		return nil
+	}
+	return append([]string{}, values...)
+}
+
+func copyStringMap(values map[string]string) map[string]string {
+	if values == nil {
+		return nil
+	}
+	copied := make(map[string]string, len(values))
+	for key, value := range values {
+		copied[key] = value
+	}
+	return copied
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	copied := *t
+	return &copied
+}
+
+// DeepCopy ... returns a copy of the asset sharing no maps, slices or pointers with it,
+// for DAOs keeping the assets in memory
+func (asset *Asset) DeepCopy() Asset {
+	copied := *asset
+	copied.DependsOn = copyStrings(asset.DependsOn)
+	copied.Tags = copyStrings(asset.Tags)
+	copied.Owners = copyStrings(asset.Owners)
+	if asset.Labels != nil {
+		copied.Labels = copyValue(asset.Labels).(map[string]interface{})
+	}
+	copied.StaleSince = copyTime(asset.StaleSince)
+	copied.DeletedAt = copyTime(asset.DeletedAt)
+	return copied
+}
+
+// DeepCopy ... returns a copy of the revision sharing no maps, slices or pointers with it
+func (rev *AssetRevision) DeepCopy() AssetRevision {
+	copied := *rev
+	copied.Asset = rev.Asset.DeepCopy()
+	if rev.SchemaChanges != nil {
+		copied.SchemaChanges = append([]SchemaChange{}, rev.SchemaChanges...)
+	}
+	copied.Impacted = copyStrings(rev.Impacted)
+	return copied
+}
+
+// DeepCopy ... returns a copy of the feature set sharing no maps or slices with it,
+// for DAOs keeping the feature sets in memory
+func (fs *FeatureSet) DeepCopy() FeatureSet {
+	copied := *fs
+	if fs.Features != nil {
+		copied.Features = make([]Feature, len(fs.Features))
+		for i, f := range fs.Features {
+			f.Value = copyValue(f.Value)
+			copied.Features[i] = f
+		}
+	}
+	copied.Labels = copyStringMap(fs.Labels)
+	return copied
+}
+
+// DeepCopy ... returns a copy of the schema sharing no slices or pointers with it
+func (s *FeatureSetSchema) DeepCopy() FeatureSetSchema {
+	copied := *s
+	if s.Features != nil {
+		copied.Features = make([]FeatureSchema, len(s.Features))
+		for i, f := range s.Features {
+			if f.Min != nil {
+				min := *f.Min
+				f.Min = &min
+			}
+			if f.Max != nil {
+				max := *f.Max
+				f.Max = &max
+			}
+			if f.Enum != nil {
+				f.Enum = copyValue(f.Enum).([]interface{})
+			}
+			copied.Features[i] = f
+		}
+	}
+	return copied
+}
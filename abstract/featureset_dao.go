@@ -1,13 +1,32 @@
 package abstract
 
-import "github.com/pilillo/mastro/utils/conf"
+import (
+	"errors"
+	"time"
+
+	"github.com/pilillo/mastro/utils/conf"
+)
+
+// ErrFeatureSetExists ... returned by Create, possibly wrapped, if a feature set with the same name, version and entity exists
+var ErrFeatureSetExists = errors.New("A feature set with the same name, version and entity already exists")
 
 // FeatureSetDAOProvider ... The interface each dao must implement
 type FeatureSetDAOProvider interface {
-	Init(*conf.DataSourceDefinition)
+	// Init ... connects to the backend, returning a permanent error (see retry.Permanent) if the definition is invalid
+	Init(*conf.DataSourceDefinition) error
+	// Create ... returns ErrFeatureSetExists if the name, version and entity of the feature set are already used
 	Create(fs *FeatureSet) error
 	GetById(id string) (*FeatureSet, error)
 	GetByName(name string) (*[]FeatureSet, error)
 	ListAllFeatureSets() (*[]FeatureSet, error)
+	// ListFeatureSets ... returns a page of feature sets, with options already validated
+	ListFeatureSets(opts *ListOptions) (*FeatureSetPage, error)
+	// GetLatestAt ... returns nil and no error if no feature set was inserted at or before the given time
+	GetLatestAt(name string, entity string, at time.Time) (*FeatureSet, error)
+	UpsertSchema(schema *FeatureSetSchema) error
+	// GetSchema ... returns nil and no error if no schema is registered for the name
+	GetSchema(name string) (*FeatureSetSchema, error)
+	// Ping ... returns an error if the backend is not reachable
+	Ping() error
 	CloseConnection()
 }
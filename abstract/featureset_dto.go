@@ -3,14 +3,17 @@ package abstract
 import (
 	"errors"
 	"fmt"
-	"log"
 	"strings"
 	"time"
 )
 
 // FeatureSet ... a versioned set of features
 type FeatureSet struct {
-	Name        string            `json:"name,omitempty"`
+	// backend specific id, set by the DAO on create
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	// optional key of the entity (e.g. customer, device) the feature values refer to
+	Entity      string            `json:"entity,omitempty"`
 	InsertedAt  time.Time         `json:"inserted_at,omitempty"`
 	Version     string            `json:"version,omitempty"`
 	Features    []Feature         `json:"features,omitempty"`
@@ -25,8 +28,45 @@ type Feature struct {
 	DataType string      `json:"data-type,omitempty"`
 }
 
-// Validate ... validate a featureSet
+// EntityTimestamp ... an entity and the time at which its feature values are requested
+type EntityTimestamp struct {
+	Entity    string    `json:"entity"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// PointInTimeFeatureSet ... the latest feature set inserted for the entity at or before the timestamp,
+// the feature set is nil if none was available at that time
+type PointInTimeFeatureSet struct {
+	Entity     string      `json:"entity"`
+	Timestamp  time.Time   `json:"timestamp"`
+	FeatureSet *FeatureSet `json:"featureset"`
+}
+
+// Validate ... validate a featureSet, data types and values of its features are converted to their canonical form
 func (fs *FeatureSet) Validate() error {
+	if err := fs.validateKeys(); err != nil {
+		return err
+	}
+
+	for i := range fs.Features {
+		if err := fs.Features[i].Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ValidateWithSchema ... validate a featureSet against the schema registered for its name
+func (fs *FeatureSet) ValidateWithSchema(schema *FeatureSetSchema) error {
+	if err := fs.validateKeys(); err != nil {
+		return err
+	}
+	return schema.ValidateFeatureSet(fs)
+}
+
+// validateKeys ... validate the fields used to retrieve the featureSet
+func (fs *FeatureSet) validateKeys() error {
 	// the name should not be empty or we may not be able to retrieve the fset
 	if len(strings.TrimSpace(fs.Name)) == 0 {
 		return errors.New("FeatureSet Name is undefined")
@@ -36,22 +76,15 @@ func (fs *FeatureSet) Validate() error {
 		return errors.New("FeatureSet Version is undefined")
 	}
 
-	for _, f := range fs.Features {
-		if err := f.Validate(); err != nil {
-			return err
-		}
-	}
-
 	return nil
 }
 
-// Validate ... validate a feature
+// Validate ... validate a feature, its value should parse as the declared data type
 func (f *Feature) Validate() error {
 	if len(strings.TrimSpace(f.Name)) == 0 {
 		return errors.New("Feature Name is undefined")
 	}
 
-	log.Println(f.Name, f.Value)
 	if f.Value == nil {
 		return errors.New(fmt.Sprintf("Feature Value for Feature %s is undefined", f.Name))
 	}
@@ -60,5 +93,16 @@ func (f *Feature) Validate() error {
 		return errors.New(fmt.Sprintf("Feature Data Type for Feature %s is undefined", f.Name))
 	}
 
+	dataType, err := CanonicalDataType(f.DataType)
+	if err != nil {
+		return fmt.Errorf("Feature %s :: %v", f.Name, err)
+	}
+	value, err := ConvertValue(f.Value, dataType)
+	if err != nil {
+		return fmt.Errorf("Feature %s :: %v", f.Name, err)
+	}
+	f.DataType = dataType
+	f.Value = value
+
 	return nil
 }
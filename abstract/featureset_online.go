@@ -0,0 +1,25 @@
+package abstract
+
+import "github.com/pilillo/mastro/utils/conf"
+
+// OnlineFeatureStoreProvider ... The interface each online store must implement,
+// an online store only holds the latest feature set for each key and is meant for low-latency lookups
+type OnlineFeatureStoreProvider interface {
+	// Init ... connects to the backend, returning a permanent error (see retry.Permanent) if the definition is invalid
+	Init(*conf.DataSourceDefinition) error
+	Put(key string, fs *FeatureSet) error
+	// Get ... returns nil and no error when the key is not available
+	Get(key string) (*FeatureSet, error)
+	// Ping ... returns an error if the backend is not reachable
+	Ping() error
+	CloseConnection()
+}
+
+// OnlineKey ... key used to materialize a feature set in the online store,
+// the entity is optional and appended to the feature set name when provided
+func OnlineKey(name string, entity string) string {
+	if entity == "" {
+		return name
+	}
+	return name + "/" + entity
+}
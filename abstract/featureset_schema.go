@@ -0,0 +1,262 @@
+package abstract
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// canonical feature data types
+const (
+	BoolType   = "bool"
+	IntType    = "int"
+	FloatType  = "float"
+	StringType = "string"
+)
+
+// dataTypeAliases ... accepted spellings of the canonical data types, compared lower case
+var dataTypeAliases = map[string]string{
+	"bool":    BoolType,
+	"boolean": BoolType,
+	"int":     IntType,
+	"integer": IntType,
+	"int32":   IntType,
+	"int64":   IntType,
+	"long":    IntType,
+	"float":   FloatType,
+	"float32": FloatType,
+	"float64": FloatType,
+	"double":  FloatType,
+	"number":  FloatType,
+	"string":  StringType,
+	"str":     StringType,
+	"text":    StringType,
+}
+
+// CanonicalDataType ... returns the canonical data type for the given data type or one of its aliases
+func CanonicalDataType(dataType string) (string, error) {
+	if canonical, exist := dataTypeAliases[strings.ToLower(strings.TrimSpace(dataType))]; exist {
+		return canonical, nil
+	}
+	return "", fmt.Errorf("Data type %s is not supported, use one of bool, int, float or string", dataType)
+}
+
+// ConvertValue ... converts the value to the go type of the canonical data type (bool, int64, float64 or string),
+// numbers and booleans can also be provided as strings
+func ConvertValue(value interface{}, dataType string) (interface{}, error) {
+	if str, isString := value.(string); isString && dataType != StringType {
+		switch dataType {
+		case BoolType:
+			return strconv.ParseBool(str)
+		case IntType:
+			return strconv.ParseInt(str, 10, 64)
+		case FloatType:
+			return strconv.ParseFloat(str, 64)
+		}
+	}
+
+	switch dataType {
+	case BoolType:
+		if b, isBool := value.(bool); isBool {
+			return b, nil
+		}
+	case IntType:
+		switch n := value.(type) {
+		case int:
+			return int64(n), nil
+		case int32:
+			return int64(n), nil
+		case int64:
+			return n, nil
+		case float64:
+			// json numbers are decoded as float64, 1<<63 being the first float64 out of the int64 range
+			if n == math.Trunc(n) && n >= -1<<63 && n < 1<<63 {
+				return int64(n), nil
+			}
+		case json.Number:
+			return n.Int64()
+		}
+	case FloatType:
+		switch n := value.(type) {
+		case int:
+			return float64(n), nil
+		case int32:
+			return float64(n), nil
+		case int64:
+			return float64(n), nil
+		case float32:
+			return float64(n), nil
+		case float64:
+			return n, nil
+		case json.Number:
+			return n.Float64()
+		}
+	case StringType:
+		if str, isString := value.(string); isString {
+			return str, nil
+		}
+	}
+	return nil, fmt.Errorf("Value %v is not of type %s", value, dataType)
+}
+
+// FeatureSetSchema ... the features expected for all feature sets with the given name
+type FeatureSetSchema struct {
+	Name      string          `json:"name,omitempty"`
+	UpdatedAt time.Time       `json:"updated_at,omitempty"`
+	Features  []FeatureSchema `json:"features,omitempty"`
+}
+
+// FeatureSchema ... the data type and the allowed values of a feature
+type FeatureSchema struct {
+	Name     string        `json:"name,omitempty"`
+	DataType string        `json:"data-type,omitempty"`
+	Nullable bool          `json:"nullable,omitempty"`
+	Min      *float64      `json:"min,omitempty"`
+	Max      *float64      `json:"max,omitempty"`
+	Enum     []interface{} `json:"enum,omitempty"`
+}
+
+// Validate ... validate a schema and convert its data types and enum values to their canonical form
+func (s *FeatureSetSchema) Validate() error {
+	if len(strings.TrimSpace(s.Name)) == 0 {
+		return errors.New("FeatureSetSchema Name is undefined")
+	}
+	if len(s.Features) == 0 {
+		return errors.New("FeatureSetSchema has no features")
+	}
+
+	names := make(map[string]bool)
+	for i := range s.Features {
+		fschema := &s.Features[i]
+		if len(strings.TrimSpace(fschema.Name)) == 0 {
+			return errors.New("Feature Name is undefined")
+		}
+		if names[fschema.Name] {
+			return fmt.Errorf("Feature %s is defined more than once", fschema.Name)
+		}
+		names[fschema.Name] = true
+
+		if err := fschema.validate(); err != nil {
+			return fmt.Errorf("Feature %s :: %v", fschema.Name, err)
+		}
+	}
+	return nil
+}
+
+func (fschema *FeatureSchema) validate() error {
+	dataType, err := CanonicalDataType(fschema.DataType)
+	if err != nil {
+		return err
+	}
+	fschema.DataType = dataType
+
+	if fschema.Min != nil || fschema.Max != nil {
+		if dataType != IntType && dataType != FloatType {
+			return fmt.Errorf("min and max are only allowed for %s and %s data types", IntType, FloatType)
+		}
+		if fschema.Min != nil && fschema.Max != nil && *fschema.Min > *fschema.Max {
+			return fmt.Errorf("min %v is greater than max %v", *fschema.Min, *fschema.Max)
+		}
+	}
+
+	for i, allowed := range fschema.Enum {
+		converted, err := ConvertValue(allowed, dataType)
+		if err != nil {
+			return err
+		}
+		fschema.Enum[i] = converted
+	}
+	return nil
+}
+
+// validateValue ... checks the value against the schema, returning it converted to the canonical data type
+func (fschema *FeatureSchema) validateValue(value interface{}) (interface{}, error) {
+	if value == nil {
+		if fschema.Nullable {
+			return nil, nil
+		}
+		return nil, errors.New("Value is undefined and the feature is not nullable")
+	}
+
+	converted, err := ConvertValue(value, fschema.DataType)
+	if err != nil {
+		return nil, err
+	}
+
+	var number float64
+	switch n := converted.(type) {
+	case int64:
+		number = float64(n)
+	case float64:
+		number = n
+	}
+	if fschema.Min != nil && number < *fschema.Min {
+		return nil, fmt.Errorf("Value %v is lower than min %v", converted, *fschema.Min)
+	}
+	if fschema.Max != nil && number > *fschema.Max {
+		return nil, fmt.Errorf("Value %v is greater than max %v", converted, *fschema.Max)
+	}
+
+	if len(fschema.Enum) > 0 {
+		for _, allowed := range fschema.Enum {
+			// enum values may come back from the dao with a different go type, e.g. float64 for json numbers
+			if allowedValue, err := ConvertValue(allowed, fschema.DataType); err == nil && allowedValue == converted {
+				return converted, nil
+			}
+		}
+		return nil, fmt.Errorf("Value %v is not one of %v", converted, fschema.Enum)
+	}
+	return converted, nil
+}
+
+// ValidateFeatureSet ... checks that the feature set only contains the features of the schema, with matching values,
+// data types and values of the feature set are converted to their canonical form
+func (s *FeatureSetSchema) ValidateFeatureSet(fs *FeatureSet) error {
+	schemas := make(map[string]*FeatureSchema)
+	for i := range s.Features {
+		schemas[s.Features[i].Name] = &s.Features[i]
+	}
+
+	provided := make(map[string]bool)
+	for i := range fs.Features {
+		f := &fs.Features[i]
+		fschema, exist := schemas[f.Name]
+		if !exist {
+			return fmt.Errorf("Feature %s is not defined in the schema of %s", f.Name, s.Name)
+		}
+		if provided[f.Name] {
+			return fmt.Errorf("Feature %s is provided more than once", f.Name)
+		}
+		provided[f.Name] = true
+
+		// the data type can be omitted, but it should agree with the schema when provided
+		if len(strings.TrimSpace(f.DataType)) > 0 {
+			dataType, err := CanonicalDataType(f.DataType)
+			if err != nil {
+				return fmt.Errorf("Feature %s :: %v", f.Name, err)
+			}
+			if dataType != fschema.DataType {
+				return fmt.Errorf("Feature %s has data type %s while the schema defines %s", f.Name, dataType, fschema.DataType)
+			}
+		}
+
+		value, err := fschema.validateValue(f.Value)
+		if err != nil {
+			return fmt.Errorf("Feature %s :: %v", f.Name, err)
+		}
+		f.DataType = fschema.DataType
+		f.Value = value
+	}
+
+	// features missing from the feature set are null
+	for _, fschema := range s.Features {
+		if !provided[fschema.Name] && !fschema.Nullable {
+			return fmt.Errorf("Feature %s is missing and the schema of %s does not define it as nullable", fschema.Name, s.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,111 @@
+package abstract
+
+import (
+	"testing"
+)
+
+func TestCanonicalDataType(t *testing.T) {
+	for _, dataType := range []string{"int", "integer", "Int64", " LONG "} {
+		if canonical, err := CanonicalDataType(dataType); err != nil || canonical != IntType {
+			t.Errorf("expected %q to be %s, got %q (%v)", dataType, IntType, canonical, err)
+		}
+	}
+	if _, err := CanonicalDataType("timestamp"); err == nil {
+		t.Error("expected an error for an unsupported data type")
+	}
+}
+
+func TestConvertValue(t *testing.T) {
+	cases := []struct {
+		value    interface{}
+		dataType string
+		expected interface{}
+	}{
+		{float64(10), IntType, int64(10)},
+		{"10", IntType, int64(10)},
+		{float64(-1 << 63), IntType, int64(-1 << 63)},
+		{10, FloatType, float64(10)},
+		{"0.5", FloatType, 0.5},
+		{"true", BoolType, true},
+		{false, BoolType, false},
+		{"label", StringType, "label"},
+	}
+	for _, c := range cases {
+		converted, err := ConvertValue(c.value, c.dataType)
+		if err != nil || converted != c.expected {
+			t.Errorf("expected %v as %s to be %v, got %v (%v)", c.value, c.dataType, c.expected, converted, err)
+		}
+	}
+
+	invalid := []struct {
+		value    interface{}
+		dataType string
+	}{
+		{10.5, IntType},
+		{float64(1 << 63), IntType},
+		{"ten", IntType},
+		{"yes please", BoolType},
+		{10, StringType},
+		{true, FloatType},
+	}
+	for _, c := range invalid {
+		if converted, err := ConvertValue(c.value, c.dataType); err == nil {
+			t.Errorf("expected %v not to be a valid %s, got %v", c.value, c.dataType, converted)
+		}
+	}
+}
+
+func TestValidateFeatureSet(t *testing.T) {
+	min, max := 0.0, 120.0
+	schema := &FeatureSetSchema{
+		Name: "customers",
+		Features: []FeatureSchema{
+			{Name: "age", DataType: "integer", Min: &min, Max: &max},
+			{Name: "segment", DataType: "string", Enum: []interface{}{"gold", "silver"}},
+			{Name: "score", DataType: "double", Nullable: true},
+		},
+	}
+	if err := schema.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	duplicate := &FeatureSetSchema{Name: "customers", Features: []FeatureSchema{{Name: "age", DataType: "int"}, {Name: "age", DataType: "float"}}}
+	if err := duplicate.Validate(); err == nil {
+		t.Error("expected a schema defining a feature twice to be rejected")
+	}
+	if schema.Features[0].DataType != IntType || schema.Features[2].DataType != FloatType {
+		t.Errorf("expected schema data types to be canonical, got %v", schema.Features)
+	}
+
+	valid := &FeatureSet{
+		Name:    "customers",
+		Version: "v1",
+		Features: []Feature{
+			{Name: "age", Value: float64(42), DataType: "Int64"},
+			{Name: "segment", Value: "gold"},
+		},
+	}
+	if err := valid.ValidateWithSchema(schema); err != nil {
+		t.Fatal(err)
+	}
+	if valid.Features[0].Value != int64(42) || valid.Features[0].DataType != IntType || valid.Features[1].DataType != StringType {
+		t.Errorf("expected features to be converted to canonical form, got %v", valid.Features)
+	}
+
+	invalid := map[string][]Feature{
+		"out of range":  {{Name: "age", Value: 130}, {Name: "segment", Value: "gold"}},
+		"not in enum":   {{Name: "age", Value: 42}, {Name: "segment", Value: "bronze"}},
+		"wrong type":    {{Name: "age", Value: "old"}, {Name: "segment", Value: "gold"}},
+		"other type":    {{Name: "age", Value: 42, DataType: "float"}, {Name: "segment", Value: "gold"}},
+		"missing":       {{Name: "segment", Value: "gold"}},
+		"null":          {{Name: "age", Value: nil}, {Name: "segment", Value: "gold"}},
+		"not in schema": {{Name: "age", Value: 42}, {Name: "segment", Value: "gold"}, {Name: "other", Value: 1}},
+		"nullable":      {{Name: "age", Value: 42}, {Name: "segment", Value: "gold"}, {Name: "score", Value: "high"}},
+		"duplicate":     {{Name: "age", Value: 42}, {Name: "segment", Value: "gold"}, {Name: "age", Value: 43}},
+	}
+	for description, features := range invalid {
+		fs := &FeatureSet{Name: "customers", Version: "v1", Features: features}
+		if err := fs.ValidateWithSchema(schema); err == nil {
+			t.Errorf("expected feature set with %s feature to be rejected", description)
+		}
+	}
+}
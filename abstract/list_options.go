@@ -0,0 +1,262 @@
+package abstract
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// sort keys available on the list endpoints
+const (
+	SortByName             = "name"
+	SortByInsertedAt       = "inserted-at"
+	SortByLastDiscoveredAt = "last-discovered-at"
+)
+
+const (
+	// DefaultLimit ... page size used when no limit is requested
+	DefaultLimit = 100
+	// MaxLimit ... maximum page size, so that a collection is never loaded at once
+	MaxLimit = 1000
+)
+
+// ListOptions ... pagination, sorting and projection of a list request
+type ListOptions struct {
+	Limit  int
+	Offset int
+	// Cursor ... opaque cursor returned with the previous page, takes precedence over the offset
+	Cursor     string
+	SortBy     string
+	Descending bool
+	// Fields ... json fields to be returned, all if empty
+	Fields []string
+}
+
+// Validate ... validate the options and set the defaults for limit and sort
+func (opts *ListOptions) Validate(allowedSorts []string, item interface{}) error {
+	if opts.Limit == 0 {
+		opts.Limit = DefaultLimit
+	}
+	if opts.Limit < 0 || opts.Limit > MaxLimit {
+		return fmt.Errorf("Limit should be between 1 and %d", MaxLimit)
+	}
+	if opts.Offset < 0 {
+		return errors.New("Offset should not be negative")
+	}
+	if opts.Cursor != "" {
+		if _, err := DecodeCursor(opts.Cursor); err != nil {
+			return err
+		}
+	}
+
+	if opts.SortBy == "" {
+		opts.SortBy = allowedSorts[0]
+	}
+	validSort := false
+	for _, s := range allowedSorts {
+		validSort = validSort || s == opts.SortBy
+	}
+	if !validSort {
+		return fmt.Errorf("Sort %s is not supported, use one of %s", opts.SortBy, strings.Join(allowedSorts, ","))
+	}
+
+	available := jsonFieldNames(item)
+	for _, f := range opts.Fields {
+		if !available[f] {
+			return fmt.Errorf("Field %s is not available", f)
+		}
+	}
+	return nil
+}
+
+// jsonFieldNames ... returns the json names of the fields of a struct
+func jsonFieldNames(item interface{}) map[string]bool {
+	names := make(map[string]bool)
+	t := reflect.TypeOf(item)
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// Cursor ... position of the next page, either an offset or the sort values of the last item for backends supporting it
+type Cursor struct {
+	Offset      int           `json:"offset,omitempty"`
+	SearchAfter []interface{} `json:"search-after,omitempty"`
+}
+
+// EncodeCursor ... returns the cursor as an opaque url-safe string
+func EncodeCursor(c *Cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor ... parses a cursor returned by EncodeCursor
+func DecodeCursor(s string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.New("Invalid cursor")
+	}
+	c := &Cursor{}
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	// keep numbers as they are, as they are passed back to the backend
+	decoder.UseNumber()
+	if err := decoder.Decode(c); err != nil {
+		return nil, errors.New("Invalid cursor")
+	}
+	return c, nil
+}
+
+// StartOffset ... offset of the first item of the page, taken from the cursor if any
+func (opts *ListOptions) StartOffset() int {
+	if opts.Cursor != "" {
+		if c, err := DecodeCursor(opts.Cursor); err == nil {
+			return c.Offset
+		}
+	}
+	return opts.Offset
+}
+
+// NextOffsetCursor ... cursor to the page following the one starting at offset,
+// empty if no more items are available
+func (opts *ListOptions) NextOffsetCursor(offset int, more bool) string {
+	if !more {
+		return ""
+	}
+	return EncodeCursor(&Cursor{Offset: offset + opts.Limit})
+}
+
+// AssetPage ... a page of assets and the cursor to the next one
+type AssetPage struct {
+	Assets     []Asset `json:"assets"`
+	NextCursor string  `json:"next-cursor,omitempty"`
+}
+
+// FeatureSetPage ... a page of feature sets and the cursor to the next one
+type FeatureSetPage struct {
+	FeatureSets []FeatureSet `json:"featuresets"`
+	NextCursor  string       `json:"next-cursor,omitempty"`
+}
+
+// window ... returns the bounds of the page within n items and whether more items follow
+func (opts *ListOptions) window(n int) (int, int, bool) {
+	start := opts.StartOffset()
+	if start > n {
+		start = n
+	}
+	end := start + opts.Limit
+	if end > n {
+		end = n
+	}
+	return start, end, end < n
+}
+
+// compareTimes ... returns -1, 0 or 1 if a is before, equal to or after b
+func compareTimes(a time.Time, b time.Time) int {
+	switch {
+	case a.Before(b):
+		return -1
+	case a.After(b):
+		return 1
+	}
+	return 0
+}
+
+// PageAssets ... sorts and pages the assets in memory, for DAOs without native pagination,
+// soft-deleted assets are not listed
+func PageAssets(all []Asset, opts *ListOptions) *AssetPage {
+	var assets []Asset
+	for _, asset := range all {
+		if !asset.IsDeleted() {
+			assets = append(assets, asset)
+		}
+	}
+	sort.Slice(assets, func(i, j int) bool {
+		c := 0
+		if opts.SortBy == SortByLastDiscoveredAt {
+			c = compareTimes(assets[i].LastDiscoveredAt, assets[j].LastDiscoveredAt)
+		}
+		// the name is unique and used to break ties
+		if c == 0 {
+			c = strings.Compare(assets[i].Name, assets[j].Name)
+		}
+		if opts.Descending {
+			return c > 0
+		}
+		return c < 0
+	})
+
+	start, end, more := opts.window(len(assets))
+	return &AssetPage{
+		Assets:     append([]Asset{}, assets[start:end]...),
+		NextCursor: opts.NextOffsetCursor(start, more),
+	}
+}
+
+// PageFeatureSets ... sorts and pages the feature sets in memory, for DAOs without native pagination,
+// the feature sets are expected in insertion order, which is used to break ties
+func PageFeatureSets(fsets []FeatureSet, opts *ListOptions) *FeatureSetPage {
+	// sort positions rather than feature sets, so that ties can be broken by insertion order
+	positions := make([]int, len(fsets))
+	for i := range positions {
+		positions[i] = i
+	}
+	sort.Slice(positions, func(i, j int) bool {
+		a, b := &fsets[positions[i]], &fsets[positions[j]]
+		c := 0
+		switch opts.SortBy {
+		case SortByName:
+			c = strings.Compare(a.Name, b.Name)
+		case SortByInsertedAt:
+			c = compareTimes(a.InsertedAt, b.InsertedAt)
+		}
+		if c == 0 {
+			c = positions[i] - positions[j]
+		}
+		if opts.Descending {
+			return c > 0
+		}
+		return c < 0
+	})
+
+	start, end, more := opts.window(len(fsets))
+	page := &FeatureSetPage{
+		FeatureSets: []FeatureSet{},
+		NextCursor:  opts.NextOffsetCursor(start, more),
+	}
+	for _, p := range positions[start:end] {
+		page.FeatureSets = append(page.FeatureSets, fsets[p])
+	}
+	return page
+}
+
+// Project ... returns the items as json objects only including the given fields
+func Project(items interface{}, fields []string) ([]map[string]interface{}, error) {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	var objects []map[string]interface{}
+	if err := json.Unmarshal(data, &objects); err != nil {
+		return nil, err
+	}
+
+	projected := make([]map[string]interface{}, len(objects))
+	for i, o := range objects {
+		projected[i] = make(map[string]interface{})
+		for _, f := range fields {
+			if v, exist := o[f]; exist {
+				projected[i][f] = v
+			}
+		}
+	}
+	return projected, nil
+}
@@ -0,0 +1,226 @@
+package abstract
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// schema change kinds
+const (
+	ColumnAdded    = "column-added"
+	ColumnDropped  = "column-dropped"
+	TypeWidened    = "type-widened"
+	TypeNarrowed   = "type-narrowed"
+	TypeChanged    = "type-changed"
+	CommentChanged = "comment-changed"
+)
+
+// SchemaChange ... a change of a column between two schemas of a table,
+// breaking if readers of the previous schema may fail on the new one
+type SchemaChange struct {
+	Column     string `json:"column"`
+	Kind       string `json:"kind"`
+	OldType    string `json:"old-type,omitempty"`
+	NewType    string `json:"new-type,omitempty"`
+	OldComment string `json:"old-comment,omitempty"`
+	NewComment string `json:"new-comment,omitempty"`
+	Breaking   bool   `json:"breaking"`
+}
+
+// SchemaEvolution ... the schema changes introduced by a revision of a table,
+// along with the downstream assets impacted by the breaking ones
+type SchemaEvolution struct {
+	Revision  int            `json:"revision"`
+	CreatedAt time.Time      `json:"created-at"`
+	Author    string         `json:"author,omitempty"`
+	Changes   []SchemaChange `json:"changes"`
+	Breaking  bool           `json:"breaking"`
+	Impacted  []string       `json:"impacted,omitempty"`
+}
+
+// IsBreaking ... returns true if any of the changes is breaking
+func IsBreaking(changes []SchemaChange) bool {
+	for _, c := range changes {
+		if c.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// DetectSchemaChanges ... classifies the changes of the schema label between the stored and the upserted version of a table,
+// nil if either is not a table or the stored version does not exist
+func DetectSchemaChanges(stored *Asset, upserted *Asset) ([]SchemaChange, error) {
+	if stored == nil || stored.Type != _Table || upserted.Type != _Table {
+		return nil, nil
+	}
+	before, err := SchemaColumns(stored)
+	if err != nil {
+		return nil, err
+	}
+	after, err := SchemaColumns(upserted)
+	if err != nil {
+		return nil, err
+	}
+	return ClassifySchemaChanges(before, after), nil
+}
+
+// ClassifySchemaChanges ... returns the changes from the before to the after schema, sorted by column and kind
+func ClassifySchemaChanges(before map[string]ColumnInfo, after map[string]ColumnInfo) []SchemaChange {
+	changes := []SchemaChange{}
+	for name, o := range before {
+		n, exist := after[name]
+		if !exist {
+			changes = append(changes, SchemaChange{Column: name, Kind: ColumnDropped, OldType: o.Type, OldComment: o.Comment, Breaking: true})
+			continue
+		}
+		if kind := classifyTypeChange(o.Type, n.Type); kind != "" {
+			changes = append(changes, SchemaChange{Column: name, Kind: kind, OldType: o.Type, NewType: n.Type, Breaking: kind != TypeWidened})
+		}
+		if o.Comment != n.Comment {
+			changes = append(changes, SchemaChange{Column: name, Kind: CommentChanged, OldComment: o.Comment, NewComment: n.Comment})
+		}
+	}
+	for name, n := range after {
+		if _, exist := before[name]; !exist {
+			changes = append(changes, SchemaChange{Column: name, Kind: ColumnAdded, NewType: n.Type, NewComment: n.Comment})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Column != changes[j].Column {
+			return changes[i].Column < changes[j].Column
+		}
+		return changes[i].Kind < changes[j].Kind
+	})
+	return changes
+}
+
+// numericRanks ... hive numeric types by increasing range, a type can be safely widened to any of a higher rank
+var numericRanks = map[string]int{
+	"tinyint":  1,
+	"smallint": 2,
+	"int":      3,
+	"integer":  3,
+	"bigint":   4,
+	"float":    5,
+	"double":   6,
+}
+
+// integerDigits ... maximum number of digits of the hive integer types, which fit a decimal with as many integer digits
+var integerDigits = map[string]int{
+	"tinyint":  3,
+	"smallint": 5,
+	"int":      10,
+	"integer":  10,
+	"bigint":   19,
+}
+
+// parameterized ... type name and parameters, e.g. decimal(10,2) or varchar(64)
+var parameterized = regexp.MustCompile(`^(\w+)\s*\(\s*(\d+)\s*(?:,\s*(\d+)\s*)?\)$`)
+
+// parseType ... returns the normalized name and the parameters of a column type, -1 if missing
+func parseType(t string) (string, int, int) {
+	t = strings.ToLower(strings.TrimSpace(t))
+	match := parameterized.FindStringSubmatch(t)
+	if match == nil {
+		return t, -1, -1
+	}
+	precision, _ := strconv.Atoi(match[2])
+	scale := -1
+	if match[3] != "" {
+		scale, _ = strconv.Atoi(match[3])
+	}
+	return match[1], precision, scale
+}
+
+// classifyTypeChange ... returns whether the type was widened, narrowed or changed, empty if the type is the same
+func classifyTypeChange(before string, after string) string {
+	oldName, oldP, oldS := parseType(before)
+	newName, newP, newS := parseType(after)
+	if oldName == newName && oldP == newP && oldS == newS {
+		return ""
+	}
+
+	oldRank, oldNumeric := numericRanks[oldName]
+	newRank, newNumeric := numericRanks[newName]
+	switch {
+	case oldNumeric && newNumeric:
+		// e.g. int and integer are aliases
+		if newRank == oldRank {
+			return ""
+		}
+		if newRank > oldRank {
+			return TypeWidened
+		}
+		return TypeNarrowed
+
+	case oldName == "decimal" && newName == "decimal":
+		oldP, oldS = decimalDefaults(oldP, oldS)
+		newP, newS = decimalDefaults(newP, newS)
+		if oldP == newP && oldS == newS {
+			return ""
+		}
+		// both the integer and the fractional digits shall fit
+		if newS >= oldS && newP-newS >= oldP-oldS {
+			return TypeWidened
+		}
+		return TypeNarrowed
+
+	case integerDigits[oldName] > 0 && newName == "decimal":
+		newP, newS = decimalDefaults(newP, newS)
+		if newP-newS >= integerDigits[oldName] {
+			return TypeWidened
+		}
+		return TypeNarrowed
+
+	case isCharacterType(oldName) && isCharacterType(newName):
+		// string is unbounded, while char and varchar have a maximum length
+		if newName == "string" {
+			return TypeWidened
+		}
+		if oldName == "string" || newP < oldP {
+			return TypeNarrowed
+		}
+		return TypeWidened
+	}
+	return TypeChanged
+}
+
+// decimalDefaults ... hive defaults to decimal(10,0)
+func decimalDefaults(precision int, scale int) (int, int) {
+	if precision < 0 {
+		precision = 10
+	}
+	if scale < 0 {
+		scale = 0
+	}
+	return precision, scale
+}
+
+func isCharacterType(name string) bool {
+	return name == "string" || name == "varchar" || name == "char"
+}
+
+// SchemaEvolutions ... returns the schema changes of the revisions of a table,
+// only those including breaking changes if breakingOnly is set
+func SchemaEvolutions(revisions []AssetRevision, breakingOnly bool) []SchemaEvolution {
+	evolution := []SchemaEvolution{}
+	for _, rev := range revisions {
+		breaking := IsBreaking(rev.SchemaChanges)
+		if len(rev.SchemaChanges) == 0 || (breakingOnly && !breaking) {
+			continue
+		}
+		evolution = append(evolution, SchemaEvolution{
+			Revision:  rev.Revision,
+			CreatedAt: rev.CreatedAt,
+			Author:    rev.Author,
+			Changes:   rev.SchemaChanges,
+			Breaking:  breaking,
+			Impacted:  rev.Impacted,
+		})
+	}
+	return evolution
+}
@@ -0,0 +1,63 @@
+package abstract
+
+import (
+	"testing"
+)
+
+func TestClassifyTypeChange(t *testing.T) {
+	cases := []struct {
+		before, after, kind string
+	}{
+		{"int", "INT", ""},
+		{"int", "integer", ""},
+		{"int", "bigint", TypeWidened},
+		{"bigint", "int", TypeNarrowed},
+		{"float", "double", TypeWidened},
+		{"decimal", "decimal(10,0)", ""},
+		{"decimal(10,2)", "decimal(12,2)", TypeWidened},
+		{"decimal(10,2)", "decimal(10,4)", TypeNarrowed},
+		{"int", "decimal(10,0)", TypeWidened},
+		{"bigint", "decimal(10,0)", TypeNarrowed},
+		{"varchar(64)", "varchar(128)", TypeWidened},
+		{"varchar(64)", "string", TypeWidened},
+		{"string", "varchar(64)", TypeNarrowed},
+		{"string", "int", TypeChanged},
+	}
+	for _, c := range cases {
+		if kind := classifyTypeChange(c.before, c.after); kind != c.kind {
+			t.Errorf("%s to %s: expected %q, got %q", c.before, c.after, c.kind, kind)
+		}
+	}
+}
+
+func TestClassifySchemaChanges(t *testing.T) {
+	before := map[string]ColumnInfo{
+		"id":    {Type: "int", Comment: "key"},
+		"name":  {Type: "string"},
+		"score": {Type: "double"},
+	}
+	after := map[string]ColumnInfo{
+		"id":    {Type: "bigint", Comment: "primary key"},
+		"email": {Type: "string"},
+		"score": {Type: "float"},
+	}
+	expected := []SchemaChange{
+		{Column: "email", Kind: ColumnAdded, NewType: "string"},
+		{Column: "id", Kind: CommentChanged, OldComment: "key", NewComment: "primary key"},
+		{Column: "id", Kind: TypeWidened, OldType: "int", NewType: "bigint"},
+		{Column: "name", Kind: ColumnDropped, OldType: "string", Breaking: true},
+		{Column: "score", Kind: TypeNarrowed, OldType: "double", NewType: "float", Breaking: true},
+	}
+	changes := ClassifySchemaChanges(before, after)
+	if len(changes) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, changes)
+	}
+	for i := range expected {
+		if changes[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected[i], changes[i])
+		}
+	}
+	if !IsBreaking(changes) || IsBreaking(changes[:3]) {
+		t.Error("expected only dropped and narrowed columns to be breaking")
+	}
+}
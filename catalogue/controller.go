@@ -1,15 +1,23 @@
 package catalogue
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/utils/auth"
 	"github.com/pilillo/mastro/utils/conf"
 	"github.com/pilillo/mastro/utils/errors"
+	"github.com/pilillo/mastro/utils/grpc"
+	"github.com/pilillo/mastro/utils/lifecycle"
+	"github.com/pilillo/mastro/utils/logging"
+	"github.com/pilillo/mastro/utils/metrics"
+	"github.com/pilillo/mastro/utils/openapi"
 	"github.com/pilillo/mastro/utils/queries"
+	"github.com/pilillo/mastro/utils/tracing"
 )
 
 const (
@@ -18,8 +26,26 @@ const (
 	// placeholders for the values actually passed to the endpoint
 	assetIDParam   string = "asset_id"
 	assetNameParam string = "asset_name"
+	ownerParam     string = "owner"
+	domainParam    string = "domain"
 )
 
+// serviceFor ... returns the asset service as seen by the authenticated principal, i.e. filtering and redacting
+// the assets above its clearance, or the whole service if endpoints are not protected
+func serviceFor(c *gin.Context) Service {
+	return serviceForPrincipal(c.Request.Context(), auth.GetPrincipal(c))
+}
+
+// serviceForPrincipal ... returns the asset service as seen by the principal, the whole service if nil,
+// tracing the calls within the context of the request
+func serviceForPrincipal(ctx context.Context, principal *auth.Principal) Service {
+	s := withContext(assetService, ctx)
+	if principal != nil {
+		return withClearance(s, principal.Clearance)
+	}
+	return s
+}
+
 // Ping ... replies to a ping message for healthcheck purposes
 func Ping(c *gin.Context) {
 	c.String(http.StatusOK, "pong")
@@ -30,11 +56,11 @@ func UpsertAsset(c *gin.Context) {
 	asset := abstract.Asset{}
 	if err := c.ShouldBindJSON(&asset); err != nil {
 		restErr := errors.GetBadRequestError("Invalid JSON Body")
-		c.JSON(restErr.Status, restErr)
+		logging.ReplyError(c, restErr)
 	} else {
-		result, saveErr := assetService.UpsertAssets(&[]abstract.Asset{asset})
+		result, saveErr := serviceFor(c).UpsertAssets(&[]abstract.Asset{asset})
 		if saveErr != nil {
-			c.JSON(saveErr.Status, saveErr)
+			logging.ReplyError(c, saveErr)
 		} else {
 			c.JSON(http.StatusCreated, result)
 		}
@@ -46,11 +72,11 @@ func BulkUpsert(c *gin.Context) {
 	assets := []abstract.Asset{}
 	if err := c.ShouldBindJSON(&assets); err != nil {
 		restErr := errors.GetBadRequestError("Invalid JSON Body")
-		c.JSON(restErr.Status, restErr)
+		logging.ReplyError(c, restErr)
 	} else {
-		result, saveErr := assetService.UpsertAssets(&assets)
+		result, saveErr := serviceFor(c).UpsertAssets(&assets)
 		if saveErr != nil {
-			c.JSON(saveErr.Status, saveErr)
+			logging.ReplyError(c, saveErr)
 		} else {
 			c.JSON(http.StatusCreated, result)
 		}
@@ -60,9 +86,9 @@ func BulkUpsert(c *gin.Context) {
 // GetAssetByID ... retrieves an asset description by its Unique Name ID
 func GetAssetByID(c *gin.Context) {
 	nameID := c.Param(assetIDParam)
-	asset, getErr := assetService.GetAssetByID(nameID)
+	asset, getErr := serviceFor(c).GetAssetByID(nameID)
 	if getErr != nil {
-		c.JSON(getErr.Status, getErr)
+		logging.ReplyError(c, getErr)
 	} else {
 		c.JSON(http.StatusOK, asset)
 	}
@@ -71,9 +97,9 @@ func GetAssetByID(c *gin.Context) {
 // GetAssetByName ... retrieves an asset description by its Unique Name
 func GetAssetByName(c *gin.Context) {
 	nameID := c.Param(assetNameParam)
-	asset, getErr := assetService.GetAssetByName(nameID)
+	asset, getErr := serviceFor(c).GetAssetByName(nameID)
 	if getErr != nil {
-		c.JSON(getErr.Status, getErr)
+		logging.ReplyError(c, getErr)
 	} else {
 		c.JSON(http.StatusOK, asset)
 	}
@@ -86,15 +112,15 @@ func SearchAssetsByTags(c *gin.Context) {
 
 	if err != nil {
 		restErr := errors.GetBadRequestError("Invalid query by tag :: invalid input json format")
-		c.JSON(restErr.Status, restErr)
+		logging.ReplyError(c, restErr)
 	} else {
 		if query.Tags == nil || len(query.Tags) == 0 {
 			restErr := errors.GetBadRequestError("Invalid query by tag :: empty tag list")
-			c.JSON(restErr.Status, restErr)
+			logging.ReplyError(c, restErr)
 		} else {
-			assets, getErr := assetService.SearchAssetsByTags(query.Tags)
+			assets, getErr := serviceFor(c).SearchAssetsByTags(query.Tags)
 			if getErr != nil {
-				c.JSON(getErr.Status, getErr)
+				logging.ReplyError(c, getErr)
 			} else {
 				c.JSON(http.StatusOK, assets)
 			}
@@ -102,46 +128,286 @@ func SearchAssetsByTags(c *gin.Context) {
 	}
 }
 
-// ListAllAssets ... returns all assets
+// SearchAssets ... retrieves the assets matching a free text query and filters, with facets per type and tag
+func SearchAssets(c *gin.Context) {
+	search := abstract.AssetSearch{}
+	if err := c.ShouldBindJSON(&search); err != nil {
+		restErr := errors.GetBadRequestError("Invalid search :: invalid input json format")
+		logging.ReplyError(c, restErr)
+		return
+	}
+	result, err := serviceFor(c).SearchAssets(&search)
+	if err != nil {
+		logging.ReplyError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// ListAssetsByOwner ... retrieves the assets of an owner, e.g. assets/owner/jane.doe?limit=10
+func ListAssetsByOwner(c *gin.Context) {
+	listAssetsBy(c, func(limit int) (*abstract.AssetSearchResult, *errors.RestErr) {
+		return serviceFor(c).ListAssetsByOwner(c.Param(ownerParam), limit)
+	})
+}
+
+// ListAssetsByDomain ... retrieves the assets of a domain, e.g. assets/domain/sales?limit=10
+func ListAssetsByDomain(c *gin.Context) {
+	listAssetsBy(c, func(limit int) (*abstract.AssetSearchResult, *errors.RestErr) {
+		return serviceFor(c).ListAssetsByDomain(c.Param(domainParam), limit)
+	})
+}
+
+func listAssetsBy(c *gin.Context, list func(limit int) (*abstract.AssetSearchResult, *errors.RestErr)) {
+	query := queries.List{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		restErr := errors.GetBadRequestError("Invalid list parameters")
+		logging.ReplyError(c, restErr)
+		return
+	}
+	result, err := list(query.Limit)
+	if err != nil {
+		logging.ReplyError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// GetAssetLineage ... retrieves the lineage graph of an asset as json or Graphviz dot,
+// e.g. asset/name/mydb.mytable/lineage?direction=downstream&depth=2&format=dot
+func GetAssetLineage(c *gin.Context) {
+	query := queries.Lineage{Direction: abstract.LineageBoth}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		restErr := errors.GetBadRequestError("Invalid lineage parameters")
+		logging.ReplyError(c, restErr)
+		return
+	}
+	if query.Format != "" && query.Format != "json" && query.Format != "dot" {
+		restErr := errors.GetBadRequestError(fmt.Sprintf("Format %s is not supported, use one of json or dot", query.Format))
+		logging.ReplyError(c, restErr)
+		return
+	}
+
+	graph, err := serviceFor(c).GetAssetLineage(c.Param(assetNameParam), query.Direction, query.Depth)
+	if err != nil {
+		logging.ReplyError(c, err)
+		return
+	}
+	if query.Format == "dot" {
+		c.Data(http.StatusOK, "text/vnd.graphviz; charset=utf-8", []byte(graph.DOT()))
+		return
+	}
+	c.JSON(http.StatusOK, graph)
+}
+
+// ListAssetRevisions ... retrieves the revision history of an asset
+func ListAssetRevisions(c *gin.Context) {
+	revisions, err := serviceFor(c).ListAssetRevisions(c.Param(assetNameParam))
+	if err != nil {
+		logging.ReplyError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, revisions)
+}
+
+// DiffAssetRevisions ... compares two revisions of an asset, e.g. asset/name/mydb.mytable/diff?from=1&to=3
+func DiffAssetRevisions(c *gin.Context) {
+	query := queries.Diff{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		restErr := errors.GetBadRequestError("Invalid diff parameters")
+		logging.ReplyError(c, restErr)
+		return
+	}
+	diff, err := serviceFor(c).DiffAssetRevisions(c.Param(assetNameParam), query.From, query.To)
+	if err != nil {
+		logging.ReplyError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, diff)
+}
+
+// ListSchemaChanges ... retrieves the schema changes of a table, e.g. asset/name/mydb.mytable/schema-changes?breaking=true
+func ListSchemaChanges(c *gin.Context) {
+	changes, err := serviceFor(c).ListSchemaChanges(c.Param(assetNameParam), c.Query("breaking") == "true")
+	if err != nil {
+		logging.ReplyError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, changes)
+}
+
+// DeleteAsset ... soft-deletes an asset by its unique name, or removes it if purge=true is set
+func DeleteAsset(c *gin.Context) {
+	purge := c.Query("purge") == "true"
+	if err := serviceFor(c).DeleteAsset(c.Param(assetNameParam), purge); err != nil {
+		logging.ReplyError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// ReconcileAssets ... marks as stale the assets of a crawler which were not found in its last walk
+func ReconcileAssets(c *gin.Context) {
+	query := queries.Reconcile{}
+	if err := c.ShouldBindJSON(&query); err != nil {
+		restErr := errors.GetBadRequestError("Invalid reconciliation :: invalid input json format")
+		logging.ReplyError(c, restErr)
+		return
+	}
+	stale, err := serviceFor(c).ReconcileAssets(query.DiscoveredBy, query.Names)
+	if err != nil {
+		logging.ReplyError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, queries.ReconcileResult{DiscoveredBy: query.DiscoveredBy, Stale: stale})
+}
+
+// ListAllAssets ... returns a page of assets, e.g. assets/?limit=10&sort=-last-discovered-at&fields=name,type
 func ListAllAssets(c *gin.Context) {
-	assets, err := assetService.ListAllAssets()
+	query := queries.List{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		restErr := errors.GetBadRequestError("Invalid list parameters")
+		logging.ReplyError(c, restErr)
+		return
+	}
+	result, err := listAllAssets(serviceFor(c), query.ToListOptions())
 	if err != nil {
-		c.JSON(err.Status, err)
-	} else {
-		c.JSON(http.StatusOK, assets)
+		logging.ReplyError(c, err)
+		return
 	}
+	c.JSON(http.StatusOK, result)
 }
 
-var router = gin.Default()
+// listAllAssets ... returns a page of assets, with only the requested fields if any
+func listAllAssets(s Service, opts *abstract.ListOptions) (interface{}, *errors.RestErr) {
+	page, err := s.ListAllAssets(opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.Fields) == 0 {
+		return page, nil
+	}
+	// only return the requested fields
+	assets, projectErr := abstract.Project(page.Assets, opts.Fields)
+	if projectErr != nil {
+		return nil, errors.GetInternalServerError(projectErr.Error())
+	}
+	result := gin.H{"assets": assets}
+	if page.NextCursor != "" {
+		result["next-cursor"] = page.NextCursor
+	}
+	return result, nil
+}
+
+var (
+	router = gin.New()
+	// the servers of the endpoints, stopped by Shutdown
+	httpServer *http.Server
+	grpcServer *grpc.Server
+)
 
 // StartEndpoint ... starts the service endpoint
-func StartEndpoint(cfg *conf.Config) {
+func StartEndpoint(cfg *conf.Config) error {
+	// assign an id to each request, log it and recover from panics
+	router.Use(logging.Middleware())
 	// https://github.com/gin-contrib/cors
-	// allow all origins
-	router.Use(cors.Default())
+	// allow all origins, along with the authorization header
+	router.Use(cors.New(auth.CorsConfig()))
+	// count and time the requests per route and status
+	router.Use(metrics.Middleware())
+	// trace the requests, as children of the spans of the callers if any
+	if err := tracing.Init(cfg.TracingDefinition, "mastro-catalogue"); err != nil {
+		return err
+	}
+	router.Use(tracing.Middleware())
 
 	// init service
-	assetService.Init(cfg)
+	if restErr := assetService.Init(cfg); restErr != nil {
+		return fmt.Errorf("Failed initializing the service :: %s", restErr.Message)
+	}
+
+	// authenticate requests and authorize them by the roles of the principal
+	authz, err := auth.NewMiddleware(cfg.AuthDefinition)
+	if err != nil {
+		return err
+	}
+	registerRoutes(router, authz)
+	// serve the same service over gRPC, if a port is set for it
+	if grpcServer, err = startGRPC(cfg, authz); err != nil {
+		return err
+	}
+
+	// run router as standalone service
+	// todo: do we need to run multiple endpoints from the main?
+	httpServer, err = lifecycle.Serve(fmt.Sprintf(":%s", cfg.Details["port"]), router)
+	return err
+}
+
+// Shutdown ... stops accepting requests and waits for the running ones to end, or for the context to be done,
+// then closes the connections to the backends and exports the pending traces
+func Shutdown(ctx context.Context) error {
+	lifecycle.Drain()
+	err := lifecycle.Shutdown(ctx, httpServer)
+	if grpcServer != nil {
+		if grpcErr := grpcServer.Shutdown(ctx); err == nil {
+			err = grpcErr
+		}
+	}
+	closeBackends()
+	tracing.Shutdown()
+	return err
+}
+
+// registerRoutes ... registers the endpoints on the router, as described in the OpenAPI document of apiDocument
+func registerRoutes(router gin.IRoutes, authz *auth.Middleware) {
+	read, write, remove := authz.Require(auth.Read), authz.Require(auth.Write), authz.Require(auth.Delete)
+
+	// serve the OpenAPI document of the endpoints
+	doc := apiDocument()
+	if !authz.Enabled() {
+		doc.Security = nil
+	}
+	router.GET(openAPIRoute, openapi.Handler(doc))
 
 	// add an healthcheck for the endpoint
 	router.GET(fmt.Sprintf("healthcheck/%s", assetRestEndpoint), Ping)
+	// expose the metrics of the endpoint
+	router.GET(metrics.Route, gin.WrapH(metrics.Handler()))
+	// probes of the process and of its backends
+	router.GET(lifecycle.LivenessRoute, lifecycle.Live)
+	router.GET(lifecycle.ReadinessRoute, lifecycle.Ready)
+	// the routes registered from now on reply 503 while the backends are not connected
+	router.Use(lifecycle.Available)
 
 	// get specific asset as asset/:id or asset/:name
-	router.GET(fmt.Sprintf("%s/id/:%s", assetRestEndpoint, assetIDParam), GetAssetByID)
-	router.GET(fmt.Sprintf("%s/name/:%s", assetRestEndpoint, assetNameParam), GetAssetByName)
+	router.GET(fmt.Sprintf("%s/id/:%s", assetRestEndpoint, assetIDParam), read, GetAssetByID)
+	router.GET(fmt.Sprintf("%s/name/:%s", assetRestEndpoint, assetNameParam), read, GetAssetByName)
+	// get the lineage graph of an asset
+	router.GET(fmt.Sprintf("%s/name/:%s/lineage", assetRestEndpoint, assetNameParam), read, GetAssetLineage)
+	// get the revision history of an asset and compare two of its revisions
+	router.GET(fmt.Sprintf("%s/name/:%s/revisions", assetRestEndpoint, assetNameParam), read, ListAssetRevisions)
+	router.GET(fmt.Sprintf("%s/name/:%s/diff", assetRestEndpoint, assetNameParam), read, DiffAssetRevisions)
+	// get the classified schema changes of a table
+	router.GET(fmt.Sprintf("%s/name/:%s/schema-changes", assetRestEndpoint, assetNameParam), read, ListSchemaChanges)
 
 	// put 1 asset as asset/
-	router.PUT(fmt.Sprintf("%s/", assetRestEndpoint), UpsertAsset)
+	router.PUT(fmt.Sprintf("%s/", assetRestEndpoint), write, UpsertAsset)
 	// put n assets as asset/
-	router.PUT(fmt.Sprintf("%s/", assetsRestEndpoint), BulkUpsert)
+	router.PUT(fmt.Sprintf("%s/", assetsRestEndpoint), write, BulkUpsert)
+
+	// delete an asset, soft by default
+	router.DELETE(fmt.Sprintf("%s/name/:%s", assetRestEndpoint, assetNameParam), remove, DeleteAsset)
+	// mark assets no longer found by a crawler as stale
+	router.POST(fmt.Sprintf("%s/reconcile", assetsRestEndpoint), write, ReconcileAssets)
 
 	// get any asset matching tags
-	router.POST(fmt.Sprintf("%s/tags", assetsRestEndpoint), SearchAssetsByTags)
+	router.POST(fmt.Sprintf("%s/tags", assetsRestEndpoint), read, SearchAssetsByTags)
+	// full-text and faceted search over assets
+	router.POST(fmt.Sprintf("%s/search", assetsRestEndpoint), read, SearchAssets)
+	// list assets by owner and by domain
+	router.GET(fmt.Sprintf("%s/owner/:%s", assetsRestEndpoint, ownerParam), read, ListAssetsByOwner)
+	router.GET(fmt.Sprintf("%s/domain/:%s", assetsRestEndpoint, domainParam), read, ListAssetsByDomain)
 
 	// list all assets
-	router.GET(fmt.Sprintf("%s/", assetsRestEndpoint), ListAllAssets)
-
-	// run router as standalone service
-	// todo: do we need to run multiple endpoints from the main?
-	router.Run(fmt.Sprintf(":%s", cfg.Details["port"]))
+	router.GET(fmt.Sprintf("%s/", assetsRestEndpoint), read, ListAllAssets)
 }
@@ -0,0 +1,85 @@
+package catalogue
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/utils/auth"
+	"github.com/pilillo/mastro/utils/conf"
+)
+
+// projectingDAO ... only retrieves the requested fields of the listed assets, as the mongo and elastic daos do
+type projectingDAO struct {
+	abstract.AssetDAOProvider
+}
+
+func (d *projectingDAO) ListAssets(opts *abstract.ListOptions) (*abstract.AssetPage, error) {
+	page, err := d.AssetDAOProvider.ListAssets(opts)
+	if err != nil || len(opts.Fields) == 0 {
+		return page, err
+	}
+	projected, err := abstract.Project(page.Assets, opts.Fields)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(projected)
+	if err != nil {
+		return nil, err
+	}
+	page.Assets = []abstract.Asset{}
+	return page, json.Unmarshal(data, &page.Assets)
+}
+
+func TestListAllAssetsProjectsRedactedAssets(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	initEmbeddedService(t)
+	dao = &projectingDAO{AssetDAOProvider: dao}
+
+	assets := []abstract.Asset{
+		{Name: "mydb.users", Type: "table", Classification: abstract.PII},
+		{Name: "mydb.salaries", Type: "table", Classification: abstract.Confidential},
+		{Name: "mydb.report", Type: "report", Classification: abstract.Public},
+	}
+	if _, err := assetService.UpsertAssets(&assets); err != nil {
+		t.Fatal(err.Message)
+	}
+
+	tokens := filepath.Join(t.TempDir(), "tokens.yml")
+	if err := ioutil.WriteFile(tokens, []byte("- name: analyst\n  token: analyst-token\n  roles: [analyst]\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	authz, err := auth.NewMiddleware(&conf.AuthDefinition{Methods: []string{auth.TokenMethod}, TokensFile: tokens})
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine := gin.New()
+	registerRoutes(engine, authz)
+	server := httptest.NewServer(engine)
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"/assets/?fields=name", nil)
+	req.Header.Set("Authorization", "Bearer analyst-token")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected ok, got %d", resp.StatusCode)
+	}
+	result := struct {
+		Assets []map[string]interface{} `json:"assets"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Assets) != 1 || result.Assets[0]["name"] != "mydb.report" || len(result.Assets[0]) != 1 {
+		t.Errorf("expected only the name of the public asset, got %v", result.Assets)
+	}
+}
@@ -1,11 +1,13 @@
 package crawlers
 
 import (
+	"context"
 	"fmt"
+	"net/http"
+	"strings"
+	"sync"
 	"time"
 
-	"log"
-
 	"github.com/go-co-op/gocron"
 
 	"github.com/pilillo/mastro/abstract"
@@ -14,11 +16,23 @@ import (
 	"github.com/pilillo/mastro/catalogue/crawlers/impala"
 	"github.com/pilillo/mastro/catalogue/crawlers/local"
 	"github.com/pilillo/mastro/catalogue/crawlers/s3"
+	"github.com/pilillo/mastro/client"
+	hdfssource "github.com/pilillo/mastro/sources/hdfs"
+	hivesource "github.com/pilillo/mastro/sources/hive"
+	impalasource "github.com/pilillo/mastro/sources/impala"
+	s3source "github.com/pilillo/mastro/sources/s3"
 	"github.com/pilillo/mastro/utils/conf"
-
-	"github.com/go-resty/resty/v2"
+	"github.com/pilillo/mastro/utils/lifecycle"
+	"github.com/pilillo/mastro/utils/logging"
+	"github.com/pilillo/mastro/utils/metrics"
+	"github.com/pilillo/mastro/utils/retry"
+	"github.com/pilillo/mastro/utils/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// sourceBackend ... name of the data source among the backends of the lifecycle
+const sourceBackend = "source"
+
 var factories = map[string]func() abstract.Crawler{
 	"local":  local.NewCrawler,
 	"hdfs":   hdfs.NewCrawler,
@@ -27,7 +41,32 @@ var factories = map[string]func() abstract.Crawler{
 	"hive":   hive.NewCrawler,
 }
 
-var client = resty.New()
+// settings of the data sources, validated along the configuration
+var settingsValidators = map[string]conf.SettingsValidator{
+	"hdfs":   hdfssource.NewHDFSConnector().ValidateDataSourceDefinition,
+	"s3":     s3source.NewS3Connector().ValidateDataSourceDefinition,
+	"impala": impalasource.NewImpalaConnector().ValidateDataSourceDefinition,
+	"hive":   hivesource.NewHiveConnector().ValidateDataSourceDefinition,
+}
+
+func init() {
+	for name := range factories {
+		conf.RegisterDataSource(conf.Crawler, conf.BackendSection, name, settingsValidators[name])
+	}
+}
+
+// the running agent, stopped by Shutdown
+var (
+	mu            sync.Mutex
+	stopping      bool
+	scheduler     *gocron.Scheduler
+	running       abstract.Crawler
+	metricsServer *http.Server
+	// runs ... the running reconciliations, waited for on shutdown
+	runs sync.WaitGroup
+	// runsCtx ... context of the runs, cancelled to interrupt them if the shutdown times out
+	runsCtx, cancelRuns = context.WithCancel(context.Background())
+)
 
 // Start ... Starts the crawler defined in the provided config
 func Start(cfg *conf.Config) (abstract.Crawler, error) {
@@ -35,14 +74,30 @@ func Start(cfg *conf.Config) (abstract.Crawler, error) {
 	var crawler abstract.Crawler
 
 	if crawlerFactory, ok := factories[cfg.DataSourceDefinition.Type]; ok {
+		// trace the runs, if an exporter is defined
+		if err := tracing.Init(cfg.TracingDefinition, "mastro-crawler"); err != nil {
+			return nil, err
+		}
+		backoff, err := retry.NewBackoff(cfg.DataSourceDefinition.RetryDefinition)
+		if err != nil {
+			return nil, err
+		}
 		// call factory for selected crawler
 		crawler = crawlerFactory()
-		// init connection on the selected crawler
-		crawler.InitConnection(cfg)
-		log.Println("Successfully initialized connection", cfg.DataSourceDefinition.Name)
+		// init connection on the selected crawler, which keeps being retried in the background if the data source is not reachable
+		err = lifecycle.Connect(sourceBackend, backoff, func() error {
+			if _, err := crawler.InitConnection(cfg); err != nil {
+				return err
+			}
+			logging.Info("Successfully initialized connection", "source", cfg.DataSourceDefinition.Name)
+			return nil
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
 		// schedule crawler
 		//every := gocron.Every(cfg.CrawlerDefinition.ScheduleValue)
-		scheduler := gocron.NewScheduler(time.UTC)
+		scheduler = gocron.NewScheduler(time.UTC)
 		every := scheduler.Every(cfg.DataSourceDefinition.CrawlerDefinition.ScheduleValue)
 		switch cfg.DataSourceDefinition.CrawlerDefinition.ScheduleEvery {
 		case conf.Seconds:
@@ -73,19 +128,27 @@ func Start(cfg *conf.Config) (abstract.Crawler, error) {
 			return nil, fmt.Errorf("crawler: schedule period %s not found", cfg.DataSourceDefinition.CrawlerDefinition.ScheduleEvery)
 		}
 		// spawn crawler for the selected schedule period
-		_, err := every.Do(Reconcile, crawler, cfg)
+		_, err = every.Do(run, crawler, cfg)
 		// if err get out
 		if err != nil {
 			return nil, err
 		}
 
-		log.Println("Scheduled crawler every", cfg.DataSourceDefinition.CrawlerDefinition.ScheduleValue, cfg.DataSourceDefinition.CrawlerDefinition.ScheduleEvery)
+		logging.Info("Scheduled crawler", "every", cfg.DataSourceDefinition.CrawlerDefinition.ScheduleValue, "period", cfg.DataSourceDefinition.CrawlerDefinition.ScheduleEvery)
 
 		// start a run right now if necessary
 		if cfg.DataSourceDefinition.CrawlerDefinition.StartNow {
-			log.Println("Starting first run")
-			go Reconcile(crawler, cfg)
+			logging.Info("Starting first run")
+			go run(crawler, cfg)
+		}
+
+		// expose the metrics of the runs, if a port is set for them
+		if port := cfg.DataSourceDefinition.CrawlerDefinition.MetricsPort; port != "" {
+			if metricsServer, err = serveMetrics(port); err != nil {
+				return nil, err
+			}
 		}
+		running = crawler
 
 		// start gocron - move outside if we decide to start multiple crawlers within the same agent
 		//<-gocron.Start()
@@ -97,28 +160,144 @@ func Start(cfg *conf.Config) (abstract.Crawler, error) {
 	return nil, fmt.Errorf("Impossible to find specified Crawler %s", cfg.DataSourceDefinition.Type)
 }
 
+// catalogueURL ... returns the base url of the catalogue from a configured endpoint, which may include the route of the assets,
+// e.g. http://localhost:8085/assets and http://localhost:8085/assets/reconcile are both http://localhost:8085
+func catalogueURL(endpoint string) string {
+	endpoint = strings.TrimSuffix(endpoint, "/")
+	for _, route := range []string{"/assets/reconcile", "/assets"} {
+		endpoint = strings.TrimSuffix(endpoint, route)
+	}
+	return endpoint
+}
+
+// catalogueClient ... returns a client of the catalogue at the endpoint, authenticated with the crawler token if any
+func catalogueClient(endpoint string, cfg *conf.Config) *client.CatalogueClient {
+	return client.NewCatalogueClient(catalogueURL(endpoint), client.WithToken(cfg.DataSourceDefinition.CrawlerDefinition.AuthToken))
+}
+
+// serveMetrics ... serves the metrics of the crawler runs at /metrics on the port, in the background
+func serveMetrics(port string) (*http.Server, error) {
+	mux := http.NewServeMux()
+	mux.Handle(metrics.Route, metrics.Handler())
+	return lifecycle.Serve(fmt.Sprintf(":%s", port), mux)
+}
+
+// Shutdown ... stops scheduling runs and waits for the running one to end, or interrupts it if the context is done first,
+// then closes the connection to the data source and exports the pending traces
+func Shutdown(ctx context.Context) error {
+	mu.Lock()
+	stopping = true
+	mu.Unlock()
+	// stop connecting the data source, if it is not reachable yet
+	lifecycle.Drain()
+	if scheduler != nil {
+		scheduler.Stop()
+	}
+	done := make(chan struct{})
+	go func() {
+		runs.Wait()
+		close(done)
+	}()
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		// interrupt the walk and the requests to the catalogue, without waiting for connectors ignoring the context
+		cancelRuns()
+		err = ctx.Err()
+	}
+	if running != nil && lifecycle.Connected(sourceBackend) {
+		running.CloseConnection()
+	}
+	if metricsErr := lifecycle.Shutdown(ctx, metricsServer); err == nil {
+		err = metricsErr
+	}
+	tracing.Shutdown()
+	return err
+}
+
+// run ... reconciles the assets of the crawler, unless the agent is shutting down or the data source is not connected yet
+func run(crawler abstract.Crawler, cfg *conf.Config) {
+	if !lifecycle.Connected(sourceBackend) {
+		logging.Warn("Skipping run, the data source is not connected yet", "source", cfg.DataSourceDefinition.Name)
+		return
+	}
+	mu.Lock()
+	if stopping {
+		mu.Unlock()
+		return
+	}
+	runs.Add(1)
+	mu.Unlock()
+	defer runs.Done()
+	Reconcile(crawler, cfg)
+}
+
 // Reconcile ... call to walkWithFilter to traverse the FS tree and post all found assets to the catalogue endpoint
 func Reconcile(crawler abstract.Crawler, cfg *conf.Config) {
-	log.Println("Running crawler", cfg.DataSourceDefinition.Name)
-	assets, err := crawler.WalkWithFilter(cfg.DataSourceDefinition.CrawlerDefinition.Root, cfg.DataSourceDefinition.CrawlerDefinition.FilterFilename)
+	run := metrics.StartCrawlerRun(cfg.DataSourceDefinition.Name, cfg.DataSourceDefinition.Type)
+	// trace the run, the spans of the catalogue being children of those of its requests
+	ctx, span := tracing.Start(runsCtx, "crawler run",
+		attribute.String("crawler.source", cfg.DataSourceDefinition.Name), attribute.String("crawler.type", cfg.DataSourceDefinition.Type))
+	defer span.End()
+	// all the requests of the run share its id, so that the logs of the catalogue can be correlated with those of the crawler
+	ctx = logging.NewContext(ctx, logging.Default().With("crawler", cfg.DataSourceDefinition.Name))
+	ctx = logging.WithRequestID(ctx, logging.NewRequestID())
+	logger := logging.FromContext(ctx)
+	logger.Info("Running crawler", "type", cfg.DataSourceDefinition.Type)
+
+	walkCtx, walkSpan := tracing.Start(ctx, "crawler walk", attribute.String("crawler.root", cfg.DataSourceDefinition.CrawlerDefinition.Root))
+	assets, err := crawler.WalkWithFilter(walkCtx, cfg.DataSourceDefinition.CrawlerDefinition.Root, cfg.DataSourceDefinition.CrawlerDefinition.FilterFilename)
+	tracing.RecordError(walkSpan, err)
+	walkSpan.SetAttributes(attribute.Int("crawler.assets", len(assets)))
+	walkSpan.End()
 	if err != nil {
-		log.Println(err.Error())
+		logger.Error("Crawler walk failed", "error", err)
+		run.Failed("walk")
+		tracing.RecordError(span, err)
 		return
 	}
-	log.Printf("Found %d assets to merge in catalogue", len(assets))
+	logger.Info("Found assets to merge in catalogue", "assets", len(assets))
+	run.Found(len(assets))
+	// the data source name identifies the crawler which discovered the assets
+	for i := range assets {
+		assets[i].DiscoveredBy = cfg.DataSourceDefinition.Name
+	}
 	// call a remote catalogue endpoint to add those assets that were just found
-	// https://github.com/go-resty/resty/blob/master/example_test.go
-	resp, err := client.R().
-		SetHeader("Content-Type", "application/json").
-		SetBody(assets).
-		//Post(cfg.DataSourceDefinition.CrawlerDefinition.CatalogueEndpoint)
-		Put(cfg.DataSourceDefinition.CrawlerDefinition.CatalogueEndpoint)
+	start := time.Now()
+	upserted, err := catalogueClient(cfg.DataSourceDefinition.CrawlerDefinition.CatalogueEndpoint, cfg).WithContext(ctx).UpsertAssets(assets)
 	if err != nil {
-		log.Println(err.Error())
+		logger.Error("Catalogue upsert failed", "duration", time.Since(start), "error", err)
+		run.Failed("upsert")
+		tracing.RecordError(span, err)
 		return
 	}
-	// print response info
-	ti := resp.Request.TraceInfo()
+	logger.Info("Catalogue upserted assets", "assets", len(upserted), "duration", time.Since(start))
+	run.Pushed(len(upserted))
 
-	log.Printf("Catalogue response - status:%s statusCode:%d time:%v body:%s", resp.Status(), resp.StatusCode(), ti.ResponseTime, string(resp.Body()))
+	// only mark assets as stale once the found ones were successfully merged
+	if cfg.DataSourceDefinition.CrawlerDefinition.ReconcileEndpoint != "" {
+		if err := markStale(ctx, assets, cfg); err != nil {
+			logger.Error("Catalogue reconcile failed", "error", err)
+			run.Failed("reconcile")
+			tracing.RecordError(span, err)
+			return
+		}
+	}
+	run.Succeeded()
+}
+
+// markStale ... calls the catalogue to mark as stale the assets of the crawler which were not found in the walk
+func markStale(ctx context.Context, assets []abstract.Asset, cfg *conf.Config) error {
+	names := []string{}
+	for _, a := range assets {
+		names = append(names, a.Name)
+	}
+	stale, err := catalogueClient(cfg.DataSourceDefinition.CrawlerDefinition.ReconcileEndpoint, cfg).WithContext(ctx).
+		ReconcileAssets(cfg.DataSourceDefinition.Name, names)
+	if err != nil {
+		return err
+	}
+	logging.FromContext(ctx).Info("Catalogue marked assets as stale", "assets", stale)
+	return nil
 }
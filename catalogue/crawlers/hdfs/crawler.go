@@ -2,14 +2,15 @@ package hdfs
 
 import (
 	"bytes"
+	"context"
 	"io"
-	"log"
 	"os"
 	"path/filepath"
 
 	"github.com/pilillo/mastro/abstract"
 	"github.com/pilillo/mastro/sources/hdfs"
 	"github.com/pilillo/mastro/utils/conf"
+	"github.com/pilillo/mastro/utils/retry"
 	"github.com/pilillo/mastro/utils/strings"
 )
 
@@ -25,14 +26,21 @@ func NewCrawler() abstract.Crawler {
 func (crawler *hadoopCrawler) InitConnection(cfg *conf.Config) (abstract.Crawler, error) {
 	crawler.connector = hdfs.NewHDFSConnector()
 	if err := crawler.connector.ValidateDataSourceDefinition(&cfg.DataSourceDefinition); err != nil {
-		log.Panicln(err)
+		return nil, retry.Permanent(err)
 	}
 	// inits connection
-	crawler.connector.InitConnection(&cfg.DataSourceDefinition)
+	if err := crawler.connector.InitConnection(&cfg.DataSourceDefinition); err != nil {
+		return nil, err
+	}
 	return crawler, nil
 }
 
-func (crawler *hadoopCrawler) WalkWithFilter(root string, filter string) ([]abstract.Asset, error) {
+// CloseConnection ... closes the connection of the connector
+func (crawler *hadoopCrawler) CloseConnection() {
+	crawler.connector.CloseConnection()
+}
+
+func (crawler *hadoopCrawler) WalkWithFilter(ctx context.Context, root string, filter string) ([]abstract.Asset, error) {
 	var assets []abstract.Asset
 
 	var walkFn filepath.WalkFunc = func(currentPath string, info os.FileInfo, e error) error {
@@ -1,14 +1,17 @@
 package hive
 
 import (
+	"context"
+
 	"github.com/pilillo/mastro/abstract"
 	"github.com/pilillo/mastro/sources/hive"
 	"github.com/pilillo/mastro/utils/conf"
+	"github.com/pilillo/mastro/utils/retry"
 
-	"fmt"
-	"log"
-
+	"github.com/pilillo/mastro/utils/logging"
 	"github.com/pilillo/mastro/utils/strings"
+	"github.com/pilillo/mastro/utils/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 type hiveCrawler struct {
@@ -23,14 +26,22 @@ func NewCrawler() abstract.Crawler {
 func (crawler *hiveCrawler) InitConnection(cfg *conf.Config) (abstract.Crawler, error) {
 	crawler.connector = hive.NewHiveConnector()
 	if err := crawler.connector.ValidateDataSourceDefinition(&cfg.DataSourceDefinition); err != nil {
+		return nil, retry.Permanent(err)
+	}
+	if err := crawler.connector.InitConnection(&cfg.DataSourceDefinition); err != nil {
 		return nil, err
 	}
-	crawler.connector.InitConnection(&cfg.DataSourceDefinition)
 	return crawler, nil
 }
 
-func (crawler *hiveCrawler) WalkWithFilter(root string, filter string) ([]abstract.Asset, error) {
+// CloseConnection ... closes the connection of the connector
+func (crawler *hiveCrawler) CloseConnection() {
+	crawler.connector.CloseConnection()
+}
+
+func (crawler *hiveCrawler) WalkWithFilter(ctx context.Context, root string, filter string) ([]abstract.Asset, error) {
 	var assets []abstract.Asset
+	logger := logging.FromContext(ctx)
 
 	levels := strings.SplitAndTrim(root, "/")
 
@@ -41,7 +52,7 @@ func (crawler *hiveCrawler) WalkWithFilter(root string, filter string) ([]abstra
 	// N.B. golang split returns a slice with one element, the empty string so len is 1 and we gotta check it
 	// https://stackoverflow.com/questions/28330908/how-to-string-split-an-empty-string-in-go
 	if levels != nil && len(levels) > 0 && levels[0] != "" {
-		log.Printf("Provided specific db levels to locate: '%s'", root)
+		logger.Info("Provided specific db levels to locate", "root", root)
 
 		dbInfo, err := abstract.GetDBInfoByName(levels[0])
 		if err != nil {
@@ -59,18 +70,24 @@ func (crawler *hiveCrawler) WalkWithFilter(root string, filter string) ([]abstra
 			dbTables[&dbInfo] = []abstract.TableInfo{tableInfo}
 		} else {
 			// only db is provided, list all tables, construct table info with sole name
+			_, span := tracing.Start(ctx, "hive list tables", attribute.String("db.name", dbInfo.Name))
 			tables, err := crawler.connector.ListTables(dbInfo.Name)
+			tracing.RecordError(span, err)
+			span.End()
 			if err != nil {
 				// error while accessing the sole DB we desired to access
 				return nil, err
 			}
-			log.Printf("Found %d tables in requested database %s: %v", len(tables), dbInfo.Name, tables)
+			logger.Info("Found tables in requested database", "database", dbInfo.Name, "tables", len(tables))
 			dbTables[&dbInfo] = tables
 		}
 
 	} else {
 		// list all databases, skip those we can't access, as it may be a right issue
+		_, span := tracing.Start(ctx, "hive list databases")
 		dbs, err := crawler.connector.ListDatabases()
+		tracing.RecordError(span, err)
+		span.End()
 
 		if err != nil {
 			return nil, err
@@ -78,13 +95,16 @@ func (crawler *hiveCrawler) WalkWithFilter(root string, filter string) ([]abstra
 
 		// list all tables in all available DBs
 		for _, dbInfo := range dbs {
+			_, span := tracing.Start(ctx, "hive list tables", attribute.String("db.name", dbInfo.Name))
 			tables, err := crawler.connector.ListTables(dbInfo.Name)
+			tracing.RecordError(span, err)
+			span.End()
 			if err != nil {
 				// skipping DB
-				log.Println(fmt.Sprintf("Error while accessing DB %s! Skipping..", dbInfo.Name))
+				logger.Warn("Error while accessing DB, skipping", "database", dbInfo.Name, "error", err)
 			} else {
 				// add all found tables to map for given db name
-				log.Printf("Found %d tables in database %s: %v", len(tables), dbInfo.Name, tables)
+				logger.Info("Found tables in database", "database", dbInfo.Name, "tables", len(tables))
 				dbTables[&dbInfo] = tables
 			}
 		}
@@ -103,11 +123,14 @@ func (crawler *hiveCrawler) WalkWithFilter(root string, filter string) ([]abstra
 		// describe each table in the db - create an asset for each
 		for _, tableInfo := range tableNames {
 			// map[string]abstract.ColumnInfo
+			_, span := tracing.Start(ctx, "hive describe table", attribute.String("db.name", dbInfo.Name), attribute.String("db.sql.table", tableInfo.Name))
 			tableSchema, err := crawler.connector.DescribeTable(dbInfo.Name, tableInfo.Name)
+			tracing.RecordError(span, err)
+			span.End()
 			if err != nil {
-				log.Print(fmt.Sprintf("Error while accessing %s.%s! Skipping..", dbInfo.Name, tableInfo.Name))
+				logger.Warn("Error while accessing table, skipping", "database", dbInfo.Name, "table", tableInfo.Name, "error", err)
 			} else {
-				log.Printf("Retrieved schema for table %s.%s", dbInfo.Name, tableInfo.Name)
+				logger.Debug("Retrieved schema for table", "database", dbInfo.Name, "table", tableInfo.Name)
 				// add table schema
 				tableInfo.Schema = tableSchema
 				// convert to actual Asset definition
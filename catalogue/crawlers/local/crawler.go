@@ -1,6 +1,7 @@
 package local
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -25,7 +26,10 @@ func (crawler *localCrawler) InitConnection(cfg *conf.Config) (abstract.Crawler,
 	return crawler, nil
 }
 
-func (crawler *localCrawler) WalkWithFilter(root string, filter string) ([]abstract.Asset, error) {
+// CloseConnection ... nothing to close for the local file system
+func (crawler *localCrawler) CloseConnection() {}
+
+func (crawler *localCrawler) WalkWithFilter(ctx context.Context, root string, filter string) ([]abstract.Asset, error) {
 	var assets []abstract.Asset
 
 	// walk file system
@@ -5,12 +5,13 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"log"
 
 	"github.com/minio/minio-go/v7"
 	"github.com/pilillo/mastro/abstract"
 	"github.com/pilillo/mastro/sources/s3"
 	"github.com/pilillo/mastro/utils/conf"
+	"github.com/pilillo/mastro/utils/logging"
+	"github.com/pilillo/mastro/utils/retry"
 	"github.com/pilillo/mastro/utils/strings"
 )
 
@@ -31,10 +32,12 @@ func (crawler *s3Crawler) GetClient() *minio.Client {
 func (crawler *s3Crawler) InitConnection(cfg *conf.Config) (abstract.Crawler, error) {
 	crawler.connector = s3.NewS3Connector()
 	if err := crawler.connector.ValidateDataSourceDefinition(&cfg.DataSourceDefinition); err != nil {
-		log.Panicln(err)
+		return nil, retry.Permanent(err)
 	}
 	// inits connection
-	crawler.connector.InitConnection(&cfg.DataSourceDefinition)
+	if err := crawler.connector.InitConnection(&cfg.DataSourceDefinition); err != nil {
+		return nil, err
+	}
 
 	// set filter for the manifest filename
 	//crawler.config = &cfg.CrawlerDefinition
@@ -88,8 +91,13 @@ func (crawler *s3Crawler) ListObjects(bucket string, prefix string, recursive bo
 	return slice, nil
 }
 
-func (crawler *s3Crawler) WalkWithFilter(root string, filter string) ([]abstract.Asset, error) {
-	ctx, cancel := context.WithCancel(context.Background())
+// CloseConnection ... closes the connection of the connector
+func (crawler *s3Crawler) CloseConnection() {
+	crawler.connector.CloseConnection()
+}
+
+func (crawler *s3Crawler) WalkWithFilter(ctx context.Context, root string, filter string) ([]abstract.Asset, error) {
+	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 	//ctx := context.Background()
 
@@ -110,7 +118,7 @@ func (crawler *s3Crawler) WalkWithFilter(root string, filter string) ([]abstract
 	var assets []abstract.Asset
 	opts := minio.GetObjectOptions{}
 	for _, o := range objs {
-		log.Println("Found ", o.Key)
+		logging.FromContext(ctx).Debug("Found manifest", "key", o.Key)
 		reader, err := crawler.connector.GetClient().GetObject(ctx, crawler.connector.Bucket, o.Key, opts)
 		if err != nil {
 			return nil, err
@@ -58,7 +58,7 @@ func TestWalk(t *testing.T) {
 	assert.NotEqual(size, 0)
 
 	// test walk function
-	fs, err := crawler.WalkWithFilter(crawler.connector.Prefix, abstract.DefaultManifestFilename)
+	fs, err := crawler.WalkWithFilter(context.Background(), crawler.connector.Prefix, abstract.DefaultManifestFilename)
 
 	assert.Equal(err, nil)
 	assert.NotEqual(fs, nil)
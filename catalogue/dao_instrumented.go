@@ -0,0 +1,170 @@
+package catalogue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/utils/conf"
+	"github.com/pilillo/mastro/utils/logging"
+	"github.com/pilillo/mastro/utils/metrics"
+	"github.com/pilillo/mastro/utils/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// instrumentedDAO ... decorates a dao by recording the latency and errors of its operations per backend,
+// by tracing them as children of the current span of its context and by logging them with the logger of its context
+type instrumentedDAO struct {
+	dao     abstract.AssetDAOProvider
+	backend string
+	ctx     context.Context
+}
+
+// instrument ... returns the dao recording metrics labelled with the backend type
+func instrument(dao abstract.AssetDAOProvider, backend string) abstract.AssetDAOProvider {
+	return &instrumentedDAO{dao: dao, backend: backend}
+}
+
+// bindContext ... returns the instrumented dao tracing and logging its calls within the context, any other dao as is
+func bindContext(dao abstract.AssetDAOProvider, ctx context.Context) abstract.AssetDAOProvider {
+	if d, isInstrumented := dao.(*instrumentedDAO); isInstrumented && ctx != nil {
+		return &instrumentedDAO{dao: d.dao, backend: d.backend, ctx: ctx}
+	}
+	return dao
+}
+
+// observe ... starts timing, tracing and logging an operation on the backend, returning the function to call with its outcome
+func observe(ctx context.Context, backend string, operation string) func(err error) {
+	start := time.Now()
+	_, span := tracing.Start(ctx, fmt.Sprintf("dao %s", operation), attribute.String("db.system", backend), attribute.String("db.operation", operation))
+	return func(err error) {
+		metrics.ObserveDAO(backend, operation, start, err)
+		if logger := logging.FromContext(ctx); err != nil {
+			logger.Debug("Backend operation failed", "backend", backend, "operation", operation, "duration", time.Since(start), "error", err)
+		} else {
+			logger.Debug("Backend operation", "backend", backend, "operation", operation, "duration", time.Since(start))
+		}
+		tracing.RecordError(span, err)
+		span.End()
+	}
+}
+
+// notFoundAsSuccess ... returns nil if the asset was not found, an expected outcome rather than a failure of the backend
+func notFoundAsSuccess(err error) error {
+	if errors.Is(err, abstract.ErrAssetNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (d *instrumentedDAO) Init(def *conf.DataSourceDefinition) error {
+	return d.dao.Init(def)
+}
+
+func (d *instrumentedDAO) Upsert(asset *abstract.Asset) error {
+	done := observe(d.ctx, d.backend, "upsert")
+	err := d.dao.Upsert(asset)
+	done(err)
+	return err
+}
+
+func (d *instrumentedDAO) GetById(id string) (*abstract.Asset, error) {
+	done := observe(d.ctx, d.backend, "get-by-id")
+	asset, err := d.dao.GetById(id)
+	done(notFoundAsSuccess(err))
+	return asset, err
+}
+
+func (d *instrumentedDAO) GetByName(name string) (*abstract.Asset, error) {
+	done := observe(d.ctx, d.backend, "get-by-name")
+	asset, err := d.dao.GetByName(name)
+	done(notFoundAsSuccess(err))
+	return asset, err
+}
+
+func (d *instrumentedDAO) SearchAssetsByTags(tags []string) (*[]abstract.Asset, error) {
+	done := observe(d.ctx, d.backend, "search-by-tags")
+	assets, err := d.dao.SearchAssetsByTags(tags)
+	done(err)
+	return assets, err
+}
+
+func (d *instrumentedDAO) ListAllAssets() (*[]abstract.Asset, error) {
+	done := observe(d.ctx, d.backend, "list-all")
+	assets, err := d.dao.ListAllAssets()
+	done(err)
+	return assets, err
+}
+
+func (d *instrumentedDAO) ListAssets(opts *abstract.ListOptions) (*abstract.AssetPage, error) {
+	done := observe(d.ctx, d.backend, "list")
+	page, err := d.dao.ListAssets(opts)
+	done(err)
+	return page, err
+}
+
+func (d *instrumentedDAO) SearchAssets(search *abstract.AssetSearch) (*abstract.AssetSearchResult, error) {
+	done := observe(d.ctx, d.backend, "search")
+	result, err := d.dao.SearchAssets(search)
+	done(err)
+	return result, err
+}
+
+func (d *instrumentedDAO) Delete(name string) error {
+	done := observe(d.ctx, d.backend, "delete")
+	err := d.dao.Delete(name)
+	done(err)
+	return err
+}
+
+func (d *instrumentedDAO) SoftDelete(name string, at time.Time) error {
+	done := observe(d.ctx, d.backend, "soft-delete")
+	err := d.dao.SoftDelete(name, at)
+	done(err)
+	return err
+}
+
+func (d *instrumentedDAO) MarkStale(discoveredBy string, seen []string, at time.Time) (int, error) {
+	done := observe(d.ctx, d.backend, "mark-stale")
+	stale, err := d.dao.MarkStale(discoveredBy, seen, at)
+	done(err)
+	return stale, err
+}
+
+func (d *instrumentedDAO) AddRevision(rev *abstract.AssetRevision) error {
+	done := observe(d.ctx, d.backend, "add-revision")
+	err := d.dao.AddRevision(rev)
+	done(err)
+	return err
+}
+
+func (d *instrumentedDAO) ListRevisions(name string) (*[]abstract.AssetRevision, error) {
+	done := observe(d.ctx, d.backend, "list-revisions")
+	revisions, err := d.dao.ListRevisions(name)
+	done(err)
+	return revisions, err
+}
+
+func (d *instrumentedDAO) GetRevision(name string, revision int) (*abstract.AssetRevision, error) {
+	done := observe(d.ctx, d.backend, "get-revision")
+	rev, err := d.dao.GetRevision(name, revision)
+	done(err)
+	return rev, err
+}
+
+func (d *instrumentedDAO) GetLatestRevision(name string) (*abstract.AssetRevision, error) {
+	done := observe(d.ctx, d.backend, "get-latest-revision")
+	rev, err := d.dao.GetLatestRevision(name)
+	done(err)
+	return rev, err
+}
+
+func (d *instrumentedDAO) Ping() error {
+	return d.dao.Ping()
+}
+
+func (d *instrumentedDAO) CloseConnection() {
+	d.dao.CloseConnection()
+}
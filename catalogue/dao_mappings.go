@@ -4,19 +4,40 @@ import (
 	"fmt"
 
 	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/catalogue/daos/elastic"
+	"github.com/pilillo/mastro/catalogue/daos/embedded"
+	"github.com/pilillo/mastro/catalogue/daos/memory"
 	"github.com/pilillo/mastro/catalogue/daos/mongo"
+	elasticsource "github.com/pilillo/mastro/sources/elastic"
+	embeddedsource "github.com/pilillo/mastro/sources/embedded"
+	mongosource "github.com/pilillo/mastro/sources/mongo"
 	"github.com/pilillo/mastro/utils/conf"
 )
 
 // available backends - lazy loaded singleton DAOs
 var availableDAOs = map[string]func() abstract.AssetDAOProvider{
-	"mongo": mongo.GetSingleton,
-	// "elastic": elastic.GetSingleton, // not implemented yet
+	"mongo":    mongo.GetSingleton,
+	"elastic":  elastic.GetSingleton,
+	"embedded": embedded.GetSingleton,
+	"memory":   memory.GetSingleton,
+}
+
+// settings of the backends, validated along the configuration
+var settingsValidators = map[string]conf.SettingsValidator{
+	"mongo":    mongosource.NewMongoConnector().ValidateDataSourceDefinition,
+	"elastic":  elasticsource.NewElasticConnector().ValidateDataSourceDefinition,
+	"embedded": embeddedsource.NewEmbeddedConnector().ValidateDataSourceDefinition,
+}
+
+func init() {
+	for name := range availableDAOs {
+		conf.RegisterDataSource(conf.Catalogue, conf.BackendSection, name, settingsValidators[name])
+	}
 }
 
 func selectDao(cfg *conf.Config) (abstract.AssetDAOProvider, error) {
 	if singletonDao, ok := availableDAOs[cfg.DataSourceDefinition.Type]; ok {
-		return singletonDao(), nil
+		return instrument(singletonDao(), cfg.DataSourceDefinition.Type), nil
 	}
 	return nil, fmt.Errorf("Impossible to find specified DAO connector %s", cfg.DataSourceDefinition.Type)
 }
@@ -0,0 +1,528 @@
+// Package daotest provides a conformance suite for catalogue DAOs,
+// so that every backend in catalogue/daos/* is verified the same way.
+package daotest
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/pilillo/mastro/abstract"
+)
+
+// DAOFactory ... returns an initialized DAO on an empty collection
+type DAOFactory func(t *testing.T) abstract.AssetDAOProvider
+
+// RunAssetDAOSuite ... runs all conformance tests against the DAO returned by the factory,
+// the factory is called once per test so that each test starts from an empty collection
+func RunAssetDAOSuite(t *testing.T, newDao DAOFactory) {
+	t.Run("EmptyCollection", func(t *testing.T) { testEmptyCollection(t, newDao(t)) })
+	t.Run("UpsertIsIdempotent", func(t *testing.T) { testUpsertIsIdempotent(t, newDao(t)) })
+	t.Run("LookupByIdAndName", func(t *testing.T) { testLookupByIDAndName(t, newDao(t)) })
+	t.Run("SearchByTags", func(t *testing.T) { testSearchByTags(t, newDao(t)) })
+	t.Run("SearchByTagsReturnsManyAssets", func(t *testing.T) { testSearchByTagsReturnsManyAssets(t, newDao(t)) })
+	t.Run("ListPages", func(t *testing.T) { testListPages(t, newDao(t)) })
+	t.Run("SearchAssets", func(t *testing.T) { testSearchAssets(t, newDao(t)) })
+	t.Run("DeleteAndSoftDelete", func(t *testing.T) { testDeleteAndSoftDelete(t, newDao(t)) })
+	t.Run("MarkStale", func(t *testing.T) { testMarkStale(t, newDao(t)) })
+	t.Run("Revisions", func(t *testing.T) { testRevisions(t, newDao(t)) })
+	t.Run("Ownership", func(t *testing.T) { testOwnership(t, newDao(t)) })
+}
+
+func newAsset(name string, tags ...string) *abstract.Asset {
+	return &abstract.Asset{
+		// backends may not store sub-millisecond precision
+		LastDiscoveredAt: time.Now().UTC().Truncate(time.Millisecond),
+		PublishedOn:      time.Date(2021, 3, 22, 21, 19, 39, 0, time.UTC),
+		Name:             name,
+		Description:      "asset " + name,
+		DependsOn:        []string{"upstream"},
+		Type:             "table",
+		Labels:           map[string]interface{}{"owner": "data-team"},
+		Tags:             tags,
+	}
+}
+
+func mustUpsert(t *testing.T, dao abstract.AssetDAOProvider, assets ...*abstract.Asset) {
+	t.Helper()
+	for _, a := range assets {
+		if err := dao.Upsert(a); err != nil {
+			t.Fatalf("upsert of %s failed: %v", a.Name, err)
+		}
+	}
+}
+
+func assetNames(assets *[]abstract.Asset) []string {
+	names := []string{}
+	if assets == nil {
+		return names
+	}
+	for _, a := range *assets {
+		names = append(names, a.Name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func equalNames(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func assertSameAsset(t *testing.T, expected *abstract.Asset, actual *abstract.Asset) {
+	t.Helper()
+	if actual.Name != expected.Name {
+		t.Errorf("expected name %s, got %s", expected.Name, actual.Name)
+	}
+	if actual.Description != expected.Description {
+		t.Errorf("expected description %q, got %q", expected.Description, actual.Description)
+	}
+	if actual.Type != expected.Type {
+		t.Errorf("expected type %s, got %s", expected.Type, actual.Type)
+	}
+	if !actual.PublishedOn.Equal(expected.PublishedOn) {
+		t.Errorf("expected published-on %v, got %v", expected.PublishedOn, actual.PublishedOn)
+	}
+	if !actual.LastDiscoveredAt.Equal(expected.LastDiscoveredAt) {
+		t.Errorf("expected last-discovered-at %v, got %v", expected.LastDiscoveredAt, actual.LastDiscoveredAt)
+	}
+	if !equalNames(actual.DependsOn, expected.DependsOn) {
+		t.Errorf("expected depends-on %v, got %v", expected.DependsOn, actual.DependsOn)
+	}
+	if !actual.HasTags(expected.Tags) || len(actual.Tags) != len(expected.Tags) {
+		t.Errorf("expected tags %v, got %v", expected.Tags, actual.Tags)
+	}
+	if actual.Labels["owner"] != expected.Labels["owner"] {
+		t.Errorf("expected labels %v, got %v", expected.Labels, actual.Labels)
+	}
+}
+
+// testEmptyCollection ... listing an empty collection returns no assets and no error,
+// while lookups and searches return an error
+func testEmptyCollection(t *testing.T, dao abstract.AssetDAOProvider) {
+	assets, err := dao.ListAllAssets()
+	if err != nil {
+		t.Fatalf("expected no error listing an empty collection, got %v", err)
+	}
+	if assets != nil && len(*assets) > 0 {
+		t.Errorf("expected no assets, got %v", assetNames(assets))
+	}
+
+	if _, err := dao.GetByName("missing"); !errors.Is(err, abstract.ErrAssetNotFound) {
+		t.Errorf("expected ErrAssetNotFound retrieving a missing asset by name, got %v", err)
+	}
+	if _, err := dao.GetById("missing"); !errors.Is(err, abstract.ErrAssetNotFound) {
+		t.Errorf("expected ErrAssetNotFound retrieving a missing asset by id, got %v", err)
+	}
+	if _, err := dao.SearchAssetsByTags([]string{"missing"}); err == nil {
+		t.Error("expected an error searching an empty collection")
+	}
+}
+
+// testUpsertIsIdempotent ... upserting the same asset name replaces the stored asset
+func testUpsertIsIdempotent(t *testing.T, dao abstract.AssetDAOProvider) {
+	asset := newAsset("mydb.mytable", "hive")
+	mustUpsert(t, dao, asset, asset)
+
+	updated := newAsset("mydb.mytable", "hive", "pii")
+	updated.Description = "updated description"
+	mustUpsert(t, dao, updated)
+
+	assets, err := dao.ListAllAssets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := assetNames(assets); !equalNames(names, []string{"mydb.mytable"}) {
+		t.Fatalf("expected a single asset after repeated upserts, got %v", names)
+	}
+
+	stored, err := dao.GetByName("mydb.mytable")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameAsset(t, updated, stored)
+}
+
+// testLookupByIDAndName ... assets are retrievable by name, which is also their id
+func testLookupByIDAndName(t *testing.T, dao abstract.AssetDAOProvider) {
+	first := newAsset("first", "a")
+	second := newAsset("second", "b")
+	mustUpsert(t, dao, first, second)
+
+	byName, err := dao.GetByName("second")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameAsset(t, second, byName)
+
+	byID, err := dao.GetById("first")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertSameAsset(t, first, byID)
+}
+
+// testSearchByTags ... assets are returned iff they have all the tags, regardless of their order
+func testSearchByTags(t *testing.T, dao abstract.AssetDAOProvider) {
+	mustUpsert(t, dao,
+		newAsset("both", "x", "y"),
+		newAsset("onlyY", "y"),
+		newAsset("none"),
+	)
+
+	cases := []struct {
+		tags     []string
+		expected []string
+	}{
+		{[]string{"y"}, []string{"both", "onlyY"}},
+		{[]string{"y", "x"}, []string{"both"}},
+		{[]string{"x", "y"}, []string{"both"}},
+	}
+	for _, c := range cases {
+		assets, err := dao.SearchAssetsByTags(c.tags)
+		if err != nil {
+			t.Errorf("search by %v failed: %v", c.tags, err)
+			continue
+		}
+		if names := assetNames(assets); !equalNames(names, c.expected) {
+			t.Errorf("search by %v: expected %v, got %v", c.tags, c.expected, names)
+		}
+	}
+
+	if _, err := dao.SearchAssetsByTags([]string{"x", "z"}); err == nil {
+		t.Error("expected an error when no asset has all the tags")
+	}
+}
+
+// testSearchByTagsReturnsManyAssets ... all the matching assets are returned, beyond the default size of a search
+func testSearchByTagsReturnsManyAssets(t *testing.T, dao abstract.AssetDAOProvider) {
+	var expected []string
+	for i := 1; i <= 12; i++ {
+		name := fmt.Sprintf("asset%02d", i)
+		mustUpsert(t, dao, newAsset(name, "many"))
+		expected = append(expected, name)
+	}
+	mustUpsert(t, dao, newAsset("other", "few"))
+
+	assets, err := dao.SearchAssetsByTags([]string{"many"})
+	if err != nil {
+		t.Fatalf("search by tags failed: %v", err)
+	}
+	if names := assetNames(assets); !equalNames(names, expected) {
+		t.Errorf("expected %v, got %v", expected, names)
+	}
+
+	all, err := dao.ListAllAssets()
+	if err != nil {
+		t.Fatalf("list all failed: %v", err)
+	}
+	if names := assetNames(all); !equalNames(names, append(expected, "other")) {
+		t.Errorf("expected all the 13 assets, got %v", names)
+	}
+}
+
+// testListPages ... following the cursors returns every asset once, in the requested order
+func testListPages(t *testing.T, dao abstract.AssetDAOProvider) {
+	base := time.Now().UTC().Truncate(time.Millisecond)
+	for i, name := range []string{"c", "a", "e", "b", "d"} {
+		asset := newAsset(name)
+		// a and e, as well as b and d, share the same discovery date so that the name breaks ties
+		asset.LastDiscoveredAt = base.Add(time.Duration(i-i/2) * time.Minute)
+		mustUpsert(t, dao, asset)
+	}
+
+	cases := []struct {
+		sortBy     string
+		descending bool
+		expected   []string
+	}{
+		{abstract.SortByName, false, []string{"a", "b", "c", "d", "e"}},
+		{abstract.SortByName, true, []string{"e", "d", "c", "b", "a"}},
+		{abstract.SortByLastDiscoveredAt, false, []string{"c", "a", "e", "b", "d"}},
+		{abstract.SortByLastDiscoveredAt, true, []string{"d", "b", "e", "a", "c"}},
+	}
+	for _, c := range cases {
+		opts := &abstract.ListOptions{Limit: 2, SortBy: c.sortBy, Descending: c.descending}
+		listed := []string{}
+		for pages := 0; pages < 5; pages++ {
+			page, err := dao.ListAssets(opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, a := range page.Assets {
+				listed = append(listed, a.Name)
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			opts.Cursor = page.NextCursor
+		}
+		if !equalNames(listed, c.expected) {
+			t.Errorf("sort by %s (descending %v): expected %v, got %v", c.sortBy, c.descending, c.expected, listed)
+		}
+	}
+}
+
+// testSearchAssets ... the query and all filters shall match, facets count all matching assets
+func testSearchAssets(t *testing.T, dao abstract.AssetDAOProvider) {
+	orders := newAsset("sales.orders", "hive", "pii")
+	orders.Description = "customer orders of the online shop"
+	orders.Labels = map[string]interface{}{"owner": "sales-team", "tier": 1, "certified": true}
+	customers := newAsset("sales.customers", "hive", "pii")
+	customers.Description = "customer master data"
+	customers.DependsOn = []string{"crm"}
+	report := newAsset("orders.report", "bi")
+	report.Type = "report"
+	report.Description = "weekly report"
+	report.PublishedOn = time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)
+	mustUpsert(t, dao, orders, customers, report)
+
+	from := time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		name     string
+		search   abstract.AssetSearch
+		expected []string
+	}{
+		{"all", abstract.AssetSearch{}, []string{"orders.report", "sales.customers", "sales.orders"}},
+		{"query", abstract.AssetSearch{Query: "customer"}, []string{"sales.customers", "sales.orders"}},
+		{"all query terms", abstract.AssetSearch{Query: "customer shop"}, []string{"sales.orders"}},
+		{"query on name", abstract.AssetSearch{Query: "orders"}, []string{"orders.report", "sales.orders"}},
+		{"type", abstract.AssetSearch{Types: []abstract.AssetType{"report"}}, []string{"orders.report"}},
+		{"tags", abstract.AssetSearch{Tags: []string{"hive", "pii"}}, []string{"sales.customers", "sales.orders"}},
+		{"label", abstract.AssetSearch{Labels: map[string]string{"owner": "sales-team"}}, []string{"sales.orders"}},
+		{"labels by text", abstract.AssetSearch{Labels: map[string]string{"tier": "1", "certified": "true"}}, []string{"sales.orders"}},
+		{"label by other text", abstract.AssetSearch{Labels: map[string]string{"tier": "1.0"}}, []string{}},
+		{"depends-on", abstract.AssetSearch{DependsOn: []string{"crm"}}, []string{"sales.customers"}},
+		{"published-on", abstract.AssetSearch{PublishedFrom: &from}, []string{"orders.report"}},
+	}
+	for _, c := range cases {
+		search := c.search
+		search.Limit = 10
+		result, err := dao.SearchAssets(&search)
+		if err != nil {
+			t.Fatalf("%s: %v", c.name, err)
+		}
+		if names := assetNames(&result.Assets); !equalNames(names, c.expected) {
+			t.Errorf("%s: expected %v, got %v", c.name, c.expected, names)
+		}
+		if result.Total != len(c.expected) {
+			t.Errorf("%s: expected total %d, got %d", c.name, len(c.expected), result.Total)
+		}
+	}
+
+	result, err := dao.SearchAssets(&abstract.AssetSearch{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Assets) != 1 || result.Total != 3 {
+		t.Errorf("expected 1 of 3 assets, got %d of %d", len(result.Assets), result.Total)
+	}
+	if result.Facets.Types["table"] != 2 || result.Facets.Types["report"] != 1 {
+		t.Errorf("unexpected type facets %v", result.Facets.Types)
+	}
+	if result.Facets.Tags["hive"] != 2 || result.Facets.Tags["pii"] != 2 || result.Facets.Tags["bi"] != 1 {
+		t.Errorf("unexpected tag facets %v", result.Facets.Tags)
+	}
+	// pages follow the name order
+	next, err := dao.SearchAssets(&abstract.AssetSearch{Limit: 1, Offset: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(next.Assets) != 1 || next.Total != 3 || next.Assets[0].Name <= result.Assets[0].Name {
+		t.Errorf("expected the second of 3 assets, got %v of %d", next.Assets, next.Total)
+	}
+}
+
+// testDeleteAndSoftDelete ... deleted assets are gone, soft-deleted ones are only hidden from lists and searches
+func testDeleteAndSoftDelete(t *testing.T, dao abstract.AssetDAOProvider) {
+	mustUpsert(t, dao, newAsset("kept", "x"), newAsset("tombstone", "x"), newAsset("removed", "x"))
+
+	if err := dao.Delete("removed"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dao.GetByName("removed"); err == nil {
+		t.Error("expected an error retrieving a deleted asset")
+	}
+	if err := dao.Delete("removed"); !errors.Is(err, abstract.ErrAssetNotFound) {
+		t.Errorf("expected ErrAssetNotFound deleting a missing asset, got %v", err)
+	}
+
+	at := time.Now().UTC().Truncate(time.Millisecond)
+	if err := dao.SoftDelete("tombstone", at); err != nil {
+		t.Fatal(err)
+	}
+	if err := dao.SoftDelete("missing", at); !errors.Is(err, abstract.ErrAssetNotFound) {
+		t.Errorf("expected ErrAssetNotFound soft-deleting a missing asset, got %v", err)
+	}
+	tombstone, err := dao.GetByName("tombstone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tombstone.DeletedAt == nil || !tombstone.DeletedAt.Equal(at) {
+		t.Errorf("expected deleted-at %v, got %v", at, tombstone.DeletedAt)
+	}
+
+	page, err := dao.ListAssets(&abstract.ListOptions{Limit: 10, SortBy: abstract.SortByName})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := assetNames(&page.Assets); !equalNames(names, []string{"kept"}) {
+		t.Errorf("expected only kept to be listed, got %v", names)
+	}
+	tagged, err := dao.SearchAssetsByTags([]string{"x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := assetNames(tagged); !equalNames(names, []string{"kept"}) {
+		t.Errorf("expected only kept to be found by tags, got %v", names)
+	}
+	result, err := dao.SearchAssets(&abstract.AssetSearch{Limit: 10, IncludeDeleted: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := assetNames(&result.Assets); !equalNames(names, []string{"kept", "tombstone"}) {
+		t.Errorf("expected deleted assets when requested, got %v", names)
+	}
+}
+
+// testMarkStale ... only assets of the same crawler missing from its walk are marked, and only once
+func testMarkStale(t *testing.T, dao abstract.AssetDAOProvider) {
+	discovered := func(name string, crawler string) *abstract.Asset {
+		asset := newAsset(name)
+		asset.DiscoveredBy = crawler
+		return asset
+	}
+	mustUpsert(t, dao,
+		discovered("seen", "s3"),
+		discovered("gone", "s3"),
+		discovered("other", "hive"),
+		newAsset("manual"),
+	)
+
+	at := time.Now().UTC().Truncate(time.Millisecond)
+	marked, err := dao.MarkStale("s3", []string{"seen"}, at)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if marked != 1 {
+		t.Errorf("expected 1 stale asset, got %d", marked)
+	}
+	gone, err := dao.GetByName("gone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gone.StaleSince == nil || !gone.StaleSince.Equal(at) {
+		t.Errorf("expected stale-since %v, got %v", at, gone.StaleSince)
+	}
+
+	stale := true
+	result, err := dao.SearchAssets(&abstract.AssetSearch{Limit: 10, Stale: &stale})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := assetNames(&result.Assets); !equalNames(names, []string{"gone"}) {
+		t.Errorf("expected only gone to be stale, got %v", names)
+	}
+
+	// already stale assets keep their original date
+	if marked, err := dao.MarkStale("s3", []string{"seen"}, at.Add(time.Hour)); err != nil || marked != 0 {
+		t.Errorf("expected no new stale asset, got %d (%v)", marked, err)
+	}
+}
+
+// testRevisions ... revisions are numbered per asset from 1 and listed by increasing number
+func testRevisions(t *testing.T, dao abstract.AssetDAOProvider) {
+	for i, description := range []string{"first", "second"} {
+		asset := newAsset("versioned")
+		asset.Description = description
+		rev := &abstract.AssetRevision{Name: asset.Name, CreatedAt: time.Now().UTC().Truncate(time.Millisecond), Author: "s3", Asset: *asset}
+		if err := dao.AddRevision(rev); err != nil {
+			t.Fatal(err)
+		}
+		if rev.Revision != i+1 {
+			t.Errorf("expected revision %d, got %d", i+1, rev.Revision)
+		}
+	}
+	if err := dao.AddRevision(&abstract.AssetRevision{Name: "other", Asset: *newAsset("other")}); err != nil {
+		t.Fatal(err)
+	}
+
+	revisions, err := dao.ListRevisions("versioned")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*revisions) != 2 || (*revisions)[0].Revision != 1 || (*revisions)[1].Asset.Description != "second" {
+		t.Errorf("expected 2 revisions in order, got %v", *revisions)
+	}
+
+	second, err := dao.GetRevision("versioned", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second == nil || second.Author != "s3" || second.Asset.Description != "second" {
+		t.Errorf("unexpected revision %v", second)
+	}
+	if missing, err := dao.GetRevision("versioned", 3); err != nil || missing != nil {
+		t.Errorf("expected no revision 3, got %v (%v)", missing, err)
+	}
+	latest, err := dao.GetLatestRevision("versioned")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest == nil || latest.Revision != 2 || latest.Asset.Description != "second" {
+		t.Errorf("expected the latest revision to be the second, got %v", latest)
+	}
+	if none, err := dao.GetLatestRevision("missing"); err != nil || none != nil {
+		t.Errorf("expected no latest revision for a missing asset, got %v (%v)", none, err)
+	}
+	if none, err := dao.ListRevisions("missing"); err != nil || len(*none) != 0 {
+		t.Errorf("expected no revisions for a missing asset, got %v (%v)", none, err)
+	}
+}
+
+// testOwnership ... ownership fields are stored and assets can be searched by owner and domain
+func testOwnership(t *testing.T, dao abstract.AssetDAOProvider) {
+	owned := func(name string, domain string, owners ...string) *abstract.Asset {
+		asset := newAsset(name)
+		asset.Domain = domain
+		asset.Owners = owners
+		return asset
+	}
+	orders := owned("orders", "sales", "jane", "platform")
+	orders.Steward = "john"
+	orders.Team = "platform"
+	orders.Contact = "platform@example.com"
+	mustUpsert(t, dao, orders, owned("customers", "sales", "john"), owned("clicks", "marketing", "jane"))
+
+	stored, err := dao.GetByName("orders")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !equalNames(stored.Owners, orders.Owners) || stored.Steward != "john" || stored.Domain != "sales" || stored.Team != "platform" || stored.Contact != orders.Contact {
+		t.Errorf("expected ownership to be stored, got %v", stored)
+	}
+
+	byOwner, err := dao.SearchAssets(&abstract.AssetSearch{Owner: "jane", Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := assetNames(&byOwner.Assets); !equalNames(names, []string{"clicks", "orders"}) {
+		t.Errorf("expected the assets of jane, got %v", names)
+	}
+	byDomain, err := dao.SearchAssets(&abstract.AssetSearch{Domain: "sales", Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if names := assetNames(&byDomain.Assets); !equalNames(names, []string{"customers", "orders"}) {
+		t.Errorf("expected the assets of sales, got %v", names)
+	}
+}
@@ -1,18 +1,32 @@
 package elastic
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
 	"sync"
+	"time"
 
+	"github.com/elastic/go-elasticsearch/v7/esapi"
 	"github.com/pilillo/mastro/abstract"
 	"github.com/pilillo/mastro/sources/elastic"
 	"github.com/pilillo/mastro/utils/conf"
+	"github.com/pilillo/mastro/utils/logging"
+	"github.com/pilillo/mastro/utils/retry"
 )
 
+// both init and sync.Once are thread-safe
+// but only sync.Once is lazy
 var once sync.Once
 var instance *dao
 
+// dao ... The struct for the ElasticSearch DAO for the Catalogue service
 type dao struct {
 	Connector *elastic.Connector
+	// RevisionsIndexName ... revisions are stored in a separate index, named after the assets one
+	RevisionsIndexName string
 }
 
 // GetSingleton ... get an instance of the dao backend
@@ -24,34 +38,787 @@ func GetSingleton() abstract.AssetDAOProvider {
 	return instance
 }
 
+// SearchResponse ... response returned by ES for a search request
+type SearchResponse struct {
+	Took     float64 `json:"took,omitempty"`
+	TimedOut bool    `json:"timed_out,omitempty"`
+	Shards   Shards  `json:"_shards,omitempty"`
+	Hits     Hits    `json:"hits,omitempty"`
+	// Aggregations ... terms aggregations by name, if any was requested
+	Aggregations map[string]Aggregation `json:"aggregations,omitempty"`
+}
+
+// Aggregation ... buckets of a terms aggregation
+type Aggregation struct {
+	Buckets []Bucket `json:"buckets,omitempty"`
+}
+
+// Bucket ... number of documents with a given value
+type Bucket struct {
+	Key      string `json:"key,omitempty"`
+	DocCount int    `json:"doc_count,omitempty"`
+}
+
+// Shards ... shards information in a search response
+type Shards struct {
+	Total       float64 `json:"total,omitempty"`
+	Successfull float64 `json:"successfull,omitempty"`
+	Skipped     float64 `json:"skipped,omitempty"`
+	Failed      float64 `json:"failed,omitempty"`
+}
+
+// Hits ... hits returned in a search response
+type Hits struct {
+	Total    Total         `json:"total,omitempty"`
+	MaxScore float64       `json:"max_score,omitempty"`
+	Hits     []ResponseDoc `json:"hits,omitempty"`
+}
+
+// Total ... total number of hits for a search
+type Total struct {
+	Value    float64 `json:"value,omitempty"`
+	Relation string  `json:"relation,omitempty"`
+}
+
+// ResponseDoc ... a single document hit, the asset is directly stored as source
+type ResponseDoc struct {
+	Index  string         `json:"_index,omitempty"`
+	Type   string         `json:"_type,omitempty"`
+	ID     string         `json:"_id,omitempty"`
+	Score  float64        `json:"_score,omitempty"`
+	Source abstract.Asset `json:"_source,omitempty"`
+	// Sort ... sort values of the hit, used to search after it
+	Sort []interface{} `json:"sort,omitempty"`
+}
+
+// GetResponse ... response returned by ES for a get document request
+type GetResponse struct {
+	Index  string         `json:"_index,omitempty"`
+	ID     string         `json:"_id,omitempty"`
+	Found  bool           `json:"found,omitempty"`
+	Source abstract.Asset `json:"_source,omitempty"`
+}
+
 // Init ... Initialize connection to elastic search and target index
-func (dao *dao) Init(def *conf.DataSourceDefinition) {
-	return
+func (dao *dao) Init(def *conf.DataSourceDefinition) error {
+	// create connector
+	dao.Connector = elastic.NewElasticConnector()
+	// validate data source definition
+	if err := dao.Connector.ValidateDataSourceDefinition(def); err != nil {
+		return retry.Permanent(err)
+	}
+	// init connector
+	if err := dao.Connector.InitConnection(def); err != nil {
+		return err
+	}
+	// make sure the target index exists
+	if err := dao.Connector.CheckIndex(def, dao.Connector.IndexName); err != nil {
+		return fmt.Errorf("Failed checking the index %s :: %v", dao.Connector.IndexName, err)
+	}
+	// indices created before the free text search lack the name.text field, names are only searched once it is added
+	if err := dao.ensureNameTextField(); err != nil {
+		logging.Error("Failed adding the name.text field, free text queries only match the description", "index", dao.Connector.IndexName, "error", err)
+	}
+	// the revisions index has a fixed definition, as the snapshots are only retrieved by name
+	dao.RevisionsIndexName = dao.Connector.IndexName + "-revisions"
+	exists, err := dao.Connector.IndexExists(dao.RevisionsIndexName)
+	if err != nil {
+		return fmt.Errorf("Failed checking the index %s :: %v", dao.RevisionsIndexName, err)
+	}
+	if !exists {
+		if err := dao.Connector.CreateIndex(dao.RevisionsIndexName, []byte(revisionsIndexDef)); err != nil {
+			return fmt.Errorf("Failed creating the index %s :: %v", dao.RevisionsIndexName, err)
+		}
+	}
+	return nil
+}
+
+// nameTextMapping ... the analyzed version of the name searched by the free text queries, as in the index-def file
+const nameTextMapping = `{
+	"properties": {
+		"name": {"type": "keyword", "fields": {"text": {"type": "text", "analyzer": "pattern"}}}
+	}
+}`
+
+// ensureNameTextField ... adds the name.text field to an index created without it and re-indexes its assets in place,
+// in the background, so that their names are matched by the free text queries
+func (dao *dao) ensureNameTextField() error {
+	ctx := context.Background()
+	index := dao.Connector.IndexName
+	getRes, err := esapi.IndicesGetMappingRequest{Index: []string{index}}.Do(ctx, dao.Connector.Client)
+	if err != nil {
+		return fmt.Errorf("IndicesGetMappingRequest ERROR: %s", err)
+	}
+	defer getRes.Body.Close()
+	if getRes.IsError() {
+		return fmt.Errorf("%s ERROR retrieving the mapping of index %s", getRes.Status(), index)
+	}
+	// the mappings are keyed by index name
+	mappings := map[string]struct {
+		Mappings struct {
+			Properties map[string]struct {
+				Fields map[string]interface{} `json:"fields"`
+			} `json:"properties"`
+		} `json:"mappings"`
+	}{}
+	if err := json.NewDecoder(getRes.Body).Decode(&mappings); err != nil {
+		return fmt.Errorf("Error parsing the response body: %s", err)
+	}
+	missing := false
+	for _, m := range mappings {
+		_, exist := m.Mappings.Properties["name"].Fields["text"]
+		missing = missing || !exist
+	}
+	if !missing {
+		return nil
+	}
+
+	putRes, err := esapi.IndicesPutMappingRequest{Index: []string{index}, Body: bytes.NewReader([]byte(nameTextMapping))}.Do(ctx, dao.Connector.Client)
+	if err != nil {
+		return fmt.Errorf("IndicesPutMappingRequest ERROR: %s", err)
+	}
+	defer putRes.Body.Close()
+	if putRes.IsError() {
+		return fmt.Errorf("%s ERROR adding the name.text field to index %s :: %s", putRes.Status(), index, putRes.String())
+	}
+
+	// an update by query without script re-indexes the documents as they are, filling the new field
+	wait := false
+	updateRes, err := esapi.UpdateByQueryRequest{Index: []string{index}, Conflicts: "proceed", WaitForCompletion: &wait}.Do(ctx, dao.Connector.Client)
+	if err != nil {
+		return fmt.Errorf("UpdateByQueryRequest ERROR: %s", err)
+	}
+	defer updateRes.Body.Close()
+	if updateRes.IsError() {
+		return fmt.Errorf("%s ERROR re-indexing the assets of index %s :: %s", updateRes.Status(), index, updateRes.String())
+	}
+	logging.Info("Added the name.text field, re-indexing the assets in the background", "index", index)
+	return nil
 }
 
-// Create ... Create asset on ES
-func (dao *dao) Upsert(fs *abstract.Asset) error {
+// revisionsIndexDef ... mappings of the revisions index, the asset snapshot is stored but not indexed
+const revisionsIndexDef = `{
+	"mappings": {
+		"properties": {
+			"name": {"type": "keyword"},
+			"revision": {"type": "integer"},
+			"created-at": {"type": "date"},
+			"author": {"type": "keyword"},
+			"asset": {"type": "object", "enabled": false},
+			"schema-changes": {"type": "object", "enabled": false},
+			"impacted": {"type": "keyword"}
+		}
+	}
+}`
+
+// Upsert ... Upsert asset on ES, using its name as document id
+func (dao *dao) Upsert(asset *abstract.Asset) error {
+	jsonVal, err := json.Marshal(asset)
+	if err != nil {
+		return err
+	}
+
+	// indexing a document with an existing id replaces it
+	// https://www.elastic.co/guide/en/elasticsearch/reference/7.x/docs-index_.html
+	req := esapi.IndexRequest{
+		Index:      dao.Connector.IndexName,
+		DocumentID: asset.Name,
+		Body:       bytes.NewReader(jsonVal),
+		Refresh:    "true",
+	}
+
+	// Return an API response object from request
+	ctx := context.Background()
+	res, err := req.Do(ctx, dao.Connector.Client)
+	if err != nil {
+		return fmt.Errorf("IndexRequest ERROR: %s", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		logging.Error("Failed indexing document", "status", res.Status(), "response", res.String())
+		return fmt.Errorf("%s ERROR indexing document ", res.Status())
+	}
+
 	return nil
 }
 
-// SearchAssetsByTags ... search for the provided tags
+func (dao *dao) search(buf *bytes.Buffer) (*SearchResponse, error) {
+	// Perform a search request.
+	res, err := dao.Connector.Client.Search(
+		dao.Connector.Client.Search.WithContext(context.Background()),
+		dao.Connector.Client.Search.WithIndex(dao.Connector.IndexName),
+		dao.Connector.Client.Search.WithBody(buf),
+		dao.Connector.Client.Search.WithTrackTotalHits(true),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting response: %s", err)
+	}
+
+	defer res.Body.Close()
+
+	if res.IsError() {
+		var e map[string]interface{}
+		if err := json.NewDecoder(res.Body).Decode(&e); err != nil {
+			return nil, fmt.Errorf("Error parsing the response body: %s", err)
+		}
+		// Print the response status and error information.
+		return nil, fmt.Errorf("[%s] %s: %s",
+			res.Status(),
+			e["error"].(map[string]interface{})["type"],
+			e["error"].(map[string]interface{})["reason"],
+		)
+	}
+
+	searchResponse := &SearchResponse{}
+	if err := json.NewDecoder(res.Body).Decode(searchResponse); err != nil {
+		return nil, fmt.Errorf("Error parsing the response body: %s", err)
+	}
+
+	return searchResponse, nil
+}
+
+// searchAssets ... Return all the assets matching the query sorted by name, using search_after to move through pages
+// of the maximum size, since a search only returns its first 10 hits by default
+func (dao *dao) searchAssets(query map[string]interface{}) (*[]abstract.Asset, error) {
+	var hits []ResponseDoc
+	var searchAfter []interface{}
+	for {
+		body := map[string]interface{}{
+			"query": query,
+			"sort":  []interface{}{map[string]interface{}{abstract.SortByName: "asc"}},
+			"size":  abstract.MaxLimit,
+		}
+		if searchAfter != nil {
+			body["search_after"] = searchAfter
+		}
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			return nil, fmt.Errorf("Error encoding query: %s", err)
+		}
+		searchResponse, err := dao.search(&buf)
+		if err != nil {
+			return nil, err
+		}
+		page := searchResponse.Hits.Hits
+		hits = append(hits, page...)
+		if len(page) < abstract.MaxLimit {
+			return convertDocumentsToAssetCollection(hits), nil
+		}
+		searchAfter = page[len(page)-1].Sort
+	}
+}
+
+// notDeleted ... excludes soft-deleted assets, i.e. those having a deletion date
+var notDeleted = map[string]interface{}{"exists": map[string]interface{}{"field": "deleted-at"}}
+
+// SearchAssetsByTags ... Retrieve assets having all the provided tags
 func (dao *dao) SearchAssetsByTags(tags []string) (*[]abstract.Asset, error) {
-	return nil, nil
+	// one term query for each tag, all of them shall match
+	// https://www.elastic.co/guide/en/elasticsearch/reference/current/query-dsl-term-query.html
+	var filters []interface{}
+	for _, tag := range tags {
+		filters = append(filters, map[string]interface{}{
+			"term": map[string]interface{}{
+				"tags": tag,
+			},
+		})
+	}
+
+	query := map[string]interface{}{
+		"bool": map[string]interface{}{
+			"filter":   filters,
+			"must_not": notDeleted,
+		},
+	}
+	assets, err := dao.searchAssets(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(*assets) == 0 {
+		return nil, fmt.Errorf("Error while retrieving assets using filter :: empty result set")
+	}
+	return assets, nil
 }
 
-// ListAllFeatureSets ... Return all assets in index
+// ListAllAssets ... Return all assets in index, an empty index returns an empty list
 func (dao *dao) ListAllAssets() (*[]abstract.Asset, error) {
-	return nil, nil
+	query := map[string]interface{}{
+		"match_all": map[string]interface{}{},
+	}
+	return dao.searchAssets(query)
+}
+
+// ListAssets ... Return a page of assets, using search_after to move through pages and the name to break ties
+func (dao *dao) ListAssets(opts *abstract.ListOptions) (*abstract.AssetPage, error) {
+	order := "asc"
+	if opts.Descending {
+		order = "desc"
+	}
+	sort := []interface{}{map[string]interface{}{opts.SortBy: order}}
+	if opts.SortBy != abstract.SortByName {
+		sort = append(sort, map[string]interface{}{abstract.SortByName: order})
+	}
+
+	// retrieve one more document to know if a next page exists
+	query := map[string]interface{}{
+		// soft-deleted assets are not listed
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{"must_not": notDeleted},
+		},
+		"sort": sort,
+		"size": opts.Limit + 1,
+	}
+	if len(opts.Fields) > 0 {
+		query["_source"] = opts.Fields
+	}
+
+	var cursor *abstract.Cursor
+	if opts.Cursor != "" {
+		c, err := abstract.DecodeCursor(opts.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		cursor = c
+	}
+	if cursor != nil && len(cursor.SearchAfter) > 0 {
+		query["search_after"] = cursor.SearchAfter
+	} else {
+		query["from"] = opts.StartOffset()
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return nil, fmt.Errorf("Error encoding query: %s", err)
+	}
+	searchResponse, err := dao.search(&buf)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := searchResponse.Hits.Hits
+	page := &abstract.AssetPage{}
+	if len(hits) > opts.Limit {
+		hits = hits[:opts.Limit]
+		page.NextCursor = abstract.EncodeCursor(&abstract.Cursor{SearchAfter: hits[len(hits)-1].Sort})
+	}
+	page.Assets = *convertDocumentsToAssetCollection(hits)
+	return page, nil
+}
+
+// facetSize ... maximum number of values returned for each facet
+const facetSize = 100
+
+// SearchAssets ... Return the assets matching the search, scoring the query on the analyzed name and description
+// and computing the facets with terms aggregations
+func (dao *dao) SearchAssets(search *abstract.AssetSearch) (*abstract.AssetSearchResult, error) {
+	boolQuery := map[string]interface{}{}
+	mustNot := []interface{}{}
+	if !search.IncludeDeleted {
+		mustNot = append(mustNot, notDeleted)
+	}
+	sort := []interface{}{map[string]interface{}{"name": "asc"}}
+	if len(search.Terms()) > 0 {
+		// the name is a keyword, its analyzed version is the name.text field
+		boolQuery["must"] = map[string]interface{}{
+			"multi_match": map[string]interface{}{
+				"query":    search.Query,
+				"fields":   []string{"name.text^2", "description"},
+				"operator": "and",
+			},
+		}
+		sort = append([]interface{}{"_score"}, sort...)
+	}
+
+	filters := []interface{}{}
+	if len(search.Types) > 0 {
+		filters = append(filters, map[string]interface{}{"terms": map[string]interface{}{"type": search.Types}})
+	}
+	for _, tag := range search.Tags {
+		filters = append(filters, map[string]interface{}{"term": map[string]interface{}{"tags": tag}})
+	}
+	for k, v := range search.Labels {
+		filters = append(filters, map[string]interface{}{"term": map[string]interface{}{"labels." + k: v}})
+	}
+	for _, dependency := range search.DependsOn {
+		filters = append(filters, map[string]interface{}{"term": map[string]interface{}{"depends-on": dependency}})
+	}
+	if search.Owner != "" {
+		filters = append(filters, map[string]interface{}{"term": map[string]interface{}{"owners": search.Owner}})
+	}
+	if search.Domain != "" {
+		filters = append(filters, map[string]interface{}{"term": map[string]interface{}{"domain": search.Domain}})
+	}
+	if len(search.Classifications) > 0 {
+		should := []interface{}{map[string]interface{}{"terms": map[string]interface{}{"classification": search.Classifications}}}
+		for _, c := range search.Classifications {
+			// unclassified assets are internal
+			if c == abstract.Internal {
+				should = append(should, map[string]interface{}{"bool": map[string]interface{}{
+					"must_not": map[string]interface{}{"exists": map[string]interface{}{"field": "classification"}},
+				}})
+			}
+		}
+		filters = append(filters, map[string]interface{}{"bool": map[string]interface{}{"should": should, "minimum_should_match": 1}})
+	}
+	published := map[string]interface{}{}
+	if search.PublishedFrom != nil {
+		published["gte"] = search.PublishedFrom.UTC().Format(time.RFC3339Nano)
+	}
+	if search.PublishedTo != nil {
+		published["lte"] = search.PublishedTo.UTC().Format(time.RFC3339Nano)
+	}
+	if len(published) > 0 {
+		filters = append(filters, map[string]interface{}{"range": map[string]interface{}{"published-on": published}})
+	}
+	staleSince := map[string]interface{}{"exists": map[string]interface{}{"field": "stale-since"}}
+	if search.Stale != nil {
+		if *search.Stale {
+			filters = append(filters, staleSince)
+		} else {
+			mustNot = append(mustNot, staleSince)
+		}
+	}
+	boolQuery["filter"] = filters
+	boolQuery["must_not"] = mustNot
+
+	query := map[string]interface{}{
+		"query": map[string]interface{}{"bool": boolQuery},
+		"sort":  sort,
+		"from":  search.Offset,
+		"size":  search.Limit,
+		"aggs": map[string]interface{}{
+			"types": map[string]interface{}{"terms": map[string]interface{}{"field": "type", "size": facetSize}},
+			"tags":  map[string]interface{}{"terms": map[string]interface{}{"field": "tags", "size": facetSize}},
+		},
+	}
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return nil, fmt.Errorf("Error encoding query: %s", err)
+	}
+	searchResponse, err := dao.search(&buf)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &abstract.AssetSearchResult{
+		Total:  int(searchResponse.Hits.Total.Value),
+		Assets: *convertDocumentsToAssetCollection(searchResponse.Hits.Hits),
+		Facets: abstract.NewAssetFacets(),
+	}
+	for _, b := range searchResponse.Aggregations["types"].Buckets {
+		result.Facets.Types[b.Key] = b.DocCount
+	}
+	for _, b := range searchResponse.Aggregations["tags"].Buckets {
+		result.Facets.Tags[b.Key] = b.DocCount
+	}
+	return result, nil
+}
+
+// Delete ... Remove the document with given name, which is its id
+func (dao *dao) Delete(name string) error {
+	res, err := dao.Connector.Client.Delete(
+		dao.Connector.IndexName,
+		name,
+		dao.Connector.Client.Delete.WithContext(context.Background()),
+		dao.Connector.Client.Delete.WithRefresh("true"),
+	)
+	if err != nil {
+		return fmt.Errorf("Error getting response: %s", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return abstract.ErrAssetNotFound
+	}
+	if res.IsError() {
+		return fmt.Errorf("%s ERROR deleting document %s", res.Status(), name)
+	}
+	return nil
+}
+
+// SoftDelete ... Set the deletion date of the document with given name with a partial update
+func (dao *dao) SoftDelete(name string, at time.Time) error {
+	body, err := json.Marshal(map[string]interface{}{
+		"doc": map[string]interface{}{"deleted-at": at},
+	})
+	if err != nil {
+		return err
+	}
+	res, err := dao.Connector.Client.Update(
+		dao.Connector.IndexName,
+		name,
+		bytes.NewReader(body),
+		dao.Connector.Client.Update.WithContext(context.Background()),
+		dao.Connector.Client.Update.WithRefresh("true"),
+	)
+	if err != nil {
+		return fmt.Errorf("Error getting response: %s", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return abstract.ErrAssetNotFound
+	}
+	if res.IsError() {
+		return fmt.Errorf("%s ERROR updating document %s", res.Status(), name)
+	}
+	return nil
+}
+
+// UpdateByQueryResponse ... response returned by ES for an update by query request
+type UpdateByQueryResponse struct {
+	Updated int `json:"updated,omitempty"`
+}
+
+// MarkStale ... Set the stale date of the documents discovered by the crawler and not seen in its last walk
+func (dao *dao) MarkStale(discoveredBy string, seen []string, at time.Time) (int, error) {
+	if seen == nil {
+		seen = []string{}
+	}
+	query := map[string]interface{}{
+		"query": map[string]interface{}{
+			"bool": map[string]interface{}{
+				"filter": map[string]interface{}{"term": map[string]interface{}{"discovered-by": discoveredBy}},
+				"must_not": []interface{}{
+					map[string]interface{}{"ids": map[string]interface{}{"values": seen}},
+					map[string]interface{}{"exists": map[string]interface{}{"field": "stale-since"}},
+					notDeleted,
+				},
+			},
+		},
+		"script": map[string]interface{}{
+			"source": "ctx._source['stale-since'] = params.at",
+			"lang":   "painless",
+			"params": map[string]interface{}{"at": at.UTC().Format(time.RFC3339Nano)},
+		},
+	}
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return 0, fmt.Errorf("Error encoding query: %s", err)
+	}
+
+	res, err := dao.Connector.Client.UpdateByQuery(
+		[]string{dao.Connector.IndexName},
+		dao.Connector.Client.UpdateByQuery.WithContext(context.Background()),
+		dao.Connector.Client.UpdateByQuery.WithBody(&buf),
+		dao.Connector.Client.UpdateByQuery.WithRefresh(true),
+		// documents concurrently upserted by the crawler are not stale
+		dao.Connector.Client.UpdateByQuery.WithConflicts("proceed"),
+	)
+	if err != nil {
+		return 0, fmt.Errorf("Error getting response: %s", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return 0, fmt.Errorf("%s ERROR marking stale documents", res.Status())
+	}
+	response := &UpdateByQueryResponse{}
+	if err := json.NewDecoder(res.Body).Decode(response); err != nil {
+		return 0, fmt.Errorf("Error parsing the response body: %s", err)
+	}
+	return response.Updated, nil
+}
+
+// RevisionsResponse ... response returned by ES for a search on the revisions index
+type RevisionsResponse struct {
+	Hits struct {
+		Hits []struct {
+			Source abstract.AssetRevision `json:"_source,omitempty"`
+		} `json:"hits,omitempty"`
+	} `json:"hits,omitempty"`
+}
+
+// searchRevisions ... Retrieve the revisions of the asset sorted by number, up to size
+func (dao *dao) searchRevisions(name string, order string, size int) ([]abstract.AssetRevision, error) {
+	query := map[string]interface{}{
+		"query": map[string]interface{}{"term": map[string]interface{}{"name": name}},
+		"sort":  []interface{}{map[string]interface{}{"revision": order}},
+		"size":  size,
+	}
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(query); err != nil {
+		return nil, fmt.Errorf("Error encoding query: %s", err)
+	}
+	res, err := dao.Connector.Client.Search(
+		dao.Connector.Client.Search.WithContext(context.Background()),
+		dao.Connector.Client.Search.WithIndex(dao.RevisionsIndexName),
+		dao.Connector.Client.Search.WithBody(&buf),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting response: %s", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		return nil, fmt.Errorf("%s ERROR retrieving revisions of %s", res.Status(), name)
+	}
+	response := &RevisionsResponse{}
+	if err := json.NewDecoder(res.Body).Decode(response); err != nil {
+		return nil, fmt.Errorf("Error parsing the response body: %s", err)
+	}
+	revisions := []abstract.AssetRevision{}
+	for _, h := range response.Hits.Hits {
+		revisions = append(revisions, h.Source)
+	}
+	return revisions, nil
+}
+
+// AddRevision ... Create the revision after the last one of the asset, using name@revision as document id
+// a concurrently taken revision number fails with ErrRevisionExists
+func (dao *dao) AddRevision(rev *abstract.AssetRevision) error {
+	last, err := dao.GetLatestRevision(rev.Name)
+	if err != nil {
+		return err
+	}
+	rev.Revision = 1
+	if last != nil {
+		rev.Revision = last.Revision + 1
+	}
+
+	jsonVal, err := json.Marshal(rev)
+	if err != nil {
+		return err
+	}
+	// the create operation fails if the document id exists already
+	req := esapi.IndexRequest{
+		Index:      dao.RevisionsIndexName,
+		DocumentID: fmt.Sprintf("%s@%d", rev.Name, rev.Revision),
+		OpType:     "create",
+		Body:       bytes.NewReader(jsonVal),
+		Refresh:    "true",
+	}
+	res, err := req.Do(context.Background(), dao.Connector.Client)
+	if err != nil {
+		return fmt.Errorf("IndexRequest ERROR: %s", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusConflict {
+		return abstract.ErrRevisionExists
+	}
+	if res.IsError() {
+		return fmt.Errorf("%s ERROR indexing revision of %s", res.Status(), rev.Name)
+	}
+	return nil
+}
+
+// ListRevisions ... Return the revisions of the asset by increasing number
+func (dao *dao) ListRevisions(name string) (*[]abstract.AssetRevision, error) {
+	revisions, err := dao.searchRevisions(name, "asc", abstract.MaxLimit)
+	if err != nil {
+		return nil, err
+	}
+	return &revisions, nil
+}
+
+// GetLatestRevision ... Return the revision of the asset with the highest number, nil if none,
+// searching the one sorted first by decreasing number rather than listing them
+func (dao *dao) GetLatestRevision(name string) (*abstract.AssetRevision, error) {
+	last, err := dao.searchRevisions(name, "desc", 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(last) == 0 {
+		return nil, nil
+	}
+	return &last[0], nil
+}
+
+// GetRevision ... Return the given revision of the asset, nil if missing
+func (dao *dao) GetRevision(name string, revision int) (*abstract.AssetRevision, error) {
+	id := fmt.Sprintf("%s@%d", name, revision)
+	res, err := dao.Connector.Client.Get(
+		dao.RevisionsIndexName,
+		id,
+		dao.Connector.Client.Get.WithContext(context.Background()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting response: %s", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.IsError() {
+		return nil, fmt.Errorf("%s ERROR retrieving revision %s", res.Status(), id)
+	}
+	response := &struct {
+		Found  bool                   `json:"found,omitempty"`
+		Source abstract.AssetRevision `json:"_source,omitempty"`
+	}{}
+	if err := json.NewDecoder(res.Body).Decode(response); err != nil {
+		return nil, fmt.Errorf("Error parsing the response body: %s", err)
+	}
+	if !response.Found {
+		return nil, nil
+	}
+	return &response.Source, nil
 }
 
 // GetById ... Retrieve document by given id
 func (dao *dao) GetById(id string) (*abstract.Asset, error) {
-	return nil, nil
+	res, err := dao.Connector.Client.Get(
+		dao.Connector.IndexName,
+		id,
+		dao.Connector.Client.Get.WithContext(context.Background()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting response: %s", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("No document found for id %s :: %w", id, abstract.ErrAssetNotFound)
+	}
+
+	if res.IsError() {
+		return nil, fmt.Errorf("%s ERROR retrieving document %s", res.Status(), id)
+	}
+
+	getResponse := &GetResponse{}
+	if err := json.NewDecoder(res.Body).Decode(getResponse); err != nil {
+		return nil, fmt.Errorf("Error parsing the response body: %s", err)
+	}
+
+	if !getResponse.Found {
+		return nil, fmt.Errorf("No document found for id %s :: %w", id, abstract.ErrAssetNotFound)
+	}
+	return &getResponse.Source, nil
+}
+
+// GetByName ... Retrieve document by given name
+func (dao *dao) GetByName(name string) (*abstract.Asset, error) {
+	// use a term query to do an exact match of the name
+	query := map[string]interface{}{
+		"term": map[string]interface{}{
+			"name": name,
+		},
+	}
+
+	assets, err := dao.searchAssets(query)
+	if err != nil {
+		return nil, err
+	}
+	if len(*assets) == 0 {
+		return nil, fmt.Errorf("No document found for name %s :: %w", name, abstract.ErrAssetNotFound)
+	}
+	return &((*assets)[0]), nil
+}
+
+func convertDocumentsToAssetCollection(documents []ResponseDoc) *[]abstract.Asset {
+	assets := []abstract.Asset{}
+	for _, d := range documents {
+		assets = append(assets, d.Source)
+	}
+	return &assets
 }
 
-// GetByName ... Retrieve document by given id
-func (dao *dao) GetByName(id string) (*abstract.Asset, error) {
-	return nil, nil
+// Ping ... pings the backend through the connector
+func (dao *dao) Ping() error {
+	return dao.Connector.Ping()
 }
 
 // CloseConnection ... Terminates the connection to ES for the DAO
@@ -0,0 +1,264 @@
+package embedded
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/sources/embedded"
+	"github.com/pilillo/mastro/utils/conf"
+	"github.com/pilillo/mastro/utils/logging"
+	"github.com/pilillo/mastro/utils/retry"
+)
+
+// bucket holding the assets, keyed by asset name
+const assetsBucket = "assets"
+
+// bucket holding the asset revisions, keyed by asset name and revision number
+const revisionsBucket = "revisions"
+
+// revisionKey ... key of a revision, zero padded so that revisions of an asset are iterated in order
+func revisionKey(name string, revision int) string {
+	return fmt.Sprintf("%s@%010d", name, revision)
+}
+
+var once sync.Once
+var instance *dao
+
+type dao struct {
+	Connector *embedded.Connector
+	// updateMutex ... serializes read-modify-write updates of stored assets
+	updateMutex sync.Mutex
+}
+
+// GetSingleton ... get an instance of the dao backend
+func GetSingleton() abstract.AssetDAOProvider {
+	// once.do is lazy, we use it to return an instance of the DAO
+	once.Do(func() {
+		instance = &dao{}
+	})
+	return instance
+}
+
+// Init ... Initialize the embedded store
+func (dao *dao) Init(def *conf.DataSourceDefinition) error {
+	dao.Connector = embedded.NewEmbeddedConnector()
+	if err := dao.Connector.ValidateDataSourceDefinition(def); err != nil {
+		return retry.Permanent(err)
+	}
+	return dao.Connector.InitConnection(def)
+}
+
+// Upsert ... Upsert asset, using its name as key
+func (dao *dao) Upsert(asset *abstract.Asset) error {
+	dao.updateMutex.Lock()
+	defer dao.updateMutex.Unlock()
+	return dao.put(asset)
+}
+
+// put ... stores the asset, the caller is expected to hold the update lock
+func (dao *dao) put(asset *abstract.Asset) error {
+	if err := dao.Connector.Put(assetsBucket, asset.Name, asset); err != nil {
+		return fmt.Errorf("Error while upserting asset :: %v", err)
+	}
+	logging.Debug("Upserted Asset", "name", asset.Name)
+	return nil
+}
+
+// GetById ... Retrieve asset by given id, which is the asset name
+func (dao *dao) GetById(id string) (*abstract.Asset, error) {
+	return dao.GetByName(id)
+}
+
+// GetByName ... Retrieve asset by given name
+func (dao *dao) GetByName(name string) (*abstract.Asset, error) {
+	asset := &abstract.Asset{}
+	found, err := dao.Connector.Get(assetsBucket, name, asset)
+	if err != nil {
+		return nil, fmt.Errorf("Error while retrieving asset :: %v", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("Error while retrieving asset %s :: %w", name, abstract.ErrAssetNotFound)
+	}
+	return asset, nil
+}
+
+// getAnyAssetUsingFilter ... returns all assets for which the filter is true, possibly none
+func (dao *dao) getAnyAssetUsingFilter(filter func(asset *abstract.Asset) bool) (*[]abstract.Asset, error) {
+	var assets []abstract.Asset
+	err := dao.Connector.ForEach(assetsBucket, func(key string, value json.RawMessage) error {
+		asset := abstract.Asset{}
+		if err := json.Unmarshal(value, &asset); err != nil {
+			return err
+		}
+		if filter(&asset) {
+			assets = append(assets, asset)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error while retrieving asset :: %v", err)
+	}
+	return &assets, nil
+}
+
+// SearchAssetsByTags ... Retrieve assets having all the given tags
+func (dao *dao) SearchAssetsByTags(tags []string) (*[]abstract.Asset, error) {
+	assets, err := dao.getAnyAssetUsingFilter(func(asset *abstract.Asset) bool {
+		return asset.HasTags(tags) && !asset.IsDeleted()
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(*assets) == 0 {
+		return nil, fmt.Errorf("Error while retrieving assets using filter :: empty result set")
+	}
+	return assets, nil
+}
+
+// ListAllAssets ... Return all assets in the store, an empty store returns an empty list
+func (dao *dao) ListAllAssets() (*[]abstract.Asset, error) {
+	return dao.getAnyAssetUsingFilter(func(asset *abstract.Asset) bool {
+		return true
+	})
+}
+
+// ListAssets ... Return a page of assets, sorted and paged in memory
+func (dao *dao) ListAssets(opts *abstract.ListOptions) (*abstract.AssetPage, error) {
+	assets, err := dao.ListAllAssets()
+	if err != nil {
+		return nil, err
+	}
+	return abstract.PageAssets(*assets, opts), nil
+}
+
+// SearchAssets ... Return the assets matching the search, filtered and counted in memory
+func (dao *dao) SearchAssets(search *abstract.AssetSearch) (*abstract.AssetSearchResult, error) {
+	assets, err := dao.ListAllAssets()
+	if err != nil {
+		return nil, err
+	}
+	return abstract.SearchAssets(*assets, search), nil
+}
+
+// Delete ... Remove the asset with given name
+func (dao *dao) Delete(name string) error {
+	found, err := dao.Connector.Delete(assetsBucket, name)
+	if err != nil {
+		return fmt.Errorf("Error while deleting asset :: %v", err)
+	}
+	if !found {
+		return abstract.ErrAssetNotFound
+	}
+	return nil
+}
+
+// SoftDelete ... Mark the asset with given name as deleted
+func (dao *dao) SoftDelete(name string, at time.Time) error {
+	dao.updateMutex.Lock()
+	defer dao.updateMutex.Unlock()
+	asset := &abstract.Asset{}
+	found, err := dao.Connector.Get(assetsBucket, name, asset)
+	if err != nil {
+		return fmt.Errorf("Error while retrieving asset :: %v", err)
+	}
+	if !found {
+		return abstract.ErrAssetNotFound
+	}
+	asset.DeletedAt = &at
+	return dao.put(asset)
+}
+
+// MarkStale ... Mark as stale the assets discovered by the crawler and not seen in its last walk
+func (dao *dao) MarkStale(discoveredBy string, seen []string, at time.Time) (int, error) {
+	dao.updateMutex.Lock()
+	defer dao.updateMutex.Unlock()
+	all, err := dao.ListAllAssets()
+	if err != nil {
+		return 0, err
+	}
+	candidates := abstract.StaleCandidates(*all, discoveredBy, seen)
+	for i := range candidates {
+		candidates[i].StaleSince = &at
+		if err := dao.put(&candidates[i]); err != nil {
+			return i, err
+		}
+	}
+	return len(candidates), nil
+}
+
+// AddRevision ... Append the revision to those of the asset
+func (dao *dao) AddRevision(rev *abstract.AssetRevision) error {
+	dao.updateMutex.Lock()
+	defer dao.updateMutex.Unlock()
+	revisions, err := dao.ListRevisions(rev.Name)
+	if err != nil {
+		return err
+	}
+	rev.Revision = len(*revisions) + 1
+	if err := dao.Connector.Put(revisionsBucket, revisionKey(rev.Name, rev.Revision), rev); err != nil {
+		return fmt.Errorf("Error while adding revision :: %v", err)
+	}
+	return nil
+}
+
+// ListRevisions ... Return the revisions of the asset by increasing number
+func (dao *dao) ListRevisions(name string) (*[]abstract.AssetRevision, error) {
+	revisions := []abstract.AssetRevision{}
+	// keys are sorted, so that the revisions of an asset are iterated in order
+	err := dao.Connector.ForEach(revisionsBucket, func(key string, value json.RawMessage) error {
+		if !strings.HasPrefix(key, name+"@") {
+			return nil
+		}
+		rev := abstract.AssetRevision{}
+		if err := json.Unmarshal(value, &rev); err != nil {
+			return err
+		}
+		if rev.Name == name {
+			revisions = append(revisions, rev)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error while retrieving revisions :: %v", err)
+	}
+	return &revisions, nil
+}
+
+// GetRevision ... Return the given revision of the asset, nil if missing
+func (dao *dao) GetRevision(name string, revision int) (*abstract.AssetRevision, error) {
+	rev := &abstract.AssetRevision{}
+	found, err := dao.Connector.Get(revisionsBucket, revisionKey(name, revision), rev)
+	if err != nil {
+		return nil, fmt.Errorf("Error while retrieving revision :: %v", err)
+	}
+	if !found {
+		return nil, nil
+	}
+	return rev, nil
+}
+
+// GetLatestRevision ... Return the revision of the asset with the highest number, nil if none
+func (dao *dao) GetLatestRevision(name string) (*abstract.AssetRevision, error) {
+	revisions, err := dao.ListRevisions(name)
+	if err != nil {
+		return nil, err
+	}
+	if len(*revisions) == 0 {
+		return nil, nil
+	}
+	return &(*revisions)[len(*revisions)-1], nil
+}
+
+// Ping ... pings the backend through the connector
+func (dao *dao) Ping() error {
+	return dao.Connector.Ping()
+}
+
+// CloseConnection ... Flushes the embedded store
+func (dao *dao) CloseConnection() {
+	dao.Connector.CloseConnection()
+}
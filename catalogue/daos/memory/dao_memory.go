@@ -0,0 +1,210 @@
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/utils/conf"
+)
+
+var once sync.Once
+var instance *dao
+
+// dao ... in-memory DAO, assets are lost when the process terminates,
+// they are copied on write and on read so that callers never share them with the DAO
+type dao struct {
+	mutex     sync.RWMutex
+	assets    map[string]abstract.Asset
+	revisions map[string][]abstract.AssetRevision
+}
+
+// GetSingleton ... get an instance of the dao backend
+func GetSingleton() abstract.AssetDAOProvider {
+	// once.do is lazy, we use it to return an instance of the DAO
+	once.Do(func() {
+		instance = &dao{}
+	})
+	return instance
+}
+
+// Init ... Initialize an empty collection, no settings are required
+func (dao *dao) Init(def *conf.DataSourceDefinition) error {
+	dao.mutex.Lock()
+	defer dao.mutex.Unlock()
+	dao.assets = make(map[string]abstract.Asset)
+	dao.revisions = make(map[string][]abstract.AssetRevision)
+	return nil
+}
+
+// Upsert ... Upsert asset, using its name as key
+func (dao *dao) Upsert(asset *abstract.Asset) error {
+	dao.mutex.Lock()
+	defer dao.mutex.Unlock()
+	dao.assets[asset.Name] = asset.DeepCopy()
+	return nil
+}
+
+// GetById ... Retrieve asset by given id, which is the asset name
+func (dao *dao) GetById(id string) (*abstract.Asset, error) {
+	return dao.GetByName(id)
+}
+
+// GetByName ... Retrieve asset by given name
+func (dao *dao) GetByName(name string) (*abstract.Asset, error) {
+	dao.mutex.RLock()
+	defer dao.mutex.RUnlock()
+
+	asset, exist := dao.assets[name]
+	if !exist {
+		return nil, fmt.Errorf("Error while retrieving asset %s :: %w", name, abstract.ErrAssetNotFound)
+	}
+	copied := asset.DeepCopy()
+	return &copied, nil
+}
+
+// getAnyAssetUsingFilter ... returns all assets for which the filter is true, sorted by name
+func (dao *dao) getAnyAssetUsingFilter(filter func(asset *abstract.Asset) bool) *[]abstract.Asset {
+	dao.mutex.RLock()
+	defer dao.mutex.RUnlock()
+
+	assets := []abstract.Asset{}
+	for _, asset := range dao.assets {
+		if filter(&asset) {
+			assets = append(assets, asset.DeepCopy())
+		}
+	}
+	sort.Slice(assets, func(i, j int) bool {
+		return assets[i].Name < assets[j].Name
+	})
+	return &assets
+}
+
+// SearchAssetsByTags ... Retrieve assets having all the given tags
+func (dao *dao) SearchAssetsByTags(tags []string) (*[]abstract.Asset, error) {
+	assets := dao.getAnyAssetUsingFilter(func(asset *abstract.Asset) bool {
+		return asset.HasTags(tags) && !asset.IsDeleted()
+	})
+	if len(*assets) == 0 {
+		return nil, fmt.Errorf("Error while retrieving assets using filter :: empty result set")
+	}
+	return assets, nil
+}
+
+// ListAllAssets ... Return all assets, an empty collection returns an empty list
+func (dao *dao) ListAllAssets() (*[]abstract.Asset, error) {
+	return dao.getAnyAssetUsingFilter(func(asset *abstract.Asset) bool {
+		return true
+	}), nil
+}
+
+// ListAssets ... Return a page of assets, sorted and paged in memory
+func (dao *dao) ListAssets(opts *abstract.ListOptions) (*abstract.AssetPage, error) {
+	assets, err := dao.ListAllAssets()
+	if err != nil {
+		return nil, err
+	}
+	return abstract.PageAssets(*assets, opts), nil
+}
+
+// SearchAssets ... Return the assets matching the search, filtered and counted in memory
+func (dao *dao) SearchAssets(search *abstract.AssetSearch) (*abstract.AssetSearchResult, error) {
+	assets, err := dao.ListAllAssets()
+	if err != nil {
+		return nil, err
+	}
+	return abstract.SearchAssets(*assets, search), nil
+}
+
+// Delete ... Remove the asset with given name
+func (dao *dao) Delete(name string) error {
+	dao.mutex.Lock()
+	defer dao.mutex.Unlock()
+	if _, exist := dao.assets[name]; !exist {
+		return abstract.ErrAssetNotFound
+	}
+	delete(dao.assets, name)
+	return nil
+}
+
+// SoftDelete ... Mark the asset with given name as deleted
+func (dao *dao) SoftDelete(name string, at time.Time) error {
+	dao.mutex.Lock()
+	defer dao.mutex.Unlock()
+	asset, exist := dao.assets[name]
+	if !exist {
+		return abstract.ErrAssetNotFound
+	}
+	asset.DeletedAt = &at
+	dao.assets[name] = asset
+	return nil
+}
+
+// MarkStale ... Mark as stale the assets discovered by the crawler and not seen in its last walk
+func (dao *dao) MarkStale(discoveredBy string, seen []string, at time.Time) (int, error) {
+	dao.mutex.Lock()
+	defer dao.mutex.Unlock()
+	all := make([]abstract.Asset, 0, len(dao.assets))
+	for _, asset := range dao.assets {
+		all = append(all, asset)
+	}
+	candidates := abstract.StaleCandidates(all, discoveredBy, seen)
+	for _, asset := range candidates {
+		asset.StaleSince = &at
+		dao.assets[asset.Name] = asset
+	}
+	return len(candidates), nil
+}
+
+// AddRevision ... Append the revision to those of the asset
+func (dao *dao) AddRevision(rev *abstract.AssetRevision) error {
+	dao.mutex.Lock()
+	defer dao.mutex.Unlock()
+	rev.Revision = len(dao.revisions[rev.Name]) + 1
+	dao.revisions[rev.Name] = append(dao.revisions[rev.Name], rev.DeepCopy())
+	return nil
+}
+
+// ListRevisions ... Return the revisions of the asset, in the order they were added
+func (dao *dao) ListRevisions(name string) (*[]abstract.AssetRevision, error) {
+	dao.mutex.RLock()
+	defer dao.mutex.RUnlock()
+	revisions := []abstract.AssetRevision{}
+	for i := range dao.revisions[name] {
+		revisions = append(revisions, dao.revisions[name][i].DeepCopy())
+	}
+	return &revisions, nil
+}
+
+// GetRevision ... Return the given revision of the asset, nil if missing
+func (dao *dao) GetRevision(name string, revision int) (*abstract.AssetRevision, error) {
+	dao.mutex.RLock()
+	defer dao.mutex.RUnlock()
+	if revision < 1 || revision > len(dao.revisions[name]) {
+		return nil, nil
+	}
+	rev := dao.revisions[name][revision-1].DeepCopy()
+	return &rev, nil
+}
+
+// GetLatestRevision ... Return the last revision added to the asset, nil if none
+func (dao *dao) GetLatestRevision(name string) (*abstract.AssetRevision, error) {
+	dao.mutex.RLock()
+	defer dao.mutex.RUnlock()
+	revisions := dao.revisions[name]
+	if len(revisions) == 0 {
+		return nil, nil
+	}
+	rev := revisions[len(revisions)-1].DeepCopy()
+	return &rev, nil
+}
+
+// Ping ... the in-memory DAO is always available
+func (dao *dao) Ping() error {
+	return nil
+}
+
+// CloseConnection ... nothing to close for the in-memory DAO
+func (dao *dao) CloseConnection() {}
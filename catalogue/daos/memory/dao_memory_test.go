@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/catalogue/daos/daotest"
+)
+
+func TestConformance(t *testing.T) {
+	daotest.RunAssetDAOSuite(t, func(t *testing.T) abstract.AssetDAOProvider {
+		dao := GetSingleton()
+		if err := dao.Init(nil); err != nil {
+			t.Fatal(err)
+		}
+		return dao
+	})
+}
+
+func TestCopies(t *testing.T) {
+	dao := GetSingleton()
+	if err := dao.Init(nil); err != nil {
+		t.Fatal(err)
+	}
+	asset := &abstract.Asset{Name: "orders", Type: "table", Tags: []string{"sales"},
+		Labels: map[string]interface{}{"schema": map[string]interface{}{"id": "int"}}}
+	if err := dao.Upsert(asset); err != nil {
+		t.Fatal(err)
+	}
+	// changing the upserted asset does not change the stored one
+	asset.Tags[0] = "changed"
+	asset.Labels["schema"].(map[string]interface{})["id"] = "string"
+
+	stored, err := dao.GetByName("orders")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Tags[0] != "sales" || stored.Labels["schema"].(map[string]interface{})["id"] != "int" {
+		t.Errorf("expected the stored asset not to share the upserted maps and slices, got %v", stored)
+	}
+	// nor does changing the retrieved one
+	stored.Tags[0] = "changed"
+	stored.Labels["owner"] = "someone"
+	again, _ := dao.GetByName("orders")
+	if again.Tags[0] != "sales" || again.Labels["owner"] != nil {
+		t.Errorf("expected the stored asset not to share the retrieved maps and slices, got %v", again)
+	}
+}
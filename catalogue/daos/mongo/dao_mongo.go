@@ -3,13 +3,17 @@ package mongo
 import (
 	"context"
 	"fmt"
-	"log"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/pilillo/mastro/abstract"
 	"github.com/pilillo/mastro/sources/mongo"
 	"github.com/pilillo/mastro/utils/conf"
+	"github.com/pilillo/mastro/utils/logging"
+	"github.com/pilillo/mastro/utils/retry"
+	driverbson "go.mongodb.org/mongo-driver/bson"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"gopkg.in/mgo.v2/bson"
 )
@@ -32,6 +36,19 @@ type assetMongoDao struct {
 	Labels map[string]interface{} `bson:"labels"`
 	// tags are flags used to simplify asset search
 	Tags []string `bson:"tags"`
+	// ownership
+	Owners  []string `bson:"owners,omitempty"`
+	Steward string   `bson:"steward,omitempty"`
+	Domain  string   `bson:"domain,omitempty"`
+	Team    string   `bson:"team,omitempty"`
+	Contact string   `bson:"contact,omitempty"`
+	// sensitivity of the asset
+	Classification abstract.Classification `bson:"classification,omitempty"`
+	// crawler which last discovered the asset
+	DiscoveredBy string `bson:"discovered-by,omitempty"`
+	// asset stale and soft-deletion datetimes
+	StaleSince *time.Time `bson:"stale-since,omitempty"`
+	DeletedAt  *time.Time `bson:"deleted-at,omitempty"`
 }
 
 func convertAssetDTOtoDAO(as *abstract.Asset) *assetMongoDao {
@@ -50,6 +67,17 @@ func convertAssetDTOtoDAO(as *abstract.Asset) *assetMongoDao {
 	asmd.Labels = as.Labels
 	asmd.Tags = as.Tags
 
+	asmd.Owners = as.Owners
+	asmd.Steward = as.Steward
+	asmd.Domain = as.Domain
+	asmd.Team = as.Team
+	asmd.Contact = as.Contact
+	asmd.Classification = as.Classification
+
+	asmd.DiscoveredBy = as.DiscoveredBy
+	asmd.StaleSince = as.StaleSince
+	asmd.DeletedAt = as.DeletedAt
+
 	return asmd
 }
 
@@ -67,6 +95,17 @@ func convertAssetDAOtoDTO(asmd *assetMongoDao) *abstract.Asset {
 	as.Labels = asmd.Labels
 	as.Tags = asmd.Tags
 
+	as.Owners = asmd.Owners
+	as.Steward = asmd.Steward
+	as.Domain = asmd.Domain
+	as.Team = asmd.Team
+	as.Contact = asmd.Contact
+	as.Classification = asmd.Classification
+
+	as.DiscoveredBy = asmd.DiscoveredBy
+	as.StaleSince = asmd.StaleSince
+	as.DeletedAt = asmd.DeletedAt
+
 	return as
 }
 
@@ -83,6 +122,21 @@ var instance *dao
 
 type dao struct {
 	Connector *mongo.Connector
+	// revisions are stored in a separate collection, named after the assets one
+	Revisions *mongodriver.Collection
+}
+
+// assetRevisionMongoDao ... a revision document, identified by asset name and revision number
+type assetRevisionMongoDao struct {
+	ID        string        `bson:"_id"`
+	Name      string        `bson:"name"`
+	Revision  int           `bson:"revision"`
+	CreatedAt time.Time     `bson:"created-at"`
+	Author    string        `bson:"author,omitempty"`
+	Asset     assetMongoDao `bson:"asset"`
+	// schema changes are stored as they are, being simple flat structs
+	SchemaChanges []abstract.SchemaChange `bson:"schema-changes,omitempty"`
+	Impacted      []string                `bson:"impacted,omitempty"`
 }
 
 var timeout = 5 * time.Second
@@ -97,12 +151,76 @@ func GetSingleton() abstract.AssetDAOProvider {
 }
 
 // Init ... Initialize connection to elastic search and target index
-func (dao *dao) Init(def *conf.DataSourceDefinition) {
+func (dao *dao) Init(def *conf.DataSourceDefinition) error {
 	dao.Connector = mongo.NewMongoConnector()
 	if err := dao.Connector.ValidateDataSourceDefinition(def); err != nil {
-		panic(err)
+		return retry.Permanent(err)
+	}
+	if err := dao.Connector.InitConnection(def); err != nil {
+		return err
+	}
+	// make sure the text index used by the search exists
+	if err := dao.ensureTextIndex(); err != nil {
+		return fmt.Errorf("Failed creating the text index :: %v", err)
+	}
+	// as well as the indexes to list assets by owner and domain
+	if err := dao.ensureOwnershipIndexes(); err != nil {
+		return fmt.Errorf("Failed creating the ownership indexes :: %v", err)
+	}
+	dao.Revisions = dao.Connector.Database.Collection(dao.Connector.Collection.Name() + "-revisions")
+	// and the index to retrieve the revisions of an asset by number, the latest first
+	if err := dao.ensureRevisionIndex(); err != nil {
+		return fmt.Errorf("Failed creating the revision index :: %v", err)
+	}
+	return nil
+}
+
+// textIndexName ... name of the text index over name and description
+const textIndexName = "asset-text"
+
+// ensureTextIndex ... creates the text index, with the name weighting twice the description,
+// creating an index with the same name and keys is a no-op
+func (dao *dao) ensureTextIndex() error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	_, err := dao.Connector.Collection.Indexes().CreateOne(ctx, mongodriver.IndexModel{
+		Keys: driverbson.D{{Key: "_id", Value: "text"}, {Key: "description", Value: "text"}},
+		Options: options.Index().
+			SetName(textIndexName).
+			SetWeights(driverbson.M{"_id": 2, "description": 1}),
+	})
+	if err != nil {
+		return fmt.Errorf("Error while creating the text index :: %v", err)
 	}
-	dao.Connector.InitConnection(def)
+	return nil
+}
+
+// ensureOwnershipIndexes ... creates the indexes on owners (a multikey index) and domain
+func (dao *dao) ensureOwnershipIndexes() error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	_, err := dao.Connector.Collection.Indexes().CreateMany(ctx, []mongodriver.IndexModel{
+		{Keys: driverbson.D{{Key: "owners", Value: 1}}, Options: options.Index().SetName("asset-owners")},
+		{Keys: driverbson.D{{Key: "domain", Value: 1}}, Options: options.Index().SetName("asset-domain")},
+	})
+	if err != nil {
+		return fmt.Errorf("Error while creating the ownership indexes :: %v", err)
+	}
+	return nil
+}
+
+// ensureRevisionIndex ... creates the index on the name and decreasing number of the revisions
+func (dao *dao) ensureRevisionIndex() error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	_, err := dao.Revisions.Indexes().CreateOne(ctx, mongodriver.IndexModel{
+		Keys:    driverbson.D{{Key: "name", Value: 1}, {Key: "revision", Value: -1}},
+		Options: options.Index().SetName("revision-name"),
+	})
+	if err != nil {
+		return fmt.Errorf("Error while creating the revision index :: %v", err)
+	}
+	return nil
 }
 
 // Upsert ... Upsert asset
@@ -134,10 +252,10 @@ func (dao *dao) Upsert(as *abstract.Asset) error {
 
 	if result.MatchedCount > 0 {
 		// we are updating an existing asset
-		log.Printf("Matched %d Asset(s) :: Modified %d documents", result.MatchedCount, result.ModifiedCount)
+		logging.Debug("Matched Assets", "matched", result.MatchedCount, "modified", result.ModifiedCount)
 	} else {
 		// upsert/insert
-		log.Printf("Upserted %d Asset(s) :: id = '%v'", result.UpsertedCount, result.UpsertedID)
+		logging.Debug("Upserted Assets", "upserted", result.UpsertedCount, "id", result.UpsertedID)
 	}
 
 	return nil
@@ -148,18 +266,21 @@ func (dao *dao) getOneDocumentUsingFilter(filter interface{}) (*abstract.Asset,
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 	err := dao.Connector.Collection.FindOne(ctx, filter).Decode(&result)
+	if err == mongodriver.ErrNoDocuments {
+		return nil, fmt.Errorf("Error while retrieving asset :: %w", abstract.ErrAssetNotFound)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("Error while retrieving asset :: %v", err)
 	}
 	return convertAssetDAOtoDTO(&result), nil
 }
 
-func (dao *dao) getAnyDocumentUsingFilter(filter interface{}) (*[]abstract.Asset, error) {
+func (dao *dao) getAnyDocumentUsingFilter(filter interface{}, opts ...*options.FindOptions) (*[]abstract.Asset, error) {
 	var assets []assetMongoDao
 
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
-	cursor, err := dao.Connector.Collection.Find(ctx, filter)
+	cursor, err := dao.Connector.Collection.Find(ctx, filter, opts...)
 	// return if any error during get
 	if err != nil {
 		return nil, fmt.Errorf("Error while retrieving asset :: %v", err)
@@ -169,10 +290,6 @@ func (dao *dao) getAnyDocumentUsingFilter(filter interface{}) (*[]abstract.Asset
 		return nil, fmt.Errorf("Error while retrieving asset :: %v", err)
 	}
 
-	if assets == nil {
-		return nil, fmt.Errorf("Error while retrieving assets using filter :: empty result set")
-	}
-
 	var resultAssets []abstract.Asset = convertAllAssets(&assets)
 	return &resultAssets, nil
 }
@@ -197,16 +314,374 @@ func (dao *dao) SearchAssetsByTags(tags []string) (*[]abstract.Asset, error) {
 	// https://docs.mongodb.com/manual/tutorial/query-arrays/#match-an-array
 	// find all docs whose tags field contains all the elements provided as tags []string in input
 	// without regard of the order
-	filter := bson.M{"tags": bson.M{"$all": tags}}
-	return dao.getAnyDocumentUsingFilter(filter)
+	// soft-deleted assets have a deleted-at date, a null filter matches missing fields
+	filter := bson.M{"tags": bson.M{"$all": tags}, "deleted-at": nil}
+	assets, err := dao.getAnyDocumentUsingFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(*assets) == 0 {
+		return nil, fmt.Errorf("Error while retrieving assets using filter :: empty result set")
+	}
+	return assets, nil
 }
 
-// ListAllAssets ... Return all assets in index
+// ListAllAssets ... Return all assets in index, an empty collection returns an empty list
 func (dao *dao) ListAllAssets() (*[]abstract.Asset, error) {
 	filter := bson.M{}
 	return dao.getAnyDocumentUsingFilter(filter)
 }
 
+// bsonFields ... bson field for each json field of the asset, used to sort and project documents
+var bsonFields = map[string]string{
+	"last-discovered-at": "last-discovered-at",
+	"published-on":       "published-on",
+	"name":               "_id",
+	"description":        "description",
+	"depends-on":         "depends-on",
+	"type":               "type",
+	"labels":             "labels",
+	"tags":               "tags",
+	"owners":             "owners",
+	"steward":            "steward",
+	"domain":             "domain",
+	"team":               "team",
+	"contact":            "contact",
+	"classification":     "classification",
+	"discovered-by":      "discovered-by",
+	"stale-since":        "stale-since",
+	"deleted-at":         "deleted-at",
+}
+
+// ListAssets ... Return a page of documents, the name (i.e. the _id) breaks ties
+func (dao *dao) ListAssets(opts *abstract.ListOptions) (*abstract.AssetPage, error) {
+	order := 1
+	if opts.Descending {
+		order = -1
+	}
+	sort := driverbson.D{{Key: bsonFields[opts.SortBy], Value: order}}
+	if opts.SortBy != abstract.SortByName {
+		sort = append(sort, driverbson.E{Key: "_id", Value: order})
+	}
+
+	offset := opts.StartOffset()
+	// retrieve one more document to know if a next page exists
+	findOpts := options.Find().SetSort(sort).SetSkip(int64(offset)).SetLimit(int64(opts.Limit + 1))
+	if len(opts.Fields) > 0 {
+		projection := driverbson.M{}
+		for _, f := range opts.Fields {
+			projection[bsonFields[f]] = 1
+		}
+		findOpts.SetProjection(projection)
+	}
+
+	// soft-deleted assets are not listed
+	assets, err := dao.getAnyDocumentUsingFilter(driverbson.M{"deleted-at": nil}, findOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &abstract.AssetPage{Assets: []abstract.Asset{}}
+	more := len(*assets) > opts.Limit
+	if more {
+		*assets = (*assets)[:opts.Limit]
+	}
+	page.Assets = append(page.Assets, *assets...)
+	page.NextCursor = opts.NextOffsetCursor(offset, more)
+	return page, nil
+}
+
+// facetBucket ... number of documents per value, as returned by $sortByCount
+type facetBucket struct {
+	Value string `bson:"_id"`
+	Count int    `bson:"count"`
+}
+
+// searchResult ... output of the $facet stage of the search
+type searchResult struct {
+	Assets []assetMongoDao `bson:"assets"`
+	Total  []struct {
+		Count int `bson:"count"`
+	} `bson:"total"`
+	Types []facetBucket `bson:"types"`
+	Tags  []facetBucket `bson:"tags"`
+}
+
+// SearchAssets ... Return the assets matching the search, using the text index for the query
+// and computing the facets on all matching documents in the same aggregation
+func (dao *dao) SearchAssets(search *abstract.AssetSearch) (*abstract.AssetSearchResult, error) {
+	match := driverbson.M{}
+	if !search.IncludeDeleted {
+		match["deleted-at"] = nil
+	}
+	if search.Stale != nil {
+		if *search.Stale {
+			match["stale-since"] = driverbson.M{"$ne": nil}
+		} else {
+			match["stale-since"] = nil
+		}
+	}
+	terms := search.Terms()
+	if len(terms) > 0 {
+		// quoted terms are all required, rather than any of them
+		match["$text"] = driverbson.M{"$search": "\"" + strings.Join(terms, "\" \"") + "\""}
+	}
+	if len(search.Types) > 0 {
+		match["type"] = driverbson.M{"$in": search.Types}
+	}
+	if len(search.Tags) > 0 {
+		match["tags"] = driverbson.M{"$all": search.Tags}
+	}
+	for k, v := range search.Labels {
+		// labels are matched by their text, as the searched values are strings
+		match["labels."+k] = driverbson.M{"$in": abstract.LabelValues(v)}
+	}
+	if len(search.DependsOn) > 0 {
+		match["depends-on"] = driverbson.M{"$all": search.DependsOn}
+	}
+	if search.Owner != "" {
+		// matches any element of the owners array
+		match["owners"] = search.Owner
+	}
+	if search.Domain != "" {
+		match["domain"] = search.Domain
+	}
+	if len(search.Classifications) > 0 {
+		classifications := []interface{}{}
+		for _, c := range search.Classifications {
+			classifications = append(classifications, c)
+			// unclassified assets are internal, a null value matches missing fields
+			if c == abstract.Internal {
+				classifications = append(classifications, nil, "")
+			}
+		}
+		match["classification"] = driverbson.M{"$in": classifications}
+	}
+	published := driverbson.M{}
+	if search.PublishedFrom != nil {
+		published["$gte"] = *search.PublishedFrom
+	}
+	if search.PublishedTo != nil {
+		published["$lte"] = *search.PublishedTo
+	}
+	if len(published) > 0 {
+		match["published-on"] = published
+	}
+
+	pipeline := []driverbson.M{{"$match": match}}
+	sort := driverbson.D{{Key: "_id", Value: 1}}
+	if len(terms) > 0 {
+		pipeline = append(pipeline, driverbson.M{"$addFields": driverbson.M{"score": driverbson.M{"$meta": "textScore"}}})
+		sort = append(driverbson.D{{Key: "score", Value: -1}}, sort...)
+	}
+	pipeline = append(pipeline, driverbson.M{"$facet": driverbson.M{
+		"assets": []driverbson.M{{"$sort": sort}, {"$skip": search.Offset}, {"$limit": search.Limit}},
+		"total":  []driverbson.M{{"$count": "count"}},
+		"types":  []driverbson.M{{"$sortByCount": "$type"}},
+		"tags":   []driverbson.M{{"$unwind": "$tags"}, {"$sortByCount": "$tags"}},
+	}})
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cursor, err := dao.Connector.Collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("Error while searching assets :: %v", err)
+	}
+	var results []searchResult
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("Error while searching assets :: %v", err)
+	}
+
+	result := &abstract.AssetSearchResult{Assets: []abstract.Asset{}, Facets: abstract.NewAssetFacets()}
+	if len(results) == 0 {
+		return result, nil
+	}
+	result.Assets = append(result.Assets, convertAllAssets(&results[0].Assets)...)
+	if len(results[0].Total) > 0 {
+		result.Total = results[0].Total[0].Count
+	}
+	for _, b := range results[0].Types {
+		result.Facets.Types[b.Value] = b.Count
+	}
+	for _, b := range results[0].Tags {
+		result.Facets.Tags[b.Value] = b.Count
+	}
+	return result, nil
+}
+
+// Delete ... Remove the document with given name
+func (dao *dao) Delete(name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	result, err := dao.Connector.Collection.DeleteOne(ctx, driverbson.M{"_id": name})
+	if err != nil {
+		return fmt.Errorf("Error while deleting asset :: %v", err)
+	}
+	if result.DeletedCount == 0 {
+		return abstract.ErrAssetNotFound
+	}
+	return nil
+}
+
+// SoftDelete ... Set the deletion date of the document with given name
+func (dao *dao) SoftDelete(name string, at time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	result, err := dao.Connector.Collection.UpdateOne(ctx,
+		driverbson.M{"_id": name},
+		driverbson.M{"$set": driverbson.M{"deleted-at": at}},
+	)
+	if err != nil {
+		return fmt.Errorf("Error while deleting asset :: %v", err)
+	}
+	if result.MatchedCount == 0 {
+		return abstract.ErrAssetNotFound
+	}
+	return nil
+}
+
+// MarkStale ... Set the stale date of the documents discovered by the crawler and not seen in its last walk
+func (dao *dao) MarkStale(discoveredBy string, seen []string, at time.Time) (int, error) {
+	if seen == nil {
+		// $nin requires an array
+		seen = []string{}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	result, err := dao.Connector.Collection.UpdateMany(ctx,
+		driverbson.M{
+			"discovered-by": discoveredBy,
+			"_id":           driverbson.M{"$nin": seen},
+			"stale-since":   nil,
+			"deleted-at":    nil,
+		},
+		driverbson.M{"$set": driverbson.M{"stale-since": at}},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("Error while marking stale assets :: %v", err)
+	}
+	return int(result.ModifiedCount), nil
+}
+
+// duplicateKeyCode ... error code returned by mongo when inserting an existing _id
+const duplicateKeyCode = 11000
+
+func isDuplicateKey(err error) bool {
+	if we, isWriteException := err.(mongodriver.WriteException); isWriteException {
+		for _, e := range we.WriteErrors {
+			if e.Code == duplicateKeyCode {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AddRevision ... Insert the revision after the last one of the asset, the _id being unique
+// a concurrently taken revision number fails with ErrRevisionExists
+func (dao *dao) AddRevision(rev *abstract.AssetRevision) error {
+	last, err := dao.GetLatestRevision(rev.Name)
+	if err != nil {
+		return err
+	}
+	rev.Revision = 1
+	if last != nil {
+		rev.Revision = last.Revision + 1
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	_, err = dao.Revisions.InsertOne(ctx, assetRevisionMongoDao{
+		ID:            fmt.Sprintf("%s@%d", rev.Name, rev.Revision),
+		Name:          rev.Name,
+		Revision:      rev.Revision,
+		CreatedAt:     rev.CreatedAt,
+		Author:        rev.Author,
+		Asset:         *convertAssetDTOtoDAO(&rev.Asset),
+		SchemaChanges: rev.SchemaChanges,
+		Impacted:      rev.Impacted,
+	})
+	if isDuplicateKey(err) {
+		return abstract.ErrRevisionExists
+	}
+	if err != nil {
+		return fmt.Errorf("Error while adding revision :: %v", err)
+	}
+	return nil
+}
+
+func convertRevisionDAOtoDTO(revmd *assetRevisionMongoDao) *abstract.AssetRevision {
+	return &abstract.AssetRevision{
+		Name:          revmd.Name,
+		Revision:      revmd.Revision,
+		CreatedAt:     revmd.CreatedAt,
+		Author:        revmd.Author,
+		Asset:         *convertAssetDAOtoDTO(&revmd.Asset),
+		SchemaChanges: revmd.SchemaChanges,
+		Impacted:      revmd.Impacted,
+	}
+}
+
+// ListRevisions ... Return the revisions of the asset by increasing number
+func (dao *dao) ListRevisions(name string) (*[]abstract.AssetRevision, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cursor, err := dao.Revisions.Find(ctx,
+		driverbson.M{"name": name},
+		options.Find().SetSort(driverbson.M{"revision": 1}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Error while retrieving revisions :: %v", err)
+	}
+	var results []assetRevisionMongoDao
+	if err = cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("Error while retrieving revisions :: %v", err)
+	}
+	revisions := []abstract.AssetRevision{}
+	for i := range results {
+		revisions = append(revisions, *convertRevisionDAOtoDTO(&results[i]))
+	}
+	return &revisions, nil
+}
+
+// GetRevision ... Return the given revision of the asset, nil if missing
+func (dao *dao) GetRevision(name string, revision int) (*abstract.AssetRevision, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	var result assetRevisionMongoDao
+	err := dao.Revisions.FindOne(ctx, driverbson.M{"name": name, "revision": revision}).Decode(&result)
+	if err == mongodriver.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error while retrieving revision :: %v", err)
+	}
+	return convertRevisionDAOtoDTO(&result), nil
+}
+
+// GetLatestRevision ... Return the revision of the asset with the highest number, nil if none
+func (dao *dao) GetLatestRevision(name string) (*abstract.AssetRevision, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	var result assetRevisionMongoDao
+	err := dao.Revisions.FindOne(ctx,
+		driverbson.M{"name": name},
+		options.FindOne().SetSort(driverbson.D{{Key: "revision", Value: -1}}),
+	).Decode(&result)
+	if err == mongodriver.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error while retrieving revisions :: %v", err)
+	}
+	return convertRevisionDAOtoDTO(&result), nil
+}
+
+// Ping ... pings the backend through the connector
+func (dao *dao) Ping() error {
+	return dao.Connector.Ping()
+}
+
 // CloseConnection ... Terminates the connection to ES for the DAO
 func (dao *dao) CloseConnection() {
 	dao.Connector.CloseConnection()
@@ -0,0 +1,287 @@
+package catalogue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/proto/cataloguepb"
+	"github.com/pilillo/mastro/utils/auth"
+	"github.com/pilillo/mastro/utils/conf"
+	"github.com/pilillo/mastro/utils/errors"
+	"github.com/pilillo/mastro/utils/grpc"
+	"github.com/pilillo/mastro/utils/queries"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// grpcPortKey ... setting of the details with the port of the gRPC server, which is not started if missing
+const grpcPortKey = "grpc-port"
+
+// grpcPermissions ... permission required by each method of the catalogue service
+var grpcPermissions = map[string]auth.Permission{
+	"UpsertAssets":       auth.Write,
+	"GetAssetByID":       auth.Read,
+	"GetAssetByName":     auth.Read,
+	"SearchAssetsByTags": auth.Read,
+	"SearchAssets":       auth.Read,
+	"ListAssetsByOwner":  auth.Read,
+	"ListAssetsByDomain": auth.Read,
+	"GetAssetLineage":    auth.Read,
+	"DeleteAsset":        auth.Delete,
+	"ReconcileAssets":    auth.Write,
+	"ListAllAssets":      auth.Read,
+	"StreamAssets":       auth.Read,
+	"ListAssetRevisions": auth.Read,
+	"DiffAssetRevisions": auth.Read,
+	"ListSchemaChanges":  auth.Read,
+}
+
+// startGRPC ... serves the gRPC methods in the background, if a port is set in the details, returning nil otherwise
+func startGRPC(cfg *conf.Config, authz *auth.Middleware) (*grpc.Server, error) {
+	port, exist := cfg.Details[grpcPortKey]
+	if !exist || port == "" {
+		return nil, nil
+	}
+	server, err := newGRPCServer(authz)
+	if err != nil {
+		return nil, err
+	}
+	if err := server.Listen(fmt.Sprintf(":%s", port)); err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+// newGRPCServer ... returns a server of the catalogue service, which shares the service of the REST endpoints
+func newGRPCServer(authz *auth.Middleware) (*grpc.Server, error) {
+	server := grpc.NewServer(authz)
+	if err := server.Register(&cataloguepb.Catalogue_ServiceDesc, catalogueServer{}, grpcPermissions); err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+// serviceForCall ... returns the asset service as seen by the principal of the call
+func serviceForCall(ctx context.Context) Service {
+	return serviceForPrincipal(ctx, grpc.GetPrincipal(ctx))
+}
+
+// internalError ... the status of a reply which cannot be converted to a message
+func internalError(err error) error {
+	return status.Error(codes.Internal, err.Error())
+}
+
+// catalogueServer ... implements the generated catalogue service on the asset service
+type catalogueServer struct {
+	cataloguepb.UnimplementedCatalogueServer
+}
+
+func (catalogueServer) UpsertAssets(ctx context.Context, req *cataloguepb.AssetList) (*cataloguepb.AssetList, error) {
+	assets := fromAssetMessages(req.Assets)
+	result, restErr := serviceForCall(ctx).UpsertAssets(&assets)
+	if restErr != nil {
+		return nil, grpc.FromRestErr(restErr)
+	}
+	messages, err := toAssetMessages(*result)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return &cataloguepb.AssetList{Assets: messages}, nil
+}
+
+// assetReply ... converts the asset retrieved by the service to a reply
+func assetReply(asset *abstract.Asset, restErr *errors.RestErr) (*cataloguepb.Asset, error) {
+	if restErr != nil {
+		return nil, grpc.FromRestErr(restErr)
+	}
+	m, err := toAssetMessage(asset)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return m, nil
+}
+
+// searchReply ... converts the search result of the service to a reply
+func searchReply(result *abstract.AssetSearchResult, restErr *errors.RestErr) (*cataloguepb.AssetSearchResult, error) {
+	if restErr != nil {
+		return nil, grpc.FromRestErr(restErr)
+	}
+	m, err := toSearchResultMessage(result)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return m, nil
+}
+
+func (catalogueServer) GetAssetByID(ctx context.Context, req *cataloguepb.GetAssetByIDRequest) (*cataloguepb.Asset, error) {
+	return assetReply(serviceForCall(ctx).GetAssetByID(req.Id))
+}
+
+func (catalogueServer) GetAssetByName(ctx context.Context, req *cataloguepb.GetAssetByNameRequest) (*cataloguepb.Asset, error) {
+	return assetReply(serviceForCall(ctx).GetAssetByName(req.Name))
+}
+
+func (catalogueServer) SearchAssetsByTags(ctx context.Context, req *cataloguepb.SearchAssetsByTagsRequest) (*cataloguepb.AssetList, error) {
+	if len(req.Tags) == 0 {
+		return nil, grpc.FromRestErr(errors.GetBadRequestError("Invalid query by tag :: empty tag list"))
+	}
+	assets, restErr := serviceForCall(ctx).SearchAssetsByTags(req.Tags)
+	if restErr != nil {
+		return nil, grpc.FromRestErr(restErr)
+	}
+	messages, err := toAssetMessages(*assets)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return &cataloguepb.AssetList{Assets: messages}, nil
+}
+
+func (catalogueServer) SearchAssets(ctx context.Context, req *cataloguepb.AssetSearch) (*cataloguepb.AssetSearchResult, error) {
+	return searchReply(serviceForCall(ctx).SearchAssets(fromSearchMessage(req)))
+}
+
+func (catalogueServer) ListAssetsByOwner(ctx context.Context, req *cataloguepb.ListAssetsByOwnerRequest) (*cataloguepb.AssetSearchResult, error) {
+	return searchReply(serviceForCall(ctx).ListAssetsByOwner(req.Owner, int(req.Limit)))
+}
+
+func (catalogueServer) ListAssetsByDomain(ctx context.Context, req *cataloguepb.ListAssetsByDomainRequest) (*cataloguepb.AssetSearchResult, error) {
+	return searchReply(serviceForCall(ctx).ListAssetsByDomain(req.Domain, int(req.Limit)))
+}
+
+func (catalogueServer) GetAssetLineage(ctx context.Context, req *cataloguepb.GetAssetLineageRequest) (*cataloguepb.LineageGraph, error) {
+	direction := req.Direction
+	if direction == "" {
+		direction = abstract.LineageBoth
+	}
+	graph, restErr := serviceForCall(ctx).GetAssetLineage(req.Name, direction, int(req.Depth))
+	if restErr != nil {
+		return nil, grpc.FromRestErr(restErr)
+	}
+	return toLineageMessage(graph), nil
+}
+
+func (catalogueServer) DeleteAsset(ctx context.Context, req *cataloguepb.DeleteAssetRequest) (*emptypb.Empty, error) {
+	if restErr := serviceForCall(ctx).DeleteAsset(req.Name, req.Purge); restErr != nil {
+		return nil, grpc.FromRestErr(restErr)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (catalogueServer) ReconcileAssets(ctx context.Context, req *cataloguepb.ReconcileAssetsRequest) (*cataloguepb.ReconcileAssetsReply, error) {
+	stale, restErr := serviceForCall(ctx).ReconcileAssets(req.DiscoveredBy, req.Names)
+	if restErr != nil {
+		return nil, grpc.FromRestErr(restErr)
+	}
+	return &cataloguepb.ReconcileAssetsReply{DiscoveredBy: req.DiscoveredBy, Stale: int32(stale)}, nil
+}
+
+// listOptions ... converts a list request to the options of the service
+func listOptions(req *cataloguepb.ListRequest) *abstract.ListOptions {
+	query := queries.List{Limit: int(req.Limit), Offset: int(req.Offset), Cursor: req.Cursor, Sort: req.Sort, Fields: strings.Join(req.Fields, ",")}
+	return query.ToListOptions()
+}
+
+// projectAssets ... returns the assets with only the given fields set, all if none is given
+func projectAssets(assets []abstract.Asset, fields []string) ([]abstract.Asset, error) {
+	if len(fields) == 0 {
+		return assets, nil
+	}
+	projected, err := abstract.Project(assets, fields)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(projected)
+	if err != nil {
+		return nil, err
+	}
+	result := []abstract.Asset{}
+	return result, json.Unmarshal(data, &result)
+}
+
+func (catalogueServer) ListAllAssets(ctx context.Context, req *cataloguepb.ListRequest) (*cataloguepb.AssetPage, error) {
+	opts := listOptions(req)
+	page, restErr := serviceForCall(ctx).ListAllAssets(opts)
+	if restErr != nil {
+		return nil, grpc.FromRestErr(restErr)
+	}
+	assets, err := projectAssets(page.Assets, opts.Fields)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	messages, err := toAssetMessages(assets)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return &cataloguepb.AssetPage{Assets: messages, NextCursor: page.NextCursor}, nil
+}
+
+// StreamAssets ... sends all assets one by one, going through the pages of the given size and sort,
+// so that large catalogues are neither loaded at once nor paginated by the caller
+func (catalogueServer) StreamAssets(req *cataloguepb.ListRequest, stream cataloguepb.Catalogue_StreamAssetsServer) error {
+	s := serviceForCall(stream.Context())
+	opts := listOptions(req)
+	for {
+		page, restErr := s.ListAllAssets(opts)
+		if restErr != nil {
+			if restErr.Status == http.StatusNotFound {
+				// the collection is empty or the last page was full
+				return nil
+			}
+			return grpc.FromRestErr(restErr)
+		}
+		assets, err := projectAssets(page.Assets, opts.Fields)
+		if err != nil {
+			return internalError(err)
+		}
+		for i := range assets {
+			m, err := toAssetMessage(&assets[i])
+			if err != nil {
+				return internalError(err)
+			}
+			if err := stream.Send(m); err != nil {
+				return err
+			}
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		opts.Cursor = page.NextCursor
+	}
+}
+
+func (catalogueServer) ListAssetRevisions(ctx context.Context, req *cataloguepb.ListAssetRevisionsRequest) (*cataloguepb.AssetRevisionList, error) {
+	revisions, restErr := serviceForCall(ctx).ListAssetRevisions(req.Name)
+	if restErr != nil {
+		return nil, grpc.FromRestErr(restErr)
+	}
+	list, err := toRevisionMessages(*revisions)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return list, nil
+}
+
+func (catalogueServer) DiffAssetRevisions(ctx context.Context, req *cataloguepb.DiffAssetRevisionsRequest) (*cataloguepb.AssetDiff, error) {
+	diff, restErr := serviceForCall(ctx).DiffAssetRevisions(req.Name, int(req.From), int(req.To))
+	if restErr != nil {
+		return nil, grpc.FromRestErr(restErr)
+	}
+	m, err := toDiffMessage(diff)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return m, nil
+}
+
+func (catalogueServer) ListSchemaChanges(ctx context.Context, req *cataloguepb.ListSchemaChangesRequest) (*cataloguepb.SchemaEvolutionList, error) {
+	evolutions, restErr := serviceForCall(ctx).ListSchemaChanges(req.Name, req.Breaking)
+	if restErr != nil {
+		return nil, grpc.FromRestErr(restErr)
+	}
+	return toEvolutionMessages(*evolutions), nil
+}
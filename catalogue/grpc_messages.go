@@ -0,0 +1,276 @@
+package catalogue
+
+import (
+	"encoding/json"
+
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/proto/cataloguepb"
+	"github.com/pilillo/mastro/utils/grpc"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// toJSONValue ... returns the value as decoded from its json, e.g. a ColumnInfo as a map, so that it fits a struct message
+func toJSONValue(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+	return decoded, nil
+}
+
+// toValueMessage ... converts any json value to a message, unset if nil
+func toValueMessage(v interface{}) (*structpb.Value, error) {
+	if v == nil {
+		return nil, nil
+	}
+	decoded, err := toJSONValue(v)
+	if err != nil {
+		return nil, err
+	}
+	return structpb.NewValue(decoded)
+}
+
+func toLabelsMessage(labels map[string]interface{}) (*structpb.Struct, error) {
+	if labels == nil {
+		return nil, nil
+	}
+	decoded, err := toJSONValue(labels)
+	if err != nil {
+		return nil, err
+	}
+	return structpb.NewStruct(decoded.(map[string]interface{}))
+}
+
+func fromLabelsMessage(m *structpb.Struct) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	return m.AsMap()
+}
+
+func toAssetMessage(a *abstract.Asset) (*cataloguepb.Asset, error) {
+	labels, err := toLabelsMessage(a.Labels)
+	if err != nil {
+		return nil, err
+	}
+	return &cataloguepb.Asset{
+		Name:             a.Name,
+		Description:      a.Description,
+		Type:             string(a.Type),
+		DependsOn:        a.DependsOn,
+		Labels:           labels,
+		Tags:             a.Tags,
+		Owners:           a.Owners,
+		Steward:          a.Steward,
+		Domain:           a.Domain,
+		Team:             a.Team,
+		Contact:          a.Contact,
+		Classification:   string(a.Classification),
+		RedactedColumns:  int32(a.RedactedColumns),
+		DiscoveredBy:     a.DiscoveredBy,
+		LastDiscoveredAt: grpc.FromTime(a.LastDiscoveredAt),
+		PublishedOn:      grpc.FromTime(a.PublishedOn),
+		StaleSince:       grpc.FromTimePtr(a.StaleSince),
+		DeletedAt:        grpc.FromTimePtr(a.DeletedAt),
+	}, nil
+}
+
+func fromAssetMessage(m *cataloguepb.Asset) abstract.Asset {
+	return abstract.Asset{
+		Name:             m.Name,
+		Description:      m.Description,
+		Type:             abstract.AssetType(m.Type),
+		DependsOn:        m.DependsOn,
+		Labels:           fromLabelsMessage(m.Labels),
+		Tags:             m.Tags,
+		Owners:           m.Owners,
+		Steward:          m.Steward,
+		Domain:           m.Domain,
+		Team:             m.Team,
+		Contact:          m.Contact,
+		Classification:   abstract.Classification(m.Classification),
+		RedactedColumns:  int(m.RedactedColumns),
+		DiscoveredBy:     m.DiscoveredBy,
+		LastDiscoveredAt: grpc.ToTime(m.LastDiscoveredAt),
+		PublishedOn:      grpc.ToTime(m.PublishedOn),
+		StaleSince:       grpc.ToTimePtr(m.StaleSince),
+		DeletedAt:        grpc.ToTimePtr(m.DeletedAt),
+	}
+}
+
+func toAssetMessages(assets []abstract.Asset) ([]*cataloguepb.Asset, error) {
+	messages := make([]*cataloguepb.Asset, len(assets))
+	for i := range assets {
+		m, err := toAssetMessage(&assets[i])
+		if err != nil {
+			return nil, err
+		}
+		messages[i] = m
+	}
+	return messages, nil
+}
+
+func fromAssetMessages(messages []*cataloguepb.Asset) []abstract.Asset {
+	assets := make([]abstract.Asset, len(messages))
+	for i, m := range messages {
+		assets[i] = fromAssetMessage(m)
+	}
+	return assets
+}
+
+func fromSearchMessage(m *cataloguepb.AssetSearch) *abstract.AssetSearch {
+	search := &abstract.AssetSearch{
+		Query:          m.Query,
+		Tags:           m.Tags,
+		Labels:         m.Labels,
+		DependsOn:      m.DependsOn,
+		Owner:          m.Owner,
+		Domain:         m.Domain,
+		PublishedFrom:  grpc.ToTimePtr(m.PublishedFrom),
+		PublishedTo:    grpc.ToTimePtr(m.PublishedTo),
+		Stale:          m.Stale,
+		IncludeDeleted: m.IncludeDeleted,
+		Limit:          int(m.Limit),
+	}
+	for _, t := range m.Types {
+		search.Types = append(search.Types, abstract.AssetType(t))
+	}
+	for _, c := range m.Classifications {
+		search.Classifications = append(search.Classifications, abstract.Classification(c))
+	}
+	return search
+}
+
+func toCountsMessage(counts map[string]int) map[string]int32 {
+	messages := make(map[string]int32, len(counts))
+	for k, v := range counts {
+		messages[k] = int32(v)
+	}
+	return messages
+}
+
+func toSearchResultMessage(result *abstract.AssetSearchResult) (*cataloguepb.AssetSearchResult, error) {
+	assets, err := toAssetMessages(result.Assets)
+	if err != nil {
+		return nil, err
+	}
+	return &cataloguepb.AssetSearchResult{
+		Total:  int32(result.Total),
+		Assets: assets,
+		Facets: &cataloguepb.AssetFacets{
+			Types: toCountsMessage(result.Facets.Types),
+			Tags:  toCountsMessage(result.Facets.Tags),
+		},
+	}, nil
+}
+
+func toLineageMessage(g *abstract.LineageGraph) *cataloguepb.LineageGraph {
+	m := &cataloguepb.LineageGraph{Root: g.Root, Depth: int32(g.Depth), Dangling: g.Dangling}
+	for _, n := range g.Nodes {
+		m.Nodes = append(m.Nodes, &cataloguepb.LineageNode{
+			Name:           n.Name,
+			Type:           string(n.Type),
+			Direction:      n.Direction,
+			Distance:       int32(n.Distance),
+			Dangling:       n.Dangling,
+			Classification: string(n.Classification),
+		})
+	}
+	for _, e := range g.Edges {
+		m.Edges = append(m.Edges, &cataloguepb.LineageEdge{From: e.From, To: e.To})
+	}
+	for _, c := range g.Cycles {
+		m.Cycles = append(m.Cycles, &cataloguepb.LineageCycle{Names: c})
+	}
+	return m
+}
+
+func toSchemaChangeMessages(changes []abstract.SchemaChange) []*cataloguepb.SchemaChange {
+	var messages []*cataloguepb.SchemaChange
+	for _, c := range changes {
+		messages = append(messages, &cataloguepb.SchemaChange{
+			Column:     c.Column,
+			Kind:       c.Kind,
+			OldType:    c.OldType,
+			NewType:    c.NewType,
+			OldComment: c.OldComment,
+			NewComment: c.NewComment,
+			Breaking:   c.Breaking,
+		})
+	}
+	return messages
+}
+
+func toRevisionMessages(revisions []abstract.AssetRevision) (*cataloguepb.AssetRevisionList, error) {
+	list := &cataloguepb.AssetRevisionList{}
+	for i := range revisions {
+		asset, err := toAssetMessage(&revisions[i].Asset)
+		if err != nil {
+			return nil, err
+		}
+		list.Revisions = append(list.Revisions, &cataloguepb.AssetRevision{
+			Name:          revisions[i].Name,
+			Revision:      int32(revisions[i].Revision),
+			CreatedAt:     grpc.FromTime(revisions[i].CreatedAt),
+			Author:        revisions[i].Author,
+			Asset:         asset,
+			SchemaChanges: toSchemaChangeMessages(revisions[i].SchemaChanges),
+			Impacted:      revisions[i].Impacted,
+		})
+	}
+	return list, nil
+}
+
+func toChangeMessages(changes []abstract.Change) ([]*cataloguepb.Change, error) {
+	var messages []*cataloguepb.Change
+	for _, c := range changes {
+		old, err := toValueMessage(c.Old)
+		if err != nil {
+			return nil, err
+		}
+		new, err := toValueMessage(c.New)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, &cataloguepb.Change{Field: c.Field, Change: c.Change, Old: old, New: new})
+	}
+	return messages, nil
+}
+
+func toDiffMessage(diff *abstract.AssetDiff) (*cataloguepb.AssetDiff, error) {
+	fields, err := toChangeMessages(diff.Fields)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := toChangeMessages(diff.Columns)
+	if err != nil {
+		return nil, err
+	}
+	return &cataloguepb.AssetDiff{
+		Name:          diff.Name,
+		From:          int32(diff.From),
+		To:            int32(diff.To),
+		Fields:        fields,
+		Columns:       columns,
+		SchemaChanges: toSchemaChangeMessages(diff.SchemaChanges),
+	}, nil
+}
+
+func toEvolutionMessages(evolutions []abstract.SchemaEvolution) *cataloguepb.SchemaEvolutionList {
+	list := &cataloguepb.SchemaEvolutionList{}
+	for _, e := range evolutions {
+		list.Evolutions = append(list.Evolutions, &cataloguepb.SchemaEvolution{
+			Revision:  int32(e.Revision),
+			CreatedAt: grpc.FromTime(e.CreatedAt),
+			Author:    e.Author,
+			Changes:   toSchemaChangeMessages(e.Changes),
+			Breaking:  e.Breaking,
+			Impacted:  e.Impacted,
+		})
+	}
+	return list
+}
@@ -0,0 +1,101 @@
+package catalogue
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/pilillo/mastro/proto/cataloguepb"
+	"github.com/pilillo/mastro/utils/auth"
+	"github.com/pilillo/mastro/utils/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestGRPCMethods(t *testing.T) {
+	initEmbeddedService(t)
+	authz, err := auth.NewMiddleware(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server, err := newGRPCServer(authz)
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server.Serve(listener)
+	defer server.Shutdown(context.Background())
+	conn, err := grpc.Dial(listener.Addr().String(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	c := cataloguepb.NewCatalogueClient(conn)
+	ctx := context.Background()
+
+	labels, err := structpb.NewStruct(map[string]interface{}{"rows": 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	upserted, err := c.UpsertAssets(ctx, &cataloguepb.AssetList{Assets: []*cataloguepb.Asset{
+		{Name: "mydb", Type: "database"},
+		{Name: "mydb.a", Type: "table", DependsOn: []string{"mydb"}, Labels: labels},
+		{Name: "mydb.b", Type: "table", DependsOn: []string{"mydb"}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(upserted.Assets) != 3 {
+		t.Fatalf("expected 3 upserted assets, got %d", len(upserted.Assets))
+	}
+
+	asset, err := c.GetAssetByName(ctx, &cataloguepb.GetAssetByNameRequest{Name: "mydb.a"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if asset.Name != "mydb.a" || asset.Labels.AsMap()["rows"] != float64(10) || asset.LastDiscoveredAt == nil {
+		t.Errorf("unexpected asset %v", asset)
+	}
+	_, err = c.GetAssetByName(ctx, &cataloguepb.GetAssetByNameRequest{Name: "missing"})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected not found, got %v", err)
+	}
+	err = conn.Invoke(ctx, "/mastro.catalogue.Catalogue/Missing", &emptypb.Empty{}, &emptypb.Empty{})
+	if status.Code(err) != codes.Unimplemented {
+		t.Errorf("expected unimplemented, got %v", err)
+	}
+
+	lineage, err := c.GetAssetLineage(ctx, &cataloguepb.GetAssetLineageRequest{Name: "mydb"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lineage.Edges) != 2 {
+		t.Errorf("expected 2 lineage edges, got %v", lineage.Edges)
+	}
+
+	// the stream goes through all pages
+	stream, err := c.StreamAssets(ctx, &cataloguepb.ListRequest{Limit: 2, Sort: "name"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var streamed []string
+	for {
+		a, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		streamed = append(streamed, a.Name)
+	}
+	if len(streamed) != 3 || streamed[0] != "mydb" || streamed[2] != "mydb.b" {
+		t.Errorf("unexpected streamed assets %v", streamed)
+	}
+}
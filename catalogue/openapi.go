@@ -0,0 +1,187 @@
+package catalogue
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/utils/lifecycle"
+	"github.com/pilillo/mastro/utils/metrics"
+	"github.com/pilillo/mastro/utils/openapi"
+	"github.com/pilillo/mastro/utils/queries"
+)
+
+// apiVersion ... version of the catalogue API, changed on breaking changes of the contract
+const apiVersion = "1.0.0"
+
+// openAPIRoute ... route serving the OpenAPI document of the endpoints
+const openAPIRoute = "openapi.json"
+
+// apiDocument ... returns the OpenAPI document describing the catalogue endpoints registered in registerRoutes
+func apiDocument() *openapi.Document {
+	doc := openapi.New("mastro catalogue", "Data assets, their lineage and their revisions", apiVersion)
+	assetName := openapi.PathParam(assetNameParam, "unique name of the asset")
+	nameRoute := fmt.Sprintf("%s/name/:%s", assetRestEndpoint, assetNameParam)
+	tags := []string{"assets"}
+
+	doc.Add(http.MethodGet, openAPIRoute, openapi.Public(openapi.Operation{
+		OperationID: "getOpenAPI",
+		Summary:     "OpenAPI document of the endpoints",
+		Tags:        []string{"meta"},
+		Responses:   map[int]openapi.Response{http.StatusOK: openapi.Text("the document", "application/json")},
+	}))
+	doc.Add(http.MethodGet, fmt.Sprintf("healthcheck/%s", assetRestEndpoint), openapi.Public(openapi.Operation{
+		OperationID: "ping",
+		Summary:     "Healthcheck, replies pong",
+		Tags:        []string{"meta"},
+		Responses:   map[int]openapi.Response{http.StatusOK: openapi.Text("pong", "text/plain")},
+	}))
+	doc.Add(http.MethodGet, lifecycle.LivenessRoute, openapi.Public(openapi.Operation{
+		OperationID: "getLiveness",
+		Summary:     "Liveness probe, replies as long as the process serves requests",
+		Tags:        []string{"meta"},
+		Responses:   map[int]openapi.Response{http.StatusOK: openapi.Text("the process is alive", "application/json")},
+	}))
+	doc.Add(http.MethodGet, lifecycle.ReadinessRoute, openapi.Public(openapi.Operation{
+		OperationID: "getReadiness",
+		Summary:     "Readiness probe, pings the backends and fails while shutting down",
+		Tags:        []string{"meta"},
+		Responses: map[int]openapi.Response{
+			http.StatusOK:                 doc.JSON("the outcome of the checks", lifecycle.Readiness{}),
+			http.StatusServiceUnavailable: doc.JSON("the outcome of the checks, some failed", lifecycle.Readiness{}),
+		},
+	}))
+	doc.Add(http.MethodGet, metrics.Route, openapi.Public(openapi.Operation{
+		OperationID: "getMetrics",
+		Summary:     "Prometheus metrics of the requests and of the backend operations",
+		Tags:        []string{"meta"},
+		Responses:   map[int]openapi.Response{http.StatusOK: openapi.Text("the metrics", "text/plain")},
+	}))
+
+	doc.Add(http.MethodGet, fmt.Sprintf("%s/id/:%s", assetRestEndpoint, assetIDParam), openapi.Operation{
+		OperationID: "getAssetByID",
+		Summary:     "Retrieves an asset by its backend id",
+		Tags:        tags,
+		Parameters:  []openapi.Parameter{openapi.PathParam(assetIDParam, "backend specific id of the asset")},
+		Responses:   map[int]openapi.Response{http.StatusOK: doc.JSON("the asset", abstract.Asset{})},
+	}, http.StatusNotFound, http.StatusInternalServerError)
+	doc.Add(http.MethodGet, nameRoute, openapi.Operation{
+		OperationID: "getAssetByName",
+		Summary:     "Retrieves an asset by its unique name",
+		Tags:        tags,
+		Parameters:  []openapi.Parameter{assetName},
+		Responses:   map[int]openapi.Response{http.StatusOK: doc.JSON("the asset", abstract.Asset{})},
+	}, http.StatusNotFound, http.StatusInternalServerError)
+
+	lineage := doc.JSON("the lineage graph", abstract.LineageGraph{})
+	lineage.Content["text/vnd.graphviz"] = openapi.MediaType{Schema: &openapi.Schema{Type: "string"}}
+	doc.Add(http.MethodGet, nameRoute+"/lineage", openapi.Operation{
+		OperationID: "getAssetLineage",
+		Summary:     "Retrieves the assets the asset depends on and depending on it, as json or Graphviz dot",
+		Tags:        []string{"lineage"},
+		Parameters: []openapi.Parameter{
+			assetName,
+			{Name: "direction", In: "query", Description: "direction of the graph, both by default",
+				Schema: &openapi.Schema{Type: "string", Enum: []interface{}{abstract.LineageUpstream, abstract.LineageDownstream, abstract.LineageBoth}}},
+			openapi.QueryParam("depth", "integer", fmt.Sprintf("maximum distance from the asset, at most %d", abstract.MaxLineageDepth)),
+			{Name: "format", In: "query", Description: "format of the graph, json by default",
+				Schema: &openapi.Schema{Type: "string", Enum: []interface{}{"json", "dot"}}},
+		},
+		Responses: map[int]openapi.Response{http.StatusOK: lineage},
+	}, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+
+	doc.Add(http.MethodGet, nameRoute+"/revisions", openapi.Operation{
+		OperationID: "listAssetRevisions",
+		Summary:     "Retrieves the revision history of an asset",
+		Tags:        []string{"revisions"},
+		Parameters:  []openapi.Parameter{assetName},
+		Responses:   map[int]openapi.Response{http.StatusOK: doc.JSON("the revisions, oldest first", []abstract.AssetRevision{})},
+	}, http.StatusNotFound, http.StatusInternalServerError)
+	doc.Add(http.MethodGet, nameRoute+"/diff", openapi.Operation{
+		OperationID: "diffAssetRevisions",
+		Summary:     "Compares two revisions of an asset",
+		Tags:        []string{"revisions"},
+		Parameters: []openapi.Parameter{
+			assetName,
+			openapi.QueryParam("from", "integer", "revision to compare from, the one before to by default"),
+			openapi.QueryParam("to", "integer", "revision to compare to, the latest by default"),
+		},
+		Responses: map[int]openapi.Response{http.StatusOK: doc.JSON("the changes", abstract.AssetDiff{})},
+	}, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+	doc.Add(http.MethodGet, nameRoute+"/schema-changes", openapi.Operation{
+		OperationID: "listSchemaChanges",
+		Summary:     "Retrieves the classified schema changes of a table",
+		Tags:        []string{"revisions"},
+		Parameters:  []openapi.Parameter{assetName, openapi.QueryParam("breaking", "boolean", "only return breaking changes")},
+		Responses:   map[int]openapi.Response{http.StatusOK: doc.JSON("the schema changes, oldest first", []abstract.SchemaEvolution{})},
+	}, http.StatusNotFound, http.StatusInternalServerError)
+
+	doc.Add(http.MethodPut, fmt.Sprintf("%s/", assetRestEndpoint), openapi.Operation{
+		OperationID: "upsertAsset",
+		Summary:     "Creates or updates an asset",
+		Tags:        tags,
+		RequestBody: doc.JSONBody(abstract.Asset{}),
+		Responses:   map[int]openapi.Response{http.StatusCreated: doc.JSON("the upserted asset", []abstract.Asset{})},
+	}, http.StatusBadRequest, http.StatusInternalServerError)
+	doc.Add(http.MethodPut, fmt.Sprintf("%s/", assetsRestEndpoint), openapi.Operation{
+		OperationID: "upsertAssets",
+		Summary:     "Creates or updates many assets",
+		Tags:        tags,
+		RequestBody: doc.JSONBody([]abstract.Asset{}),
+		Responses:   map[int]openapi.Response{http.StatusCreated: doc.JSON("the upserted assets", []abstract.Asset{})},
+	}, http.StatusBadRequest, http.StatusInternalServerError)
+
+	doc.Add(http.MethodDelete, nameRoute, openapi.Operation{
+		OperationID: "deleteAsset",
+		Summary:     "Soft-deletes an asset, or removes it with its revisions if purged",
+		Tags:        tags,
+		Parameters:  []openapi.Parameter{assetName, openapi.QueryParam("purge", "boolean", "remove the asset rather than leaving a tombstone")},
+		Responses:   map[int]openapi.Response{http.StatusNoContent: {Description: "the asset was deleted"}},
+	}, http.StatusNotFound, http.StatusInternalServerError)
+	doc.Add(http.MethodPost, fmt.Sprintf("%s/reconcile", assetsRestEndpoint), openapi.Operation{
+		OperationID: "reconcileAssets",
+		Summary:     "Marks as stale the assets of a crawler which were not found in its last walk",
+		Tags:        tags,
+		RequestBody: doc.JSONBody(queries.Reconcile{}),
+		Responses:   map[int]openapi.Response{http.StatusOK: doc.JSON("the number of stale assets", queries.ReconcileResult{})},
+	}, http.StatusBadRequest, http.StatusInternalServerError)
+
+	doc.Add(http.MethodPost, fmt.Sprintf("%s/tags", assetsRestEndpoint), openapi.Operation{
+		OperationID: "searchAssetsByTags",
+		Summary:     "Retrieves the assets having all the tags",
+		Tags:        []string{"search"},
+		RequestBody: doc.JSONBody(queries.ByTags{}),
+		Responses:   map[int]openapi.Response{http.StatusOK: doc.JSON("the assets", []abstract.Asset{})},
+	}, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+	doc.Add(http.MethodPost, fmt.Sprintf("%s/search", assetsRestEndpoint), openapi.Operation{
+		OperationID: "searchAssets",
+		Summary:     "Searches the assets by free text and filters, with facets per type and tag",
+		Tags:        []string{"search"},
+		RequestBody: doc.JSONBody(abstract.AssetSearch{}),
+		Responses:   map[int]openapi.Response{http.StatusOK: doc.JSON("the matching assets", abstract.AssetSearchResult{})},
+	}, http.StatusBadRequest, http.StatusInternalServerError)
+	doc.Add(http.MethodGet, fmt.Sprintf("%s/owner/:%s", assetsRestEndpoint, ownerParam), openapi.Operation{
+		OperationID: "listAssetsByOwner",
+		Summary:     "Retrieves the assets having the owner among their owners",
+		Tags:        []string{"search"},
+		Parameters:  []openapi.Parameter{openapi.PathParam(ownerParam, "user or group"), openapi.QueryParam("limit", "integer", "maximum number of assets")},
+		Responses:   map[int]openapi.Response{http.StatusOK: doc.JSON("the assets of the owner", abstract.AssetSearchResult{})},
+	}, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+	doc.Add(http.MethodGet, fmt.Sprintf("%s/domain/:%s", assetsRestEndpoint, domainParam), openapi.Operation{
+		OperationID: "listAssetsByDomain",
+		Summary:     "Retrieves the assets of the business domain",
+		Tags:        []string{"search"},
+		Parameters:  []openapi.Parameter{openapi.PathParam(domainParam, "business domain"), openapi.QueryParam("limit", "integer", "maximum number of assets")},
+		Responses:   map[int]openapi.Response{http.StatusOK: doc.JSON("the assets of the domain", abstract.AssetSearchResult{})},
+	}, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+
+	doc.Add(http.MethodGet, fmt.Sprintf("%s/", assetsRestEndpoint), openapi.Operation{
+		OperationID: "listAllAssets",
+		Summary:     "Retrieves a page of assets, with only the requested fields if any",
+		Tags:        tags,
+		Parameters:  openapi.ListParams(abstract.SortByName, abstract.SortByLastDiscoveredAt),
+		Responses:   map[int]openapi.Response{http.StatusOK: doc.JSON("the page of assets", abstract.AssetPage{})},
+	}, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+
+	return doc
+}
@@ -0,0 +1,124 @@
+package catalogue
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/client"
+	"github.com/pilillo/mastro/utils/auth"
+	"github.com/pilillo/mastro/utils/openapi"
+)
+
+// newTestServer ... serves the endpoints of the catalogue on the embedded store, without authentication
+func newTestServer(t *testing.T) *httptest.Server {
+	gin.SetMode(gin.TestMode)
+	initEmbeddedService(t)
+	authz, err := auth.NewMiddleware(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine := gin.New()
+	registerRoutes(engine, authz)
+	server := httptest.NewServer(engine)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestOpenAPIDescribesAllRoutes(t *testing.T) {
+	server := newTestServer(t)
+
+	resp, err := http.Get(server.URL + "/" + openAPIRoute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	doc := openapi.Document{}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		t.Fatal(err)
+	}
+
+	engine := gin.New()
+	authz, _ := auth.NewMiddleware(nil)
+	registerRoutes(engine, authz)
+	routes := make(map[string][]string)
+	for _, r := range engine.Routes() {
+		routes[openapi.Path(r.Path)] = append(routes[openapi.Path(r.Path)], r.Method)
+	}
+	described := apiDocument().Methods()
+	for path, methods := range routes {
+		for _, method := range methods {
+			found := false
+			for _, m := range described[path] {
+				found = found || m == method
+			}
+			if !found {
+				t.Errorf("route %s %s is not described", method, path)
+			}
+		}
+	}
+	if len(doc.Paths) != len(routes) {
+		t.Errorf("expected %d paths to be served, got %d", len(routes), len(doc.Paths))
+	}
+	if _, exist := doc.Components.Schemas["Asset"]; !exist {
+		t.Error("expected the asset schema among the components")
+	}
+}
+
+func TestCatalogueClient(t *testing.T) {
+	server := newTestServer(t)
+	c := client.NewCatalogueClient(server.URL)
+
+	if err := c.Ping(); err != nil {
+		t.Fatal(err)
+	}
+	assets := []abstract.Asset{
+		{Name: "mydb", Type: "database", Tags: []string{"hive"}},
+		{Name: "mydb.mytable", Type: "table", DependsOn: []string{"mydb"}, Tags: []string{"hive"}},
+	}
+	if _, err := c.UpsertAssets(assets); err != nil {
+		t.Fatal(err)
+	}
+
+	asset, err := c.GetAssetByName("mydb.mytable")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(asset.DependsOn, []string{"mydb"}) {
+		t.Errorf("unexpected asset %v", asset)
+	}
+	if _, err := c.GetAssetByName("unknown"); !client.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+
+	page, err := c.ListAllAssets(&abstract.ListOptions{Limit: 1, SortBy: abstract.SortByName})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Assets) != 1 || page.Assets[0].Name != "mydb" || page.NextCursor == "" {
+		t.Errorf("expected the first page, got %v", page)
+	}
+
+	graph, err := c.GetAssetLineage("mydb", abstract.LineageDownstream, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(graph.Edges) != 1 {
+		t.Errorf("expected mydb.mytable downstream, got %v", graph)
+	}
+
+	stale, err := c.ReconcileAssets("s3-crawler", []string{"mydb"})
+	if err != nil || stale != 0 {
+		t.Errorf("expected no stale asset for manually added ones, got %d %v", stale, err)
+	}
+	if err := c.DeleteAsset("mydb.mytable", true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.GetAssetByName("mydb.mytable"); !client.IsNotFound(err) {
+		t.Errorf("expected a purged asset not to be found, got %v", err)
+	}
+}
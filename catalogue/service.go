@@ -1,12 +1,18 @@
 package catalogue
 
 import (
-	"log"
+	"context"
+	goerrors "errors"
+	"fmt"
+	"strings"
 
 	"github.com/pilillo/mastro/abstract"
 	"github.com/pilillo/mastro/utils/conf"
 	"github.com/pilillo/mastro/utils/date"
 	"github.com/pilillo/mastro/utils/errors"
+	"github.com/pilillo/mastro/utils/lifecycle"
+	"github.com/pilillo/mastro/utils/logging"
+	"github.com/pilillo/mastro/utils/retry"
 )
 
 // Service ... Service Interface listing implemented methods
@@ -16,11 +22,24 @@ type Service interface {
 	GetAssetByID(assetID string) (*abstract.Asset, *errors.RestErr)
 	GetAssetByName(name string) (*abstract.Asset, *errors.RestErr)
 	SearchAssetsByTags(tags []string) (*[]abstract.Asset, *errors.RestErr)
-	ListAllAssets() (*[]abstract.Asset, *errors.RestErr)
+	SearchAssets(search *abstract.AssetSearch) (*abstract.AssetSearchResult, *errors.RestErr)
+	ListAssetsByOwner(owner string, limit int) (*abstract.AssetSearchResult, *errors.RestErr)
+	ListAssetsByDomain(domain string, limit int) (*abstract.AssetSearchResult, *errors.RestErr)
+	GetAssetLineage(name string, direction string, depth int) (*abstract.LineageGraph, *errors.RestErr)
+	DeleteAsset(name string, purge bool) *errors.RestErr
+	ReconcileAssets(discoveredBy string, names []string) (int, *errors.RestErr)
+	ListAllAssets(opts *abstract.ListOptions) (*abstract.AssetPage, *errors.RestErr)
+	ListAssetRevisions(name string) (*[]abstract.AssetRevision, *errors.RestErr)
+	DiffAssetRevisions(name string, from int, to int) (*abstract.AssetDiff, *errors.RestErr)
+	ListSchemaChanges(name string, breakingOnly bool) (*[]abstract.SchemaEvolution, *errors.RestErr)
+	// store ... returns the dao of the service, tracing its calls within the context of the service
+	store() abstract.AssetDAOProvider
 }
 
-// assetServiceType ... Service Type
-type assetServiceType struct{}
+// assetServiceType ... Service Type, tracing the dao calls within the context of the request being served, if any
+type assetServiceType struct {
+	ctx context.Context
+}
 
 // assetService ... Group all service methods in a kind FeatureSetServiceType implementing the FeatureSetService
 var assetService Service = &assetServiceType{}
@@ -28,6 +47,22 @@ var assetService Service = &assetServiceType{}
 // selected dao for the featureSetService
 var dao abstract.AssetDAOProvider
 
+// backendName ... name of the dao among the backends of the lifecycle, as reported by the readiness probe
+const backendName = "backend"
+
+// withContext ... returns the service tracing its dao calls as children of the current span of the context
+func withContext(s Service, ctx context.Context) Service {
+	if _, isAssetService := s.(*assetServiceType); isAssetService {
+		return &assetServiceType{ctx: ctx}
+	}
+	return s
+}
+
+// store ... returns the selected dao, tracing its calls within the context of the service
+func (s *assetServiceType) store() abstract.AssetDAOProvider {
+	return bindContext(dao, s.ctx)
+}
+
 // Init ... initializes the service
 func (s *assetServiceType) Init(cfg *conf.Config) *errors.RestErr {
 	// select target DAO based on used connector
@@ -36,34 +71,105 @@ func (s *assetServiceType) Init(cfg *conf.Config) *errors.RestErr {
 	// select dao using mapping function in same package
 	dao, err = selectDao(cfg)
 	if err != nil {
-		log.Panicln(err)
+		return errors.GetBadRequestError(err.Error())
+	}
+	backoff, err := retry.NewBackoff(cfg.DataSourceDefinition.RetryDefinition)
+	if err != nil {
+		return errors.GetBadRequestError(err.Error())
+	}
+	// the service is ready as long as its backend is reachable, and starts in degraded mode until it is connected
+	err = lifecycle.Connect(backendName, backoff, func() error { return dao.Init(&cfg.DataSourceDefinition) }, dao.Ping)
+	if err != nil {
+		return errors.GetInternalServerError(err.Error())
 	}
-	dao.Init(&cfg.DataSourceDefinition)
 	return nil
 }
 
+// closeBackends ... closes the connections of the selected dao, once the service stopped handling requests
+func closeBackends() {
+	if dao != nil && lifecycle.Connected(backendName) {
+		dao.CloseConnection()
+	}
+}
+
 // UpsertAsset ... Adds and asset description
 func (s *assetServiceType) UpsertAssets(assets *[]abstract.Asset) (*[]abstract.Asset, *errors.RestErr) {
 	for _, a := range *assets {
 		if err := a.Validate(); err != nil {
 			return nil, errors.GetBadRequestError(err.Error())
 		}
-		// add last discovered date
+		// add last discovered date, an upserted asset is neither stale nor deleted anymore
 		a.LastDiscoveredAt = date.GetNow()
-		err := dao.Upsert(&a)
+		a.StaleSince = nil
+		a.DeletedAt = nil
 
+		// compare the schema of tables with the stored one, if any
+		stored, err := s.store().GetByName(a.Name)
+		if goerrors.Is(err, abstract.ErrAssetNotFound) {
+			stored = nil
+		} else if err != nil {
+			return nil, errors.GetInternalServerError(err.Error())
+		}
+		changes, err := abstract.DetectSchemaChanges(stored, &a)
 		if err != nil {
 			return nil, errors.GetBadRequestError(err.Error())
 		}
+
+		rev := &abstract.AssetRevision{Name: a.Name, CreatedAt: a.LastDiscoveredAt, Author: a.DiscoveredBy, Asset: a, SchemaChanges: changes}
+		if abstract.IsBreaking(changes) {
+			if rev.Impacted, err = s.impactedAssets(&a); err != nil {
+				return nil, errors.GetInternalServerError(err.Error())
+			}
+			logging.FromContext(s.ctx).Info("Breaking schema changes", "name", a.Name, "impacted", strings.Join(rev.Impacted, ","))
+		}
+
+		err = s.store().Upsert(&a)
+
+		if err != nil {
+			return nil, errors.GetBadRequestError(err.Error())
+		}
+
+		// the asset is written already, a missing revision is added on its next upsert as it differs from the latest one
+		if err := s.addRevision(rev); err != nil {
+			logging.FromContext(s.ctx).Error("Failed adding revision", "name", a.Name, "error", err)
+		}
 	}
 
 	// what should we actually return of the newly inserted object?
 	return assets, nil
 }
 
+// revisionAttempts ... number of times a revision is added when its number is concurrently taken
+const revisionAttempts = 3
+
+// addRevision ... appends a snapshot of the upserted asset to its history, authored by the crawler which discovered it,
+// unless the asset is unchanged from the latest revision, e.g. when rediscovered by the crawler
+func (s *assetServiceType) addRevision(rev *abstract.AssetRevision) error {
+	latest, err := s.store().GetLatestRevision(rev.Name)
+	if err != nil {
+		return err
+	}
+	if latest != nil {
+		diff, err := abstract.DiffRevisions(latest, rev)
+		if err != nil {
+			return err
+		}
+		if len(diff.Fields) == 0 && len(diff.Columns) == 0 {
+			return nil
+		}
+	}
+	for i := 0; i < revisionAttempts; i++ {
+		err = s.store().AddRevision(rev)
+		if !goerrors.Is(err, abstract.ErrRevisionExists) {
+			return err
+		}
+	}
+	return err
+}
+
 // GetAssetById ... Retrieves an asset by its unique id
 func (s *assetServiceType) GetAssetByID(assetID string) (*abstract.Asset, *errors.RestErr) {
-	asset, err := dao.GetById(assetID)
+	asset, err := s.store().GetById(assetID)
 	if err != nil {
 		return nil, errors.GetNotFoundError(err.Error())
 	}
@@ -72,7 +178,7 @@ func (s *assetServiceType) GetAssetByID(assetID string) (*abstract.Asset, *error
 
 // GetAssetByName ... Retrieves an asset by its unique name
 func (s *assetServiceType) GetAssetByName(name string) (*abstract.Asset, *errors.RestErr) {
-	asset, err := dao.GetByName(name)
+	asset, err := s.store().GetByName(name)
 	if err != nil {
 		return nil, errors.GetNotFoundError(err.Error())
 	}
@@ -80,23 +186,204 @@ func (s *assetServiceType) GetAssetByName(name string) (*abstract.Asset, *errors
 }
 
 func (s *assetServiceType) SearchAssetsByTags(tags []string) (*[]abstract.Asset, *errors.RestErr) {
-	assets, err := dao.SearchAssetsByTags(tags)
+	assets, err := s.store().SearchAssetsByTags(tags)
 	if err != nil {
 		return nil, errors.GetNotFoundError(err.Error())
 	}
 	return assets, nil
 }
 
-// ListAllAssets ... Retrieves all stored assets
-func (s *assetServiceType) ListAllAssets() (*[]abstract.Asset, *errors.RestErr) {
-	assets, err := dao.ListAllAssets()
+// SearchAssets ... Retrieves the assets matching a free text query and filters, along with facet counts,
+// no matching asset is not an error as the facets are still meaningful
+func (s *assetServiceType) SearchAssets(search *abstract.AssetSearch) (*abstract.AssetSearchResult, *errors.RestErr) {
+	if err := search.Validate(); err != nil {
+		return nil, errors.GetBadRequestError(err.Error())
+	}
+	result, err := s.store().SearchAssets(search)
 	if err != nil {
 		return nil, errors.GetInternalServerError(err.Error())
 	}
-	// n.b. - assets empty if collection is empty
-	// better to return an error or an empty list?
-	if assets == nil || len(*assets) == 0 {
+	return result, nil
+}
+
+// ListAssetsByOwner ... Retrieves the assets owned by the given user or group
+func (s *assetServiceType) ListAssetsByOwner(owner string, limit int) (*abstract.AssetSearchResult, *errors.RestErr) {
+	return searchAssetsBy(s, &abstract.AssetSearch{Owner: owner, Limit: limit})
+}
+
+// ListAssetsByDomain ... Retrieves the assets belonging to the given domain
+func (s *assetServiceType) ListAssetsByDomain(domain string, limit int) (*abstract.AssetSearchResult, *errors.RestErr) {
+	return searchAssetsBy(s, &abstract.AssetSearch{Domain: domain, Limit: limit})
+}
+
+// searchAssetsBy ... runs the search, returning not found if no asset matches as for lists
+func searchAssetsBy(s Service, search *abstract.AssetSearch) (*abstract.AssetSearchResult, *errors.RestErr) {
+	result, restErr := s.SearchAssets(search)
+	if restErr != nil {
+		return nil, restErr
+	}
+	if result.Total == 0 {
+		return nil, errors.GetNotFoundError("No assets found")
+	}
+	return result, nil
+}
+
+// getDependents ... returns all the assets directly depending on the given one, page by page
+func (s *assetServiceType) getDependents(name string) ([]abstract.Asset, error) {
+	var dependents []abstract.Asset
+	for {
+		result, err := s.store().SearchAssets(&abstract.AssetSearch{DependsOn: []string{name}, Limit: abstract.MaxLimit, Offset: len(dependents)})
+		if err != nil {
+			return nil, err
+		}
+		dependents = append(dependents, result.Assets...)
+		if len(result.Assets) == 0 || len(dependents) >= result.Total {
+			return dependents, nil
+		}
+	}
+}
+
+// impactedAssets ... returns the names of all assets downstream of the given one
+func (s *assetServiceType) impactedAssets(a *abstract.Asset) ([]string, error) {
+	graph, err := abstract.BuildLineage(a, abstract.LineageDownstream, abstract.MaxLineageDepth, s.store().GetByName, s.getDependents)
+	if err != nil {
+		return nil, err
+	}
+	var impacted []string
+	for _, node := range graph.Nodes {
+		if node.Direction == abstract.LineageDownstream {
+			impacted = append(impacted, node.Name)
+		}
+	}
+	return impacted, nil
+}
+
+// GetAssetLineage ... Retrieves the lineage graph of an asset, following depends-on upstream and dependents downstream
+func (s *assetServiceType) GetAssetLineage(name string, direction string, depth int) (*abstract.LineageGraph, *errors.RestErr) {
+	depth, err := abstract.ValidateLineage(direction, depth)
+	if err != nil {
+		return nil, errors.GetBadRequestError(err.Error())
+	}
+	root, err := s.store().GetByName(name)
+	if err != nil {
+		return nil, errors.GetNotFoundError(err.Error())
+	}
+
+	graph, err := abstract.BuildLineage(root, direction, depth, s.store().GetByName, s.getDependents)
+	if err != nil {
+		return nil, errors.GetInternalServerError(err.Error())
+	}
+	return graph, nil
+}
+
+// DeleteAsset ... Soft-deletes an asset, keeping a tombstone, or removes it if purge is set
+func (s *assetServiceType) DeleteAsset(name string, purge bool) *errors.RestErr {
+	var err error
+	if purge {
+		err = s.store().Delete(name)
+	} else {
+		err = s.store().SoftDelete(name, date.GetNow())
+	}
+	if goerrors.Is(err, abstract.ErrAssetNotFound) {
+		return errors.GetNotFoundError(fmt.Sprintf("No asset found for name %s", name))
+	}
+	if err != nil {
+		return errors.GetInternalServerError(err.Error())
+	}
+	return nil
+}
+
+// ReconcileAssets ... Marks as stale the assets discovered by the crawler which were not found in its last walk,
+// returning the number of assets marked as stale
+func (s *assetServiceType) ReconcileAssets(discoveredBy string, names []string) (int, *errors.RestErr) {
+	if len(strings.TrimSpace(discoveredBy)) == 0 {
+		return 0, errors.GetBadRequestError("discovered-by is undefined")
+	}
+	stale, err := s.store().MarkStale(discoveredBy, names, date.GetNow())
+	if err != nil {
+		return 0, errors.GetInternalServerError(err.Error())
+	}
+	return stale, nil
+}
+
+// ListAllAssets ... Retrieves a page of the stored assets
+func (s *assetServiceType) ListAllAssets(opts *abstract.ListOptions) (*abstract.AssetPage, *errors.RestErr) {
+	if err := opts.Validate([]string{abstract.SortByName, abstract.SortByLastDiscoveredAt}, abstract.Asset{}); err != nil {
+		return nil, errors.GetBadRequestError(err.Error())
+	}
+	// the backend projection keeps the fields needed to redact the assets, the requested ones are only projected afterwards
+	retrieved := *opts
+	retrieved.Fields = abstract.RedactableFields(opts.Fields)
+	page, err := s.store().ListAssets(&retrieved)
+	if err != nil {
+		return nil, errors.GetInternalServerError(err.Error())
+	}
+	// n.b. - page empty if collection is empty or the offset is beyond its size
+	if len(page.Assets) == 0 {
 		return nil, errors.GetNotFoundError("No assets in given collection")
 	}
-	return assets, nil
+	return page, nil
+}
+
+// ListAssetRevisions ... Retrieves the revisions of an asset, from the oldest
+func (s *assetServiceType) ListAssetRevisions(name string) (*[]abstract.AssetRevision, *errors.RestErr) {
+	revisions, err := s.store().ListRevisions(name)
+	if err != nil {
+		return nil, errors.GetInternalServerError(err.Error())
+	}
+	if len(*revisions) == 0 {
+		return nil, errors.GetNotFoundError(fmt.Sprintf("No revisions found for name %s", name))
+	}
+	return revisions, nil
+}
+
+// DiffAssetRevisions ... Compares two revisions of an asset, to defaulting to the latest and from to the one preceding to
+func (s *assetServiceType) DiffAssetRevisions(name string, from int, to int) (*abstract.AssetDiff, *errors.RestErr) {
+	if from < 0 || to < 0 {
+		return nil, errors.GetBadRequestError("Revisions should be positive numbers")
+	}
+	if to == 0 {
+		latest, err := s.store().GetLatestRevision(name)
+		if err != nil {
+			return nil, errors.GetInternalServerError(err.Error())
+		}
+		if latest == nil {
+			return nil, errors.GetNotFoundError(fmt.Sprintf("No revisions found for name %s", name))
+		}
+		to = latest.Revision
+	}
+	if from == 0 {
+		from = to - 1
+	}
+	if from == 0 {
+		return nil, errors.GetBadRequestError(fmt.Sprintf("Revision %d of %s has no previous revision to compare with", to, name))
+	}
+
+	var revisions [2]*abstract.AssetRevision
+	for i, number := range []int{from, to} {
+		revision, err := s.store().GetRevision(name, number)
+		if err != nil {
+			return nil, errors.GetInternalServerError(err.Error())
+		}
+		if revision == nil {
+			return nil, errors.GetNotFoundError(fmt.Sprintf("No revision %d found for name %s", number, name))
+		}
+		revisions[i] = revision
+	}
+	diff, err := abstract.DiffRevisions(revisions[0], revisions[1])
+	if err != nil {
+		return nil, errors.GetInternalServerError(err.Error())
+	}
+	return diff, nil
+}
+
+// ListSchemaChanges ... Retrieves the schema changes of a table across its revisions, from the oldest,
+// only those including breaking changes if breakingOnly is set
+func (s *assetServiceType) ListSchemaChanges(name string, breakingOnly bool) (*[]abstract.SchemaEvolution, *errors.RestErr) {
+	revisions, restErr := s.ListAssetRevisions(name)
+	if restErr != nil {
+		return nil, restErr
+	}
+	evolution := abstract.SchemaEvolutions(*revisions, breakingOnly)
+	return &evolution, nil
 }
@@ -0,0 +1,258 @@
+package catalogue
+
+import (
+	goerrors "errors"
+	"fmt"
+
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/utils/errors"
+)
+
+// clearedService ... the asset service as seen by a caller with a clearance, which filters the assets above the clearance
+// and redacts the schema columns above it, while it can only write the assets within the clearance
+type clearedService struct {
+	Service
+	clearance abstract.Classification
+}
+
+// withClearance ... returns the service filtering and redacting assets for the clearance
+func withClearance(s Service, clearance abstract.Classification) Service {
+	return &clearedService{Service: s, clearance: clearance}
+}
+
+// notFound ... assets above the clearance are not found, rather than forbidden, not to disclose their existence
+func notFound(name string) *errors.RestErr {
+	return errors.GetNotFoundError(fmt.Sprintf("No document found for name %s", name))
+}
+
+// UpsertAssets ... Inserts or updates the assets, provided that both their classification and the one of the stored
+// assets being replaced are within the clearance, so that assets above it can neither be created nor overwritten
+func (s *clearedService) UpsertAssets(assets *[]abstract.Asset) (*[]abstract.Asset, *errors.RestErr) {
+	for _, asset := range *assets {
+		if !s.clearance.Allows(asset.Classification) {
+			return nil, aboveClearance(asset.Name)
+		}
+		stored, err := s.Service.store().GetByName(asset.Name)
+		if err != nil {
+			if goerrors.Is(err, abstract.ErrAssetNotFound) {
+				continue
+			}
+			return nil, errors.GetInternalServerError(fmt.Sprintf("Error while retrieving asset %s :: %v", asset.Name, err))
+		}
+		if !s.clearance.Allows(stored.Classification) {
+			return nil, aboveClearance(asset.Name)
+		}
+	}
+	return s.Service.UpsertAssets(assets)
+}
+
+// aboveClearance ... the same error is returned for incoming and stored assets above the clearance
+func aboveClearance(name string) *errors.RestErr {
+	return errors.GetForbiddenError(fmt.Sprintf("Asset %s is classified above the clearance of the caller", name))
+}
+
+// GetAssetByID ... Retrieves an asset by its unique id, if within the clearance
+func (s *clearedService) GetAssetByID(assetID string) (*abstract.Asset, *errors.RestErr) {
+	asset, restErr := s.Service.GetAssetByID(assetID)
+	if restErr != nil {
+		return nil, restErr
+	}
+	redacted, allowed := abstract.Redact(asset, s.clearance)
+	if !allowed {
+		return nil, errors.GetNotFoundError(fmt.Sprintf("No document found for id %s", assetID))
+	}
+	return redacted, nil
+}
+
+// GetAssetByName ... Retrieves an asset by its unique name, if within the clearance
+func (s *clearedService) GetAssetByName(name string) (*abstract.Asset, *errors.RestErr) {
+	asset, restErr := s.Service.GetAssetByName(name)
+	if restErr != nil {
+		return nil, restErr
+	}
+	redacted, allowed := abstract.Redact(asset, s.clearance)
+	if !allowed {
+		return nil, notFound(name)
+	}
+	return redacted, nil
+}
+
+// SearchAssetsByTags ... Retrieves the assets within the clearance having all tags
+func (s *clearedService) SearchAssetsByTags(tags []string) (*[]abstract.Asset, *errors.RestErr) {
+	assets, restErr := s.Service.SearchAssetsByTags(tags)
+	if restErr != nil {
+		return nil, restErr
+	}
+	redacted := abstract.RedactAll(*assets, s.clearance)
+	if len(redacted) == 0 {
+		return nil, errors.GetNotFoundError("Error while retrieving assets using filter :: empty result set")
+	}
+	return &redacted, nil
+}
+
+// SearchAssets ... Searches the assets within the clearance, so that totals and facets only count those
+func (s *clearedService) SearchAssets(search *abstract.AssetSearch) (*abstract.AssetSearchResult, *errors.RestErr) {
+	if len(search.Classifications) == 0 {
+		search.Classifications = s.clearance.Cleared()
+	} else {
+		var cleared []abstract.Classification
+		for _, c := range search.Classifications {
+			if s.clearance.Allows(c) {
+				cleared = append(cleared, c)
+			}
+		}
+		if len(cleared) == 0 {
+			return &abstract.AssetSearchResult{Assets: []abstract.Asset{}, Facets: abstract.NewAssetFacets()}, nil
+		}
+		search.Classifications = cleared
+	}
+	result, restErr := s.Service.SearchAssets(search)
+	if restErr != nil {
+		return nil, restErr
+	}
+	result.Assets = abstract.RedactAll(result.Assets, s.clearance)
+	return result, nil
+}
+
+// ListAssetsByOwner ... Retrieves the assets within the clearance owned by the given user or group
+func (s *clearedService) ListAssetsByOwner(owner string, limit int) (*abstract.AssetSearchResult, *errors.RestErr) {
+	return searchAssetsBy(s, &abstract.AssetSearch{Owner: owner, Limit: limit})
+}
+
+// ListAssetsByDomain ... Retrieves the assets within the clearance belonging to the given domain
+func (s *clearedService) ListAssetsByDomain(domain string, limit int) (*abstract.AssetSearchResult, *errors.RestErr) {
+	return searchAssetsBy(s, &abstract.AssetSearch{Domain: domain, Limit: limit})
+}
+
+// ListAllAssets ... Retrieves a page of assets, without those above the clearance,
+// the page may thus be shorter than the limit while a next page exists
+func (s *clearedService) ListAllAssets(opts *abstract.ListOptions) (*abstract.AssetPage, *errors.RestErr) {
+	page, restErr := s.Service.ListAllAssets(opts)
+	if restErr != nil {
+		return nil, restErr
+	}
+	page.Assets = abstract.RedactAll(page.Assets, s.clearance)
+	if len(page.Assets) == 0 && page.NextCursor == "" {
+		return nil, errors.GetNotFoundError("No assets in given collection")
+	}
+	return page, nil
+}
+
+// GetAssetLineage ... Retrieves the lineage graph of an asset within the clearance, without the nodes above it
+func (s *clearedService) GetAssetLineage(name string, direction string, depth int) (*abstract.LineageGraph, *errors.RestErr) {
+	graph, restErr := s.Service.GetAssetLineage(name, direction, depth)
+	if restErr != nil {
+		return nil, restErr
+	}
+	for _, node := range graph.Nodes {
+		if node.Direction == abstract.LineageRoot && !s.clearance.Allows(node.Classification) {
+			return nil, notFound(name)
+		}
+	}
+	return graph.Redact(s.clearance), nil
+}
+
+// hiddenColumns ... returns the columns of the asset above the clearance
+func (s *clearedService) hiddenColumns(asset *abstract.Asset) map[string]bool {
+	hidden := make(map[string]bool)
+	columns, _ := abstract.SchemaColumns(asset)
+	for name, column := range columns {
+		if !s.clearance.Allows(column.Classification) {
+			hidden[name] = true
+		}
+	}
+	return hidden
+}
+
+// visibleChanges ... returns the schema changes not concerning hidden columns
+func visibleChanges(changes []abstract.SchemaChange, hidden map[string]bool) []abstract.SchemaChange {
+	var visible []abstract.SchemaChange
+	for _, c := range changes {
+		if !hidden[c.Column] {
+			visible = append(visible, c)
+		}
+	}
+	return visible
+}
+
+// visibleAssets ... returns the names of the assets within the clearance
+func (s *clearedService) visibleAssets(names []string) []string {
+	var visible []string
+	for _, name := range names {
+		if asset, err := s.Service.store().GetByName(name); err == nil && s.clearance.Allows(asset.Classification) {
+			visible = append(visible, name)
+		}
+	}
+	return visible
+}
+
+// ListAssetRevisions ... Retrieves the revisions of an asset within the clearance, with redacted snapshots
+// and without the schema changes of hidden columns, both in the revision and in the previous one (e.g. dropped columns)
+func (s *clearedService) ListAssetRevisions(name string) (*[]abstract.AssetRevision, *errors.RestErr) {
+	if _, restErr := s.GetAssetByName(name); restErr != nil {
+		return nil, restErr
+	}
+	revisions, restErr := s.Service.ListAssetRevisions(name)
+	if restErr != nil {
+		return nil, restErr
+	}
+	redacted := []abstract.AssetRevision{}
+	previous := map[string]bool{}
+	for _, rev := range *revisions {
+		hidden := s.hiddenColumns(&rev.Asset)
+		asset, allowed := abstract.Redact(&rev.Asset, s.clearance)
+		if allowed {
+			for column := range previous {
+				hidden[column] = true
+			}
+			rev.Asset = *asset
+			rev.SchemaChanges = visibleChanges(rev.SchemaChanges, hidden)
+			rev.Impacted = s.visibleAssets(rev.Impacted)
+			redacted = append(redacted, rev)
+		}
+		previous = s.hiddenColumns(&rev.Asset)
+		for column := range hidden {
+			previous[column] = true
+		}
+	}
+	return &redacted, nil
+}
+
+// DiffAssetRevisions ... Compares two revisions of an asset within the clearance, without the hidden columns
+func (s *clearedService) DiffAssetRevisions(name string, from int, to int) (*abstract.AssetDiff, *errors.RestErr) {
+	if _, restErr := s.GetAssetByName(name); restErr != nil {
+		return nil, restErr
+	}
+	diff, restErr := s.Service.DiffAssetRevisions(name, from, to)
+	if restErr != nil {
+		return nil, restErr
+	}
+	hidden := make(map[string]bool)
+	columns := []abstract.Change{}
+	for _, c := range diff.Columns {
+		allowed := true
+		for _, value := range []interface{}{c.Old, c.New} {
+			if column, isColumn := value.(abstract.ColumnInfo); isColumn && !s.clearance.Allows(column.Classification) {
+				allowed = false
+			}
+		}
+		if !allowed {
+			hidden[c.Field] = true
+			continue
+		}
+		columns = append(columns, c)
+	}
+	diff.Columns = columns
+	diff.SchemaChanges = visibleChanges(diff.SchemaChanges, hidden)
+	return diff, nil
+}
+
+// ListSchemaChanges ... Retrieves the schema changes of a table within the clearance, without those of hidden columns
+func (s *clearedService) ListSchemaChanges(name string, breakingOnly bool) (*[]abstract.SchemaEvolution, *errors.RestErr) {
+	revisions, restErr := s.ListAssetRevisions(name)
+	if restErr != nil {
+		return nil, restErr
+	}
+	evolution := abstract.SchemaEvolutions(*revisions, breakingOnly)
+	return &evolution, nil
+}
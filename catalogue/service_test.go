@@ -0,0 +1,454 @@
+package catalogue
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/utils/conf"
+)
+
+// initEmbeddedService ... inits the asset service on an embedded store in a temporary folder
+func initEmbeddedService(t *testing.T) {
+	cfg := &conf.Config{
+		ConfigType: conf.Catalogue,
+		DataSourceDefinition: conf.DataSourceDefinition{
+			Name: "test-embedded",
+			Type: "embedded",
+			Settings: map[string]string{
+				"path": filepath.Join(t.TempDir(), "catalogue.json"),
+			},
+		},
+	}
+	if err := assetService.Init(cfg); err != nil {
+		t.Fatal(err.Message)
+	}
+}
+
+func TestUpsertAndGetAssets(t *testing.T) {
+	initEmbeddedService(t)
+
+	if _, err := assetService.ListAllAssets(&abstract.ListOptions{}); err == nil || err.Status != http.StatusNotFound {
+		t.Fatalf("expected not found on empty catalogue, got %v", err)
+	}
+
+	assets := []abstract.Asset{
+		{Name: "mydb", Type: "database", Tags: []string{"hive"}},
+		{Name: "mydb.mytable", Type: "table", DependsOn: []string{"mydb"}, Tags: []string{"hive", "pii"}},
+	}
+	if _, err := assetService.UpsertAssets(&assets); err != nil {
+		t.Fatal(err.Message)
+	}
+
+	asset, err := assetService.GetAssetByName("mydb.mytable")
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	if asset.LastDiscoveredAt.IsZero() {
+		t.Error("expected last discovered date to be set by the service")
+	}
+
+	found, err := assetService.SearchAssetsByTags([]string{"pii", "hive"})
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	if len(*found) != 1 || (*found)[0].Name != "mydb.mytable" {
+		t.Errorf("expected only mydb.mytable, got %v", *found)
+	}
+
+	first, err := assetService.ListAllAssets(&abstract.ListOptions{Limit: 1, SortBy: "name", Descending: true})
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	if len(first.Assets) != 1 || first.Assets[0].Name != "mydb.mytable" || first.NextCursor == "" {
+		t.Fatalf("expected mydb.mytable and a cursor, got %v", first)
+	}
+	second, err := assetService.ListAllAssets(&abstract.ListOptions{Limit: 1, SortBy: "name", Descending: true, Cursor: first.NextCursor})
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	if len(second.Assets) != 1 || second.Assets[0].Name != "mydb" || second.NextCursor != "" {
+		t.Errorf("expected mydb as last page, got %v", second)
+	}
+
+	if _, err := assetService.ListAllAssets(&abstract.ListOptions{SortBy: "type"}); err == nil || err.Status != http.StatusBadRequest {
+		t.Errorf("expected bad request on unsupported sort, got %v", err)
+	}
+}
+
+func TestUpsertInvalidAsset(t *testing.T) {
+	initEmbeddedService(t)
+
+	assets := []abstract.Asset{{Name: "noType"}}
+	if _, err := assetService.UpsertAssets(&assets); err == nil || err.Status != http.StatusBadRequest {
+		t.Fatalf("expected bad request for asset without type, got %v", err)
+	}
+}
+
+func TestDeleteAndRestoreAsset(t *testing.T) {
+	initEmbeddedService(t)
+
+	if err := assetService.DeleteAsset("missing", false); err == nil || err.Status != http.StatusNotFound {
+		t.Fatalf("expected not found deleting a missing asset, got %v", err)
+	}
+
+	assets := []abstract.Asset{{Name: "mydb", Type: "database"}}
+	if _, err := assetService.UpsertAssets(&assets); err != nil {
+		t.Fatal(err.Message)
+	}
+	if err := assetService.DeleteAsset("mydb", false); err != nil {
+		t.Fatal(err.Message)
+	}
+	tombstone, err := assetService.GetAssetByName("mydb")
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	if !tombstone.IsDeleted() {
+		t.Error("expected a tombstone after a soft delete")
+	}
+
+	// upserting the asset again restores it
+	if _, err := assetService.UpsertAssets(&assets); err != nil {
+		t.Fatal(err.Message)
+	}
+	restored, err := assetService.GetAssetByName("mydb")
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	if restored.IsDeleted() {
+		t.Error("expected the upsert to restore the asset")
+	}
+
+	if err := assetService.DeleteAsset("mydb", true); err != nil {
+		t.Fatal(err.Message)
+	}
+	if _, err := assetService.GetAssetByName("mydb"); err == nil || err.Status != http.StatusNotFound {
+		t.Errorf("expected not found after a purge, got %v", err)
+	}
+}
+
+func TestRevisionsAndDiff(t *testing.T) {
+	initEmbeddedService(t)
+
+	schema := func(columns ...string) map[string]interface{} {
+		s := map[string]interface{}{}
+		for _, c := range columns {
+			s[c] = abstract.ColumnInfo{Type: "string"}
+		}
+		return s
+	}
+	assets := []abstract.Asset{{Name: "mydb.mytable", Type: "table", Labels: map[string]interface{}{"schema": schema("id", "name")}}}
+	if _, err := assetService.UpsertAssets(&assets); err != nil {
+		t.Fatal(err.Message)
+	}
+	if _, err := assetService.DiffAssetRevisions("mydb.mytable", 0, 0); err == nil || err.Status != http.StatusBadRequest {
+		t.Errorf("expected bad request diffing a single revision, got %v", err)
+	}
+
+	assets[0].Description = "users"
+	assets[0].Labels = map[string]interface{}{"schema": schema("id", "email")}
+	assets[0].DiscoveredBy = "hive"
+	if _, err := assetService.UpsertAssets(&assets); err != nil {
+		t.Fatal(err.Message)
+	}
+
+	// rediscovering the unchanged asset adds no revision
+	if _, err := assetService.UpsertAssets(&assets); err != nil {
+		t.Fatal(err.Message)
+	}
+
+	revisions, err := assetService.ListAssetRevisions("mydb.mytable")
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	if len(*revisions) != 2 || (*revisions)[1].Author != "hive" {
+		t.Fatalf("expected 2 revisions, the last by hive, got %v", *revisions)
+	}
+
+	diff, err := assetService.DiffAssetRevisions("mydb.mytable", 0, 0)
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	if diff.From != 1 || diff.To != 2 {
+		t.Errorf("expected a diff from 1 to 2, got %d to %d", diff.From, diff.To)
+	}
+	var fields []string
+	for _, c := range diff.Fields {
+		fields = append(fields, c.Field+":"+c.Change)
+	}
+	if strings.Join(fields, " ") != "description:added discovered-by:added" {
+		t.Errorf("unexpected field changes %v", fields)
+	}
+	var columns []string
+	for _, c := range diff.Columns {
+		columns = append(columns, c.Field+":"+c.Change)
+	}
+	if strings.Join(columns, " ") != "email:added name:removed" {
+		t.Errorf("unexpected column changes %v", columns)
+	}
+
+	if _, err := assetService.DiffAssetRevisions("mydb.mytable", 1, 3); err == nil || err.Status != http.StatusNotFound {
+		t.Errorf("expected not found on a missing revision, got %v", err)
+	}
+	if _, err := assetService.ListAssetRevisions("missing"); err == nil || err.Status != http.StatusNotFound {
+		t.Errorf("expected not found on a missing asset, got %v", err)
+	}
+}
+
+// failingRevisionsDAO ... fails adding revisions
+type failingRevisionsDAO struct {
+	abstract.AssetDAOProvider
+}
+
+func (d *failingRevisionsDAO) AddRevision(rev *abstract.AssetRevision) error {
+	return fmt.Errorf("revisions unavailable")
+}
+
+func TestUpsertDespiteRevisionFailure(t *testing.T) {
+	initEmbeddedService(t)
+	healthy := dao
+	dao = &failingRevisionsDAO{AssetDAOProvider: healthy}
+
+	assets := []abstract.Asset{{Name: "mydb", Type: "database"}}
+	if _, err := assetService.UpsertAssets(&assets); err != nil {
+		t.Fatalf("expected the upsert to succeed without its revision, got %v", err)
+	}
+	if _, err := assetService.GetAssetByName("mydb"); err != nil {
+		t.Fatal(err.Message)
+	}
+
+	// the missing revision is added on the next upsert
+	dao = healthy
+	if _, err := assetService.UpsertAssets(&assets); err != nil {
+		t.Fatal(err.Message)
+	}
+	revisions, err := assetService.ListAssetRevisions("mydb")
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	if len(*revisions) != 1 {
+		t.Errorf("expected the revision of the next upsert, got %v", *revisions)
+	}
+}
+
+// unavailableDAO ... fails retrieving the assets
+type unavailableDAO struct {
+	abstract.AssetDAOProvider
+}
+
+func (d *unavailableDAO) GetByName(name string) (*abstract.Asset, error) {
+	return nil, fmt.Errorf("backend unavailable")
+}
+
+func TestUpsertWithUnavailableBackend(t *testing.T) {
+	initEmbeddedService(t)
+	dao = &unavailableDAO{AssetDAOProvider: dao}
+
+	// the stored asset is unknown, so the asset is not overwritten as if it were new
+	assets := []abstract.Asset{{Name: "mydb", Type: "database"}}
+	if _, err := assetService.UpsertAssets(&assets); err == nil || err.Status != http.StatusInternalServerError {
+		t.Errorf("expected an internal server error, got %v", err)
+	}
+}
+
+// smallPagesDAO ... returns search results in pages of 2 assets at most
+type smallPagesDAO struct {
+	abstract.AssetDAOProvider
+}
+
+func (d *smallPagesDAO) SearchAssets(search *abstract.AssetSearch) (*abstract.AssetSearchResult, error) {
+	paged := *search
+	paged.Limit = 2
+	return d.AssetDAOProvider.SearchAssets(&paged)
+}
+
+func TestLineageAcrossPages(t *testing.T) {
+	initEmbeddedService(t)
+	dao = &smallPagesDAO{AssetDAOProvider: dao}
+
+	assets := []abstract.Asset{{Name: "raw", Type: "table"}}
+	for i := 0; i < 5; i++ {
+		assets = append(assets, abstract.Asset{Name: fmt.Sprintf("view%d", i), Type: "table", DependsOn: []string{"raw"}})
+	}
+	if _, err := assetService.UpsertAssets(&assets); err != nil {
+		t.Fatal(err.Message)
+	}
+	graph, err := assetService.GetAssetLineage("raw", abstract.LineageDownstream, 1)
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	if len(graph.Nodes) != 6 {
+		t.Errorf("expected raw and its 5 dependents, got %v", graph.Nodes)
+	}
+}
+
+func TestBreakingSchemaChanges(t *testing.T) {
+	initEmbeddedService(t)
+
+	table := func(columns map[string]string) abstract.Asset {
+		schema := map[string]interface{}{}
+		for name, columnType := range columns {
+			schema[name] = abstract.ColumnInfo{Type: columnType}
+		}
+		return abstract.Asset{Name: "mydb.users", Type: "table", Labels: map[string]interface{}{"schema": schema}}
+	}
+	assets := []abstract.Asset{
+		table(map[string]string{"id": "int", "name": "string"}),
+		{Name: "mydb.report", Type: "report", DependsOn: []string{"mydb.users"}},
+		{Name: "dashboard", Type: "report", DependsOn: []string{"mydb.report"}},
+	}
+	if _, err := assetService.UpsertAssets(&assets); err != nil {
+		t.Fatal(err.Message)
+	}
+
+	// widening a column is not breaking
+	widened := []abstract.Asset{table(map[string]string{"id": "bigint", "name": "string"})}
+	if _, err := assetService.UpsertAssets(&widened); err != nil {
+		t.Fatal(err.Message)
+	}
+	// dropping a column is
+	dropped := []abstract.Asset{table(map[string]string{"id": "bigint"})}
+	if _, err := assetService.UpsertAssets(&dropped); err != nil {
+		t.Fatal(err.Message)
+	}
+
+	all, err := assetService.ListSchemaChanges("mydb.users", false)
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	if len(*all) != 2 || (*all)[0].Breaking || (*all)[0].Changes[0].Kind != abstract.TypeWidened {
+		t.Fatalf("expected a widening and a drop, got %v", *all)
+	}
+	breaking, err := assetService.ListSchemaChanges("mydb.users", true)
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	if len(*breaking) != 1 || (*breaking)[0].Revision != 3 || (*breaking)[0].Changes[0].Kind != abstract.ColumnDropped {
+		t.Fatalf("expected the drop only, got %v", *breaking)
+	}
+	if impacted := strings.Join((*breaking)[0].Impacted, ","); impacted != "mydb.report,dashboard" {
+		t.Errorf("expected the downstream assets to be impacted, got %s", impacted)
+	}
+}
+
+func TestClearedService(t *testing.T) {
+	initEmbeddedService(t)
+
+	assets := []abstract.Asset{
+		{Name: "mydb.users", Type: "table", Labels: map[string]interface{}{"schema": map[string]interface{}{
+			"id":    abstract.ColumnInfo{Type: "int"},
+			"email": abstract.ColumnInfo{Type: "string", Classification: abstract.PII},
+		}}},
+		{Name: "mydb.salaries", Type: "table", Classification: abstract.Confidential, DependsOn: []string{"mydb.users"}},
+		{Name: "mydb.report", Type: "report", Classification: abstract.Public, DependsOn: []string{"mydb.salaries"}},
+	}
+	if _, err := assetService.UpsertAssets(&assets); err != nil {
+		t.Fatal(err.Message)
+	}
+	analyst := withClearance(assetService, abstract.Internal)
+
+	users, err := analyst.GetAssetByName("mydb.users")
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	columns, _ := abstract.SchemaColumns(users)
+	if _, exist := columns["email"]; exist || len(columns) != 1 || users.RedactedColumns != 1 {
+		t.Errorf("expected the pii column to be redacted, got %v", users.Labels)
+	}
+	if _, err := analyst.GetAssetByName("mydb.salaries"); err == nil || err.Status != http.StatusNotFound {
+		t.Errorf("expected a confidential asset not to be found, got %v", err)
+	}
+
+	page, err := analyst.ListAllAssets(&abstract.ListOptions{})
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	if len(page.Assets) != 2 {
+		t.Errorf("expected the confidential asset to be filtered, got %v", page.Assets)
+	}
+	result, err := analyst.SearchAssets(&abstract.AssetSearch{Classifications: []abstract.Classification{abstract.Confidential}})
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	if result.Total != 0 || len(result.Assets) != 0 {
+		t.Errorf("expected no confidential asset to be searchable, got %v", result.Assets)
+	}
+
+	graph, err := analyst.GetAssetLineage("mydb.report", abstract.LineageUpstream, 0)
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	for _, node := range graph.Nodes {
+		if node.Name == "mydb.salaries" {
+			t.Errorf("expected the confidential node to be removed, got %v", graph.Nodes)
+		}
+	}
+
+	admin := withClearance(assetService, abstract.PII)
+	if users, err := admin.GetAssetByName("mydb.users"); err != nil || users.RedactedColumns != 0 {
+		t.Errorf("expected nothing redacted for admins, got %v", err)
+	}
+}
+
+func TestClearedServiceUpserts(t *testing.T) {
+	initEmbeddedService(t)
+
+	assets := []abstract.Asset{
+		{Name: "mydb.salaries", Type: "table", Classification: abstract.Confidential},
+	}
+	if _, err := assetService.UpsertAssets(&assets); err != nil {
+		t.Fatal(err.Message)
+	}
+	analyst := withClearance(assetService, abstract.Internal)
+
+	above := []abstract.Asset{{Name: "mydb.bonuses", Type: "table", Classification: abstract.PII}}
+	if _, err := analyst.UpsertAssets(&above); err == nil || err.Status != http.StatusForbidden {
+		t.Errorf("expected an asset above the clearance to be forbidden, got %v", err)
+	}
+	declassified := []abstract.Asset{{Name: "mydb.salaries", Type: "table", Classification: abstract.Public}}
+	if _, err := analyst.UpsertAssets(&declassified); err == nil || err.Status != http.StatusForbidden {
+		t.Errorf("expected a stored asset above the clearance not to be overwritten, got %v", err)
+	}
+	stored, err := assetService.GetAssetByName("mydb.salaries")
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	if stored.Classification != abstract.Confidential {
+		t.Errorf("expected the stored asset to keep its classification, got %s", stored.Classification)
+	}
+	if _, err := assetService.GetAssetByName("mydb.bonuses"); err == nil {
+		t.Errorf("expected the forbidden asset not to be created")
+	}
+
+	cleared := []abstract.Asset{
+		{Name: "mydb.users", Type: "table"},
+		{Name: "mydb.report", Type: "report", Classification: abstract.Public},
+	}
+	if _, err := analyst.UpsertAssets(&cleared); err != nil {
+		t.Fatal(err.Message)
+	}
+}
+
+func TestValidateElasticCert(t *testing.T) {
+	cert := filepath.Join(t.TempDir(), "ca.pem")
+	cfg := &conf.Config{
+		ConfigType: conf.Catalogue,
+		Details:    map[string]string{"port": "8085"},
+		DataSourceDefinition: conf.DataSourceDefinition{
+			Name: "test-elastic",
+			Type: "elastic",
+			Settings: map[string]string{
+				"username": "elastic", "password": "changeme", "hosts": "http://localhost:9200", "index": "catalogue", "cert": cert,
+			},
+		},
+	}
+	err := conf.Validate(cfg)
+	validationErr, isInvalid := err.(*conf.ValidationError)
+	if !isInvalid || len(validationErr.Problems) != 1 || validationErr.Problems[0].Path != "backend.settings" ||
+		!strings.Contains(validationErr.Problems[0].Message, cert) {
+		t.Errorf("expected the missing certificate to be reported, got %v", err)
+	}
+}
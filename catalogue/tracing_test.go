@@ -0,0 +1,89 @@
+package catalogue
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/client"
+	"github.com/pilillo/mastro/utils/auth"
+	"github.com/pilillo/mastro/utils/conf"
+	"github.com/pilillo/mastro/utils/tracing"
+)
+
+// exportedSpan ... the fields of the spans written by the stdout exporter which are checked by the test
+type exportedSpan struct {
+	Name        string
+	SpanContext struct {
+		TraceID string
+		SpanID  string
+	}
+	Parent struct {
+		SpanID string
+	}
+}
+
+func TestTraceFromClientToDAO(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	initEmbeddedService(t)
+	path := filepath.Join(t.TempDir(), "traces.json")
+	if err := tracing.Init(&conf.TracingDefinition{Exporter: "file", Path: path}, "mastro-catalogue"); err != nil {
+		t.Fatal(err)
+	}
+	authz, err := auth.NewMiddleware(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine := gin.New()
+	engine.Use(tracing.Middleware())
+	registerRoutes(engine, authz)
+	server := httptest.NewServer(engine)
+	defer server.Close()
+
+	ctx, root := tracing.Start(context.Background(), "crawler run")
+	c := client.NewCatalogueClient(server.URL).WithContext(ctx)
+	if _, err := c.UpsertAssets([]abstract.Asset{{Name: "mydb", Type: "database"}}); err != nil {
+		t.Fatal(err)
+	}
+	root.End()
+	tracing.Shutdown()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	spans := make(map[string]exportedSpan)
+	var daoSpan exportedSpan
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		s := exportedSpan{}
+		if err := json.Unmarshal(scanner.Bytes(), &s); err != nil {
+			t.Fatal(err)
+		}
+		spans[s.SpanContext.SpanID] = s
+		if s.Name == "dao upsert" {
+			daoSpan = s
+		}
+	}
+
+	// the backend call, its request handling, the client request and the crawler run form a single trace
+	var chain []string
+	for span, exist := daoSpan, daoSpan.SpanContext.SpanID != ""; exist; span, exist = spans[span.Parent.SpanID] {
+		if span.SpanContext.TraceID != root.SpanContext().TraceID().String() {
+			t.Errorf("expected span %s to be in the trace of the crawler run", span.Name)
+		}
+		chain = append(chain, span.Name)
+	}
+	expected := []string{"dao upsert", "PUT /assets/", "PUT /assets/", "crawler run"}
+	if !reflect.DeepEqual(chain, expected) {
+		t.Errorf("expected the chain of spans %v, got %v", expected, chain)
+	}
+}
@@ -0,0 +1,193 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/utils/queries"
+)
+
+// CatalogueClient ... client of the catalogue endpoints
+type CatalogueClient struct {
+	base
+}
+
+// NewCatalogueClient ... returns a client of the catalogue at the base url, e.g. http://localhost:8085
+func NewCatalogueClient(baseURL string, opts ...Option) *CatalogueClient {
+	return &CatalogueClient{base: newBase(baseURL, opts...)}
+}
+
+// WithContext ... returns a copy of the client sending its requests within the context, the current span of the context
+// being propagated to the service
+func (c *CatalogueClient) WithContext(ctx context.Context) *CatalogueClient {
+	return &CatalogueClient{base: c.base.withContext(ctx)}
+}
+
+// Ping ... calls the healthcheck of the catalogue
+func (c *CatalogueClient) Ping() error {
+	return c.ping("/healthcheck/asset")
+}
+
+// UpsertAsset ... creates or updates an asset
+func (c *CatalogueClient) UpsertAsset(asset abstract.Asset) ([]abstract.Asset, error) {
+	var result []abstract.Asset
+	err := c.do(c.request(nil).SetBody(asset), http.MethodPut, "/asset/", &result)
+	return result, err
+}
+
+// UpsertAssets ... creates or updates many assets
+func (c *CatalogueClient) UpsertAssets(assets []abstract.Asset) ([]abstract.Asset, error) {
+	var result []abstract.Asset
+	err := c.do(c.request(nil).SetBody(assets), http.MethodPut, "/assets/", &result)
+	return result, err
+}
+
+// GetAssetByID ... retrieves an asset by its backend id
+func (c *CatalogueClient) GetAssetByID(assetID string) (*abstract.Asset, error) {
+	asset := &abstract.Asset{}
+	if err := c.do(c.request(map[string]string{"id": assetID}), http.MethodGet, "/asset/id/{id}", asset); err != nil {
+		return nil, err
+	}
+	return asset, nil
+}
+
+// GetAssetByName ... retrieves an asset by its unique name
+func (c *CatalogueClient) GetAssetByName(name string) (*abstract.Asset, error) {
+	asset := &abstract.Asset{}
+	if err := c.do(c.request(map[string]string{"name": name}), http.MethodGet, "/asset/name/{name}", asset); err != nil {
+		return nil, err
+	}
+	return asset, nil
+}
+
+// lineageRequest ... a request of the lineage graph, the default direction and depth being used if empty and 0
+func (c *CatalogueClient) lineageRequest(name string, direction string, depth int, format string) *resty.Request {
+	req := c.request(map[string]string{"name": name}).SetQueryParam("format", format)
+	if direction != "" {
+		req.SetQueryParam("direction", direction)
+	}
+	if depth > 0 {
+		req.SetQueryParam("depth", strconv.Itoa(depth))
+	}
+	return req
+}
+
+// GetAssetLineage ... retrieves the lineage graph of an asset, the default direction and depth being used if empty and 0
+func (c *CatalogueClient) GetAssetLineage(name string, direction string, depth int) (*abstract.LineageGraph, error) {
+	graph := &abstract.LineageGraph{}
+	if err := c.do(c.lineageRequest(name, direction, depth, "json"), http.MethodGet, "/asset/name/{name}/lineage", graph); err != nil {
+		return nil, err
+	}
+	return graph, nil
+}
+
+// GetAssetLineageDOT ... retrieves the lineage graph of an asset in the Graphviz dot format
+func (c *CatalogueClient) GetAssetLineageDOT(name string, direction string, depth int) (string, error) {
+	req := c.lineageRequest(name, direction, depth, "dot").SetHeader("Accept", "text/vnd.graphviz")
+	resp, err := c.send(req, http.MethodGet, "/asset/name/{name}/lineage")
+	if err != nil {
+		return "", err
+	}
+	return string(resp.Body()), nil
+}
+
+// ListAssetRevisions ... retrieves the revision history of an asset, oldest first
+func (c *CatalogueClient) ListAssetRevisions(name string) ([]abstract.AssetRevision, error) {
+	var revisions []abstract.AssetRevision
+	err := c.do(c.request(map[string]string{"name": name}), http.MethodGet, "/asset/name/{name}/revisions", &revisions)
+	return revisions, err
+}
+
+// DiffAssetRevisions ... compares two revisions of an asset, to defaults to the latest revision and from to the previous one if 0
+func (c *CatalogueClient) DiffAssetRevisions(name string, from int, to int) (*abstract.AssetDiff, error) {
+	req := c.request(map[string]string{"name": name})
+	if from > 0 {
+		req.SetQueryParam("from", strconv.Itoa(from))
+	}
+	if to > 0 {
+		req.SetQueryParam("to", strconv.Itoa(to))
+	}
+	diff := &abstract.AssetDiff{}
+	if err := c.do(req, http.MethodGet, "/asset/name/{name}/diff", diff); err != nil {
+		return nil, err
+	}
+	return diff, nil
+}
+
+// ListSchemaChanges ... retrieves the classified schema changes of a table, oldest first
+func (c *CatalogueClient) ListSchemaChanges(name string, breakingOnly bool) ([]abstract.SchemaEvolution, error) {
+	var changes []abstract.SchemaEvolution
+	req := c.request(map[string]string{"name": name}).SetQueryParam("breaking", strconv.FormatBool(breakingOnly))
+	err := c.do(req, http.MethodGet, "/asset/name/{name}/schema-changes", &changes)
+	return changes, err
+}
+
+// DeleteAsset ... soft-deletes an asset, or removes it with its revisions if purge is set
+func (c *CatalogueClient) DeleteAsset(name string, purge bool) error {
+	req := c.request(map[string]string{"name": name}).SetQueryParam("purge", strconv.FormatBool(purge))
+	return c.do(req, http.MethodDelete, "/asset/name/{name}", nil)
+}
+
+// ReconcileAssets ... marks as stale the assets of the crawler which are not among the names, returning their number
+func (c *CatalogueClient) ReconcileAssets(discoveredBy string, names []string) (int, error) {
+	if names == nil {
+		names = []string{}
+	}
+	result := &queries.ReconcileResult{}
+	req := c.request(nil).SetBody(queries.Reconcile{DiscoveredBy: discoveredBy, Names: names})
+	if err := c.do(req, http.MethodPost, "/assets/reconcile", result); err != nil {
+		return 0, err
+	}
+	return result.Stale, nil
+}
+
+// SearchAssetsByTags ... retrieves the assets having all the tags
+func (c *CatalogueClient) SearchAssetsByTags(tags []string) ([]abstract.Asset, error) {
+	var assets []abstract.Asset
+	err := c.do(c.request(nil).SetBody(queries.ByTags{Tags: tags}), http.MethodPost, "/assets/tags", &assets)
+	return assets, err
+}
+
+// SearchAssets ... searches the assets by free text and filters
+func (c *CatalogueClient) SearchAssets(search abstract.AssetSearch) (*abstract.AssetSearchResult, error) {
+	result := &abstract.AssetSearchResult{}
+	if err := c.do(c.request(nil).SetBody(search), http.MethodPost, "/assets/search", result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// listAssetsBy ... retrieves up to limit assets, the default limit being used if 0
+func (c *CatalogueClient) listAssetsBy(path string, pathParams map[string]string, limit int) (*abstract.AssetSearchResult, error) {
+	req := c.request(pathParams)
+	if limit > 0 {
+		req.SetQueryParam("limit", strconv.Itoa(limit))
+	}
+	result := &abstract.AssetSearchResult{}
+	if err := c.do(req, http.MethodGet, path, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListAssetsByOwner ... retrieves the assets having the owner among their owners
+func (c *CatalogueClient) ListAssetsByOwner(owner string, limit int) (*abstract.AssetSearchResult, error) {
+	return c.listAssetsBy("/assets/owner/{owner}", map[string]string{"owner": owner}, limit)
+}
+
+// ListAssetsByDomain ... retrieves the assets of the business domain
+func (c *CatalogueClient) ListAssetsByDomain(domain string, limit int) (*abstract.AssetSearchResult, error) {
+	return c.listAssetsBy("/assets/domain/{domain}", map[string]string{"domain": domain}, limit)
+}
+
+// ListAllAssets ... retrieves a page of assets, pass the next cursor in the options to get the following one
+func (c *CatalogueClient) ListAllAssets(opts *abstract.ListOptions) (*abstract.AssetPage, error) {
+	page := &abstract.AssetPage{}
+	if err := c.do(c.request(nil).SetQueryParams(listParams(opts)), http.MethodGet, "/assets/", page); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
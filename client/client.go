@@ -0,0 +1,179 @@
+// Package client ... typed clients of the catalogue and feature store endpoints, as described by their OpenAPI documents
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/utils/errors"
+	"github.com/pilillo/mastro/utils/logging"
+	"github.com/pilillo/mastro/utils/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Option ... configures the http client of a client
+type Option func(*resty.Client)
+
+// WithToken ... authenticates requests with a bearer token, i.e. an API token or a JWT
+func WithToken(token string) Option {
+	return func(c *resty.Client) {
+		if token != "" {
+			c.SetAuthToken(token)
+		}
+	}
+}
+
+// WithBasicAuth ... authenticates requests with a user and password
+func WithBasicAuth(user string, password string) Option {
+	return func(c *resty.Client) {
+		c.SetBasicAuth(user, password)
+	}
+}
+
+// WithTimeout ... sets the timeout of the requests
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *resty.Client) {
+		c.SetTimeout(timeout)
+	}
+}
+
+// Error ... a RestErr replied by the endpoints
+type Error struct {
+	errors.RestErr
+}
+
+// Error ... implements the error interface
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d %s: %s", e.Status, e.RestErr.Error, e.Message)
+}
+
+// IsNotFound ... returns true if the error is a 404 reply
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsConflict ... returns true if the error is a 409 reply
+func IsConflict(err error) bool {
+	return hasStatus(err, http.StatusConflict)
+}
+
+func hasStatus(err error, status int) bool {
+	restErr, isRestErr := err.(*Error)
+	return isRestErr && restErr.Status == status
+}
+
+// base ... the http client shared by the typed clients
+type base struct {
+	rest *resty.Client
+	// ctx ... context of the requests, whose current span is propagated to the service
+	ctx context.Context
+}
+
+// newBase ... returns a client for the base url, e.g. http://localhost:8085, the scheme defaulting to http
+func newBase(baseURL string, opts ...Option) base {
+	if !strings.Contains(baseURL, "://") {
+		baseURL = "http://" + baseURL
+	}
+	rest := resty.New().
+		SetHostURL(strings.TrimSuffix(baseURL, "/")).
+		SetHeader("Accept", "application/json")
+	for _, opt := range opts {
+		opt(rest)
+	}
+	return base{rest: rest, ctx: context.Background()}
+}
+
+// withContext ... returns a copy of the client sending its requests within the context
+func (b base) withContext(ctx context.Context) base {
+	b.ctx = ctx
+	return b
+}
+
+// request ... returns a request with the given path parameters
+func (b *base) request(pathParams map[string]string) *resty.Request {
+	return b.rest.R().SetContext(b.ctx).SetPathParams(pathParams)
+}
+
+// do ... sends the request, decoding the json reply in result if not nil and any error reply as an Error
+func (b *base) do(req *resty.Request, method string, path string, result interface{}) error {
+	if result != nil {
+		req.SetResult(result)
+	}
+	_, err := b.send(req, method, path)
+	return err
+}
+
+// send ... sends the request, returning the reply or any error reply as an Error
+func (b *base) send(req *resty.Request, method string, path string) (*resty.Response, error) {
+	// trace the request in a client span, propagated to the service so that its spans are children of it
+	ctx, span := tracing.StartWithKind(req.Context(), trace.SpanKindClient, fmt.Sprintf("%s %s", method, path),
+		attribute.String("http.method", method), attribute.String("http.route", path))
+	defer span.End()
+	tracing.Inject(ctx, req.Header)
+	// the id of the request being handled or of the crawler run, to correlate the logs of the service
+	if id := logging.RequestID(ctx); id != "" {
+		req.SetHeader(logging.RequestIDHeader, id)
+	}
+	resp, err := req.SetContext(ctx).SetError(&Error{}).Execute(method, path)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode()))
+	if resp.StatusCode() >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, resp.Status())
+	}
+	if resp.IsError() {
+		restErr, _ := resp.Error().(*Error)
+		if restErr == nil || restErr.Status == 0 {
+			// not a RestErr, e.g. a 404 of an unknown route
+			restErr = &Error{RestErr: errors.RestErr{
+				Status:  resp.StatusCode(),
+				Error:   strings.ToLower(strings.ReplaceAll(http.StatusText(resp.StatusCode()), " ", "_")),
+				Message: strings.TrimSpace(string(resp.Body())),
+			}}
+		}
+		return nil, restErr
+	}
+	return resp, nil
+}
+
+// ping ... calls the healthcheck of the service
+func (b *base) ping(path string) error {
+	return b.do(b.request(nil), http.MethodGet, path, nil)
+}
+
+// listParams ... the query parameters of the list endpoints for the options, see queries.List
+func listParams(opts *abstract.ListOptions) map[string]string {
+	params := make(map[string]string)
+	if opts == nil {
+		return params
+	}
+	if opts.Limit > 0 {
+		params["limit"] = strconv.Itoa(opts.Limit)
+	}
+	if opts.Offset > 0 {
+		params["offset"] = strconv.Itoa(opts.Offset)
+	}
+	if opts.Cursor != "" {
+		params["cursor"] = opts.Cursor
+	}
+	if opts.SortBy != "" {
+		params["sort"] = opts.SortBy
+		if opts.Descending {
+			params["sort"] = "-" + opts.SortBy
+		}
+	}
+	if len(opts.Fields) > 0 {
+		params["fields"] = strings.Join(opts.Fields, ",")
+	}
+	return params
+}
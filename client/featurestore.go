@@ -0,0 +1,115 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/pilillo/mastro/abstract"
+)
+
+// FeatureStoreClient ... client of the feature store endpoints
+type FeatureStoreClient struct {
+	base
+}
+
+// NewFeatureStoreClient ... returns a client of the feature store at the base url, e.g. http://localhost:8085
+func NewFeatureStoreClient(baseURL string, opts ...Option) *FeatureStoreClient {
+	return &FeatureStoreClient{base: newBase(baseURL, opts...)}
+}
+
+// WithContext ... returns a copy of the client sending its requests within the context, the current span of the context
+// being propagated to the service
+func (c *FeatureStoreClient) WithContext(ctx context.Context) *FeatureStoreClient {
+	return &FeatureStoreClient{base: c.base.withContext(ctx)}
+}
+
+// Ping ... calls the healthcheck of the feature store
+func (c *FeatureStoreClient) Ping() error {
+	return c.ping("/healthcheck/featureset")
+}
+
+// CreateFeatureSet ... creates a feature set, validated against the schema registered for its name if any
+func (c *FeatureStoreClient) CreateFeatureSet(fs abstract.FeatureSet) (*abstract.FeatureSet, error) {
+	created := &abstract.FeatureSet{}
+	if err := c.do(c.request(nil).SetBody(fs), http.MethodPut, "/featureset/", created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// GetFeatureSetByID ... retrieves a feature set by its backend id
+func (c *FeatureStoreClient) GetFeatureSetByID(fsID string) (*abstract.FeatureSet, error) {
+	fs := &abstract.FeatureSet{}
+	if err := c.do(c.request(map[string]string{"id": fsID}), http.MethodGet, "/featureset/id/{id}", fs); err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+// GetFeatureSetByName ... retrieves all versions of a feature set
+func (c *FeatureStoreClient) GetFeatureSetByName(fsName string) ([]abstract.FeatureSet, error) {
+	var fsets []abstract.FeatureSet
+	err := c.do(c.request(map[string]string{"name": fsName}), http.MethodGet, "/featureset/name/{name}", &fsets)
+	return fsets, err
+}
+
+// GetFeatureSetByVersion ... retrieves a feature set at a version, latest or semver range, for the entity if not empty
+func (c *FeatureStoreClient) GetFeatureSetByVersion(fsName string, version string, entity string) (*abstract.FeatureSet, error) {
+	req := c.request(map[string]string{"name": fsName, "version": version})
+	if entity != "" {
+		req.SetQueryParam("entity", entity)
+	}
+	fs := &abstract.FeatureSet{}
+	if err := c.do(req, http.MethodGet, "/featureset/name/{name}/version/{version}", fs); err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+// GetFeatureSetsAt ... retrieves the feature sets available at each entity and timestamp pair, in the order of the pairs
+func (c *FeatureStoreClient) GetFeatureSetsAt(fsName string, requests []abstract.EntityTimestamp) ([]abstract.PointInTimeFeatureSet, error) {
+	var fsets []abstract.PointInTimeFeatureSet
+	req := c.request(map[string]string{"name": fsName}).SetBody(requests)
+	err := c.do(req, http.MethodPost, "/featureset/name/{name}/point-in-time", &fsets)
+	return fsets, err
+}
+
+// GetOnlineFeatureSet ... retrieves the latest feature set from the online store, for the entity if not empty
+func (c *FeatureStoreClient) GetOnlineFeatureSet(fsName string, entity string) (*abstract.FeatureSet, error) {
+	req := c.request(map[string]string{"name": fsName})
+	if entity != "" {
+		req.SetQueryParam("entity", entity)
+	}
+	fs := &abstract.FeatureSet{}
+	if err := c.do(req, http.MethodGet, "/featureset/online/{name}", fs); err != nil {
+		return nil, err
+	}
+	return fs, nil
+}
+
+// UpsertFeatureSetSchema ... registers the schema of the feature sets with a name
+func (c *FeatureStoreClient) UpsertFeatureSetSchema(schema abstract.FeatureSetSchema) (*abstract.FeatureSetSchema, error) {
+	registered := &abstract.FeatureSetSchema{}
+	if err := c.do(c.request(nil).SetBody(schema), http.MethodPut, "/featureset/schema/", registered); err != nil {
+		return nil, err
+	}
+	return registered, nil
+}
+
+// GetFeatureSetSchema ... retrieves the schema registered for the feature sets with a name
+func (c *FeatureStoreClient) GetFeatureSetSchema(fsName string) (*abstract.FeatureSetSchema, error) {
+	schema := &abstract.FeatureSetSchema{}
+	if err := c.do(c.request(map[string]string{"name": fsName}), http.MethodGet, "/featureset/schema/{name}", schema); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// ListAllFeatureSets ... retrieves a page of feature sets, pass the next cursor in the options to get the following one
+func (c *FeatureStoreClient) ListAllFeatureSets(opts *abstract.ListOptions) (*abstract.FeatureSetPage, error) {
+	page := &abstract.FeatureSetPage{}
+	if err := c.do(c.request(nil).SetQueryParams(listParams(opts)), http.MethodGet, "/featureset/", page); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
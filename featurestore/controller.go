@@ -1,6 +1,7 @@
 package featurestore
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -8,16 +9,31 @@ import (
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/utils/auth"
 	"github.com/pilillo/mastro/utils/conf"
 	"github.com/pilillo/mastro/utils/errors"
+	"github.com/pilillo/mastro/utils/grpc"
+	"github.com/pilillo/mastro/utils/lifecycle"
+	"github.com/pilillo/mastro/utils/logging"
+	"github.com/pilillo/mastro/utils/metrics"
+	"github.com/pilillo/mastro/utils/openapi"
+	"github.com/pilillo/mastro/utils/queries"
+	"github.com/pilillo/mastro/utils/tracing"
 )
 
 const (
 	featureSetRestEndpoint string = "featureset"
 	featureSetIDParam      string = "featureset_id"
 	featureSetNameParam    string = "featureset_name"
+	versionParam           string = "version"
+	entityQueryParam       string = "entity"
 )
 
+// serviceFor ... returns the feature set service tracing its calls within the context of the request
+func serviceFor(c *gin.Context) Service {
+	return withContext(featureSetService, c.Request.Context())
+}
+
 // Ping ... replies to a ping message for healthcheck purposes
 func Ping(c *gin.Context) {
 	c.String(http.StatusOK, "pong")
@@ -28,12 +44,12 @@ func CreateFeatureSet(c *gin.Context) {
 	fs := abstract.FeatureSet{}
 	if err := c.ShouldBindJSON(&fs); err != nil {
 		restErr := errors.GetBadRequestError("Invalid JSON Body")
-		c.JSON(restErr.Status, restErr)
+		logging.ReplyError(c, restErr)
 	} else {
 		// call service to add the featureset
-		result, saveErr := featureSetService.CreateFeatureSet(fs)
+		result, saveErr := serviceFor(c).CreateFeatureSet(fs)
 		if saveErr != nil {
-			c.JSON(saveErr.Status, saveErr)
+			logging.ReplyError(c, saveErr)
 		} else {
 			c.JSON(http.StatusCreated, result)
 		}
@@ -55,12 +71,12 @@ func GetFeatureSetByID(c *gin.Context) {
 	id := c.Param(featureSetIDParam)
 	/*
 		if err != nil {
-			c.JSON(err.Status, err)
+			logging.ReplyError(c, err)
 		} else {
 	*/
-	fs, getErr := featureSetService.GetFeatureSetByID(id)
+	fs, getErr := serviceFor(c).GetFeatureSetByID(id)
 	if getErr != nil {
-		c.JSON(getErr.Status, getErr)
+		logging.ReplyError(c, getErr)
 	} else {
 		c.JSON(http.StatusOK, fs)
 	}
@@ -73,54 +89,226 @@ func GetFeatureSetByName(c *gin.Context) {
 	name := c.Param(featureSetNameParam)
 	/*
 		if err != nil {
-			c.JSON(err.Status, err)
+			logging.ReplyError(c, err)
 		} else {
 	*/
-	fs, getErr := featureSetService.GetFeatureSetByName(name)
+	fs, getErr := serviceFor(c).GetFeatureSetByName(name)
 	if getErr != nil {
-		c.JSON(getErr.Status, getErr)
+		logging.ReplyError(c, getErr)
 	} else {
 		c.JSON(http.StatusOK, fs)
 	}
 	//}
 }
 
-// ListAllFeatureSets ... lists all featuresets in the DB
+// GetFeatureSetByVersion ... retrieves a featureSet by the provided Name, version and optional entity
+func GetFeatureSetByVersion(c *gin.Context) {
+	name := c.Param(featureSetNameParam)
+	version := c.Param(versionParam)
+	entity := c.Query(entityQueryParam)
+	fs, getErr := serviceFor(c).GetFeatureSetByVersion(name, version, entity)
+	if getErr != nil {
+		logging.ReplyError(c, getErr)
+	} else {
+		c.JSON(http.StatusOK, fs)
+	}
+}
+
+// GetFeatureSetsAt ... retrieves the featureSets with the provided Name available at each entity and timestamp pair
+func GetFeatureSetsAt(c *gin.Context) {
+	name := c.Param(featureSetNameParam)
+	requests := []abstract.EntityTimestamp{}
+	if err := c.ShouldBindJSON(&requests); err != nil {
+		restErr := errors.GetBadRequestError("Invalid JSON Body")
+		logging.ReplyError(c, restErr)
+	} else {
+		fsets, getErr := serviceFor(c).GetFeatureSetsAt(name, requests)
+		if getErr != nil {
+			logging.ReplyError(c, getErr)
+		} else {
+			c.JSON(http.StatusOK, fsets)
+		}
+	}
+}
+
+// GetOnlineFeatureSet ... retrieves the latest featureSet with the provided Name and optional entity from the online store
+func GetOnlineFeatureSet(c *gin.Context) {
+	name := c.Param(featureSetNameParam)
+	entity := c.Query(entityQueryParam)
+	fs, getErr := serviceFor(c).GetOnlineFeatureSet(name, entity)
+	if getErr != nil {
+		logging.ReplyError(c, getErr)
+	} else {
+		c.JSON(http.StatusOK, fs)
+	}
+}
+
+// UpsertFeatureSetSchema ... registers the schema of a featureSet
+func UpsertFeatureSetSchema(c *gin.Context) {
+	schema := abstract.FeatureSetSchema{}
+	if err := c.ShouldBindJSON(&schema); err != nil {
+		restErr := errors.GetBadRequestError("Invalid JSON Body")
+		logging.ReplyError(c, restErr)
+	} else {
+		result, saveErr := serviceFor(c).UpsertFeatureSetSchema(schema)
+		if saveErr != nil {
+			logging.ReplyError(c, saveErr)
+		} else {
+			c.JSON(http.StatusOK, result)
+		}
+	}
+}
+
+// GetFeatureSetSchema ... retrieves the schema registered for the provided featureSet Name
+func GetFeatureSetSchema(c *gin.Context) {
+	name := c.Param(featureSetNameParam)
+	schema, getErr := serviceFor(c).GetFeatureSetSchema(name)
+	if getErr != nil {
+		logging.ReplyError(c, getErr)
+	} else {
+		c.JSON(http.StatusOK, schema)
+	}
+}
+
+// ListAllFeatureSets ... lists a page of featuresets in the DB, e.g. featureset/?limit=10&sort=-inserted-at&fields=name,version
 func ListAllFeatureSets(c *gin.Context) {
-	fsets, err := featureSetService.ListAllFeatureSets()
+	query := queries.List{}
+	if err := c.ShouldBindQuery(&query); err != nil {
+		restErr := errors.GetBadRequestError("Invalid list parameters")
+		logging.ReplyError(c, restErr)
+		return
+	}
+	result, err := listAllFeatureSets(serviceFor(c), query.ToListOptions())
 	if err != nil {
-		c.JSON(err.Status, err)
-	} else {
-		c.JSON(http.StatusOK, fsets)
+		logging.ReplyError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// listAllFeatureSets ... returns a page of featuresets, only with the fields of the options if any
+func listAllFeatureSets(s Service, opts *abstract.ListOptions) (interface{}, *errors.RestErr) {
+	page, err := s.ListAllFeatureSets(opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.Fields) == 0 {
+		return page, nil
 	}
+	// only return the requested fields
+	fsets, projectErr := abstract.Project(page.FeatureSets, opts.Fields)
+	if projectErr != nil {
+		return nil, errors.GetInternalServerError(projectErr.Error())
+	}
+	result := gin.H{"featuresets": fsets}
+	if page.NextCursor != "" {
+		result["next-cursor"] = page.NextCursor
+	}
+	return result, nil
 }
 
-var router = gin.Default()
+var (
+	router = gin.New()
+	// the servers of the endpoints, stopped by Shutdown
+	httpServer *http.Server
+	grpcServer *grpc.Server
+)
 
 // StartEndpoint ... handles requests for the endpoint on the specified port
-func StartEndpoint(cfg *conf.Config) {
+func StartEndpoint(cfg *conf.Config) error {
+	// assign an id to each request, log it and recover from panics
+	router.Use(logging.Middleware())
 	// https://github.com/gin-contrib/cors
-	// allow all origins
-	router.Use(cors.Default())
+	// allow all origins, along with the authorization header
+	router.Use(cors.New(auth.CorsConfig()))
+	// count and time the requests per route and status
+	router.Use(metrics.Middleware())
+	// trace the requests, as children of the spans of the callers if any
+	if err := tracing.Init(cfg.TracingDefinition, "mastro-featurestore"); err != nil {
+		return err
+	}
+	router.Use(tracing.Middleware())
 
 	// init service
-	featureSetService.Init(cfg)
+	if restErr := featureSetService.Init(cfg); restErr != nil {
+		return fmt.Errorf("Failed initializing the service :: %s", restErr.Message)
+	}
+
+	// authenticate requests and authorize them by the roles of the principal
+	authz, err := auth.NewMiddleware(cfg.AuthDefinition)
+	if err != nil {
+		return err
+	}
+	registerRoutes(router, authz)
+	// serve the same service over gRPC, if a port is set for it
+	if grpcServer, err = startGRPC(cfg, authz); err != nil {
+		return err
+	}
+
+	// run router as standalone service
+	// todo: do we need to run multiple endpoints from the main?
+	httpServer, err = lifecycle.Serve(fmt.Sprintf(":%s", cfg.Details["port"]), router)
+	return err
+}
+
+// Shutdown ... stops accepting requests and waits for the running ones to end, or for the context to be done,
+// then closes the connections to the backends and exports the pending traces
+func Shutdown(ctx context.Context) error {
+	lifecycle.Drain()
+	err := lifecycle.Shutdown(ctx, httpServer)
+	if grpcServer != nil {
+		if grpcErr := grpcServer.Shutdown(ctx); err == nil {
+			err = grpcErr
+		}
+	}
+	closeBackends()
+	tracing.Shutdown()
+	return err
+}
+
+// registerRoutes ... registers the endpoints on the router, as described in the OpenAPI document of apiDocument
+func registerRoutes(router gin.IRoutes, authz *auth.Middleware) {
+	read, write := authz.Require(auth.Read), authz.Require(auth.Write)
+
+	// serve the OpenAPI document of the endpoints
+	doc := apiDocument()
+	if !authz.Enabled() {
+		doc.Security = nil
+	}
+	router.GET(openAPIRoute, openapi.Handler(doc))
 
 	// add an healthcheck for the endpoint
 	router.GET(fmt.Sprintf("healthcheck/%s", featureSetRestEndpoint), Ping)
+	// expose the metrics of the endpoint
+	router.GET(metrics.Route, gin.WrapH(metrics.Handler()))
+	// probes of the process and of its backends
+	router.GET(lifecycle.LivenessRoute, lifecycle.Live)
+	router.GET(lifecycle.ReadinessRoute, lifecycle.Ready)
+	// the routes registered from now on reply 503 while the backends are not connected
+	router.Use(lifecycle.Available)
 
 	// get feature set as featureset/id/:fs_id with :fs_id being a placeholder for the value passed
-	router.GET(fmt.Sprintf("%s/id/:%s", featureSetRestEndpoint, featureSetIDParam), GetFeatureSetByID)
+	router.GET(fmt.Sprintf("%s/id/:%s", featureSetRestEndpoint, featureSetIDParam), read, GetFeatureSetByID)
 	// get feature set as featureset/name/:fs_name with :fs_name being a placeholder for the value passed
-	router.GET(fmt.Sprintf("%s/name/:%s", featureSetRestEndpoint, featureSetNameParam), GetFeatureSetByName)
+	router.GET(fmt.Sprintf("%s/name/:%s", featureSetRestEndpoint, featureSetNameParam), read, GetFeatureSetByName)
+
+	// get feature set at a version, latest or semver range as featureset/name/:fs_name/version/:version?entity=:entity
+	router.GET(fmt.Sprintf("%s/name/:%s/version/:%s", featureSetRestEndpoint, featureSetNameParam, versionParam), read, GetFeatureSetByVersion)
+
+	// get feature sets available at given entity and timestamp pairs as featureset/name/:fs_name/point-in-time
+	router.POST(fmt.Sprintf("%s/name/:%s/point-in-time", featureSetRestEndpoint, featureSetNameParam), read, GetFeatureSetsAt)
+
+	// get latest feature set from the online store as featureset/online/:fs_name?entity=:entity
+	router.GET(fmt.Sprintf("%s/online/:%s", featureSetRestEndpoint, featureSetNameParam), read, GetOnlineFeatureSet)
+
+	// put feature set schema as featureset/schema/
+	router.PUT(fmt.Sprintf("%s/schema/", featureSetRestEndpoint), write, UpsertFeatureSetSchema)
+	// get feature set schema as featureset/schema/:fs_name
+	router.GET(fmt.Sprintf("%s/schema/:%s", featureSetRestEndpoint, featureSetNameParam), read, GetFeatureSetSchema)
 
 	// put feature set as featureset/
-	router.PUT(fmt.Sprintf("%s/", featureSetRestEndpoint), CreateFeatureSet)
+	router.PUT(fmt.Sprintf("%s/", featureSetRestEndpoint), write, CreateFeatureSet)
 
 	// list all feature sets
-	router.GET(fmt.Sprintf("%s/", featureSetRestEndpoint), ListAllFeatureSets)
-
-	// run router as standalone service
-	// todo: do we need to run multiple endpoints from the main?
-	router.Run(fmt.Sprintf(":%s", cfg.Details["port"]))
+	router.GET(fmt.Sprintf("%s/", featureSetRestEndpoint), read, ListAllFeatureSets)
 }
@@ -0,0 +1,166 @@
+package featurestore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/utils/conf"
+	"github.com/pilillo/mastro/utils/logging"
+	"github.com/pilillo/mastro/utils/metrics"
+	"github.com/pilillo/mastro/utils/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// instrumentedDAO ... decorates a dao by recording the latency and errors of its operations per backend,
+// by tracing them as children of the current span of its context and by logging them with the logger of its context
+type instrumentedDAO struct {
+	dao     abstract.FeatureSetDAOProvider
+	backend string
+	ctx     context.Context
+}
+
+// instrument ... returns the dao recording metrics labelled with the backend type
+func instrument(dao abstract.FeatureSetDAOProvider, backend string) abstract.FeatureSetDAOProvider {
+	return &instrumentedDAO{dao: dao, backend: backend}
+}
+
+// bindContext ... returns the instrumented dao tracing and logging its calls within the context, any other dao as is
+func bindContext(dao abstract.FeatureSetDAOProvider, ctx context.Context) abstract.FeatureSetDAOProvider {
+	if d, isInstrumented := dao.(*instrumentedDAO); isInstrumented && ctx != nil {
+		return &instrumentedDAO{dao: d.dao, backend: d.backend, ctx: ctx}
+	}
+	return dao
+}
+
+// observe ... starts timing, tracing and logging an operation on the backend, returning the function to call with its outcome
+func observe(ctx context.Context, backend string, operation string) func(err error) {
+	start := time.Now()
+	_, span := tracing.Start(ctx, fmt.Sprintf("dao %s", operation), attribute.String("db.system", backend), attribute.String("db.operation", operation))
+	return func(err error) {
+		metrics.ObserveDAO(backend, operation, start, err)
+		if logger := logging.FromContext(ctx); err != nil {
+			logger.Debug("Backend operation failed", "backend", backend, "operation", operation, "duration", time.Since(start), "error", err)
+		} else {
+			logger.Debug("Backend operation", "backend", backend, "operation", operation, "duration", time.Since(start))
+		}
+		tracing.RecordError(span, err)
+		span.End()
+	}
+}
+
+func (d *instrumentedDAO) Init(def *conf.DataSourceDefinition) error {
+	return d.dao.Init(def)
+}
+
+func (d *instrumentedDAO) Create(fs *abstract.FeatureSet) error {
+	done := observe(d.ctx, d.backend, "create")
+	err := d.dao.Create(fs)
+	done(err)
+	return err
+}
+
+func (d *instrumentedDAO) GetById(id string) (*abstract.FeatureSet, error) {
+	done := observe(d.ctx, d.backend, "get-by-id")
+	fs, err := d.dao.GetById(id)
+	done(err)
+	return fs, err
+}
+
+func (d *instrumentedDAO) GetByName(name string) (*[]abstract.FeatureSet, error) {
+	done := observe(d.ctx, d.backend, "get-by-name")
+	fsets, err := d.dao.GetByName(name)
+	done(err)
+	return fsets, err
+}
+
+func (d *instrumentedDAO) ListAllFeatureSets() (*[]abstract.FeatureSet, error) {
+	done := observe(d.ctx, d.backend, "list-all")
+	fsets, err := d.dao.ListAllFeatureSets()
+	done(err)
+	return fsets, err
+}
+
+func (d *instrumentedDAO) ListFeatureSets(opts *abstract.ListOptions) (*abstract.FeatureSetPage, error) {
+	done := observe(d.ctx, d.backend, "list")
+	page, err := d.dao.ListFeatureSets(opts)
+	done(err)
+	return page, err
+}
+
+func (d *instrumentedDAO) GetLatestAt(name string, entity string, at time.Time) (*abstract.FeatureSet, error) {
+	done := observe(d.ctx, d.backend, "get-latest-at")
+	fs, err := d.dao.GetLatestAt(name, entity, at)
+	done(err)
+	return fs, err
+}
+
+func (d *instrumentedDAO) UpsertSchema(schema *abstract.FeatureSetSchema) error {
+	done := observe(d.ctx, d.backend, "upsert-schema")
+	err := d.dao.UpsertSchema(schema)
+	done(err)
+	return err
+}
+
+func (d *instrumentedDAO) GetSchema(name string) (*abstract.FeatureSetSchema, error) {
+	done := observe(d.ctx, d.backend, "get-schema")
+	schema, err := d.dao.GetSchema(name)
+	done(err)
+	return schema, err
+}
+
+func (d *instrumentedDAO) Ping() error {
+	return d.dao.Ping()
+}
+
+func (d *instrumentedDAO) CloseConnection() {
+	d.dao.CloseConnection()
+}
+
+// instrumentedOnlineStore ... decorates an online store by recording the latency and errors of its operations,
+// by tracing them as children of the current span of its context and by logging them with the logger of its context
+type instrumentedOnlineStore struct {
+	store   abstract.OnlineFeatureStoreProvider
+	backend string
+	ctx     context.Context
+}
+
+// instrumentOnlineStore ... returns the online store recording metrics labelled with the backend type
+func instrumentOnlineStore(store abstract.OnlineFeatureStoreProvider, backend string) abstract.OnlineFeatureStoreProvider {
+	return &instrumentedOnlineStore{store: store, backend: backend}
+}
+
+// bindOnlineContext ... returns the instrumented online store tracing its calls within the context, any other store as is
+func bindOnlineContext(store abstract.OnlineFeatureStoreProvider, ctx context.Context) abstract.OnlineFeatureStoreProvider {
+	if s, isInstrumented := store.(*instrumentedOnlineStore); isInstrumented && ctx != nil {
+		return &instrumentedOnlineStore{store: s.store, backend: s.backend, ctx: ctx}
+	}
+	return store
+}
+
+func (s *instrumentedOnlineStore) Init(def *conf.DataSourceDefinition) error {
+	return s.store.Init(def)
+}
+
+func (s *instrumentedOnlineStore) Put(key string, fs *abstract.FeatureSet) error {
+	done := observe(s.ctx, s.backend, "online-put")
+	err := s.store.Put(key, fs)
+	done(err)
+	return err
+}
+
+func (s *instrumentedOnlineStore) Get(key string) (*abstract.FeatureSet, error) {
+	done := observe(s.ctx, s.backend, "online-get")
+	fs, err := s.store.Get(key)
+	done(err)
+	return fs, err
+}
+
+func (s *instrumentedOnlineStore) Ping() error {
+	return s.store.Ping()
+}
+
+func (s *instrumentedOnlineStore) CloseConnection() {
+	s.store.CloseConnection()
+}
@@ -5,20 +5,44 @@ import (
 
 	"github.com/pilillo/mastro/abstract"
 	"github.com/pilillo/mastro/featurestore/daos/elastic"
+	"github.com/pilillo/mastro/featurestore/daos/embedded"
+	"github.com/pilillo/mastro/featurestore/daos/memory"
 	"github.com/pilillo/mastro/featurestore/daos/mongo"
+	"github.com/pilillo/mastro/featurestore/daos/postgres"
+	elasticsource "github.com/pilillo/mastro/sources/elastic"
+	embeddedsource "github.com/pilillo/mastro/sources/embedded"
+	mongosource "github.com/pilillo/mastro/sources/mongo"
+	postgressource "github.com/pilillo/mastro/sources/postgres"
 	"github.com/pilillo/mastro/utils/conf"
 )
 
 // available backends - lazy loaded singleton DAOs
 var availableDAOs = map[string]func() abstract.FeatureSetDAOProvider{
-	"mongo":   mongo.GetSingleton,
-	"elastic": elastic.GetSingleton,
+	"mongo":    mongo.GetSingleton,
+	"elastic":  elastic.GetSingleton,
+	"postgres": postgres.GetSingleton,
+	"embedded": embedded.GetSingleton,
+	"memory":   memory.GetSingleton,
+}
+
+// settings of the backends, validated along the configuration
+var settingsValidators = map[string]conf.SettingsValidator{
+	"mongo":    mongosource.NewMongoConnector().ValidateDataSourceDefinition,
+	"elastic":  elasticsource.NewElasticConnector().ValidateDataSourceDefinition,
+	"postgres": postgressource.NewPostgresConnector().ValidateDataSourceDefinition,
+	"embedded": embeddedsource.NewEmbeddedConnector().ValidateDataSourceDefinition,
+}
+
+func init() {
+	for name := range availableDAOs {
+		conf.RegisterDataSource(conf.FeatureStore, conf.BackendSection, name, settingsValidators[name])
+	}
 }
 
 func selectDao(cfg *conf.Config) (abstract.FeatureSetDAOProvider, error) {
 	if singletonDao, ok := availableDAOs[cfg.DataSourceDefinition.Type]; ok {
 		// call singleton constructor on dao
-		return singletonDao(), nil
+		return instrument(singletonDao(), cfg.DataSourceDefinition.Type), nil
 	}
 	return nil, fmt.Errorf("Impossible to find specified DAO connector %s", cfg.DataSourceDefinition.Type)
 }
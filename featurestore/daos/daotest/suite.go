@@ -0,0 +1,402 @@
+// Package daotest provides a conformance suite for featurestore DAOs,
+// so that every backend in featurestore/daos/* is verified the same way.
+package daotest
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pilillo/mastro/abstract"
+)
+
+// DAOFactory ... returns an initialized DAO on an empty collection
+type DAOFactory func(t *testing.T) abstract.FeatureSetDAOProvider
+
+// RunFeatureSetDAOSuite ... runs all conformance tests against the DAO returned by the factory,
+// the factory is called once per test so that each test starts from an empty collection
+func RunFeatureSetDAOSuite(t *testing.T, newDao DAOFactory) {
+	t.Run("EmptyCollection", func(t *testing.T) { testEmptyCollection(t, newDao(t)) })
+	t.Run("CreateAssignsID", func(t *testing.T) { testCreateAssignsID(t, newDao(t)) })
+	t.Run("CreateRejectsDuplicates", func(t *testing.T) { testCreateRejectsDuplicates(t, newDao(t)) })
+	t.Run("CreateRejectsConcurrentDuplicates", func(t *testing.T) { testCreateRejectsConcurrentDuplicates(t, newDao(t)) })
+	t.Run("GetByNameReturnsAllVersions", func(t *testing.T) { testGetByNameReturnsAllVersions(t, newDao(t)) })
+	t.Run("GetByNameReturnsManyVersions", func(t *testing.T) { testGetByNameReturnsManyVersions(t, newDao(t)) })
+	t.Run("ListAll", func(t *testing.T) { testListAll(t, newDao(t)) })
+	t.Run("ListPages", func(t *testing.T) { testListPages(t, newDao(t)) })
+	t.Run("GetLatestAt", func(t *testing.T) { testGetLatestAt(t, newDao(t)) })
+	t.Run("UpsertSchema", func(t *testing.T) { testUpsertSchema(t, newDao(t)) })
+}
+
+// insertedAt ... a deterministic insertion time, backends may not store sub-millisecond precision
+func insertedAt(offset int) time.Time {
+	return time.Date(2021, 3, 22, 21, 19, 39, 0, time.UTC).Add(time.Duration(offset) * time.Minute)
+}
+
+func newFeatureSet(name string, version string, offset int) *abstract.FeatureSet {
+	return &abstract.FeatureSet{
+		Name:        name,
+		InsertedAt:  insertedAt(offset),
+		Version:     version,
+		Description: fmt.Sprintf("%s at %s", name, version),
+		Labels:      map[string]string{"environment": "test"},
+		Features: []abstract.Feature{
+			{Name: "feature1", Value: 10, DataType: "int"},
+			{Name: "feature2", Value: true, DataType: "bool"},
+			{Name: "feature3", Value: "label", DataType: "string"},
+		},
+	}
+}
+
+func mustCreate(t *testing.T, dao abstract.FeatureSetDAOProvider, fsets ...*abstract.FeatureSet) {
+	t.Helper()
+	for _, fs := range fsets {
+		if err := dao.Create(fs); err != nil {
+			t.Fatalf("create of %s %s failed: %v", fs.Name, fs.Version, err)
+		}
+	}
+}
+
+// sameValue ... compares feature values, numbers may come back with a different go type depending on the backend
+func sameValue(expected interface{}, actual interface{}) bool {
+	return fmt.Sprintf("%v", expected) == fmt.Sprintf("%v", actual)
+}
+
+func assertSameFeatureSet(t *testing.T, expected *abstract.FeatureSet, actual *abstract.FeatureSet) {
+	t.Helper()
+	if actual.Name != expected.Name || actual.Version != expected.Version || actual.Entity != expected.Entity {
+		t.Errorf("expected %s %s %q, got %s %s %q",
+			expected.Name, expected.Version, expected.Entity, actual.Name, actual.Version, actual.Entity)
+	}
+	if !actual.InsertedAt.Equal(expected.InsertedAt) {
+		t.Errorf("expected inserted at %v, got %v", expected.InsertedAt, actual.InsertedAt)
+	}
+	if actual.Description != expected.Description {
+		t.Errorf("expected description %q, got %q", expected.Description, actual.Description)
+	}
+	if actual.Labels["environment"] != expected.Labels["environment"] {
+		t.Errorf("expected labels %v, got %v", expected.Labels, actual.Labels)
+	}
+	if len(actual.Features) != len(expected.Features) {
+		t.Fatalf("expected %d features, got %d", len(expected.Features), len(actual.Features))
+	}
+	for i, f := range expected.Features {
+		af := actual.Features[i]
+		if af.Name != f.Name || af.DataType != f.DataType || !sameValue(f.Value, af.Value) {
+			t.Errorf("expected feature %v, got %v", f, af)
+		}
+	}
+}
+
+func versions(fsets *[]abstract.FeatureSet) []string {
+	result := []string{}
+	if fsets == nil {
+		return result
+	}
+	for _, fs := range *fsets {
+		result = append(result, fs.Version)
+	}
+	return result
+}
+
+// testEmptyCollection ... listing and searching by name return an empty list, while lookups by id fail
+func testEmptyCollection(t *testing.T, dao abstract.FeatureSetDAOProvider) {
+	fsets, err := dao.ListAllFeatureSets()
+	if err != nil {
+		t.Fatalf("expected no error listing an empty collection, got %v", err)
+	}
+	if fsets != nil && len(*fsets) > 0 {
+		t.Errorf("expected no feature sets, got %v", versions(fsets))
+	}
+
+	fsets, err = dao.GetByName("missing")
+	if err != nil {
+		t.Fatalf("expected no error retrieving a missing name, got %v", err)
+	}
+	if fsets != nil && len(*fsets) > 0 {
+		t.Errorf("expected no feature sets, got %v", versions(fsets))
+	}
+
+	if _, err := dao.GetById("000000000000000000000000"); err == nil {
+		t.Error("expected an error retrieving a missing id")
+	}
+}
+
+// testCreateAssignsID ... create sets the id of the feature set, which can be used to retrieve it
+func testCreateAssignsID(t *testing.T, dao abstract.FeatureSetDAOProvider) {
+	fs := newFeatureSet("myfeatureset", "v1", 0)
+	mustCreate(t, dao, fs)
+
+	if fs.ID == "" {
+		t.Fatal("expected create to set the feature set id")
+	}
+
+	stored, err := dao.GetById(fs.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.ID != fs.ID {
+		t.Errorf("expected id %s, got %s", fs.ID, stored.ID)
+	}
+	assertSameFeatureSet(t, fs, stored)
+}
+
+// testCreateRejectsDuplicates ... a name, version and entity can only be used once
+func testCreateRejectsDuplicates(t *testing.T, dao abstract.FeatureSetDAOProvider) {
+	v1 := newFeatureSet("myfeatureset", "v1", 0)
+	mustCreate(t, dao, v1)
+
+	err := dao.Create(newFeatureSet("myfeatureset", "v1", 1))
+	if !errors.Is(err, abstract.ErrFeatureSetExists) {
+		t.Fatalf("expected ErrFeatureSetExists for a duplicate, got %v", err)
+	}
+
+	// the same version is allowed for other names and entities
+	other := newFeatureSet("myfeatureset", "v1", 2)
+	other.Entity = "customer1"
+	mustCreate(t, dao, newFeatureSet("otherfeatureset", "v1", 2), other)
+
+	fsets, err := dao.GetByName("myfeatureset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*fsets) != 2 {
+		t.Errorf("expected the duplicate not to be stored, got %d feature sets", len(*fsets))
+	}
+}
+
+// testCreateRejectsConcurrentDuplicates ... concurrent creates of the same version store it only once
+func testCreateRejectsConcurrentDuplicates(t *testing.T, dao abstract.FeatureSetDAOProvider) {
+	const creates = 8
+	errs := make([]error, creates)
+	var wg sync.WaitGroup
+	for i := 0; i < creates; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = dao.Create(newFeatureSet("myfeatureset", "v1", i))
+		}(i)
+	}
+	wg.Wait()
+
+	created := 0
+	for _, err := range errs {
+		if err == nil {
+			created++
+		} else if !errors.Is(err, abstract.ErrFeatureSetExists) {
+			t.Errorf("expected ErrFeatureSetExists for a duplicate, got %v", err)
+		}
+	}
+	if created != 1 {
+		t.Errorf("expected a single create to succeed, got %d", created)
+	}
+}
+
+// testGetByNameReturnsAllVersions ... all feature sets with the name are returned in insertion order
+func testGetByNameReturnsAllVersions(t *testing.T, dao abstract.FeatureSetDAOProvider) {
+	v1 := newFeatureSet("myfeatureset", "v1", 0)
+	other := newFeatureSet("otherfeatureset", "v1", 1)
+	v2 := newFeatureSet("myfeatureset", "v2", 2)
+	mustCreate(t, dao, v1, other, v2)
+
+	fsets, err := dao.GetByName("myfeatureset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := versions(fsets); len(got) != 2 || got[0] != "v1" || got[1] != "v2" {
+		t.Fatalf("expected versions [v1 v2], got %v", got)
+	}
+	assertSameFeatureSet(t, v1, &(*fsets)[0])
+	assertSameFeatureSet(t, v2, &(*fsets)[1])
+}
+
+// testGetByNameReturnsManyVersions ... all versions are returned, beyond the default page size of the backends
+func testGetByNameReturnsManyVersions(t *testing.T, dao abstract.FeatureSetDAOProvider) {
+	var expected []string
+	for i := 1; i <= 12; i++ {
+		version := fmt.Sprintf("%d.0.0", i)
+		mustCreate(t, dao, newFeatureSet("myfeatureset", version, i))
+		expected = append(expected, version)
+	}
+
+	fsets, err := dao.GetByName("myfeatureset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := versions(fsets); !equalStrings(got, expected) {
+		t.Errorf("expected versions %v, got %v", expected, got)
+	}
+	all, err := dao.ListAllFeatureSets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*all) != len(expected) {
+		t.Errorf("expected %d feature sets, got %d", len(expected), len(*all))
+	}
+}
+
+// testListAll ... all feature sets are returned
+func testListAll(t *testing.T, dao abstract.FeatureSetDAOProvider) {
+	mustCreate(t, dao,
+		newFeatureSet("a", "v1", 0),
+		newFeatureSet("b", "v1", 1),
+		newFeatureSet("a", "v2", 2),
+	)
+
+	fsets, err := dao.ListAllFeatureSets()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(*fsets) != 3 {
+		t.Errorf("expected 3 feature sets, got %d", len(*fsets))
+	}
+}
+
+// testListPages ... following the cursors returns every feature set once, in the requested order
+func testListPages(t *testing.T, dao abstract.FeatureSetDAOProvider) {
+	mustCreate(t, dao,
+		newFeatureSet("c", "v1", 0),
+		newFeatureSet("a", "v1", 1),
+		newFeatureSet("b", "v1", 2),
+		newFeatureSet("a", "v2", 3),
+		newFeatureSet("d", "v1", 4),
+	)
+
+	cases := []struct {
+		sortBy     string
+		descending bool
+		expected   []string
+	}{
+		{abstract.SortByInsertedAt, false, []string{"c v1", "a v1", "b v1", "a v2", "d v1"}},
+		{abstract.SortByInsertedAt, true, []string{"d v1", "a v2", "b v1", "a v1", "c v1"}},
+		{abstract.SortByName, false, []string{"a", "a", "b", "c", "d"}},
+	}
+	for _, c := range cases {
+		opts := &abstract.ListOptions{Limit: 2, SortBy: c.sortBy, Descending: c.descending}
+		var listed []string
+		for pages := 0; pages < 5; pages++ {
+			page, err := dao.ListFeatureSets(opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, fs := range page.FeatureSets {
+				if c.sortBy == abstract.SortByName {
+					listed = append(listed, fs.Name)
+				} else {
+					listed = append(listed, fs.Name+" "+fs.Version)
+				}
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			opts.Cursor = page.NextCursor
+		}
+		if !equalStrings(listed, c.expected) {
+			t.Errorf("sort by %s (descending %v): expected %v, got %v", c.sortBy, c.descending, c.expected, listed)
+		}
+	}
+}
+
+// equalStrings ... true if both slices have the same items in the same order
+func equalStrings(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// testGetLatestAt ... the latest feature set of the entity at the given time is returned, never a later one
+func testGetLatestAt(t *testing.T, dao abstract.FeatureSetDAOProvider) {
+	entity := func(fs *abstract.FeatureSet, entity string) *abstract.FeatureSet {
+		fs.Entity = entity
+		return fs
+	}
+	c1v1 := entity(newFeatureSet("myfeatureset", "v1", 0), "customer1")
+	c2v1 := entity(newFeatureSet("myfeatureset", "v1", 1), "customer2")
+	c1v2 := entity(newFeatureSet("myfeatureset", "v2", 2), "customer1")
+	noEntity := newFeatureSet("myfeatureset", "v3", 3)
+	mustCreate(t, dao, c1v1, c2v1, c1v2, noEntity)
+
+	cases := []struct {
+		name     string
+		entity   string
+		at       time.Time
+		expected *abstract.FeatureSet
+	}{
+		{"myfeatureset", "customer1", insertedAt(0).Add(-time.Second), nil},
+		{"myfeatureset", "customer1", insertedAt(0), c1v1},
+		{"myfeatureset", "customer1", insertedAt(1), c1v1},
+		{"myfeatureset", "customer1", insertedAt(2), c1v2},
+		{"myfeatureset", "customer1", insertedAt(10), c1v2},
+		{"myfeatureset", "customer2", insertedAt(10), c2v1},
+		{"myfeatureset", "customer3", insertedAt(10), nil},
+		{"myfeatureset", "", insertedAt(2), nil},
+		{"myfeatureset", "", insertedAt(10), noEntity},
+		{"otherfeatureset", "customer1", insertedAt(10), nil},
+	}
+	for _, c := range cases {
+		fs, err := dao.GetLatestAt(c.name, c.entity, c.at)
+		if err != nil {
+			t.Errorf("%s %q at %v: %v", c.name, c.entity, c.at, err)
+			continue
+		}
+		if c.expected == nil {
+			if fs != nil {
+				t.Errorf("%s %q at %v: expected no feature set, got %s", c.name, c.entity, c.at, fs.Version)
+			}
+			continue
+		}
+		if fs == nil {
+			t.Errorf("%s %q at %v: expected %s, got no feature set", c.name, c.entity, c.at, c.expected.Version)
+			continue
+		}
+		assertSameFeatureSet(t, c.expected, fs)
+	}
+}
+
+// testUpsertSchema ... schemas are retrievable by feature set name and replaced on upsert
+func testUpsertSchema(t *testing.T, dao abstract.FeatureSetDAOProvider) {
+	if schema, err := dao.GetSchema("myfeatureset"); err != nil || schema != nil {
+		t.Fatalf("expected no schema and no error for a missing name, got %v (%v)", schema, err)
+	}
+
+	max := 100.0
+	schema := &abstract.FeatureSetSchema{
+		Name:      "myfeatureset",
+		UpdatedAt: insertedAt(0),
+		Features: []abstract.FeatureSchema{
+			{Name: "feature1", DataType: abstract.IntType, Max: &max},
+			{Name: "feature2", DataType: abstract.StringType, Nullable: true, Enum: []interface{}{"a", "b"}},
+		},
+	}
+	if err := dao.UpsertSchema(schema); err != nil {
+		t.Fatal(err)
+	}
+	schema.UpdatedAt = insertedAt(1)
+	schema.Features = schema.Features[:1]
+	if err := dao.UpsertSchema(schema); err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := dao.GetSchema("myfeatureset")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored == nil || stored.Name != schema.Name || !stored.UpdatedAt.Equal(schema.UpdatedAt) {
+		t.Fatalf("expected the last upserted schema, got %v", stored)
+	}
+	if len(stored.Features) != 1 {
+		t.Fatalf("expected 1 feature, got %v", stored.Features)
+	}
+	f := stored.Features[0]
+	if f.Name != "feature1" || f.DataType != abstract.IntType || f.Max == nil || *f.Max != max || f.Min != nil {
+		t.Errorf("expected feature1 with max %v, got %v", max, f)
+	}
+}
@@ -3,11 +3,11 @@ package elastic
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"log"
-	"path/filepath"
+	"net/http"
 	"strconv"
 	"strings"
 	"sync"
@@ -17,6 +17,8 @@ import (
 	"github.com/pilillo/mastro/abstract"
 	"github.com/pilillo/mastro/sources/elastic"
 	"github.com/pilillo/mastro/utils/conf"
+	"github.com/pilillo/mastro/utils/logging"
+	"github.com/pilillo/mastro/utils/retry"
 )
 
 // both init and sync.Once are thread-safe
@@ -33,9 +35,23 @@ func GetSingleton() abstract.FeatureSetDAOProvider {
 	return instance
 }
 
+// schemasIndexDef ... definition of the index holding the feature set schemas, one document per feature set name
+const schemasIndexDef = `{
+  "settings": { "number_of_shards": 1, "number_of_replicas": 0 },
+  "mappings": {
+    "properties": {
+      "name": { "type": "keyword" },
+      "updated_at": { "type": "date" },
+      "features": { "type": "object", "enabled": false }
+    }
+  }
+}`
+
 // dao ... The struct for the ElasticSearch DAO for the FeatureStore service
 type dao struct {
 	Connector *elastic.Connector
+	// schemas are stored in a separate index, named after the feature sets one
+	SchemasIndexName string
 }
 
 /*
@@ -103,10 +119,21 @@ type ResponseDoc struct {
 	ID     string     `json:"_id,omitempty"`
 	Score  float64    `json:"_score,omitempty"`
 	Source FeatureSet `json:"_source,omitempty"`
+	// Sort ... sort values of the hit, used to search after it
+	Sort []interface{} `json:"sort,omitempty"`
+}
+
+// IndexResponse ... response returned by ES when indexing a document
+type IndexResponse struct {
+	Index  string `json:"_index,omitempty"`
+	ID     string `json:"_id,omitempty"`
+	Result string `json:"result,omitempty"`
 }
 
 // FeatureSet ... a versioned set of features
 type FeatureSet struct {
+	Name        string            `json:"name,omitempty"`
+	Entity      string            `json:"entity,omitempty"`
 	InsertedAt  time.Time         `json:"inserted_at,omitempty"`
 	Version     string            `json:"version,omitempty"`
 	Features    []Feature         `json:"features,omitempty"`
@@ -119,78 +146,63 @@ type Version struct{}
 
 // Feature ... a named variable with a data type
 type Feature struct {
-	Name     string `json:"name,omitempty"`
-	Value    string `json:"value,omitempty"`
-	DataType string `json:"data-type,omitempty"`
+	Name     string      `json:"name,omitempty"`
+	Value    interface{} `json:"value,omitempty"`
+	DataType string      `json:"data-type,omitempty"`
 }
 
-func (dao *dao) checkIndex(def *conf.DataSourceDefinition) error {
-	// if file location has no specified folder, check at the same position of the config
-	indexDefFilePath := def.Settings["index-def"]
-
-	// if the def is indicated without an actual path (parent is itself and dir is ., or directly as ./file)
-	if filepath.Dir(indexDefFilePath) == "." || filepath.Dir(indexDefFilePath) == "." {
-		// look for the index def in the same location of the application config
-		indexDefFilePath = filepath.Join(filepath.Dir(conf.Args.Config), indexDefFilePath)
+// Init ... Initialize connection to elastic search and target index
+func (dao *dao) Init(def *conf.DataSourceDefinition) error {
+	// create connector
+	dao.Connector = elastic.NewElasticConnector()
+	// validate data source definition
+	if err := dao.Connector.ValidateDataSourceDefinition(def); err != nil {
+		return retry.Permanent(err)
 	}
-	log.Println("Attempting loading index def file from folder", indexDefFilePath)
-
-	// read definition from file
-	defFile, err := ioutil.ReadFile(indexDefFilePath)
-	if err != nil {
+	// init connector
+	if err := dao.Connector.InitConnection(def); err != nil {
 		return err
 	}
-
-	// Instantiate an indexRequest object
-	req := esapi.IndexRequest{
-		Index:   dao.Connector.IndexName,
-		Body:    strings.NewReader(string(defFile)),
-		Refresh: "true",
+	// make sure the target index exists
+	if err := dao.Connector.CheckIndex(def, dao.Connector.IndexName); err != nil {
+		return fmt.Errorf("Failed checking the index %s :: %v", dao.Connector.IndexName, err)
 	}
-
-	// Return an API response object from request
-	ctx := context.Background()
-	res, err := req.Do(ctx, dao.Connector.Client)
+	// make sure the schemas index exists
+	dao.SchemasIndexName = dao.Connector.IndexName + "-schemas"
+	exists, err := dao.Connector.IndexExists(dao.SchemasIndexName)
 	if err != nil {
-		return fmt.Errorf("IndexRequest ERROR: %s", err)
+		return fmt.Errorf("Failed checking the index %s :: %v", dao.SchemasIndexName, err)
+	}
+	if !exists {
+		if err := dao.Connector.CreateIndex(dao.SchemasIndexName, []byte(schemasIndexDef)); err != nil {
+			return fmt.Errorf("Failed creating the index %s :: %v", dao.SchemasIndexName, err)
+		}
 	}
-	defer res.Body.Close()
 	return nil
 }
 
-// Init ... Initialize connection to elastic search and target index
-func (dao *dao) Init(def *conf.DataSourceDefinition) {
-	// create connector
-	dao.Connector = elastic.NewElasticConnector()
-	// validate data source definition
-	if err := dao.Connector.ValidateDataSourceDefinition(def); err != nil {
-		panic(err)
-	}
-	// init connector
-	dao.Connector.InitConnection(def)
-	// make sure the target index exists
-	//err := dao.checkIndex(def)
-	//if err != nil {
-	//	log.Fatalln(err)
-	//}
+// featureSetID ... document id of a feature set, derived from its name, version and entity
+// so that indexing the same version twice conflicts, hashed as the entity is free text
+func featureSetID(fs *abstract.FeatureSet) string {
+	key := sha256.Sum256([]byte(strings.Join([]string{fs.Name, fs.Version, fs.Entity}, "\x00")))
+	return hex.EncodeToString(key[:])
 }
 
-// Create ... Create featureset on ES
+// Create ... Create featureset on ES, failing with ErrFeatureSetExists if its name, version and entity are taken
 func (dao *dao) Create(fs *abstract.FeatureSet) error {
-
 	jsonVal, err := json.Marshal(fs)
 	if err != nil {
 		return err
 	}
 
 	body := string(jsonVal)
-	// Instantiate an indexRequest object
+	// the create operation fails if the document id exists already
 	req := esapi.IndexRequest{
-		Index: dao.Connector.IndexName,
-		// https://www.elastic.co/guide/en/elasticsearch/reference/6.8/mapping-id-field.html
-		//DocumentID: strconv.Itoa(i),
-		Body:    strings.NewReader(body),
-		Refresh: "true",
+		Index:      dao.Connector.IndexName,
+		DocumentID: featureSetID(fs),
+		OpType:     "create",
+		Body:       strings.NewReader(body),
+		Refresh:    "true",
 	}
 
 	// Return an API response object from request
@@ -201,11 +213,21 @@ func (dao *dao) Create(fs *abstract.FeatureSet) error {
 	}
 	defer res.Body.Close()
 
+	if res.StatusCode == http.StatusConflict {
+		return fmt.Errorf("Error while creating feature set :: %w", abstract.ErrFeatureSetExists)
+	}
 	if res.IsError() {
-		log.Println(res.String())
+		logging.Error("Failed indexing document", "status", res.Status(), "response", res.String())
 		return fmt.Errorf("%s ERROR indexing document ", res.Status())
 	}
 
+	// get the id assigned to the new document
+	indexResponse := &IndexResponse{}
+	if err := json.NewDecoder(res.Body).Decode(indexResponse); err != nil {
+		return fmt.Errorf("Error parsing the response body: %s", err)
+	}
+	fs.ID = indexResponse.ID
+
 	return nil
 }
 
@@ -294,7 +316,7 @@ func (dao *dao) GetById(id string) (*abstract.FeatureSet, error) {
 		return nil, err
 	}
 
-	log.Println("GetById :: Retrieved", searchResponse.Hits.Total.Value, "documents")
+	logging.Debug("Retrieved documents", "operation", "GetById", "documents", searchResponse.Hits.Total.Value)
 	if searchResponse.Hits.Total.Value > 0 {
 		hitDocs, err := convertDocumentsToFeatureSetCollection(searchResponse.Hits.Hits)
 		if err != nil {
@@ -306,20 +328,94 @@ func (dao *dao) GetById(id string) (*abstract.FeatureSet, error) {
 	return nil, fmt.Errorf("No document found for id %s", id)
 }
 
-// GetByName ... Retrieve document by given name
+// GetByName ... Retrieve all documents with given name, in insertion order
 func (dao *dao) GetByName(name string) (*[]abstract.FeatureSet, error) {
-
-	var buf bytes.Buffer
 	// use a term query to do an exact match of the name
 	// https://www.elastic.co/guide/en/elasticsearch/reference/current/query-dsl-term-query.html
-	query := map[string]interface{}{
-		"query": map[string]interface{}{
-			"term": map[string]interface{}{
-				"name": name,
-			},
+	hits, err := dao.searchAll(map[string]interface{}{
+		"term": map[string]interface{}{
+			"name": name,
 		},
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	logging.Debug("Retrieved documents", "operation", "GetByName", "documents", len(hits))
+	// an empty list is returned if no feature set has the given name
+	return convertDocumentsToFeatureSetCollection(hits)
+}
+
+// insertionOrder ... sorts the feature sets in insertion order, ties being broken by name, version and entity
+// which identify a feature set, so that search_after never skips any
+var insertionOrder = []interface{}{
+	map[string]interface{}{"inserted_at": "asc"},
+	map[string]interface{}{"name": "asc"},
+	map[string]interface{}{"version": "asc"},
+	map[string]interface{}{"entity": map[string]interface{}{"order": "asc", "missing": "_first"}},
+}
+
+// searchAll ... returns all the documents matching the query in insertion order, retrieving them page by page
+// with search_after since a search returns 10 hits by default
+func (dao *dao) searchAll(query map[string]interface{}) ([]ResponseDoc, error) {
+	var hits []ResponseDoc
+	var searchAfter []interface{}
+	for {
+		body := map[string]interface{}{
+			"query": query,
+			"sort":  insertionOrder,
+			"size":  abstract.MaxLimit,
+		}
+		if searchAfter != nil {
+			body["search_after"] = searchAfter
+		}
+		var buf bytes.Buffer
+		if err := json.NewEncoder(&buf).Encode(body); err != nil {
+			return nil, fmt.Errorf("Error encoding query: %s", err)
+		}
+		searchResponse, err := dao.search(&buf)
+		if err != nil {
+			return nil, err
+		}
+		page := searchResponse.Hits.Hits
+		hits = append(hits, page...)
+		if len(page) < abstract.MaxLimit {
+			return hits, nil
+		}
+		searchAfter = page[len(page)-1].Sort
+	}
+}
+
+// featureSetQuery ... bool query on the feature sets with given name and entity, plus any additional filter
+func featureSetQuery(name string, entity string, filters ...interface{}) map[string]interface{} {
+	filter := append([]interface{}{
+		map[string]interface{}{"term": map[string]interface{}{"name": name}},
+	}, filters...)
+	boolQuery := map[string]interface{}{"filter": filter}
+	// the entity is omitted when empty, so we look for documents without one
+	if entity == "" {
+		boolQuery["must_not"] = map[string]interface{}{"exists": map[string]interface{}{"field": "entity"}}
+	} else {
+		boolQuery["filter"] = append(filter, map[string]interface{}{"term": map[string]interface{}{"entity": entity}})
+	}
+	return map[string]interface{}{"bool": boolQuery}
+}
+
+// GetLatestAt ... Retrieve the latest document with given name and entity inserted at or before the given time
+func (dao *dao) GetLatestAt(name string, entity string, at time.Time) (*abstract.FeatureSet, error) {
+	var buf bytes.Buffer
+	query := map[string]interface{}{
+		"query": featureSetQuery(name, entity,
+			// never return documents inserted after the requested time
+			map[string]interface{}{"range": map[string]interface{}{
+				"inserted_at": map[string]interface{}{"lte": at.UTC().Format(time.RFC3339Nano)},
+			}},
+		),
+		"sort": []interface{}{
+			map[string]interface{}{"inserted_at": "desc"},
+		},
+		"size": 1,
+	}
 	if err := json.NewEncoder(&buf).Encode(query); err != nil {
 		return nil, fmt.Errorf("Error encoding query: %s", err)
 	}
@@ -328,58 +424,95 @@ func (dao *dao) GetByName(name string) (*[]abstract.FeatureSet, error) {
 	if err != nil {
 		return nil, err
 	}
-
-	log.Println("GetByName :: Retrieved", searchResponse.Hits.Total.Value, "documents")
-	if searchResponse.Hits.Total.Value > 0 {
-		hitDocs, err := convertDocumentsToFeatureSetCollection(searchResponse.Hits.Hits)
-		if err != nil {
-			return nil, err
-		}
-		//return &((*hitDocs)[0]), nil
-		return hitDocs, nil
+	if len(searchResponse.Hits.Hits) == 0 {
+		return nil, nil
 	}
-	// else return an empty feature set
-	return nil, fmt.Errorf("No document found for name %s", name)
+	return convertDocumentToFeatureSet(searchResponse.Hits.Hits[0])
 }
 
-// ListAllFeatureSets ... Return all featuresets in index
+// ListAllFeatureSets ... Return all featuresets in index, in insertion order
 func (dao *dao) ListAllFeatureSets() (*[]abstract.FeatureSet, error) {
+	hits, err := dao.searchAll(map[string]interface{}{
+		"match_all": map[string]interface{}{},
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	// in ES to return all documents in an index we need the following query:
-	/*
-		{
-		    "query": {
-		        "match_all": {}
-		    }
-		}
-	*/
+	logging.Debug("Retrieved documents", "operation", "ListAllFeatureSets", "documents", len(hits))
+	// an empty list is returned if the index is empty
+	return convertDocumentsToFeatureSetCollection(hits)
+}
 
-	// prepare search query
-	var buf bytes.Buffer
+// sortFields ... index field for each sort key of the list endpoint
+var sortFields = map[string]string{
+	abstract.SortByInsertedAt: "inserted_at",
+	abstract.SortByName:       "name",
+}
+
+// ListFeatureSets ... Return a page of featuresets, using search_after to move through pages,
+// ties are broken by name, version and entity which identify a feature set
+func (dao *dao) ListFeatureSets(opts *abstract.ListOptions) (*abstract.FeatureSetPage, error) {
+	order, missing := "asc", "_first"
+	if opts.Descending {
+		order, missing = "desc", "_last"
+	}
+	sort := []interface{}{map[string]interface{}{sortFields[opts.SortBy]: order}}
+	if opts.SortBy != abstract.SortByName {
+		sort = append(sort, map[string]interface{}{"name": order})
+	}
+	sort = append(sort,
+		map[string]interface{}{"version": order},
+		map[string]interface{}{"entity": map[string]interface{}{"order": order, "missing": missing}},
+	)
+
+	// retrieve one more document to know if a next page exists
 	query := map[string]interface{}{
 		"query": map[string]interface{}{
 			"match_all": map[string]interface{}{},
 		},
+		"sort": sort,
+		"size": opts.Limit + 1,
+	}
+	if len(opts.Fields) > 0 {
+		query["_source"] = opts.Fields
 	}
+
+	var cursor *abstract.Cursor
+	if opts.Cursor != "" {
+		c, err := abstract.DecodeCursor(opts.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		cursor = c
+	}
+	if cursor != nil && len(cursor.SearchAfter) > 0 {
+		query["search_after"] = cursor.SearchAfter
+	} else {
+		query["from"] = opts.StartOffset()
+	}
+
+	var buf bytes.Buffer
 	if err := json.NewEncoder(&buf).Encode(query); err != nil {
 		return nil, fmt.Errorf("Error encoding query: %s", err)
 	}
-
 	searchResponse, err := dao.search(&buf)
 	if err != nil {
 		return nil, err
 	}
 
-	log.Println("ListAllFeatureSets :: Retrieved", searchResponse.Hits.Total.Value, "documents")
-	if searchResponse.Hits.Total.Value > 0 {
-		fsColl, err := convertDocumentsToFeatureSetCollection(searchResponse.Hits.Hits)
-		if err != nil {
-			return nil, err
-		}
-		return fsColl, nil
+	hits := searchResponse.Hits.Hits
+	page := &abstract.FeatureSetPage{}
+	if len(hits) > opts.Limit {
+		hits = hits[:opts.Limit]
+		page.NextCursor = abstract.EncodeCursor(&abstract.Cursor{SearchAfter: hits[len(hits)-1].Sort})
 	}
-	// else return an empty feature set
-	return nil, fmt.Errorf("No document found in index %s", dao.Connector.IndexName)
+	fsets, err := convertDocumentsToFeatureSetCollection(hits)
+	if err != nil {
+		return nil, err
+	}
+	page.FeatureSets = *fsets
+	return page, nil
 }
 
 func convertDocumentsToFeatureSetCollection(documents []ResponseDoc) (*[]abstract.FeatureSet, error) {
@@ -396,6 +529,9 @@ func convertDocumentsToFeatureSetCollection(documents []ResponseDoc) (*[]abstrac
 
 func convertDocumentToFeatureSet(document ResponseDoc) (*abstract.FeatureSet, error) {
 	fs := abstract.FeatureSet{}
+	fs.ID = document.ID
+	fs.Name = document.Source.Name
+	fs.Entity = document.Source.Entity
 	fs.InsertedAt = document.Source.InsertedAt
 	fs.Version = document.Source.Version
 	features, err := convertDaoFeaturesToFeatures(document.Source.Features)
@@ -414,33 +550,95 @@ func convertDaoFeaturesToFeatures(features []Feature) (*[]abstract.Feature, erro
 		af := abstract.Feature{}
 		af.Name = f.Name
 		af.DataType = f.DataType
-		switch af.DataType {
-		case "bool":
-			b, err := strconv.ParseBool(f.Value)
-			if err != nil {
-				return nil, err
-			}
-			af.Value = b
-		case "int":
-			n, err := strconv.ParseInt(f.Value, 10, 64)
-			if err != nil {
-				return nil, err
+		af.Value = f.Value
+
+		// values stored as strings are converted back to the declared data type
+		if strValue, isString := f.Value.(string); isString {
+			switch af.DataType {
+			case "bool":
+				b, err := strconv.ParseBool(strValue)
+				if err != nil {
+					return nil, err
+				}
+				af.Value = b
+			case "int":
+				n, err := strconv.ParseInt(strValue, 10, 64)
+				if err != nil {
+					return nil, err
+				}
+				af.Value = n
+			case "float":
+				f, err := strconv.ParseFloat(strValue, 64)
+				if err != nil {
+					return nil, err
+				}
+				af.Value = f
 			}
-			af.Value = n
-		case "float":
-			f, err := strconv.ParseFloat(f.Value, 64)
-			if err != nil {
-				return nil, err
-			}
-			af.Value = f
-		case "string":
-			af.Value = f.Value
 		}
 		result = append(result, af)
 	}
 	return &result, nil
 }
 
+// UpsertSchema ... Index the schema using the feature set name as document id, replacing any previous one
+func (dao *dao) UpsertSchema(schema *abstract.FeatureSetSchema) error {
+	jsonVal, err := json.Marshal(schema)
+	if err != nil {
+		return err
+	}
+
+	req := esapi.IndexRequest{
+		Index:      dao.SchemasIndexName,
+		DocumentID: schema.Name,
+		Body:       bytes.NewReader(jsonVal),
+		Refresh:    "true",
+	}
+	res, err := req.Do(context.Background(), dao.Connector.Client)
+	if err != nil {
+		return fmt.Errorf("IndexRequest ERROR: %s", err)
+	}
+	defer res.Body.Close()
+
+	if res.IsError() {
+		logging.Error("Failed indexing schema", "status", res.Status(), "response", res.String())
+		return fmt.Errorf("%s ERROR indexing schema", res.Status())
+	}
+	return nil
+}
+
+// GetSchema ... Retrieve the schema document with the feature set name as id
+func (dao *dao) GetSchema(name string) (*abstract.FeatureSetSchema, error) {
+	req := esapi.GetRequest{
+		Index:      dao.SchemasIndexName,
+		DocumentID: name,
+	}
+	res, err := req.Do(context.Background(), dao.Connector.Client)
+	if err != nil {
+		return nil, fmt.Errorf("GetRequest ERROR: %s", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if res.IsError() {
+		return nil, fmt.Errorf("%s ERROR retrieving schema %s", res.Status(), name)
+	}
+
+	doc := struct {
+		Source abstract.FeatureSetSchema `json:"_source"`
+	}{}
+	if err := json.NewDecoder(res.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("Error parsing the response body: %s", err)
+	}
+	return &doc.Source, nil
+}
+
+// Ping ... pings the backend through the connector
+func (dao *dao) Ping() error {
+	return dao.Connector.Ping()
+}
+
 // CloseConnection ... Terminates the connection to ES for the DAO
 func (dao *dao) CloseConnection() {
 	dao.Connector.CloseConnection()
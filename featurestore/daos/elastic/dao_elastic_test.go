@@ -0,0 +1,48 @@
+package elastic
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/featurestore/daos/daotest"
+	"github.com/pilillo/mastro/utils/conf"
+)
+
+// TestConformance ... runs against a live elastic search, e.g. the one of integration_tests/start_elastic.sh,
+// by setting MASTRO_TEST_ELASTIC_HOSTS=http://localhost:9200
+func TestConformance(t *testing.T) {
+	hosts, exist := os.LookupEnv("MASTRO_TEST_ELASTIC_HOSTS")
+	if !exist {
+		t.Skip("MASTRO_TEST_ELASTIC_HOSTS not set, skipping elastic integration test")
+	}
+
+	indexDef, err := filepath.Abs("../../../conf/featurestore/elastic/index_def.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	daotest.RunFeatureSetDAOSuite(t, func(t *testing.T) abstract.FeatureSetDAOProvider {
+		dao := GetSingleton()
+		if err := dao.Init(&conf.DataSourceDefinition{
+			Name: "test-elastic",
+			Type: "elastic",
+			Settings: map[string]string{
+				"username":  "elastic",
+				"password":  "test",
+				"hosts":     hosts,
+				"index":     fmt.Sprintf("mastro-test-%d", time.Now().UnixNano()),
+				"index-def": indexDef,
+			},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			instance.Connector.Client.Indices.Delete([]string{instance.Connector.IndexName, instance.SchemasIndexName})
+		})
+		return dao
+	})
+}
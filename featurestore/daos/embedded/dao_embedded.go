@@ -0,0 +1,204 @@
+package embedded
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/sources/embedded"
+	"github.com/pilillo/mastro/utils/conf"
+	"github.com/pilillo/mastro/utils/logging"
+	"github.com/pilillo/mastro/utils/retry"
+)
+
+// bucket holding the feature sets, keyed by an autoincrementing id
+const featureSetsBucket = "featuresets"
+
+// bucket holding the feature set schemas, keyed by feature set name
+const schemasBucket = "schemas"
+
+type dao struct {
+	Connector *embedded.Connector
+	// serializes creates, so that the check for existing feature sets is not raced
+	createMutex sync.Mutex
+}
+
+// both init and sync.Once are thread-safe
+// but only sync.Once is lazy
+var once sync.Once
+var instance *dao
+
+// GetSingleton ... lazy singleton on DAO
+func GetSingleton() abstract.FeatureSetDAOProvider {
+	// once.do is lazy, we use it to return an instance of the DAO
+	once.Do(func() {
+		instance = &dao{}
+	})
+	return instance
+}
+
+// Init ... Initialize the embedded store
+func (dao *dao) Init(def *conf.DataSourceDefinition) error {
+	dao.Connector = embedded.NewEmbeddedConnector()
+	if err := dao.Connector.ValidateDataSourceDefinition(def); err != nil {
+		return retry.Permanent(err)
+	}
+	return dao.Connector.InitConnection(def)
+}
+
+// Ping ... pings the backend through the connector
+func (dao *dao) Ping() error {
+	return dao.Connector.Ping()
+}
+
+// CloseConnection ... Flushes the embedded store
+func (dao *dao) CloseConnection() {
+	dao.Connector.CloseConnection()
+}
+
+// Create ... Insert the feature set with a new id
+func (dao *dao) Create(fs *abstract.FeatureSet) error {
+	dao.createMutex.Lock()
+	defer dao.createMutex.Unlock()
+
+	existing, err := dao.getAnyFeatureSetUsingFilter(func(stored *abstract.FeatureSet) bool {
+		return stored.Name == fs.Name && stored.Version == fs.Version && stored.Entity == fs.Entity
+	})
+	if err != nil {
+		return fmt.Errorf("Error while creating feature set :: %v", err)
+	}
+	if len(*existing) > 0 {
+		return fmt.Errorf("Error while creating feature set :: %w", abstract.ErrFeatureSetExists)
+	}
+
+	id, err := dao.Connector.NextSequence(featureSetsBucket)
+	if err != nil {
+		return fmt.Errorf("Error while creating feature set :: %v", err)
+	}
+
+	fs.ID = strconv.FormatUint(id, 10)
+	if err := dao.Connector.Put(featureSetsBucket, fs.ID, fs); err != nil {
+		return fmt.Errorf("Error while creating feature set :: %v", err)
+	}
+	logging.Debug("Inserted FeatureSet", "id", id)
+	return nil
+}
+
+// getAnyFeatureSetUsingFilter ... returns all feature sets for which the filter is true, in insertion order
+func (dao *dao) getAnyFeatureSetUsingFilter(filter func(fs *abstract.FeatureSet) bool) (*[]abstract.FeatureSet, error) {
+	type storedFeatureSet struct {
+		id uint64
+		fs abstract.FeatureSet
+	}
+
+	var stored []storedFeatureSet
+	err := dao.Connector.ForEach(featureSetsBucket, func(key string, value json.RawMessage) error {
+		id, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			return err
+		}
+		fs := abstract.FeatureSet{}
+		if err := json.Unmarshal(value, &fs); err != nil {
+			return err
+		}
+		if filter(&fs) {
+			stored = append(stored, storedFeatureSet{id: id, fs: fs})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error while retrieving feature set :: %v", err)
+	}
+
+	// keys are sorted as strings, restore insertion order using the numeric id
+	sort.Slice(stored, func(i, j int) bool {
+		return stored[i].id < stored[j].id
+	})
+
+	fsets := []abstract.FeatureSet{}
+	for _, s := range stored {
+		fsets = append(fsets, s.fs)
+	}
+	return &fsets, nil
+}
+
+// GetById ... Retrieve feature set by given id
+func (dao *dao) GetById(id string) (*abstract.FeatureSet, error) {
+	fs := &abstract.FeatureSet{}
+	found, err := dao.Connector.Get(featureSetsBucket, id, fs)
+	if err != nil {
+		return nil, fmt.Errorf("Error while retrieving feature set :: %v", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("Error while retrieving feature set :: no feature set found for id %s", id)
+	}
+	return fs, nil
+}
+
+// GetByName ... Retrieve all feature sets with the given name
+func (dao *dao) GetByName(name string) (*[]abstract.FeatureSet, error) {
+	return dao.getAnyFeatureSetUsingFilter(func(fs *abstract.FeatureSet) bool {
+		return fs.Name == name
+	})
+}
+
+// GetLatestAt ... Retrieve the latest feature set with the given name and entity inserted at or before the given time
+func (dao *dao) GetLatestAt(name string, entity string, at time.Time) (*abstract.FeatureSet, error) {
+	fsets, err := dao.getAnyFeatureSetUsingFilter(func(fs *abstract.FeatureSet) bool {
+		return fs.Name == name && fs.Entity == entity && !fs.InsertedAt.After(at)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// feature sets are in insertion order, the last one wins on ties
+	var latest *abstract.FeatureSet
+	for i := range *fsets {
+		if latest == nil || !(*fsets)[i].InsertedAt.Before(latest.InsertedAt) {
+			latest = &(*fsets)[i]
+		}
+	}
+	return latest, nil
+}
+
+// ListAllFeatureSets ... Return all feature sets available in the store
+func (dao *dao) ListAllFeatureSets() (*[]abstract.FeatureSet, error) {
+	return dao.getAnyFeatureSetUsingFilter(func(fs *abstract.FeatureSet) bool {
+		return true
+	})
+}
+
+// UpsertSchema ... Store the schema, replacing the one registered for the same name
+func (dao *dao) UpsertSchema(schema *abstract.FeatureSetSchema) error {
+	if err := dao.Connector.Put(schemasBucket, schema.Name, schema); err != nil {
+		return fmt.Errorf("Error while upserting schema :: %v", err)
+	}
+	logging.Debug("Upserted schema for FeatureSet", "name", schema.Name)
+	return nil
+}
+
+// GetSchema ... Retrieve the schema registered for the given feature set name
+func (dao *dao) GetSchema(name string) (*abstract.FeatureSetSchema, error) {
+	schema := &abstract.FeatureSetSchema{}
+	found, err := dao.Connector.Get(schemasBucket, name, schema)
+	if err != nil {
+		return nil, fmt.Errorf("Error while retrieving schema :: %v", err)
+	}
+	if !found {
+		return nil, nil
+	}
+	return schema, nil
+}
+
+// ListFeatureSets ... Return a page of feature sets, sorted and paged in memory
+func (dao *dao) ListFeatureSets(opts *abstract.ListOptions) (*abstract.FeatureSetPage, error) {
+	fsets, err := dao.ListAllFeatureSets()
+	if err != nil {
+		return nil, err
+	}
+	return abstract.PageFeatureSets(*fsets, opts), nil
+}
@@ -0,0 +1,26 @@
+package embedded
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/featurestore/daos/daotest"
+	"github.com/pilillo/mastro/utils/conf"
+)
+
+func TestConformance(t *testing.T) {
+	daotest.RunFeatureSetDAOSuite(t, func(t *testing.T) abstract.FeatureSetDAOProvider {
+		dao := GetSingleton()
+		if err := dao.Init(&conf.DataSourceDefinition{
+			Name: "test-embedded",
+			Type: "embedded",
+			Settings: map[string]string{
+				"path": filepath.Join(t.TempDir(), "store.json"),
+			},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		return dao
+	})
+}
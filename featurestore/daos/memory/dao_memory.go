@@ -0,0 +1,153 @@
+package memory
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/utils/conf"
+)
+
+// dao ... in-memory DAO, feature sets are lost when the process terminates,
+// they are copied on write and on read so that callers never share them with the DAO
+type dao struct {
+	mutex       sync.RWMutex
+	sequence    uint64
+	featureSets []abstract.FeatureSet
+	schemas     map[string]abstract.FeatureSetSchema
+}
+
+// both init and sync.Once are thread-safe
+// but only sync.Once is lazy
+var once sync.Once
+var instance *dao
+
+// GetSingleton ... lazy singleton on DAO
+func GetSingleton() abstract.FeatureSetDAOProvider {
+	// once.do is lazy, we use it to return an instance of the DAO
+	once.Do(func() {
+		instance = &dao{}
+	})
+	return instance
+}
+
+// Init ... Initialize an empty collection, no settings are required
+func (dao *dao) Init(def *conf.DataSourceDefinition) error {
+	dao.mutex.Lock()
+	defer dao.mutex.Unlock()
+	dao.sequence = 0
+	dao.featureSets = nil
+	dao.schemas = make(map[string]abstract.FeatureSetSchema)
+	return nil
+}
+
+// Ping ... the in-memory DAO is always available
+func (dao *dao) Ping() error {
+	return nil
+}
+
+// CloseConnection ... nothing to close for the in-memory DAO
+func (dao *dao) CloseConnection() {}
+
+// Create ... Append the feature set with a new id
+func (dao *dao) Create(fs *abstract.FeatureSet) error {
+	dao.mutex.Lock()
+	defer dao.mutex.Unlock()
+
+	for _, existing := range dao.featureSets {
+		if existing.Name == fs.Name && existing.Version == fs.Version && existing.Entity == fs.Entity {
+			return fmt.Errorf("Error while creating feature set :: %w", abstract.ErrFeatureSetExists)
+		}
+	}
+
+	dao.sequence++
+	fs.ID = strconv.FormatUint(dao.sequence, 10)
+	dao.featureSets = append(dao.featureSets, fs.DeepCopy())
+	return nil
+}
+
+// getAnyFeatureSetUsingFilter ... returns all feature sets for which the filter is true, in insertion order
+func (dao *dao) getAnyFeatureSetUsingFilter(filter func(fs *abstract.FeatureSet) bool) *[]abstract.FeatureSet {
+	dao.mutex.RLock()
+	defer dao.mutex.RUnlock()
+
+	fsets := []abstract.FeatureSet{}
+	for _, fs := range dao.featureSets {
+		if filter(&fs) {
+			fsets = append(fsets, fs.DeepCopy())
+		}
+	}
+	return &fsets
+}
+
+// GetById ... Retrieve feature set by given id
+func (dao *dao) GetById(id string) (*abstract.FeatureSet, error) {
+	fsets := dao.getAnyFeatureSetUsingFilter(func(fs *abstract.FeatureSet) bool {
+		return fs.ID == id
+	})
+	if len(*fsets) == 0 {
+		return nil, fmt.Errorf("Error while retrieving feature set :: no feature set found for id %s", id)
+	}
+	return &(*fsets)[0], nil
+}
+
+// GetByName ... Retrieve all feature sets with the given name
+func (dao *dao) GetByName(name string) (*[]abstract.FeatureSet, error) {
+	return dao.getAnyFeatureSetUsingFilter(func(fs *abstract.FeatureSet) bool {
+		return fs.Name == name
+	}), nil
+}
+
+// GetLatestAt ... Retrieve the latest feature set with the given name and entity inserted at or before the given time
+func (dao *dao) GetLatestAt(name string, entity string, at time.Time) (*abstract.FeatureSet, error) {
+	fsets := dao.getAnyFeatureSetUsingFilter(func(fs *abstract.FeatureSet) bool {
+		return fs.Name == name && fs.Entity == entity && !fs.InsertedAt.After(at)
+	})
+
+	// feature sets are in insertion order, the last one wins on ties
+	var latest *abstract.FeatureSet
+	for i := range *fsets {
+		if latest == nil || !(*fsets)[i].InsertedAt.Before(latest.InsertedAt) {
+			latest = &(*fsets)[i]
+		}
+	}
+	return latest, nil
+}
+
+// ListAllFeatureSets ... Return all feature sets
+func (dao *dao) ListAllFeatureSets() (*[]abstract.FeatureSet, error) {
+	return dao.getAnyFeatureSetUsingFilter(func(fs *abstract.FeatureSet) bool {
+		return true
+	}), nil
+}
+
+// UpsertSchema ... Store the schema, replacing the one registered for the same name
+func (dao *dao) UpsertSchema(schema *abstract.FeatureSetSchema) error {
+	dao.mutex.Lock()
+	defer dao.mutex.Unlock()
+	dao.schemas[schema.Name] = schema.DeepCopy()
+	return nil
+}
+
+// GetSchema ... Retrieve the schema registered for the given feature set name
+func (dao *dao) GetSchema(name string) (*abstract.FeatureSetSchema, error) {
+	dao.mutex.RLock()
+	defer dao.mutex.RUnlock()
+	schema, exist := dao.schemas[name]
+	if !exist {
+		return nil, nil
+	}
+	copied := schema.DeepCopy()
+	return &copied, nil
+}
+
+// ListFeatureSets ... Return a page of feature sets, sorted and paged in memory
+func (dao *dao) ListFeatureSets(opts *abstract.ListOptions) (*abstract.FeatureSetPage, error) {
+	fsets, err := dao.ListAllFeatureSets()
+	if err != nil {
+		return nil, err
+	}
+	return abstract.PageFeatureSets(*fsets, opts), nil
+}
@@ -0,0 +1,47 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/featurestore/daos/daotest"
+)
+
+func TestConformance(t *testing.T) {
+	daotest.RunFeatureSetDAOSuite(t, func(t *testing.T) abstract.FeatureSetDAOProvider {
+		dao := GetSingleton()
+		if err := dao.Init(nil); err != nil {
+			t.Fatal(err)
+		}
+		return dao
+	})
+}
+
+func TestCopies(t *testing.T) {
+	dao := GetSingleton()
+	if err := dao.Init(nil); err != nil {
+		t.Fatal(err)
+	}
+	fs := &abstract.FeatureSet{Name: "customers", Version: "v1", Labels: map[string]string{"team": "sales"},
+		Features: []abstract.Feature{{Name: "age", Value: int64(42), DataType: abstract.IntType}}}
+	if err := dao.Create(fs); err != nil {
+		t.Fatal(err)
+	}
+	// changing the created feature set does not change the stored one
+	fs.Labels["team"] = "changed"
+	fs.Features[0].Value = int64(43)
+
+	stored, err := dao.GetById(fs.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Labels["team"] != "sales" || stored.Features[0].Value != int64(42) {
+		t.Errorf("expected the stored feature set not to share the created maps and slices, got %v", stored)
+	}
+	// nor does changing the retrieved one
+	stored.Features[0].Value = int64(44)
+	again, _ := dao.GetById(fs.ID)
+	if again.Features[0].Value != int64(42) {
+		t.Errorf("expected the stored feature set not to share the retrieved maps and slices, got %v", again)
+	}
+}
@@ -3,7 +3,7 @@ package mongo
 import (
 	"context"
 	"fmt"
-	"log"
+	"strings"
 	"sync"
 	"time"
 
@@ -11,19 +11,24 @@ import (
 	"github.com/pilillo/mastro/sources/mongo"
 
 	"github.com/pilillo/mastro/utils/conf"
+	"github.com/pilillo/mastro/utils/logging"
+	"github.com/pilillo/mastro/utils/retry"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	mongodriver "go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 // featureSetMongoDao ... DAO for the FeatureSet in Mongo
 type featureSetMongoDao struct {
-	//ID          primitive.ObjectID `bson:"_id,omitempty"`
-	Name        string            `bson:"name,omitempty"`
-	InsertedAt  time.Time         `bson:"inserted-at,omitempty"`
-	Version     string            `bson:"version,omitempty"`
-	Features    []featureMongoDao `bson:"features,omitempty"`
-	Description string            `bson:"description,omitempty"`
-	Labels      map[string]string `bson:"labels,omitempty"`
+	ID          primitive.ObjectID `bson:"_id,omitempty"`
+	Name        string             `bson:"name,omitempty"`
+	Entity      string             `bson:"entity,omitempty"`
+	InsertedAt  time.Time          `bson:"inserted-at,omitempty"`
+	Version     string             `bson:"version,omitempty"`
+	Features    []featureMongoDao  `bson:"features,omitempty"`
+	Description string             `bson:"description,omitempty"`
+	Labels      map[string]string  `bson:"labels,omitempty"`
 }
 
 // featureMongoDao ... a named variable with a data type
@@ -34,8 +39,27 @@ type featureMongoDao struct {
 	DataType string             `bson:"data-type,omitempty"`
 }
 
+// featureSetSchemaMongoDao ... DAO for the FeatureSetSchema in Mongo
+type featureSetSchemaMongoDao struct {
+	Name      string                  `bson:"name,omitempty"`
+	UpdatedAt time.Time               `bson:"updated-at,omitempty"`
+	Features  []featureSchemaMongoDao `bson:"features,omitempty"`
+}
+
+// featureSchemaMongoDao ... the data type and the allowed values of a feature
+type featureSchemaMongoDao struct {
+	Name     string        `bson:"name,omitempty"`
+	DataType string        `bson:"data-type,omitempty"`
+	Nullable bool          `bson:"nullable,omitempty"`
+	Min      *float64      `bson:"min,omitempty"`
+	Max      *float64      `bson:"max,omitempty"`
+	Enum     []interface{} `bson:"enum,omitempty"`
+}
+
 type dao struct {
 	Connector *mongo.Connector
+	// schemas are stored in a separate collection, named after the feature sets one
+	Schemas *mongodriver.Collection
 }
 
 var timeout = 5 * time.Second
@@ -56,6 +80,7 @@ func convertFeatureSetDTOtoDAO(fs *abstract.FeatureSet) *featureSetMongoDao {
 
 	//fsmd.ID = fs.ID // not set at time of insert
 	fsmd.Name = fs.Name
+	fsmd.Entity = fs.Entity
 	fsmd.InsertedAt = fs.InsertedAt
 	fsmd.Version = fs.Version
 
@@ -85,8 +110,9 @@ func convertFeatureDAOToDTO(fmd *featureMongoDao) *abstract.Feature {
 func convertFeatureSetDAOToDTO(fsmd *featureSetMongoDao) *abstract.FeatureSet {
 	fs := &abstract.FeatureSet{}
 
-	//fs.ID = fsmd.ID.String() // set it in DAO, propagate to DTO?
+	fs.ID = fsmd.ID.Hex()
 	fs.Name = fsmd.Name
+	fs.Entity = fsmd.Entity
 	fs.InsertedAt = fsmd.InsertedAt
 	fs.Version = fsmd.Version
 
@@ -127,15 +153,108 @@ func GetSingleton() abstract.FeatureSetDAOProvider {
 	return instance
 }
 
-func (dao *dao) Init(def *conf.DataSourceDefinition) {
+func (dao *dao) Init(def *conf.DataSourceDefinition) error {
 	// create mongo connector
 	dao.Connector = mongo.NewMongoConnector()
 	// validate data source definition
 	if err := dao.Connector.ValidateDataSourceDefinition(def); err != nil {
-		panic(err)
+		return retry.Permanent(err)
 	}
 	// init mongo connector
-	dao.Connector.InitConnection(def)
+	if err := dao.Connector.InitConnection(def); err != nil {
+		return err
+	}
+	// make sure the same version of a feature set cannot be created twice
+	if err := dao.ensureKeyIndex(); err != nil {
+		// the index cannot be built on the duplicates created before it, which retrying does not remove
+		if isDuplicateKey(err) {
+			return retry.Permanent(dao.duplicatesError(err))
+		}
+		return fmt.Errorf("Failed creating the key index :: %v", err)
+	}
+	dao.Schemas = dao.Connector.Database.Collection(dao.Connector.Collection.Name() + "-schemas")
+	return nil
+}
+
+// ensureKeyIndex ... creates the unique index on name, version and entity, a missing entity being indexed as null,
+// creating an index with the same name and keys is a no-op
+func (dao *dao) ensureKeyIndex() error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	_, err := dao.Connector.Collection.Indexes().CreateOne(ctx, mongodriver.IndexModel{
+		Keys: bson.D{{Key: "name", Value: 1}, {Key: "version", Value: 1}, {Key: "entity", Value: 1}},
+		Options: options.Index().
+			SetName("featureset-key").
+			SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("Error while creating the key index :: %v", err)
+	}
+	return nil
+}
+
+// maxReportedDuplicates ... number of duplicated keys listed when the key index cannot be built
+const maxReportedDuplicates = 10
+
+// duplicatesError ... returns the error explaining how to remove the feature sets sharing name, version and entity
+// which prevent building the key index
+func (dao *dao) duplicatesError(indexErr error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cursor, err := dao.Connector.Collection.Aggregate(ctx, []bson.M{
+		{"$group": bson.M{
+			"_id":   bson.M{"name": "$name", "version": "$version", "entity": "$entity"},
+			"count": bson.M{"$sum": 1},
+		}},
+		{"$match": bson.M{"count": bson.M{"$gt": 1}}},
+		{"$sort": bson.M{"_id.name": 1, "_id.version": 1, "_id.entity": 1}},
+		{"$limit": maxReportedDuplicates},
+	})
+	var duplicates []struct {
+		Key struct {
+			Name    string `bson:"name"`
+			Version string `bson:"version"`
+			Entity  string `bson:"entity"`
+		} `bson:"_id"`
+		Count int `bson:"count"`
+	}
+	if err == nil {
+		err = cursor.All(ctx, &duplicates)
+	}
+	if err != nil {
+		return fmt.Errorf("Failed creating the key index, as some feature sets share name, version and entity :: %v", indexErr)
+	}
+
+	var keys []string
+	for _, d := range duplicates {
+		keys = append(keys, fmt.Sprintf("%s %s (entity %q, %d copies)", d.Key.Name, d.Key.Version, d.Key.Entity, d.Count))
+	}
+	return fmt.Errorf("Failed creating the key index, as some feature sets share name, version and entity, e.g. %s :: "+
+		"keep one feature set per name, version and entity in collection %s and restart :: %v",
+		strings.Join(keys, ", "), dao.Connector.Collection.Name(), indexErr)
+}
+
+// duplicateKeyCode ... error code returned by mongo when violating a unique index
+const duplicateKeyCode = 11000
+
+func isDuplicateKey(err error) bool {
+	// building a unique index on duplicates fails with a command error
+	if ce, isCommandError := err.(mongodriver.CommandError); isCommandError {
+		return ce.Code == duplicateKeyCode
+	}
+	if we, isWriteException := err.(mongodriver.WriteException); isWriteException {
+		for _, e := range we.WriteErrors {
+			if e.Code == duplicateKeyCode {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Ping ... pings the backend through the connector
+func (dao *dao) Ping() error {
+	return dao.Connector.Ping()
 }
 
 func (dao *dao) CloseConnection() {
@@ -152,15 +271,20 @@ func (dao *dao) Create(fs *abstract.FeatureSet) error {
 		return err
 	}
 
-	// insert
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
+
+	// insert, the key index rejecting an existing name, version and entity
 	res, err := dao.Connector.Collection.InsertOne(ctx, bsonVal)
+	if isDuplicateKey(err) {
+		return fmt.Errorf("Error while creating feature set :: %w", abstract.ErrFeatureSetExists)
+	}
 	if err != nil {
 		return fmt.Errorf("Error while creating feature set :: %v", err)
 	}
-	id := res.InsertedID
-	log.Printf("Inserted FeatureSet %d", id)
+	id := res.InsertedID.(primitive.ObjectID)
+	fs.ID = id.Hex()
+	logging.Debug("Inserted FeatureSet", "id", fs.ID)
 	return nil
 }
 
@@ -177,12 +301,16 @@ func (dao *dao) getOneDocumentUsingFilter(filter interface{}) (*abstract.Feature
 	return convertFeatureSetDAOToDTO(&result), nil
 }
 
-func (dao *dao) getAnyDocumentUsingFilter(filter interface{}) (*[]abstract.FeatureSet, error) {
+func (dao *dao) getAnyDocumentUsingFilter(filter interface{}, opts ...*options.FindOptions) (*[]abstract.FeatureSet, error) {
 	var features []featureSetMongoDao
 
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
-	cursor, err := dao.Connector.Collection.Find(ctx, filter)
+	// return feature sets in insertion order, unless a different order is given
+	opts = append([]*options.FindOptions{
+		options.Find().SetSort(bson.D{{Key: "inserted-at", Value: 1}, {Key: "_id", Value: 1}}),
+	}, opts...)
+	cursor, err := dao.Connector.Collection.Find(ctx, filter, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -190,6 +318,10 @@ func (dao *dao) getAnyDocumentUsingFilter(filter interface{}) (*[]abstract.Featu
 	if err = cursor.All(ctx, &features); err != nil {
 		return nil, err
 	}
+	// return an empty list rather than a nil one if nothing matched
+	if features == nil {
+		return &[]abstract.FeatureSet{}, nil
+	}
 
 	var resultFeats []abstract.FeatureSet = convertAllFeatureSets(&features)
 	return &resultFeats, nil
@@ -197,7 +329,11 @@ func (dao *dao) getAnyDocumentUsingFilter(filter interface{}) (*[]abstract.Featu
 
 // GetById ... Retrieve document by given id
 func (dao *dao) GetById(id string) (*abstract.FeatureSet, error) {
-	filter := bson.M{"_id": id}
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("Error while retrieving feature set :: invalid id %s", id)
+	}
+	filter := bson.M{"_id": objectID}
 	return dao.getOneDocumentUsingFilter(filter)
 }
 
@@ -207,8 +343,126 @@ func (dao *dao) GetByName(name string) (*[]abstract.FeatureSet, error) {
 	return dao.getAnyDocumentUsingFilter(filter)
 }
 
+// GetLatestAt ... Retrieve the latest document with given name and entity inserted at or before the given time
+func (dao *dao) GetLatestAt(name string, entity string, at time.Time) (*abstract.FeatureSet, error) {
+	filter := bson.M{
+		"name":        name,
+		"inserted-at": bson.M{"$lte": at},
+	}
+	// the entity is omitted when empty, and a null filter also matches missing fields
+	if entity == "" {
+		filter["entity"] = nil
+	} else {
+		filter["entity"] = entity
+	}
+
+	var result featureSetMongoDao
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	opts := options.FindOne().SetSort(bson.D{{Key: "inserted-at", Value: -1}, {Key: "_id", Value: -1}})
+	err := dao.Connector.Collection.FindOne(ctx, filter, opts).Decode(&result)
+	if err == mongodriver.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error while retrieving feature set :: %v", err)
+	}
+	return convertFeatureSetDAOToDTO(&result), nil
+}
+
+// bsonFields ... bson field for each json field of the feature set, used to sort and project documents
+var bsonFields = map[string]string{
+	"id":          "_id",
+	"name":        "name",
+	"entity":      "entity",
+	"inserted_at": "inserted-at",
+	"inserted-at": "inserted-at",
+	"version":     "version",
+	"features":    "features",
+	"description": "description",
+	"labels":      "labels",
+}
+
+// ListFeatureSets ... Return a page of documents, the _id breaks ties
+func (dao *dao) ListFeatureSets(opts *abstract.ListOptions) (*abstract.FeatureSetPage, error) {
+	order := 1
+	if opts.Descending {
+		order = -1
+	}
+	offset := opts.StartOffset()
+	// retrieve one more document to know if a next page exists
+	findOpts := options.Find().
+		SetSort(bson.D{{Key: bsonFields[opts.SortBy], Value: order}, {Key: "_id", Value: order}}).
+		SetSkip(int64(offset)).
+		SetLimit(int64(opts.Limit + 1))
+	if len(opts.Fields) > 0 {
+		projection := bson.M{}
+		for _, f := range opts.Fields {
+			projection[bsonFields[f]] = 1
+		}
+		findOpts.SetProjection(projection)
+	}
+
+	fsets, err := dao.getAnyDocumentUsingFilter(bson.M{}, findOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	more := len(*fsets) > opts.Limit
+	if more {
+		*fsets = (*fsets)[:opts.Limit]
+	}
+	return &abstract.FeatureSetPage{
+		FeatureSets: *fsets,
+		NextCursor:  opts.NextOffsetCursor(offset, more),
+	}, nil
+}
+
 // ListAllFeatureSets ... Return all feature sets available in collection
 func (dao *dao) ListAllFeatureSets() (*[]abstract.FeatureSet, error) {
 	filter := bson.M{}
 	return dao.getAnyDocumentUsingFilter(filter)
 }
+
+// UpsertSchema ... Replace the schema document with the same name, or insert it if missing
+func (dao *dao) UpsertSchema(schema *abstract.FeatureSetSchema) error {
+	fssmd := featureSetSchemaMongoDao{
+		Name:      schema.Name,
+		UpdatedAt: schema.UpdatedAt,
+	}
+	for _, f := range schema.Features {
+		fssmd.Features = append(fssmd.Features, featureSchemaMongoDao(f))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	opts := options.Replace().SetUpsert(true)
+	if _, err := dao.Schemas.ReplaceOne(ctx, bson.M{"name": schema.Name}, fssmd, opts); err != nil {
+		return fmt.Errorf("Error while upserting schema :: %v", err)
+	}
+	logging.Debug("Upserted schema for FeatureSet", "name", schema.Name)
+	return nil
+}
+
+// GetSchema ... Retrieve the schema document with the given feature set name
+func (dao *dao) GetSchema(name string) (*abstract.FeatureSetSchema, error) {
+	var result featureSetSchemaMongoDao
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	err := dao.Schemas.FindOne(ctx, bson.M{"name": name}).Decode(&result)
+	if err == mongodriver.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error while retrieving schema :: %v", err)
+	}
+
+	schema := &abstract.FeatureSetSchema{
+		Name:      result.Name,
+		UpdatedAt: result.UpdatedAt,
+	}
+	for _, f := range result.Features {
+		schema.Features = append(schema.Features, abstract.FeatureSchema(f))
+	}
+	return schema, nil
+}
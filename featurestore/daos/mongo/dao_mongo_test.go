@@ -0,0 +1,44 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/featurestore/daos/daotest"
+	"github.com/pilillo/mastro/utils/conf"
+)
+
+// TestConformance ... runs against a live mongo, e.g. the one of integration_tests/start_mongo.sh,
+// by setting MASTRO_TEST_MONGO_HOST=localhost:27017
+func TestConformance(t *testing.T) {
+	host, exist := os.LookupEnv("MASTRO_TEST_MONGO_HOST")
+	if !exist {
+		t.Skip("MASTRO_TEST_MONGO_HOST not set, skipping mongo integration test")
+	}
+
+	daotest.RunFeatureSetDAOSuite(t, func(t *testing.T) abstract.FeatureSetDAOProvider {
+		dao := GetSingleton()
+		if err := dao.Init(&conf.DataSourceDefinition{
+			Name: "test-mongo",
+			Type: "mongo",
+			Settings: map[string]string{
+				"username":   "mongo",
+				"password":   "test",
+				"host":       host,
+				"database":   "mastro",
+				"collection": fmt.Sprintf("mastro-test-%d", time.Now().UnixNano()),
+			},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			instance.Connector.Collection.Drop(context.Background())
+			instance.Schemas.Drop(context.Background())
+		})
+		return dao
+	})
+}
@@ -0,0 +1,383 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/sources/postgres"
+	"github.com/pilillo/mastro/utils/conf"
+	"github.com/pilillo/mastro/utils/logging"
+	"github.com/pilillo/mastro/utils/retry"
+)
+
+// featureSetPostgresDao ... row of the feature_sets table
+type featureSetPostgresDao struct {
+	ID          int64
+	Name        string
+	Entity      string
+	Version     string
+	InsertedAt  time.Time
+	Description string
+	Labels      []byte
+}
+
+// featurePostgresDao ... row of the features table
+type featurePostgresDao struct {
+	FeatureSetID int64
+	Name         string
+	Value        []byte
+	DataType     string
+}
+
+type dao struct {
+	Connector *postgres.Connector
+}
+
+// both init and sync.Once are thread-safe
+// but only sync.Once is lazy
+var once sync.Once
+var instance *dao
+
+// GetSingleton ... lazy singleton on DAO
+func GetSingleton() abstract.FeatureSetDAOProvider {
+	// once.do is lazy, we use it to return an instance of the DAO
+	once.Do(func() {
+		instance = &dao{}
+	})
+	return instance
+}
+
+// Init ... Initialize connection to postgres and migrate the schema
+func (dao *dao) Init(def *conf.DataSourceDefinition) error {
+	// create postgres connector
+	dao.Connector = postgres.NewPostgresConnector()
+	// validate data source definition
+	if err := dao.Connector.ValidateDataSourceDefinition(def); err != nil {
+		return retry.Permanent(err)
+	}
+	// init postgres connector
+	if err := dao.Connector.InitConnection(def); err != nil {
+		return err
+	}
+	// bring the schema to the latest version
+	if err := migrate(dao.Connector.DB, dao.Connector.Schema); err != nil {
+		return fmt.Errorf("Failed migrating the schema %s :: %v", dao.Connector.Schema, err)
+	}
+	return nil
+}
+
+// Ping ... pings the backend through the connector
+func (dao *dao) Ping() error {
+	return dao.Connector.Ping()
+}
+
+// CloseConnection ... Terminates the connection to postgres for the DAO
+func (dao *dao) CloseConnection() {
+	dao.Connector.CloseConnection()
+}
+
+// Create ... Insert the feature set and its features in a single transaction
+func (dao *dao) Create(fs *abstract.FeatureSet) error {
+	labels, err := json.Marshal(fs.Labels)
+	if err != nil {
+		return err
+	}
+
+	tx, err := dao.Connector.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("Error while creating feature set :: %v", err)
+	}
+
+	// serialize creates of the same feature set name, so that the check for existing ones is not raced
+	if _, err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext($1))", fs.Name); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("Error while creating feature set :: %v", err)
+	}
+	var exists bool
+	err = tx.QueryRow(
+		fmt.Sprintf(
+			"SELECT EXISTS (SELECT 1 FROM %s WHERE name = $1 AND version = $2 AND entity = $3)",
+			dao.Connector.Table("feature_sets"),
+		),
+		fs.Name, fs.Version, fs.Entity,
+	).Scan(&exists)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("Error while creating feature set :: %v", err)
+	}
+	if exists {
+		tx.Rollback()
+		return fmt.Errorf("Error while creating feature set :: %w", abstract.ErrFeatureSetExists)
+	}
+
+	var id int64
+	err = tx.QueryRow(
+		fmt.Sprintf(
+			"INSERT INTO %s (name, entity, version, inserted_at, description, labels) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id",
+			dao.Connector.Table("feature_sets"),
+		),
+		fs.Name, fs.Entity, fs.Version, fs.InsertedAt, fs.Description, labels,
+	).Scan(&id)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("Error while creating feature set :: %v", err)
+	}
+
+	insertFeature := fmt.Sprintf(
+		"INSERT INTO %s (feature_set_id, name, value, data_type) VALUES ($1, $2, $3, $4)",
+		dao.Connector.Table("features"),
+	)
+	for _, f := range fs.Features {
+		value, err := json.Marshal(f.Value)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if _, err := tx.Exec(insertFeature, id, f.Name, value, f.DataType); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("Error while creating feature %s :: %v", f.Name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("Error while creating feature set :: %v", err)
+	}
+
+	fs.ID = strconv.FormatInt(id, 10)
+	logging.Debug("Inserted FeatureSet", "id", id)
+	return nil
+}
+
+// getFeatureSets ... runs the provided select on the feature_sets table and attaches all features,
+// the clauses following the table name must include the order by
+func (dao *dao) getFeatureSets(clauses string, args ...interface{}) ([]abstract.FeatureSet, error) {
+	rows, err := dao.Connector.DB.Query(
+		fmt.Sprintf(
+			"SELECT id, name, entity, version, inserted_at, description, labels FROM %s %s",
+			dao.Connector.Table("feature_sets"), clauses,
+		),
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Error while retrieving feature set :: %v", err)
+	}
+	defer rows.Close()
+
+	var fsets []featureSetPostgresDao
+	var ids []int64
+	for rows.Next() {
+		fspd := featureSetPostgresDao{}
+		if err := rows.Scan(&fspd.ID, &fspd.Name, &fspd.Entity, &fspd.Version, &fspd.InsertedAt, &fspd.Description, &fspd.Labels); err != nil {
+			return nil, fmt.Errorf("Error while retrieving feature set :: %v", err)
+		}
+		fsets = append(fsets, fspd)
+		ids = append(ids, fspd.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Error while retrieving feature set :: %v", err)
+	}
+
+	features, err := dao.getFeatures(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []abstract.FeatureSet{}
+	for _, fspd := range fsets {
+		fs, err := convertFeatureSetDAOToDTO(&fspd, features[fspd.ID])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *fs)
+	}
+	return result, nil
+}
+
+// getFeatures ... retrieves all features for the given feature set ids, grouped by feature set id
+func (dao *dao) getFeatures(ids []int64) (map[int64][]featurePostgresDao, error) {
+	features := make(map[int64][]featurePostgresDao)
+	if len(ids) == 0 {
+		return features, nil
+	}
+
+	rows, err := dao.Connector.DB.Query(
+		fmt.Sprintf(
+			"SELECT feature_set_id, name, value, data_type FROM %s WHERE feature_set_id = ANY($1) ORDER BY id",
+			dao.Connector.Table("features"),
+		),
+		pq.Array(ids),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Error while retrieving features :: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		fpd := featurePostgresDao{}
+		if err := rows.Scan(&fpd.FeatureSetID, &fpd.Name, &fpd.Value, &fpd.DataType); err != nil {
+			return nil, fmt.Errorf("Error while retrieving features :: %v", err)
+		}
+		features[fpd.FeatureSetID] = append(features[fpd.FeatureSetID], fpd)
+	}
+	return features, rows.Err()
+}
+
+func convertFeatureSetDAOToDTO(fspd *featureSetPostgresDao, features []featurePostgresDao) (*abstract.FeatureSet, error) {
+	fs := &abstract.FeatureSet{}
+
+	fs.ID = strconv.FormatInt(fspd.ID, 10)
+	fs.Name = fspd.Name
+	fs.Entity = fspd.Entity
+	fs.Version = fspd.Version
+	fs.InsertedAt = fspd.InsertedAt.UTC()
+	fs.Description = fspd.Description
+	if len(fspd.Labels) > 0 {
+		if err := json.Unmarshal(fspd.Labels, &fs.Labels); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, fpd := range features {
+		f := abstract.Feature{}
+		f.Name = fpd.Name
+		f.DataType = fpd.DataType
+		if len(fpd.Value) > 0 {
+			if err := json.Unmarshal(fpd.Value, &f.Value); err != nil {
+				return nil, err
+			}
+		}
+		fs.Features = append(fs.Features, f)
+	}
+	return fs, nil
+}
+
+// GetById ... Retrieve feature set by given id
+func (dao *dao) GetById(id string) (*abstract.FeatureSet, error) {
+	numericID, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("Error while retrieving feature set :: invalid id %s", id)
+	}
+
+	fsets, err := dao.getFeatureSets("WHERE id = $1 ORDER BY id", numericID)
+	if err != nil {
+		return nil, err
+	}
+	if len(fsets) == 0 {
+		return nil, fmt.Errorf("Error while retrieving feature set :: %v", sql.ErrNoRows)
+	}
+	return &fsets[0], nil
+}
+
+// GetByName ... Retrieve all feature sets with the given name
+func (dao *dao) GetByName(name string) (*[]abstract.FeatureSet, error) {
+	fsets, err := dao.getFeatureSets("WHERE name = $1 ORDER BY inserted_at, id", name)
+	if err != nil {
+		return nil, err
+	}
+	return &fsets, nil
+}
+
+// GetLatestAt ... Retrieve the latest feature set with given name and entity inserted at or before the given time
+func (dao *dao) GetLatestAt(name string, entity string, at time.Time) (*abstract.FeatureSet, error) {
+	fsets, err := dao.getFeatureSets(
+		"WHERE name = $1 AND entity = $2 AND inserted_at <= $3 ORDER BY inserted_at DESC, id DESC LIMIT 1",
+		name, entity, at,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(fsets) == 0 {
+		return nil, nil
+	}
+	return &fsets[0], nil
+}
+
+// sortColumns ... columns of the feature_sets table for each sort key
+var sortColumns = map[string]string{
+	abstract.SortByName:       "name",
+	abstract.SortByInsertedAt: "inserted_at",
+}
+
+// ListFeatureSets ... Return a page of feature sets, the id breaks ties
+func (dao *dao) ListFeatureSets(opts *abstract.ListOptions) (*abstract.FeatureSetPage, error) {
+	order := "ASC"
+	if opts.Descending {
+		order = "DESC"
+	}
+	offset := opts.StartOffset()
+	// retrieve one more feature set to know if a next page exists
+	fsets, err := dao.getFeatureSets(
+		fmt.Sprintf("ORDER BY %s %s, id %s LIMIT $1 OFFSET $2", sortColumns[opts.SortBy], order, order),
+		opts.Limit+1, offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	more := len(fsets) > opts.Limit
+	if more {
+		fsets = fsets[:opts.Limit]
+	}
+	return &abstract.FeatureSetPage{
+		FeatureSets: fsets,
+		NextCursor:  opts.NextOffsetCursor(offset, more),
+	}, nil
+}
+
+// ListAllFeatureSets ... Return all feature sets available in the table
+func (dao *dao) ListAllFeatureSets() (*[]abstract.FeatureSet, error) {
+	fsets, err := dao.getFeatureSets("ORDER BY inserted_at, id")
+	if err != nil {
+		return nil, err
+	}
+	return &fsets, nil
+}
+
+// UpsertSchema ... Store the schema, replacing the one registered for the same name
+func (dao *dao) UpsertSchema(schema *abstract.FeatureSetSchema) error {
+	features, err := json.Marshal(schema.Features)
+	if err != nil {
+		return err
+	}
+
+	_, err = dao.Connector.DB.Exec(
+		fmt.Sprintf(
+			"INSERT INTO %s (name, updated_at, features) VALUES ($1, $2, $3) ON CONFLICT (name) DO UPDATE SET updated_at = EXCLUDED.updated_at, features = EXCLUDED.features",
+			dao.Connector.Table("feature_set_schemas"),
+		),
+		schema.Name, schema.UpdatedAt, features,
+	)
+	if err != nil {
+		return fmt.Errorf("Error while upserting schema :: %v", err)
+	}
+	logging.Debug("Upserted schema for FeatureSet", "name", schema.Name)
+	return nil
+}
+
+// GetSchema ... Retrieve the schema registered for the given feature set name
+func (dao *dao) GetSchema(name string) (*abstract.FeatureSetSchema, error) {
+	schema := &abstract.FeatureSetSchema{}
+	var features []byte
+	err := dao.Connector.DB.QueryRow(
+		fmt.Sprintf("SELECT name, updated_at, features FROM %s WHERE name = $1", dao.Connector.Table("feature_set_schemas")),
+		name,
+	).Scan(&schema.Name, &schema.UpdatedAt, &features)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error while retrieving schema :: %v", err)
+	}
+
+	schema.UpdatedAt = schema.UpdatedAt.UTC()
+	if err := json.Unmarshal(features, &schema.Features); err != nil {
+		return nil, fmt.Errorf("Error while retrieving schema :: %v", err)
+	}
+	return schema, nil
+}
@@ -0,0 +1,44 @@
+package postgres
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/featurestore/daos/daotest"
+	"github.com/pilillo/mastro/utils/conf"
+)
+
+// TestConformance ... runs against a live postgres, e.g. the one of integration_tests/start_postgres.sh,
+// by setting MASTRO_TEST_POSTGRES_HOST=localhost:54300
+func TestConformance(t *testing.T) {
+	host, exist := os.LookupEnv("MASTRO_TEST_POSTGRES_HOST")
+	if !exist {
+		t.Skip("MASTRO_TEST_POSTGRES_HOST not set, skipping postgres integration test")
+	}
+
+	daotest.RunFeatureSetDAOSuite(t, func(t *testing.T) abstract.FeatureSetDAOProvider {
+		dao := GetSingleton()
+		if err := dao.Init(&conf.DataSourceDefinition{
+			Name: "test-postgres",
+			Type: "postgres",
+			Settings: map[string]string{
+				"username": "postgres",
+				"password": "test",
+				"host":     host,
+				"database": "features",
+				"schema":   fmt.Sprintf("mastro_test_%d", time.Now().UnixNano()),
+			},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() {
+			instance.Connector.DB.Exec(fmt.Sprintf("DROP SCHEMA %s CASCADE", pq.QuoteIdentifier(instance.Connector.Schema)))
+			instance.Connector.CloseConnection()
+		})
+		return dao
+	})
+}
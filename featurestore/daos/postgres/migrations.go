@@ -0,0 +1,122 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/pilillo/mastro/utils/logging"
+)
+
+// migration ... a versioned set of statements to evolve the featurestore schema
+// statements can refer to the target schema and to its tables using the {{schema}} placeholder
+type migration struct {
+	version     int
+	description string
+	statements  []string
+}
+
+// migrations ... ordered list of schema migrations, append new ones at the end and never modify applied ones
+var migrations = []migration{
+	{
+		version:     1,
+		description: "create feature sets and features tables",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS {{schema}}.feature_sets (
+				id BIGSERIAL PRIMARY KEY,
+				name TEXT NOT NULL,
+				version TEXT NOT NULL,
+				inserted_at TIMESTAMPTZ NOT NULL,
+				description TEXT NOT NULL DEFAULT '',
+				labels JSONB
+			)`,
+			`CREATE INDEX IF NOT EXISTS feature_sets_name_idx ON {{schema}}.feature_sets (name)`,
+			`CREATE TABLE IF NOT EXISTS {{schema}}.features (
+				id BIGSERIAL PRIMARY KEY,
+				feature_set_id BIGINT NOT NULL REFERENCES {{schema}}.feature_sets (id) ON DELETE CASCADE,
+				name TEXT NOT NULL,
+				value JSONB,
+				data_type TEXT NOT NULL
+			)`,
+			`CREATE INDEX IF NOT EXISTS features_feature_set_id_idx ON {{schema}}.features (feature_set_id)`,
+		},
+	},
+	{
+		version:     2,
+		description: "add entity key to feature sets",
+		statements: []string{
+			`ALTER TABLE {{schema}}.feature_sets ADD COLUMN IF NOT EXISTS entity TEXT NOT NULL DEFAULT ''`,
+			`CREATE INDEX IF NOT EXISTS feature_sets_name_entity_inserted_at_idx ON {{schema}}.feature_sets (name, entity, inserted_at)`,
+		},
+	},
+	{
+		version:     3,
+		description: "create feature set schemas table",
+		statements: []string{
+			`CREATE TABLE IF NOT EXISTS {{schema}}.feature_set_schemas (
+				name TEXT PRIMARY KEY,
+				updated_at TIMESTAMPTZ NOT NULL,
+				features JSONB NOT NULL
+			)`,
+		},
+	},
+}
+
+// migrate ... creates the schema if missing and applies any pending migration, each in its own transaction
+func migrate(db *sql.DB, schema string) error {
+	quotedSchema := pq.QuoteIdentifier(schema)
+
+	if _, err := db.Exec(fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", quotedSchema)); err != nil {
+		return fmt.Errorf("Error while creating schema %s :: %v", schema, err)
+	}
+
+	if _, err := db.Exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s.schema_migrations (
+		version INTEGER PRIMARY KEY,
+		description TEXT NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`, quotedSchema)); err != nil {
+		return fmt.Errorf("Error while creating migrations table :: %v", err)
+	}
+
+	var current int
+	row := db.QueryRow(fmt.Sprintf("SELECT COALESCE(MAX(version), 0) FROM %s.schema_migrations", quotedSchema))
+	if err := row.Scan(&current); err != nil {
+		return fmt.Errorf("Error while retrieving current schema version :: %v", err)
+	}
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		if err := applyMigration(db, quotedSchema, m); err != nil {
+			return fmt.Errorf("Error while applying migration %d (%s) :: %v", m.version, m.description, err)
+		}
+		logging.Info("Applied migration", "version", m.version, "description", m.description)
+	}
+	return nil
+}
+
+func applyMigration(db *sql.DB, quotedSchema string, m migration) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	for _, statement := range m.statements {
+		if _, err := tx.Exec(strings.Replace(statement, "{{schema}}", quotedSchema, -1)); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(
+		fmt.Sprintf("INSERT INTO %s.schema_migrations (version, description) VALUES ($1, $2)", quotedSchema),
+		m.version, m.description,
+	); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
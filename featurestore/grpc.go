@@ -0,0 +1,212 @@
+package featurestore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/proto/featurestorepb"
+	"github.com/pilillo/mastro/utils/auth"
+	"github.com/pilillo/mastro/utils/conf"
+	"github.com/pilillo/mastro/utils/errors"
+	"github.com/pilillo/mastro/utils/grpc"
+	"github.com/pilillo/mastro/utils/queries"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcPortKey ... setting of the details with the port of the gRPC server, which is not started if missing
+const grpcPortKey = "grpc-port"
+
+// grpcPermissions ... permission required by each method of the feature store service
+var grpcPermissions = map[string]auth.Permission{
+	"CreateFeatureSet":       auth.Write,
+	"GetFeatureSetByID":      auth.Read,
+	"GetFeatureSetByName":    auth.Read,
+	"GetFeatureSetByVersion": auth.Read,
+	"GetFeatureSetsAt":       auth.Read,
+	"GetOnlineFeatureSet":    auth.Read,
+	"UpsertFeatureSetSchema": auth.Write,
+	"GetFeatureSetSchema":    auth.Read,
+	"ListAllFeatureSets":     auth.Read,
+	"StreamFeatureSets":      auth.Read,
+}
+
+// startGRPC ... serves the gRPC methods in the background, if a port is set in the details, returning nil otherwise
+func startGRPC(cfg *conf.Config, authz *auth.Middleware) (*grpc.Server, error) {
+	port, exist := cfg.Details[grpcPortKey]
+	if !exist || port == "" {
+		return nil, nil
+	}
+	server := grpc.NewServer(authz)
+	if err := server.Register(&featurestorepb.FeatureStore_ServiceDesc, featureStoreServer{}, grpcPermissions); err != nil {
+		return nil, err
+	}
+	if err := server.Listen(fmt.Sprintf(":%s", port)); err != nil {
+		return nil, err
+	}
+	return server, nil
+}
+
+// serviceForCall ... returns the feature set service tracing its calls within the context of the call
+func serviceForCall(ctx context.Context) Service {
+	return withContext(featureSetService, ctx)
+}
+
+// internalError ... the status of a reply which cannot be converted to a message
+func internalError(err error) error {
+	return status.Error(codes.Internal, err.Error())
+}
+
+// featureSetReply ... converts the feature set retrieved by the service to a reply
+func featureSetReply(fs *abstract.FeatureSet, restErr *errors.RestErr) (*featurestorepb.FeatureSet, error) {
+	if restErr != nil {
+		return nil, grpc.FromRestErr(restErr)
+	}
+	m, err := toFeatureSetMessage(fs)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return m, nil
+}
+
+// schemaReply ... converts the schema retrieved by the service to a reply
+func schemaReply(schema *abstract.FeatureSetSchema, restErr *errors.RestErr) (*featurestorepb.FeatureSetSchema, error) {
+	if restErr != nil {
+		return nil, grpc.FromRestErr(restErr)
+	}
+	m, err := toSchemaMessage(schema)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return m, nil
+}
+
+// featureStoreServer ... implements the generated feature store service on the feature set service
+type featureStoreServer struct {
+	featurestorepb.UnimplementedFeatureStoreServer
+}
+
+func (featureStoreServer) CreateFeatureSet(ctx context.Context, req *featurestorepb.FeatureSet) (*featurestorepb.FeatureSet, error) {
+	return featureSetReply(serviceForCall(ctx).CreateFeatureSet(fromFeatureSetMessage(req)))
+}
+
+func (featureStoreServer) GetFeatureSetByID(ctx context.Context, req *featurestorepb.GetFeatureSetByIDRequest) (*featurestorepb.FeatureSet, error) {
+	return featureSetReply(serviceForCall(ctx).GetFeatureSetByID(req.Id))
+}
+
+func (featureStoreServer) GetFeatureSetByName(ctx context.Context, req *featurestorepb.GetFeatureSetByNameRequest) (*featurestorepb.FeatureSetList, error) {
+	fsets, restErr := serviceForCall(ctx).GetFeatureSetByName(req.Name)
+	if restErr != nil {
+		return nil, grpc.FromRestErr(restErr)
+	}
+	messages, err := toFeatureSetMessages(*fsets)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return &featurestorepb.FeatureSetList{FeatureSets: messages}, nil
+}
+
+func (featureStoreServer) GetFeatureSetByVersion(ctx context.Context, req *featurestorepb.GetFeatureSetByVersionRequest) (*featurestorepb.FeatureSet, error) {
+	return featureSetReply(serviceForCall(ctx).GetFeatureSetByVersion(req.Name, req.Version, req.Entity))
+}
+
+func (featureStoreServer) GetFeatureSetsAt(ctx context.Context, req *featurestorepb.GetFeatureSetsAtRequest) (*featurestorepb.PointInTimeFeatureSetList, error) {
+	fsets, restErr := serviceForCall(ctx).GetFeatureSetsAt(req.Name, fromEntityTimestampMessages(req.Requests))
+	if restErr != nil {
+		return nil, grpc.FromRestErr(restErr)
+	}
+	list, err := toPointInTimeMessages(*fsets)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return list, nil
+}
+
+func (featureStoreServer) GetOnlineFeatureSet(ctx context.Context, req *featurestorepb.GetOnlineFeatureSetRequest) (*featurestorepb.FeatureSet, error) {
+	return featureSetReply(serviceForCall(ctx).GetOnlineFeatureSet(req.Name, req.Entity))
+}
+
+func (featureStoreServer) UpsertFeatureSetSchema(ctx context.Context, req *featurestorepb.FeatureSetSchema) (*featurestorepb.FeatureSetSchema, error) {
+	return schemaReply(serviceForCall(ctx).UpsertFeatureSetSchema(fromSchemaMessage(req)))
+}
+
+func (featureStoreServer) GetFeatureSetSchema(ctx context.Context, req *featurestorepb.GetFeatureSetSchemaRequest) (*featurestorepb.FeatureSetSchema, error) {
+	return schemaReply(serviceForCall(ctx).GetFeatureSetSchema(req.Name))
+}
+
+// listOptions ... converts a list request to the options of the service
+func listOptions(req *featurestorepb.ListRequest) *abstract.ListOptions {
+	query := queries.List{Limit: int(req.Limit), Offset: int(req.Offset), Cursor: req.Cursor, Sort: req.Sort, Fields: strings.Join(req.Fields, ",")}
+	return query.ToListOptions()
+}
+
+// projectFeatureSets ... returns the feature sets with only the given fields set, all if none is given
+func projectFeatureSets(fsets []abstract.FeatureSet, fields []string) ([]abstract.FeatureSet, error) {
+	if len(fields) == 0 {
+		return fsets, nil
+	}
+	projected, err := abstract.Project(fsets, fields)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(projected)
+	if err != nil {
+		return nil, err
+	}
+	result := []abstract.FeatureSet{}
+	return result, json.Unmarshal(data, &result)
+}
+
+func (featureStoreServer) ListAllFeatureSets(ctx context.Context, req *featurestorepb.ListRequest) (*featurestorepb.FeatureSetPage, error) {
+	opts := listOptions(req)
+	page, restErr := serviceForCall(ctx).ListAllFeatureSets(opts)
+	if restErr != nil {
+		return nil, grpc.FromRestErr(restErr)
+	}
+	fsets, err := projectFeatureSets(page.FeatureSets, opts.Fields)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	messages, err := toFeatureSetMessages(fsets)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return &featurestorepb.FeatureSetPage{FeatureSets: messages, NextCursor: page.NextCursor}, nil
+}
+
+// StreamFeatureSets ... sends all featuresets one by one, going through the pages of the given size and sort
+func (featureStoreServer) StreamFeatureSets(req *featurestorepb.ListRequest, stream featurestorepb.FeatureStore_StreamFeatureSetsServer) error {
+	s := serviceForCall(stream.Context())
+	opts := listOptions(req)
+	for {
+		page, restErr := s.ListAllFeatureSets(opts)
+		if restErr != nil {
+			if restErr.Status == http.StatusNotFound {
+				// the collection is empty or the last page was full
+				return nil
+			}
+			return grpc.FromRestErr(restErr)
+		}
+		fsets, err := projectFeatureSets(page.FeatureSets, opts.Fields)
+		if err != nil {
+			return internalError(err)
+		}
+		for i := range fsets {
+			m, err := toFeatureSetMessage(&fsets[i])
+			if err != nil {
+				return internalError(err)
+			}
+			if err := stream.Send(m); err != nil {
+				return err
+			}
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		opts.Cursor = page.NextCursor
+	}
+}
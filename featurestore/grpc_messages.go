@@ -0,0 +1,152 @@
+package featurestore
+
+import (
+	"fmt"
+
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/proto/featurestorepb"
+	"github.com/pilillo/mastro/utils/grpc"
+)
+
+// toFeatureValueMessage ... converts a feature value to a message, of its data type if any, unset if nil
+func toFeatureValueMessage(value interface{}, dataType string) (*featurestorepb.FeatureValue, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if canonical, err := abstract.CanonicalDataType(dataType); err == nil {
+		if converted, err := abstract.ConvertValue(value, canonical); err == nil {
+			value = converted
+		}
+	}
+	switch v := value.(type) {
+	case bool:
+		return &featurestorepb.FeatureValue{Kind: &featurestorepb.FeatureValue_BoolValue{BoolValue: v}}, nil
+	case int:
+		return &featurestorepb.FeatureValue{Kind: &featurestorepb.FeatureValue_IntValue{IntValue: int64(v)}}, nil
+	case int32:
+		return &featurestorepb.FeatureValue{Kind: &featurestorepb.FeatureValue_IntValue{IntValue: int64(v)}}, nil
+	case int64:
+		return &featurestorepb.FeatureValue{Kind: &featurestorepb.FeatureValue_IntValue{IntValue: v}}, nil
+	case float32:
+		return &featurestorepb.FeatureValue{Kind: &featurestorepb.FeatureValue_FloatValue{FloatValue: float64(v)}}, nil
+	case float64:
+		return &featurestorepb.FeatureValue{Kind: &featurestorepb.FeatureValue_FloatValue{FloatValue: v}}, nil
+	case string:
+		return &featurestorepb.FeatureValue{Kind: &featurestorepb.FeatureValue_StringValue{StringValue: v}}, nil
+	}
+	return nil, fmt.Errorf("Value %v of type %T is not supported", value, value)
+}
+
+func fromFeatureValueMessage(m *featurestorepb.FeatureValue) interface{} {
+	switch kind := m.GetKind().(type) {
+	case *featurestorepb.FeatureValue_BoolValue:
+		return kind.BoolValue
+	case *featurestorepb.FeatureValue_IntValue:
+		return kind.IntValue
+	case *featurestorepb.FeatureValue_FloatValue:
+		return kind.FloatValue
+	case *featurestorepb.FeatureValue_StringValue:
+		return kind.StringValue
+	}
+	return nil
+}
+
+func toFeatureSetMessage(fs *abstract.FeatureSet) (*featurestorepb.FeatureSet, error) {
+	m := &featurestorepb.FeatureSet{
+		Id:          fs.ID,
+		Name:        fs.Name,
+		Entity:      fs.Entity,
+		InsertedAt:  grpc.FromTime(fs.InsertedAt),
+		Version:     fs.Version,
+		Description: fs.Description,
+		Labels:      fs.Labels,
+	}
+	for _, f := range fs.Features {
+		value, err := toFeatureValueMessage(f.Value, f.DataType)
+		if err != nil {
+			return nil, fmt.Errorf("Error while converting feature %s :: %v", f.Name, err)
+		}
+		m.Features = append(m.Features, &featurestorepb.Feature{Name: f.Name, Value: value, DataType: f.DataType})
+	}
+	return m, nil
+}
+
+func fromFeatureSetMessage(m *featurestorepb.FeatureSet) abstract.FeatureSet {
+	fs := abstract.FeatureSet{
+		ID:          m.Id,
+		Name:        m.Name,
+		Entity:      m.Entity,
+		InsertedAt:  grpc.ToTime(m.InsertedAt),
+		Version:     m.Version,
+		Description: m.Description,
+		Labels:      m.Labels,
+	}
+	for _, f := range m.Features {
+		fs.Features = append(fs.Features, abstract.Feature{Name: f.Name, Value: fromFeatureValueMessage(f.Value), DataType: f.DataType})
+	}
+	return fs
+}
+
+func toFeatureSetMessages(fsets []abstract.FeatureSet) ([]*featurestorepb.FeatureSet, error) {
+	messages := make([]*featurestorepb.FeatureSet, len(fsets))
+	for i := range fsets {
+		m, err := toFeatureSetMessage(&fsets[i])
+		if err != nil {
+			return nil, err
+		}
+		messages[i] = m
+	}
+	return messages, nil
+}
+
+func toPointInTimeMessages(fsets []abstract.PointInTimeFeatureSet) (*featurestorepb.PointInTimeFeatureSetList, error) {
+	list := &featurestorepb.PointInTimeFeatureSetList{}
+	for _, p := range fsets {
+		m := &featurestorepb.PointInTimeFeatureSet{Entity: p.Entity, Timestamp: grpc.FromTime(p.Timestamp)}
+		if p.FeatureSet != nil {
+			fs, err := toFeatureSetMessage(p.FeatureSet)
+			if err != nil {
+				return nil, err
+			}
+			m.FeatureSet = fs
+		}
+		list.FeatureSets = append(list.FeatureSets, m)
+	}
+	return list, nil
+}
+
+func fromEntityTimestampMessages(messages []*featurestorepb.EntityTimestamp) []abstract.EntityTimestamp {
+	requests := make([]abstract.EntityTimestamp, len(messages))
+	for i, m := range messages {
+		requests[i] = abstract.EntityTimestamp{Entity: m.Entity, Timestamp: grpc.ToTime(m.Timestamp)}
+	}
+	return requests
+}
+
+func toSchemaMessage(schema *abstract.FeatureSetSchema) (*featurestorepb.FeatureSetSchema, error) {
+	m := &featurestorepb.FeatureSetSchema{Name: schema.Name, UpdatedAt: grpc.FromTime(schema.UpdatedAt)}
+	for _, f := range schema.Features {
+		feature := &featurestorepb.FeatureSchema{Name: f.Name, DataType: f.DataType, Nullable: f.Nullable, Min: f.Min, Max: f.Max}
+		for _, v := range f.Enum {
+			value, err := toFeatureValueMessage(v, f.DataType)
+			if err != nil {
+				return nil, fmt.Errorf("Error while converting the enum of feature %s :: %v", f.Name, err)
+			}
+			feature.Enum = append(feature.Enum, value)
+		}
+		m.Features = append(m.Features, feature)
+	}
+	return m, nil
+}
+
+func fromSchemaMessage(m *featurestorepb.FeatureSetSchema) abstract.FeatureSetSchema {
+	schema := abstract.FeatureSetSchema{Name: m.Name, UpdatedAt: grpc.ToTime(m.UpdatedAt)}
+	for _, f := range m.Features {
+		feature := abstract.FeatureSchema{Name: f.Name, DataType: f.DataType, Nullable: f.Nullable, Min: f.Min, Max: f.Max}
+		for _, v := range f.Enum {
+			feature.Enum = append(feature.Enum, fromFeatureValueMessage(v))
+		}
+		schema.Features = append(schema.Features, feature)
+	}
+	return schema
+}
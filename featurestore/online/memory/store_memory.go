@@ -0,0 +1,63 @@
+package memory
+
+import (
+	"sync"
+
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/utils/conf"
+)
+
+// store ... in-memory online store, feature sets are lost when the process terminates
+type store struct {
+	mutex       sync.RWMutex
+	featureSets map[string]abstract.FeatureSet
+}
+
+// both init and sync.Once are thread-safe
+// but only sync.Once is lazy
+var once sync.Once
+var instance *store
+
+// GetSingleton ... lazy singleton on the online store
+func GetSingleton() abstract.OnlineFeatureStoreProvider {
+	// once.do is lazy, we use it to return an instance of the store
+	once.Do(func() {
+		instance = &store{}
+	})
+	return instance
+}
+
+// Init ... Initialize an empty store, no settings are required
+func (s *store) Init(def *conf.DataSourceDefinition) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.featureSets = make(map[string]abstract.FeatureSet)
+	return nil
+}
+
+// Ping ... the in-memory store is always available
+func (s *store) Ping() error {
+	return nil
+}
+
+// CloseConnection ... nothing to close for the in-memory store
+func (s *store) CloseConnection() {}
+
+// Put ... Stores a copy of the feature set, replacing any feature set already stored for the key
+func (s *store) Put(key string, fs *abstract.FeatureSet) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.featureSets[key] = *fs
+	return nil
+}
+
+// Get ... Retrieves the feature set stored for the key
+func (s *store) Get(key string) (*abstract.FeatureSet, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	fs, exist := s.featureSets[key]
+	if !exist {
+		return nil, nil
+	}
+	return &fs, nil
+}
@@ -0,0 +1,86 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	goredis "github.com/go-redis/redis/v8"
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/sources/redis"
+	"github.com/pilillo/mastro/utils/conf"
+	"github.com/pilillo/mastro/utils/retry"
+)
+
+// keyPrefix ... namespace of the feature set keys, so that the redis db can be shared
+const keyPrefix = "mastro:featureset:"
+
+type store struct {
+	Connector *redis.Connector
+}
+
+// both init and sync.Once are thread-safe
+// but only sync.Once is lazy
+var once sync.Once
+var instance *store
+
+// GetSingleton ... lazy singleton on the online store
+func GetSingleton() abstract.OnlineFeatureStoreProvider {
+	// once.do is lazy, we use it to return an instance of the store
+	once.Do(func() {
+		instance = &store{}
+	})
+	return instance
+}
+
+// Init ... Initialize connection to redis
+func (s *store) Init(def *conf.DataSourceDefinition) error {
+	// create redis connector
+	s.Connector = redis.NewRedisConnector()
+	// validate data source definition
+	if err := s.Connector.ValidateDataSourceDefinition(def); err != nil {
+		return retry.Permanent(err)
+	}
+	// init redis connector
+	return s.Connector.InitConnection(def)
+}
+
+// Ping ... pings redis through the connector
+func (s *store) Ping() error {
+	return s.Connector.Ping()
+}
+
+// CloseConnection ... Terminates the connection to redis
+func (s *store) CloseConnection() {
+	s.Connector.CloseConnection()
+}
+
+// Put ... Stores the feature set as json, replacing any feature set already stored for the key
+func (s *store) Put(key string, fs *abstract.FeatureSet) error {
+	value, err := json.Marshal(fs)
+	if err != nil {
+		return err
+	}
+	if err := s.Connector.Client.Set(context.Background(), keyPrefix+key, value, 0).Err(); err != nil {
+		return fmt.Errorf("Error while materializing feature set :: %v", err)
+	}
+	return nil
+}
+
+// Get ... Retrieves the feature set stored for the key
+func (s *store) Get(key string) (*abstract.FeatureSet, error) {
+	value, err := s.Connector.Client.Get(context.Background(), keyPrefix+key).Bytes()
+	if err == goredis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Error while retrieving online feature set :: %v", err)
+	}
+
+	fs := &abstract.FeatureSet{}
+	if err := json.Unmarshal(value, fs); err != nil {
+		return nil, fmt.Errorf("Error while retrieving online feature set :: %v", err)
+	}
+	return fs, nil
+}
@@ -0,0 +1,36 @@
+package featurestore
+
+import (
+	"fmt"
+
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/featurestore/online/memory"
+	"github.com/pilillo/mastro/featurestore/online/redis"
+	redissource "github.com/pilillo/mastro/sources/redis"
+	"github.com/pilillo/mastro/utils/conf"
+)
+
+// available online stores - lazy loaded singletons
+var availableOnlineStores = map[string]func() abstract.OnlineFeatureStoreProvider{
+	"redis":  redis.GetSingleton,
+	"memory": memory.GetSingleton,
+}
+
+// settings of the online stores, validated along the configuration
+var onlineSettingsValidators = map[string]conf.SettingsValidator{
+	"redis": redissource.NewRedisConnector().ValidateDataSourceDefinition,
+}
+
+func init() {
+	for name := range availableOnlineStores {
+		conf.RegisterDataSource(conf.FeatureStore, conf.OnlineStoreSection, name, onlineSettingsValidators[name])
+	}
+}
+
+func selectOnlineStore(def *conf.DataSourceDefinition) (abstract.OnlineFeatureStoreProvider, error) {
+	if singletonStore, ok := availableOnlineStores[def.Type]; ok {
+		// call singleton constructor on store
+		return instrumentOnlineStore(singletonStore(), def.Type), nil
+	}
+	return nil, fmt.Errorf("Impossible to find specified online store %s", def.Type)
+}
@@ -0,0 +1,128 @@
+package featurestore
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/utils/lifecycle"
+	"github.com/pilillo/mastro/utils/metrics"
+	"github.com/pilillo/mastro/utils/openapi"
+)
+
+// apiVersion ... version of the feature store API, changed on breaking changes of the contract
+const apiVersion = "1.0.0"
+
+// openAPIRoute ... route serving the OpenAPI document of the endpoints
+const openAPIRoute = "openapi.json"
+
+// apiDocument ... returns the OpenAPI document describing the feature store endpoints registered in registerRoutes
+func apiDocument() *openapi.Document {
+	doc := openapi.New("mastro feature store", "Versioned feature sets, their schemas and their online values", apiVersion)
+	name := openapi.PathParam(featureSetNameParam, "name of the feature set")
+	entity := openapi.QueryParam(entityQueryParam, "string", "key of the entity the feature values refer to")
+	tags := []string{"featuresets"}
+
+	doc.Add(http.MethodGet, openAPIRoute, openapi.Public(openapi.Operation{
+		OperationID: "getOpenAPI",
+		Summary:     "OpenAPI document of the endpoints",
+		Tags:        []string{"meta"},
+		Responses:   map[int]openapi.Response{http.StatusOK: openapi.Text("the document", "application/json")},
+	}))
+	doc.Add(http.MethodGet, fmt.Sprintf("healthcheck/%s", featureSetRestEndpoint), openapi.Public(openapi.Operation{
+		OperationID: "ping",
+		Summary:     "Healthcheck, replies pong",
+		Tags:        []string{"meta"},
+		Responses:   map[int]openapi.Response{http.StatusOK: openapi.Text("pong", "text/plain")},
+	}))
+	doc.Add(http.MethodGet, lifecycle.LivenessRoute, openapi.Public(openapi.Operation{
+		OperationID: "getLiveness",
+		Summary:     "Liveness probe, replies as long as the process serves requests",
+		Tags:        []string{"meta"},
+		Responses:   map[int]openapi.Response{http.StatusOK: openapi.Text("the process is alive", "application/json")},
+	}))
+	doc.Add(http.MethodGet, lifecycle.ReadinessRoute, openapi.Public(openapi.Operation{
+		OperationID: "getReadiness",
+		Summary:     "Readiness probe, pings the backends and fails while shutting down",
+		Tags:        []string{"meta"},
+		Responses: map[int]openapi.Response{
+			http.StatusOK:                 doc.JSON("the outcome of the checks", lifecycle.Readiness{}),
+			http.StatusServiceUnavailable: doc.JSON("the outcome of the checks, some failed", lifecycle.Readiness{}),
+		},
+	}))
+	doc.Add(http.MethodGet, metrics.Route, openapi.Public(openapi.Operation{
+		OperationID: "getMetrics",
+		Summary:     "Prometheus metrics of the requests and of the backend operations",
+		Tags:        []string{"meta"},
+		Responses:   map[int]openapi.Response{http.StatusOK: openapi.Text("the metrics", "text/plain")},
+	}))
+
+	doc.Add(http.MethodGet, fmt.Sprintf("%s/id/:%s", featureSetRestEndpoint, featureSetIDParam), openapi.Operation{
+		OperationID: "getFeatureSetByID",
+		Summary:     "Retrieves a feature set by its backend id",
+		Tags:        tags,
+		Parameters:  []openapi.Parameter{openapi.PathParam(featureSetIDParam, "backend specific id of the feature set")},
+		Responses:   map[int]openapi.Response{http.StatusOK: doc.JSON("the feature set", abstract.FeatureSet{})},
+	}, http.StatusNotFound, http.StatusInternalServerError)
+	doc.Add(http.MethodGet, fmt.Sprintf("%s/name/:%s", featureSetRestEndpoint, featureSetNameParam), openapi.Operation{
+		OperationID: "getFeatureSetByName",
+		Summary:     "Retrieves all versions of a feature set",
+		Tags:        tags,
+		Parameters:  []openapi.Parameter{name},
+		Responses:   map[int]openapi.Response{http.StatusOK: doc.JSON("the feature sets", []abstract.FeatureSet{})},
+	}, http.StatusNotFound, http.StatusInternalServerError)
+	doc.Add(http.MethodGet, fmt.Sprintf("%s/name/:%s/version/:%s", featureSetRestEndpoint, featureSetNameParam, versionParam), openapi.Operation{
+		OperationID: "getFeatureSetByVersion",
+		Summary:     "Retrieves a feature set at a version, the latest one or the highest one in a semver range",
+		Tags:        tags,
+		Parameters:  []openapi.Parameter{name, openapi.PathParam(versionParam, "a version, latest or a semver range, e.g. ^1.2"), entity},
+		Responses:   map[int]openapi.Response{http.StatusOK: doc.JSON("the feature set", abstract.FeatureSet{})},
+	}, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+	doc.Add(http.MethodPost, fmt.Sprintf("%s/name/:%s/point-in-time", featureSetRestEndpoint, featureSetNameParam), openapi.Operation{
+		OperationID: "getFeatureSetsAt",
+		Summary:     "Retrieves the feature sets available at each entity and timestamp pair",
+		Tags:        tags,
+		Parameters:  []openapi.Parameter{name},
+		RequestBody: doc.JSONBody([]abstract.EntityTimestamp{}),
+		Responses:   map[int]openapi.Response{http.StatusOK: doc.JSON("the feature sets, in the order of the pairs", []abstract.PointInTimeFeatureSet{})},
+	}, http.StatusBadRequest, http.StatusInternalServerError)
+	doc.Add(http.MethodGet, fmt.Sprintf("%s/online/:%s", featureSetRestEndpoint, featureSetNameParam), openapi.Operation{
+		OperationID: "getOnlineFeatureSet",
+		Summary:     "Retrieves the latest feature set from the online store",
+		Tags:        []string{"online"},
+		Parameters:  []openapi.Parameter{name, entity},
+		Responses:   map[int]openapi.Response{http.StatusOK: doc.JSON("the feature set", abstract.FeatureSet{})},
+	}, http.StatusNotFound, http.StatusInternalServerError, http.StatusNotImplemented)
+
+	doc.Add(http.MethodPut, fmt.Sprintf("%s/schema/", featureSetRestEndpoint), openapi.Operation{
+		OperationID: "upsertFeatureSetSchema",
+		Summary:     "Registers the schema of the feature sets with a name",
+		Tags:        []string{"schemas"},
+		RequestBody: doc.JSONBody(abstract.FeatureSetSchema{}),
+		Responses:   map[int]openapi.Response{http.StatusOK: doc.JSON("the registered schema", abstract.FeatureSetSchema{})},
+	}, http.StatusBadRequest, http.StatusInternalServerError)
+	doc.Add(http.MethodGet, fmt.Sprintf("%s/schema/:%s", featureSetRestEndpoint, featureSetNameParam), openapi.Operation{
+		OperationID: "getFeatureSetSchema",
+		Summary:     "Retrieves the schema registered for the feature sets with a name",
+		Tags:        []string{"schemas"},
+		Parameters:  []openapi.Parameter{name},
+		Responses:   map[int]openapi.Response{http.StatusOK: doc.JSON("the schema", abstract.FeatureSetSchema{})},
+	}, http.StatusNotFound, http.StatusInternalServerError)
+
+	doc.Add(http.MethodPut, fmt.Sprintf("%s/", featureSetRestEndpoint), openapi.Operation{
+		OperationID: "createFeatureSet",
+		Summary:     "Creates a feature set, validated against the schema registered for its name if any",
+		Tags:        tags,
+		RequestBody: doc.JSONBody(abstract.FeatureSet{}),
+		Responses:   map[int]openapi.Response{http.StatusCreated: doc.JSON("the created feature set", abstract.FeatureSet{})},
+	}, http.StatusBadRequest, http.StatusConflict, http.StatusInternalServerError)
+	doc.Add(http.MethodGet, fmt.Sprintf("%s/", featureSetRestEndpoint), openapi.Operation{
+		OperationID: "listAllFeatureSets",
+		Summary:     "Retrieves a page of feature sets, with only the requested fields if any",
+		Tags:        tags,
+		Parameters:  openapi.ListParams(abstract.SortByInsertedAt, abstract.SortByName),
+		Responses:   map[int]openapi.Response{http.StatusOK: doc.JSON("the page of feature sets", abstract.FeatureSetPage{})},
+	}, http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError)
+
+	return doc
+}
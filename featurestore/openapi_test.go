@@ -0,0 +1,70 @@
+package featurestore
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/client"
+	"github.com/pilillo/mastro/utils/auth"
+	"github.com/pilillo/mastro/utils/openapi"
+)
+
+func TestOpenAPIDescribesAllRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	authz, err := auth.NewMiddleware(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	engine := gin.New()
+	registerRoutes(engine, authz)
+
+	described := apiDocument().Methods()
+	for _, r := range engine.Routes() {
+		found := false
+		for _, m := range described[openapi.Path(r.Path)] {
+			found = found || m == r.Method
+		}
+		if !found {
+			t.Errorf("route %s %s is not described", r.Method, r.Path)
+		}
+	}
+}
+
+func TestFeatureStoreClient(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	initEmbeddedService(t)
+	authz, _ := auth.NewMiddleware(nil)
+	engine := gin.New()
+	registerRoutes(engine, authz)
+	server := httptest.NewServer(engine)
+	defer server.Close()
+	c := client.NewFeatureStoreClient(server.URL)
+
+	fs := abstract.FeatureSet{
+		Name:     "myfeatureset",
+		Version:  "1.0.0",
+		Features: []abstract.Feature{{Name: "age", Value: 30, DataType: "int"}},
+	}
+	if _, err := c.CreateFeatureSet(fs); err != nil {
+		t.Fatal(err)
+	}
+	latest, err := c.GetFeatureSetByVersion("myfeatureset", "latest", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if latest.Version != "1.0.0" || len(latest.Features) != 1 {
+		t.Errorf("unexpected feature set %v", latest)
+	}
+	if _, err := c.GetFeatureSetSchema("myfeatureset"); !client.IsNotFound(err) {
+		t.Errorf("expected no schema to be found, got %v", err)
+	}
+	page, err := c.ListAllFeatureSets(&abstract.ListOptions{Fields: []string{"name"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.FeatureSets) != 1 || page.FeatureSets[0].Version != "" {
+		t.Errorf("expected only the name of the feature set, got %v", page.FeatureSets)
+	}
+}
@@ -1,12 +1,18 @@
 package featurestore
 
 import (
-	"log"
+	"context"
+	goerrors "errors"
+	"fmt"
 
 	"github.com/pilillo/mastro/abstract"
 	"github.com/pilillo/mastro/utils/conf"
 	"github.com/pilillo/mastro/utils/date"
 	"github.com/pilillo/mastro/utils/errors"
+	"github.com/pilillo/mastro/utils/lifecycle"
+	"github.com/pilillo/mastro/utils/logging"
+	"github.com/pilillo/mastro/utils/retry"
+	"github.com/pilillo/mastro/utils/semver"
 )
 
 // Service ... Service Interface listing implemented methods
@@ -15,11 +21,18 @@ type Service interface {
 	CreateFeatureSet(fs abstract.FeatureSet) (*abstract.FeatureSet, *errors.RestErr)
 	GetFeatureSetByID(fsID string) (*abstract.FeatureSet, *errors.RestErr)
 	GetFeatureSetByName(fsName string) (*[]abstract.FeatureSet, *errors.RestErr)
-	ListAllFeatureSets() (*[]abstract.FeatureSet, *errors.RestErr)
+	GetFeatureSetByVersion(fsName string, version string, entity string) (*abstract.FeatureSet, *errors.RestErr)
+	ListAllFeatureSets(opts *abstract.ListOptions) (*abstract.FeatureSetPage, *errors.RestErr)
+	GetFeatureSetsAt(fsName string, requests []abstract.EntityTimestamp) (*[]abstract.PointInTimeFeatureSet, *errors.RestErr)
+	GetOnlineFeatureSet(fsName string, entity string) (*abstract.FeatureSet, *errors.RestErr)
+	UpsertFeatureSetSchema(schema abstract.FeatureSetSchema) (*abstract.FeatureSetSchema, *errors.RestErr)
+	GetFeatureSetSchema(fsName string) (*abstract.FeatureSetSchema, *errors.RestErr)
 }
 
-// featureSetServiceType ... Service Type
-type featureSetServiceType struct{}
+// featureSetServiceType ... Service Type, tracing the dao calls within the context of the request being served, if any
+type featureSetServiceType struct {
+	ctx context.Context
+}
 
 // FeatureSetService ... Group all service methods in a kind FeatureSetServiceType implementing the FeatureSetService
 var featureSetService Service = &featureSetServiceType{}
@@ -27,6 +40,33 @@ var featureSetService Service = &featureSetServiceType{}
 // selected dao for the featureSetService
 var dao abstract.FeatureSetDAOProvider
 
+// selected online store for the featureSetService, nil if not configured
+var onlineStore abstract.OnlineFeatureStoreProvider
+
+// names of the dao and of the online store among the backends of the lifecycle, as reported by the readiness probe
+const (
+	backendName     = "backend"
+	onlineStoreName = "online-store"
+)
+
+// withContext ... returns the service tracing its dao and online store calls as children of the current span of the context
+func withContext(s Service, ctx context.Context) Service {
+	if _, isFeatureSetService := s.(*featureSetServiceType); isFeatureSetService {
+		return &featureSetServiceType{ctx: ctx}
+	}
+	return s
+}
+
+// store ... returns the selected dao, tracing its calls within the context of the service
+func (s *featureSetServiceType) store() abstract.FeatureSetDAOProvider {
+	return bindContext(dao, s.ctx)
+}
+
+// online ... returns the selected online store, tracing its calls within the context of the service
+func (s *featureSetServiceType) online() abstract.OnlineFeatureStoreProvider {
+	return bindOnlineContext(onlineStore, s.ctx)
+}
+
 // Init ... Initializes the connector by validating the config and initializing the connection
 func (s *featureSetServiceType) Init(cfg *conf.Config) *errors.RestErr {
 	// select target DAO based on used connector
@@ -35,30 +75,90 @@ func (s *featureSetServiceType) Init(cfg *conf.Config) *errors.RestErr {
 	// select dao using mapping function in same package
 	dao, err = selectDao(cfg)
 	if err != nil {
-		log.Panicln(err)
+		return errors.GetBadRequestError(err.Error())
+	}
+	backoff, err := retry.NewBackoff(cfg.DataSourceDefinition.RetryDefinition)
+	if err != nil {
+		return errors.GetBadRequestError(err.Error())
+	}
+	// the service is ready as long as its backends are reachable, and starts in degraded mode until they are connected
+	err = lifecycle.Connect(backendName, backoff, func() error { return dao.Init(&cfg.DataSourceDefinition) }, dao.Ping)
+	if err != nil {
+		return errors.GetInternalServerError(err.Error())
+	}
+
+	// the online store is optional, the dao alone keeps the history of all feature sets
+	onlineStore = nil
+	if cfg.OnlineStoreDefinition != nil {
+		onlineStore, err = selectOnlineStore(cfg.OnlineStoreDefinition)
+		if err != nil {
+			return errors.GetBadRequestError(err.Error())
+		}
+		onlineBackoff, err := retry.NewBackoff(cfg.OnlineStoreDefinition.RetryDefinition)
+		if err != nil {
+			return errors.GetBadRequestError(err.Error())
+		}
+		err = lifecycle.Connect(onlineStoreName, onlineBackoff, func() error { return onlineStore.Init(cfg.OnlineStoreDefinition) }, onlineStore.Ping)
+		if err != nil {
+			return errors.GetInternalServerError(err.Error())
+		}
 	}
-	dao.Init(&cfg.DataSourceDefinition)
 	return nil
 }
 
+// closeBackends ... closes the connections of the selected dao and online store, once the service stopped handling requests
+func closeBackends() {
+	if dao != nil && lifecycle.Connected(backendName) {
+		dao.CloseConnection()
+	}
+	if onlineStore != nil && lifecycle.Connected(onlineStoreName) {
+		onlineStore.CloseConnection()
+	}
+}
+
 // CreateFeatureSet ... Create a FeatureSet entry
 func (s *featureSetServiceType) CreateFeatureSet(fs abstract.FeatureSet) (*abstract.FeatureSet, *errors.RestErr) {
-	if err := fs.Validate(); err != nil {
+	// validate against the registered schema if any, otherwise only against the declared data types
+	schema, err := s.store().GetSchema(fs.Name)
+	if err != nil {
+		return nil, errors.GetInternalServerError(err.Error())
+	}
+	if schema != nil {
+		err = fs.ValidateWithSchema(schema)
+	} else {
+		err = fs.Validate()
+	}
+	if err != nil {
 		return nil, errors.GetBadRequestError(err.Error())
 	}
 	// set insert time to current date, then insert using selected dao
 	fs.InsertedAt = date.GetNow()
-	err := dao.Create(&fs)
+	err = s.store().Create(&fs)
+	if goerrors.Is(err, abstract.ErrFeatureSetExists) {
+		return nil, errors.GetConflictError(err.Error())
+	}
 	if err != nil {
 		return nil, errors.GetBadRequestError(err.Error())
 	}
+	// the online store keeps the latest version for the name and entity, which a backfill of an older version is not
+	if onlineStore != nil {
+		latest, restErr := s.GetFeatureSetByVersion(fs.Name, latestVersion, fs.Entity)
+		if restErr != nil {
+			logging.FromContext(s.ctx).Warn("Failed resolving the latest version of the feature set", "name", fs.Name, "error", restErr.Message)
+		} else if latest.Version == fs.Version {
+			if err := s.online().Put(abstract.OnlineKey(fs.Name, fs.Entity), &fs); err != nil {
+				// the feature set is persisted anyway, a later lookup reloads it from the dao
+				logging.FromContext(s.ctx).Warn("Failed caching the feature set in the online store", "name", fs.Name, "error", err)
+			}
+		}
+	}
 	// what should we actually return of the newly inserted object?
 	return &fs, nil
 }
 
 // GetFeatureSetByID ... Retrieves a FeatureSet
 func (s *featureSetServiceType) GetFeatureSetByID(fsID string) (*abstract.FeatureSet, *errors.RestErr) {
-	fset, err := dao.GetById(fsID)
+	fset, err := s.store().GetById(fsID)
 	if err != nil {
 		return nil, errors.GetNotFoundError(err.Error())
 	}
@@ -67,23 +167,166 @@ func (s *featureSetServiceType) GetFeatureSetByID(fsID string) (*abstract.Featur
 
 // GetFeatureSetByName ... Retrieves a FeatureSet
 func (s *featureSetServiceType) GetFeatureSetByName(fsName string) (*[]abstract.FeatureSet, *errors.RestErr) {
-	fset, err := dao.GetByName(fsName)
+	fset, err := s.store().GetByName(fsName)
 	if err != nil {
 		return nil, errors.GetNotFoundError(err.Error())
 	}
 	return fset, nil
 }
 
-// ListAllFeatureSets ... Retrieves all FeatureSets
-func (s *featureSetServiceType) ListAllFeatureSets() (*[]abstract.FeatureSet, *errors.RestErr) {
-	fsets, err := dao.ListAllFeatureSets()
+// latestVersion ... version alias resolving to the highest semantic version
+const latestVersion = "latest"
+
+// GetFeatureSetByVersion ... Retrieves the FeatureSet with the given name and entity at a version, which is either
+// an exact version, "latest" or a semver range (e.g. ^1.2), resolving to the highest release or matching version
+func (s *featureSetServiceType) GetFeatureSetByVersion(fsName string, version string, entity string) (*abstract.FeatureSet, *errors.RestErr) {
+	fsets, err := s.store().GetByName(fsName)
 	if err != nil {
 		return nil, errors.GetInternalServerError(err.Error())
 	}
-	// n.b. - fsets empty if collection is empty
-	// better to return an error or an empty list?
-	if fsets == nil || len(*fsets) == 0 {
+
+	var candidates []abstract.FeatureSet
+	for _, fs := range *fsets {
+		if fs.Entity == entity {
+			candidates = append(candidates, fs)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, errors.GetNotFoundError(fmt.Sprintf("No feature set found for name %s and entity %q", fsName, entity))
+	}
+
+	// an exact match takes precedence, so that versions not following semver can also be retrieved
+	for i := range candidates {
+		if candidates[i].Version == version {
+			return &candidates[i], nil
+		}
+	}
+
+	var constraint *semver.Constraint
+	if version != latestVersion {
+		constraint, err = semver.ParseConstraint(version)
+		if err != nil {
+			return nil, errors.GetBadRequestError(fmt.Sprintf("Version %s is neither an existing version nor a valid range :: %v", version, err))
+		}
+	}
+
+	var result *abstract.FeatureSet
+	var resultVersion *semver.Version
+	for i := range candidates {
+		v, err := semver.Parse(candidates[i].Version)
+		if err != nil {
+			continue
+		}
+		// latest only considers releases, while ranges decide on pre-releases themselves
+		if (constraint == nil && len(v.PreRelease) > 0) || (constraint != nil && !constraint.Check(v)) {
+			continue
+		}
+		// on equal versions, e.g. 1.2 and v1.2.0, the last inserted one wins
+		if resultVersion == nil || v.Compare(resultVersion) >= 0 {
+			result, resultVersion = &candidates[i], v
+		}
+	}
+
+	// if no release follows semver, latest is the last inserted feature set
+	if result == nil && constraint == nil {
+		result = &candidates[len(candidates)-1]
+	}
+	if result == nil {
+		return nil, errors.GetNotFoundError(fmt.Sprintf("No feature set found for name %s matching version %s", fsName, version))
+	}
+	return result, nil
+}
+
+// ListAllFeatureSets ... Retrieves a page of the stored FeatureSets
+func (s *featureSetServiceType) ListAllFeatureSets(opts *abstract.ListOptions) (*abstract.FeatureSetPage, *errors.RestErr) {
+	if err := opts.Validate([]string{abstract.SortByInsertedAt, abstract.SortByName}, abstract.FeatureSet{}); err != nil {
+		return nil, errors.GetBadRequestError(err.Error())
+	}
+	page, err := s.store().ListFeatureSets(opts)
+	if err != nil {
+		return nil, errors.GetInternalServerError(err.Error())
+	}
+	// n.b. - page empty if collection is empty or the offset is beyond its size
+	if len(page.FeatureSets) == 0 {
 		return nil, errors.GetNotFoundError("No feature sets in given collection")
 	}
-	return fsets, nil
+	return page, nil
+}
+
+// GetOnlineFeatureSet ... Retrieves the latest FeatureSet for the entity from the online store,
+// a feature set missing from the online store is loaded from the dao and materialized
+func (s *featureSetServiceType) GetOnlineFeatureSet(fsName string, entity string) (*abstract.FeatureSet, *errors.RestErr) {
+	if onlineStore == nil {
+		return nil, errors.GetNotImplementedError("No online store configured")
+	}
+
+	key := abstract.OnlineKey(fsName, entity)
+	fs, err := s.online().Get(key)
+	if err != nil {
+		return nil, errors.GetInternalServerError(err.Error())
+	}
+	if fs != nil {
+		return fs, nil
+	}
+
+	// cache miss, e.g. feature sets created before the online store was configured
+	fs, err = s.store().GetLatestAt(fsName, entity, date.GetNow())
+	if err != nil {
+		return nil, errors.GetInternalServerError(err.Error())
+	}
+	if fs == nil {
+		return nil, errors.GetNotFoundError(fmt.Sprintf("No feature set found for name %s and entity %q", fsName, entity))
+	}
+	if err := s.online().Put(key, fs); err != nil {
+		logging.FromContext(s.ctx).Warn("Failed caching the feature set in the online store", "name", fsName, "error", err)
+	}
+	return fs, nil
+}
+
+// GetFeatureSetsAt ... Retrieves, for each entity, the latest FeatureSet inserted at or before the requested timestamp
+func (s *featureSetServiceType) GetFeatureSetsAt(fsName string, requests []abstract.EntityTimestamp) (*[]abstract.PointInTimeFeatureSet, *errors.RestErr) {
+	if len(requests) == 0 {
+		return nil, errors.GetBadRequestError("No entity and timestamp pairs provided")
+	}
+
+	results := []abstract.PointInTimeFeatureSet{}
+	for _, r := range requests {
+		if r.Timestamp.IsZero() {
+			return nil, errors.GetBadRequestError(fmt.Sprintf("Timestamp is undefined for entity %q", r.Entity))
+		}
+		fs, err := s.store().GetLatestAt(fsName, r.Entity, r.Timestamp)
+		if err != nil {
+			return nil, errors.GetInternalServerError(err.Error())
+		}
+		results = append(results, abstract.PointInTimeFeatureSet{
+			Entity:     r.Entity,
+			Timestamp:  r.Timestamp,
+			FeatureSet: fs,
+		})
+	}
+	return &results, nil
+}
+
+// UpsertFeatureSetSchema ... Registers the schema of all FeatureSets with the given name
+func (s *featureSetServiceType) UpsertFeatureSetSchema(schema abstract.FeatureSetSchema) (*abstract.FeatureSetSchema, *errors.RestErr) {
+	if err := schema.Validate(); err != nil {
+		return nil, errors.GetBadRequestError(err.Error())
+	}
+	schema.UpdatedAt = date.GetNow()
+	if err := s.store().UpsertSchema(&schema); err != nil {
+		return nil, errors.GetInternalServerError(err.Error())
+	}
+	return &schema, nil
+}
+
+// GetFeatureSetSchema ... Retrieves the schema registered for the FeatureSet name
+func (s *featureSetServiceType) GetFeatureSetSchema(fsName string) (*abstract.FeatureSetSchema, *errors.RestErr) {
+	schema, err := s.store().GetSchema(fsName)
+	if err != nil {
+		return nil, errors.GetInternalServerError(err.Error())
+	}
+	if schema == nil {
+		return nil, errors.GetNotFoundError(fmt.Sprintf("No schema registered for feature set %s", fsName))
+	}
+	return schema, nil
 }
@@ -0,0 +1,274 @@
+package featurestore
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/utils/conf"
+)
+
+// initEmbeddedService ... inits the feature set service on an embedded store in a temporary folder
+func initEmbeddedService(t *testing.T) {
+	cfg := &conf.Config{
+		ConfigType: conf.FeatureStore,
+		DataSourceDefinition: conf.DataSourceDefinition{
+			Name: "test-embedded",
+			Type: "embedded",
+			Settings: map[string]string{
+				"path": filepath.Join(t.TempDir(), "featurestore.json"),
+			},
+		},
+	}
+	if err := featureSetService.Init(cfg); err != nil {
+		t.Fatal(err.Message)
+	}
+}
+
+func TestCreateAndGetFeatureSets(t *testing.T) {
+	initEmbeddedService(t)
+
+	if _, err := featureSetService.ListAllFeatureSets(&abstract.ListOptions{}); err == nil || err.Status != http.StatusNotFound {
+		t.Fatalf("expected not found on empty featurestore, got %v", err)
+	}
+
+	for _, version := range []string{"v1", "v2"} {
+		fs := abstract.FeatureSet{
+			Name:    "myfeatureset",
+			Version: version,
+			Features: []abstract.Feature{
+				{Name: "feature1", Value: 10, DataType: "int"},
+			},
+		}
+		created, err := featureSetService.CreateFeatureSet(fs)
+		if err != nil {
+			t.Fatal(err.Message)
+		}
+		if created.InsertedAt.IsZero() {
+			t.Error("expected insertion date to be set by the service")
+		}
+	}
+
+	fsets, err := featureSetService.GetFeatureSetByName("myfeatureset")
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	if len(*fsets) != 2 || (*fsets)[0].Version != "v1" || (*fsets)[1].Version != "v2" {
+		t.Errorf("expected versions v1 and v2 in insertion order, got %v", *fsets)
+	}
+
+	if _, err := featureSetService.GetFeatureSetByID("1"); err != nil {
+		t.Error(err.Message)
+	}
+
+	page, err := featureSetService.ListAllFeatureSets(&abstract.ListOptions{Fields: []string{"name", "version"}})
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	if len(page.FeatureSets) != 2 || page.NextCursor != "" {
+		t.Errorf("expected a single page of 2 feature sets, got %v", page)
+	}
+	if _, err := featureSetService.ListAllFeatureSets(&abstract.ListOptions{Fields: []string{"unknown"}}); err == nil || err.Status != http.StatusBadRequest {
+		t.Errorf("expected bad request on unknown field, got %v", err)
+	}
+}
+
+func TestCreateInvalidFeatureSet(t *testing.T) {
+	initEmbeddedService(t)
+
+	fs := abstract.FeatureSet{Name: "noVersion"}
+	if _, err := featureSetService.CreateFeatureSet(fs); err == nil || err.Status != http.StatusBadRequest {
+		t.Fatalf("expected bad request for feature set without version, got %v", err)
+	}
+}
+
+func TestOnlineFeatureSet(t *testing.T) {
+	initEmbeddedService(t)
+	if _, err := featureSetService.GetOnlineFeatureSet("myfeatureset", ""); err == nil || err.Status != http.StatusNotImplemented {
+		t.Fatalf("expected not implemented without an online store, got %v", err)
+	}
+
+	cfg := &conf.Config{
+		ConfigType:            conf.FeatureStore,
+		DataSourceDefinition:  conf.DataSourceDefinition{Name: "test-memory", Type: "memory"},
+		OnlineStoreDefinition: &conf.DataSourceDefinition{Name: "test-online", Type: "memory"},
+	}
+	if err := featureSetService.Init(cfg); err != nil {
+		t.Fatal(err.Message)
+	}
+	if _, err := featureSetService.CreateFeatureSet(abstract.FeatureSet{Name: "previous", Version: "v1"}); err != nil {
+		t.Fatal(err.Message)
+	}
+	// reset the online store, as for feature sets created before it was configured
+	if err := onlineStore.Init(cfg.OnlineStoreDefinition); err != nil {
+		t.Fatal(err)
+	}
+
+	fs, err := featureSetService.GetOnlineFeatureSet("previous", "")
+	if err != nil {
+		t.Fatalf("expected feature set to be loaded from the dao, got %v", err)
+	}
+	if fs.Version != "v1" {
+		t.Errorf("expected version v1, got %s", fs.Version)
+	}
+
+	for _, version := range []string{"v1", "v2"} {
+		if _, err := featureSetService.CreateFeatureSet(abstract.FeatureSet{Name: "myfeatureset", Version: version}); err != nil {
+			t.Fatal(err.Message)
+		}
+	}
+	fs, err = featureSetService.GetOnlineFeatureSet("myfeatureset", "")
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	if fs.Version != "v2" {
+		t.Errorf("expected latest version v2, got %s", fs.Version)
+	}
+
+	if _, err := featureSetService.CreateFeatureSet(abstract.FeatureSet{Name: "myfeatureset", Entity: "customer1", Version: "v3"}); err != nil {
+		t.Fatal(err.Message)
+	}
+	fs, err = featureSetService.GetOnlineFeatureSet("myfeatureset", "customer1")
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	if fs.Version != "v3" {
+		t.Errorf("expected version v3 for the entity, got %s", fs.Version)
+	}
+	if fs, _ = featureSetService.GetOnlineFeatureSet("myfeatureset", ""); fs == nil || fs.Version != "v2" {
+		t.Errorf("expected the feature set without entity to be unchanged, got %v", fs)
+	}
+
+	// backfilling an older version, or a pre-release, leaves the latest version in the online store
+	for _, version := range []string{"v1.5", "v3.0.0-rc1"} {
+		if _, err := featureSetService.CreateFeatureSet(abstract.FeatureSet{Name: "myfeatureset", Version: version}); err != nil {
+			t.Fatal(err.Message)
+		}
+		if fs, _ = featureSetService.GetOnlineFeatureSet("myfeatureset", ""); fs == nil || fs.Version != "v2" {
+			t.Errorf("expected the latest version v2 after creating %s, got %v", version, fs)
+		}
+	}
+
+	if _, err := featureSetService.GetOnlineFeatureSet("missing", ""); err == nil || err.Status != http.StatusNotFound {
+		t.Errorf("expected not found for a missing feature set, got %v", err)
+	}
+}
+
+func TestPointInTimeFeatureSets(t *testing.T) {
+	initEmbeddedService(t)
+
+	if _, err := featureSetService.GetFeatureSetsAt("myfeatureset", nil); err == nil || err.Status != http.StatusBadRequest {
+		t.Fatalf("expected bad request without entity and timestamp pairs, got %v", err)
+	}
+
+	created, err := featureSetService.CreateFeatureSet(abstract.FeatureSet{Name: "myfeatureset", Entity: "customer1", Version: "v1"})
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+
+	results, err := featureSetService.GetFeatureSetsAt("myfeatureset", []abstract.EntityTimestamp{
+		{Entity: "customer1", Timestamp: created.InsertedAt.Add(-time.Second)},
+		{Entity: "customer1", Timestamp: created.InsertedAt},
+		{Entity: "customer2", Timestamp: created.InsertedAt},
+	})
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	if len(*results) != 3 {
+		t.Fatalf("expected one result per pair, got %d", len(*results))
+	}
+	if (*results)[0].FeatureSet != nil {
+		t.Error("expected no feature set before its insertion")
+	}
+	if fs := (*results)[1].FeatureSet; fs == nil || fs.Version != "v1" {
+		t.Errorf("expected v1 at its insertion time, got %v", fs)
+	}
+	if (*results)[2].FeatureSet != nil {
+		t.Error("expected no feature set for another entity")
+	}
+}
+
+func TestSchemaEnforcement(t *testing.T) {
+	initEmbeddedService(t)
+
+	if _, err := featureSetService.GetFeatureSetSchema("customers"); err == nil || err.Status != http.StatusNotFound {
+		t.Fatalf("expected not found without a registered schema, got %v", err)
+	}
+
+	// without schema, values should still parse as the declared data type
+	fs := abstract.FeatureSet{Name: "customers", Version: "v1", Features: []abstract.Feature{{Name: "age", Value: "old", DataType: "int"}}}
+	if _, err := featureSetService.CreateFeatureSet(fs); err == nil || err.Status != http.StatusBadRequest {
+		t.Fatalf("expected bad request for a value not matching its data type, got %v", err)
+	}
+
+	schema := abstract.FeatureSetSchema{
+		Name:     "customers",
+		Features: []abstract.FeatureSchema{{Name: "age", DataType: "Integer"}},
+	}
+	registered, err := featureSetService.UpsertFeatureSetSchema(schema)
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	if registered.Features[0].DataType != abstract.IntType {
+		t.Errorf("expected canonical data type, got %s", registered.Features[0].DataType)
+	}
+
+	fs = abstract.FeatureSet{Name: "customers", Version: "v1", Features: []abstract.Feature{{Name: "unknown", Value: 1, DataType: "int"}}}
+	if _, err := featureSetService.CreateFeatureSet(fs); err == nil || err.Status != http.StatusBadRequest {
+		t.Fatalf("expected bad request for a feature not in the schema, got %v", err)
+	}
+
+	fs = abstract.FeatureSet{Name: "customers", Version: "v1", Features: []abstract.Feature{{Name: "age", Value: "42"}}}
+	created, err := featureSetService.CreateFeatureSet(fs)
+	if err != nil {
+		t.Fatal(err.Message)
+	}
+	if f := created.Features[0]; f.Value != int64(42) || f.DataType != abstract.IntType {
+		t.Errorf("expected value converted to the schema data type, got %v", f)
+	}
+}
+
+func TestVersionResolution(t *testing.T) {
+	initEmbeddedService(t)
+
+	for _, version := range []string{"1.0.0", "1.2.0", "1.10.1", "2.0.0-rc.1", "test-v1.0", "1.2.5"} {
+		if _, err := featureSetService.CreateFeatureSet(abstract.FeatureSet{Name: "myfeatureset", Version: version}); err != nil {
+			t.Fatal(err.Message)
+		}
+	}
+	if _, err := featureSetService.CreateFeatureSet(abstract.FeatureSet{Name: "myfeatureset", Version: "1.2.0"}); err == nil || err.Status != http.StatusConflict {
+		t.Fatalf("expected conflict when re-creating an existing version, got %v", err)
+	}
+
+	cases := map[string]string{
+		"latest":    "1.10.1",
+		"1.2.0":     "1.2.0",
+		"test-v1.0": "test-v1.0",
+		"^1.2":      "1.10.1",
+		"~1.2":      "1.2.5",
+		"<1.2.0":    "1.0.0",
+		">=2.0.0-0": "2.0.0-rc.1",
+	}
+	for version, expected := range cases {
+		fs, err := featureSetService.GetFeatureSetByVersion("myfeatureset", version, "")
+		if err != nil {
+			t.Errorf("%s: %s", version, err.Message)
+			continue
+		}
+		if fs.Version != expected {
+			t.Errorf("expected %s to resolve to %s, got %s", version, expected, fs.Version)
+		}
+	}
+
+	if _, err := featureSetService.GetFeatureSetByVersion("myfeatureset", "^3.0", ""); err == nil || err.Status != http.StatusNotFound {
+		t.Errorf("expected not found for an unmatched range, got %v", err)
+	}
+	if _, err := featureSetService.GetFeatureSetByVersion("myfeatureset", "not-a-version", ""); err == nil || err.Status != http.StatusBadRequest {
+		t.Errorf("expected bad request for a malformed range, got %v", err)
+	}
+	if _, err := featureSetService.GetFeatureSetByVersion("myfeatureset", "latest", "customer1"); err == nil || err.Status != http.StatusNotFound {
+		t.Errorf("expected not found for another entity, got %v", err)
+	}
+}
@@ -0,0 +1,6 @@
+# Generates the go messages and gRPC stubs in proto/*pb from the protos in proto/mastro
+# requires buf, protoc-gen-go and protoc-gen-go-grpc, e.g.
+# go install github.com/bufbuild/buf/cmd/buf@v1.73.0
+# go install google.golang.org/protobuf/cmd/protoc-gen-go@v1.36.12
+# go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@v1.6.2
+buf generate proto --template proto/buf.gen.yaml
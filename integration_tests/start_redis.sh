@@ -0,0 +1,7 @@
+export DB_PASSWORD=test
+
+docker run --rm \
+--name test_redis \
+-p 6379:6379 \
+redis:6 \
+redis-server --requirepass $DB_PASSWORD
@@ -1,10 +1,11 @@
 package main
 
 import (
-	"log"
+	"context"
+	"fmt"
 	"os"
 	"os/signal"
-	"sync"
+	"syscall"
 
 	"github.com/alexflint/go-arg"
 	"github.com/kelseyhightower/envconfig"
@@ -12,65 +13,89 @@ import (
 	"github.com/pilillo/mastro/catalogue/crawlers"
 	"github.com/pilillo/mastro/featurestore"
 	"github.com/pilillo/mastro/utils/conf"
+	"github.com/pilillo/mastro/utils/logging"
 	"github.com/pilillo/mastro/utils/ux"
 )
 
-func waitForCtrlC() {
-	var endWaiter sync.WaitGroup
-	endWaiter.Add(1)
-	var signalChannel chan os.Signal
-	signalChannel = make(chan os.Signal, 1)
-	signal.Notify(signalChannel, os.Interrupt)
-	go func() {
-		<-signalChannel
-		endWaiter.Done()
-	}()
-	endWaiter.Wait()
+// waitForSignal ... blocks until the process is asked to terminate, with Ctrl+C or by the orchestrator
+func waitForSignal() os.Signal {
+	signalChannel := make(chan os.Signal, 1)
+	signal.Notify(signalChannel, os.Interrupt, syscall.SIGTERM)
+	return <-signalChannel
 }
 
-func loadCfg() *conf.Config {
+// parseArgs ... reads the arguments from the env vars, or from the command line if any is given
+func parseArgs() {
 	err := envconfig.Process("mastro", &conf.Args)
 	if err != nil {
-		log.Printf("Impossible to parse from env vars - %v", err.Error())
-		log.Printf("Attempting parsing string arguments")
+		logging.Warn("Impossible to parse from env vars", "error", err)
+		logging.Info("Attempting parsing string arguments")
+	}
+	// the command line takes precedence, e.g. to validate a configuration
+	if err != nil || len(os.Args) > 1 {
 		arg.MustParse(&conf.Args)
 	}
-	// load config from file
-	return conf.Load(conf.Args.Config)
 }
 
-func start() {
+func start() error {
 	switch Cfg.ConfigType {
 	case "crawler":
 		_, err := crawlers.Start(Cfg)
-		if err != nil {
-			panic(err.Error())
-		}
+		return err
 	case "catalogue":
-		catalogue.StartEndpoint(Cfg)
+		return catalogue.StartEndpoint(Cfg)
 	case "featurestore":
-		featurestore.StartEndpoint(Cfg)
+		return featurestore.StartEndpoint(Cfg)
 	default:
-		log.Println("Invalid config type", Cfg.ConfigType)
+		return fmt.Errorf("Invalid config type %s", Cfg.ConfigType)
 	}
 }
 
+// stop ... gracefully stops the started service, within the context
+func stop(ctx context.Context) error {
+	switch Cfg.ConfigType {
+	case "crawler":
+		return crawlers.Shutdown(ctx)
+	case "catalogue":
+		return catalogue.Shutdown(ctx)
+	case "featurestore":
+		return featurestore.Shutdown(ctx)
+	}
+	return nil
+}
+
 var (
 	// Cfg ... global Config
 	Cfg *conf.Config
 )
 
 func main() {
-	log.Println("Starting")
-	log.Println(ux.Header)
-	log.Println(ux.Description)
+	logging.Info("Starting")
+	fmt.Fprintln(os.Stderr, ux.Header)
+	logging.Info(ux.Description)
 
-	// load configuration
-	Cfg = loadCfg()
+	parseArgs()
+	if conf.Args.Validate != nil {
+		os.Exit(conf.ValidateFile(conf.Args.Config))
+	}
+	// load configuration from file
+	Cfg = conf.Load(conf.Args.Config)
 
 	// start selected service
-	start()
+	if err := start(); err != nil {
+		logging.Fatal("Failed starting", "type", Cfg.ConfigType, "error", err)
+	}
+	timeout, err := Cfg.ShutdownTimeout()
+	if err != nil {
+		logging.Fatal("Invalid configuration", "error", err)
+	}
 
-	log.Println("Waiting for Ctrl+C...")
-	waitForCtrlC()
+	sig := waitForSignal()
+	logging.Info("Shutting down", "signal", sig, "timeout", timeout)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	if err := stop(ctx); err != nil {
+		logging.Fatal("Failed shutting down gracefully", "error", err)
+	}
+	logging.Info("Stopped")
 }
@@ -0,0 +1,2357 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: mastro/catalogue/catalogue.proto
+
+// the catalogue of data assets, served over gRPC alongside the REST endpoints
+
+package cataloguepb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Asset ... a data asset, as abstract.Asset
+type Asset struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Name        string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Type        string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	DependsOn   []string               `protobuf:"bytes,4,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	// labels ... free-form labels, e.g. the schema of a table
+	Labels           *structpb.Struct       `protobuf:"bytes,5,opt,name=labels,proto3" json:"labels,omitempty"`
+	Tags             []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	Owners           []string               `protobuf:"bytes,7,rep,name=owners,proto3" json:"owners,omitempty"`
+	Steward          string                 `protobuf:"bytes,8,opt,name=steward,proto3" json:"steward,omitempty"`
+	Domain           string                 `protobuf:"bytes,9,opt,name=domain,proto3" json:"domain,omitempty"`
+	Team             string                 `protobuf:"bytes,10,opt,name=team,proto3" json:"team,omitempty"`
+	Contact          string                 `protobuf:"bytes,11,opt,name=contact,proto3" json:"contact,omitempty"`
+	Classification   string                 `protobuf:"bytes,12,opt,name=classification,proto3" json:"classification,omitempty"`
+	RedactedColumns  int32                  `protobuf:"varint,13,opt,name=redacted_columns,json=redactedColumns,proto3" json:"redacted_columns,omitempty"`
+	DiscoveredBy     string                 `protobuf:"bytes,14,opt,name=discovered_by,json=discoveredBy,proto3" json:"discovered_by,omitempty"`
+	LastDiscoveredAt *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=last_discovered_at,json=lastDiscoveredAt,proto3" json:"last_discovered_at,omitempty"`
+	PublishedOn      *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=published_on,json=publishedOn,proto3" json:"published_on,omitempty"`
+	StaleSince       *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=stale_since,json=staleSince,proto3" json:"stale_since,omitempty"`
+	DeletedAt        *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Asset) Reset() {
+	*x = Asset{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Asset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Asset) ProtoMessage() {}
+
+func (x *Asset) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Asset.ProtoReflect.Descriptor instead.
+func (*Asset) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Asset) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Asset) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Asset) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Asset) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *Asset) GetLabels() *structpb.Struct {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *Asset) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Asset) GetOwners() []string {
+	if x != nil {
+		return x.Owners
+	}
+	return nil
+}
+
+func (x *Asset) GetSteward() string {
+	if x != nil {
+		return x.Steward
+	}
+	return ""
+}
+
+func (x *Asset) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *Asset) GetTeam() string {
+	if x != nil {
+		return x.Team
+	}
+	return ""
+}
+
+func (x *Asset) GetContact() string {
+	if x != nil {
+		return x.Contact
+	}
+	return ""
+}
+
+func (x *Asset) GetClassification() string {
+	if x != nil {
+		return x.Classification
+	}
+	return ""
+}
+
+func (x *Asset) GetRedactedColumns() int32 {
+	if x != nil {
+		return x.RedactedColumns
+	}
+	return 0
+}
+
+func (x *Asset) GetDiscoveredBy() string {
+	if x != nil {
+		return x.DiscoveredBy
+	}
+	return ""
+}
+
+func (x *Asset) GetLastDiscoveredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastDiscoveredAt
+	}
+	return nil
+}
+
+func (x *Asset) GetPublishedOn() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishedOn
+	}
+	return nil
+}
+
+func (x *Asset) GetStaleSince() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StaleSince
+	}
+	return nil
+}
+
+func (x *Asset) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+type AssetList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assets        []*Asset               `protobuf:"bytes,1,rep,name=assets,proto3" json:"assets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssetList) Reset() {
+	*x = AssetList{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssetList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetList) ProtoMessage() {}
+
+func (x *AssetList) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetList.ProtoReflect.Descriptor instead.
+func (*AssetList) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *AssetList) GetAssets() []*Asset {
+	if x != nil {
+		return x.Assets
+	}
+	return nil
+}
+
+type GetAssetByIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAssetByIDRequest) Reset() {
+	*x = GetAssetByIDRequest{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAssetByIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAssetByIDRequest) ProtoMessage() {}
+
+func (x *GetAssetByIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAssetByIDRequest.ProtoReflect.Descriptor instead.
+func (*GetAssetByIDRequest) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetAssetByIDRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetAssetByNameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAssetByNameRequest) Reset() {
+	*x = GetAssetByNameRequest{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAssetByNameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAssetByNameRequest) ProtoMessage() {}
+
+func (x *GetAssetByNameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAssetByNameRequest.ProtoReflect.Descriptor instead.
+func (*GetAssetByNameRequest) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetAssetByNameRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type SearchAssetsByTagsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tags          []string               `protobuf:"bytes,1,rep,name=tags,proto3" json:"tags,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchAssetsByTagsRequest) Reset() {
+	*x = SearchAssetsByTagsRequest{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchAssetsByTagsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchAssetsByTagsRequest) ProtoMessage() {}
+
+func (x *SearchAssetsByTagsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchAssetsByTagsRequest.ProtoReflect.Descriptor instead.
+func (*SearchAssetsByTagsRequest) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SearchAssetsByTagsRequest) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+// AssetSearch ... full-text query and filters, as abstract.AssetSearch
+type AssetSearch struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Query           string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Types           []string               `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"`
+	Tags            []string               `protobuf:"bytes,3,rep,name=tags,proto3" json:"tags,omitempty"`
+	Labels          map[string]string      `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	DependsOn       []string               `protobuf:"bytes,5,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	Owner           string                 `protobuf:"bytes,6,opt,name=owner,proto3" json:"owner,omitempty"`
+	Domain          string                 `protobuf:"bytes,7,opt,name=domain,proto3" json:"domain,omitempty"`
+	Classifications []string               `protobuf:"bytes,8,rep,name=classifications,proto3" json:"classifications,omitempty"`
+	PublishedFrom   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=published_from,json=publishedFrom,proto3" json:"published_from,omitempty"`
+	PublishedTo     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=published_to,json=publishedTo,proto3" json:"published_to,omitempty"`
+	// stale ... only stale or only fresh assets if set, both otherwise
+	Stale          *bool `protobuf:"varint,11,opt,name=stale,proto3,oneof" json:"stale,omitempty"`
+	IncludeDeleted bool  `protobuf:"varint,12,opt,name=include_deleted,json=includeDeleted,proto3" json:"include_deleted,omitempty"`
+	Limit          int32 `protobuf:"varint,13,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AssetSearch) Reset() {
+	*x = AssetSearch{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssetSearch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetSearch) ProtoMessage() {}
+
+func (x *AssetSearch) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetSearch.ProtoReflect.Descriptor instead.
+func (*AssetSearch) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AssetSearch) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *AssetSearch) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *AssetSearch) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *AssetSearch) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *AssetSearch) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *AssetSearch) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *AssetSearch) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *AssetSearch) GetClassifications() []string {
+	if x != nil {
+		return x.Classifications
+	}
+	return nil
+}
+
+func (x *AssetSearch) GetPublishedFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishedFrom
+	}
+	return nil
+}
+
+func (x *AssetSearch) GetPublishedTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishedTo
+	}
+	return nil
+}
+
+func (x *AssetSearch) GetStale() bool {
+	if x != nil && x.Stale != nil {
+		return *x.Stale
+	}
+	return false
+}
+
+func (x *AssetSearch) GetIncludeDeleted() bool {
+	if x != nil {
+		return x.IncludeDeleted
+	}
+	return false
+}
+
+func (x *AssetSearch) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type AssetFacets struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Types         map[string]int32       `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	Tags          map[string]int32       `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssetFacets) Reset() {
+	*x = AssetFacets{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssetFacets) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetFacets) ProtoMessage() {}
+
+func (x *AssetFacets) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetFacets.ProtoReflect.Descriptor instead.
+func (*AssetFacets) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AssetFacets) GetTypes() map[string]int32 {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *AssetFacets) GetTags() map[string]int32 {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+type AssetSearchResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Total         int32                  `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Assets        []*Asset               `protobuf:"bytes,2,rep,name=assets,proto3" json:"assets,omitempty"`
+	Facets        *AssetFacets           `protobuf:"bytes,3,opt,name=facets,proto3" json:"facets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssetSearchResult) Reset() {
+	*x = AssetSearchResult{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssetSearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetSearchResult) ProtoMessage() {}
+
+func (x *AssetSearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetSearchResult.ProtoReflect.Descriptor instead.
+func (*AssetSearchResult) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AssetSearchResult) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *AssetSearchResult) GetAssets() []*Asset {
+	if x != nil {
+		return x.Assets
+	}
+	return nil
+}
+
+func (x *AssetSearchResult) GetFacets() *AssetFacets {
+	if x != nil {
+		return x.Facets
+	}
+	return nil
+}
+
+type ListAssetsByOwnerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Owner         string                 `protobuf:"bytes,1,opt,name=owner,proto3" json:"owner,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssetsByOwnerRequest) Reset() {
+	*x = ListAssetsByOwnerRequest{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssetsByOwnerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssetsByOwnerRequest) ProtoMessage() {}
+
+func (x *ListAssetsByOwnerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssetsByOwnerRequest.ProtoReflect.Descriptor instead.
+func (*ListAssetsByOwnerRequest) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListAssetsByOwnerRequest) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *ListAssetsByOwnerRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListAssetsByDomainRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domain        string                 `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssetsByDomainRequest) Reset() {
+	*x = ListAssetsByDomainRequest{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssetsByDomainRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssetsByDomainRequest) ProtoMessage() {}
+
+func (x *ListAssetsByDomainRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssetsByDomainRequest.ProtoReflect.Descriptor instead.
+func (*ListAssetsByDomainRequest) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListAssetsByDomainRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *ListAssetsByDomainRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type GetAssetLineageRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// direction ... upstream, downstream or both (default)
+	Direction     string `protobuf:"bytes,2,opt,name=direction,proto3" json:"direction,omitempty"`
+	Depth         int32  `protobuf:"varint,3,opt,name=depth,proto3" json:"depth,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAssetLineageRequest) Reset() {
+	*x = GetAssetLineageRequest{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAssetLineageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAssetLineageRequest) ProtoMessage() {}
+
+func (x *GetAssetLineageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAssetLineageRequest.ProtoReflect.Descriptor instead.
+func (*GetAssetLineageRequest) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetAssetLineageRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetAssetLineageRequest) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *GetAssetLineageRequest) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+type LineageNode struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type           string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Direction      string                 `protobuf:"bytes,3,opt,name=direction,proto3" json:"direction,omitempty"`
+	Distance       int32                  `protobuf:"varint,4,opt,name=distance,proto3" json:"distance,omitempty"`
+	Dangling       bool                   `protobuf:"varint,5,opt,name=dangling,proto3" json:"dangling,omitempty"`
+	Classification string                 `protobuf:"bytes,6,opt,name=classification,proto3" json:"classification,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *LineageNode) Reset() {
+	*x = LineageNode{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LineageNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LineageNode) ProtoMessage() {}
+
+func (x *LineageNode) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LineageNode.ProtoReflect.Descriptor instead.
+func (*LineageNode) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *LineageNode) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LineageNode) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *LineageNode) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *LineageNode) GetDistance() int32 {
+	if x != nil {
+		return x.Distance
+	}
+	return 0
+}
+
+func (x *LineageNode) GetDangling() bool {
+	if x != nil {
+		return x.Dangling
+	}
+	return false
+}
+
+func (x *LineageNode) GetClassification() string {
+	if x != nil {
+		return x.Classification
+	}
+	return ""
+}
+
+type LineageEdge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          string                 `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	To            string                 `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LineageEdge) Reset() {
+	*x = LineageEdge{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LineageEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LineageEdge) ProtoMessage() {}
+
+func (x *LineageEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LineageEdge.ProtoReflect.Descriptor instead.
+func (*LineageEdge) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *LineageEdge) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *LineageEdge) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+type LineageCycle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Names         []string               `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LineageCycle) Reset() {
+	*x = LineageCycle{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LineageCycle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LineageCycle) ProtoMessage() {}
+
+func (x *LineageCycle) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LineageCycle.ProtoReflect.Descriptor instead.
+func (*LineageCycle) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *LineageCycle) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+type LineageGraph struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Root          string                 `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	Depth         int32                  `protobuf:"varint,2,opt,name=depth,proto3" json:"depth,omitempty"`
+	Nodes         []*LineageNode         `protobuf:"bytes,3,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Edges         []*LineageEdge         `protobuf:"bytes,4,rep,name=edges,proto3" json:"edges,omitempty"`
+	Dangling      []string               `protobuf:"bytes,5,rep,name=dangling,proto3" json:"dangling,omitempty"`
+	Cycles        []*LineageCycle        `protobuf:"bytes,6,rep,name=cycles,proto3" json:"cycles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LineageGraph) Reset() {
+	*x = LineageGraph{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LineageGraph) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LineageGraph) ProtoMessage() {}
+
+func (x *LineageGraph) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LineageGraph.ProtoReflect.Descriptor instead.
+func (*LineageGraph) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *LineageGraph) GetRoot() string {
+	if x != nil {
+		return x.Root
+	}
+	return ""
+}
+
+func (x *LineageGraph) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *LineageGraph) GetNodes() []*LineageNode {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+func (x *LineageGraph) GetEdges() []*LineageEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *LineageGraph) GetDangling() []string {
+	if x != nil {
+		return x.Dangling
+	}
+	return nil
+}
+
+func (x *LineageGraph) GetCycles() []*LineageCycle {
+	if x != nil {
+		return x.Cycles
+	}
+	return nil
+}
+
+type DeleteAssetRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// purge ... removes the asset and its history rather than marking it as deleted
+	Purge         bool `protobuf:"varint,2,opt,name=purge,proto3" json:"purge,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAssetRequest) Reset() {
+	*x = DeleteAssetRequest{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAssetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAssetRequest) ProtoMessage() {}
+
+func (x *DeleteAssetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAssetRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAssetRequest) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteAssetRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DeleteAssetRequest) GetPurge() bool {
+	if x != nil {
+		return x.Purge
+	}
+	return false
+}
+
+type ReconcileAssetsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DiscoveredBy  string                 `protobuf:"bytes,1,opt,name=discovered_by,json=discoveredBy,proto3" json:"discovered_by,omitempty"`
+	Names         []string               `protobuf:"bytes,2,rep,name=names,proto3" json:"names,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileAssetsRequest) Reset() {
+	*x = ReconcileAssetsRequest{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileAssetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileAssetsRequest) ProtoMessage() {}
+
+func (x *ReconcileAssetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileAssetsRequest.ProtoReflect.Descriptor instead.
+func (*ReconcileAssetsRequest) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ReconcileAssetsRequest) GetDiscoveredBy() string {
+	if x != nil {
+		return x.DiscoveredBy
+	}
+	return ""
+}
+
+func (x *ReconcileAssetsRequest) GetNames() []string {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+type ReconcileAssetsReply struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DiscoveredBy  string                 `protobuf:"bytes,1,opt,name=discovered_by,json=discoveredBy,proto3" json:"discovered_by,omitempty"`
+	Stale         int32                  `protobuf:"varint,2,opt,name=stale,proto3" json:"stale,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileAssetsReply) Reset() {
+	*x = ReconcileAssetsReply{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileAssetsReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileAssetsReply) ProtoMessage() {}
+
+func (x *ReconcileAssetsReply) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileAssetsReply.ProtoReflect.Descriptor instead.
+func (*ReconcileAssetsReply) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ReconcileAssetsReply) GetDiscoveredBy() string {
+	if x != nil {
+		return x.DiscoveredBy
+	}
+	return ""
+}
+
+func (x *ReconcileAssetsReply) GetStale() int32 {
+	if x != nil {
+		return x.Stale
+	}
+	return 0
+}
+
+// ListRequest ... pagination, sorting and projection of a list
+type ListRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Limit  int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// cursor ... returned with the previous page, takes precedence over the offset
+	Cursor string `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// sort ... sort key, prefixed by - for a descending order
+	Sort string `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`
+	// fields ... fields of the assets to return, all if empty
+	Fields        []string `protobuf:"bytes,5,rep,name=fields,proto3" json:"fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListRequest) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type AssetPage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Assets        []*Asset               `protobuf:"bytes,1,rep,name=assets,proto3" json:"assets,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssetPage) Reset() {
+	*x = AssetPage{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssetPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetPage) ProtoMessage() {}
+
+func (x *AssetPage) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetPage.ProtoReflect.Descriptor instead.
+func (*AssetPage) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AssetPage) GetAssets() []*Asset {
+	if x != nil {
+		return x.Assets
+	}
+	return nil
+}
+
+func (x *AssetPage) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type ListAssetRevisionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListAssetRevisionsRequest) Reset() {
+	*x = ListAssetRevisionsRequest{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListAssetRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAssetRevisionsRequest) ProtoMessage() {}
+
+func (x *ListAssetRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAssetRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*ListAssetRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListAssetRevisionsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type SchemaChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Column        string                 `protobuf:"bytes,1,opt,name=column,proto3" json:"column,omitempty"`
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`
+	OldType       string                 `protobuf:"bytes,3,opt,name=old_type,json=oldType,proto3" json:"old_type,omitempty"`
+	NewType       string                 `protobuf:"bytes,4,opt,name=new_type,json=newType,proto3" json:"new_type,omitempty"`
+	OldComment    string                 `protobuf:"bytes,5,opt,name=old_comment,json=oldComment,proto3" json:"old_comment,omitempty"`
+	NewComment    string                 `protobuf:"bytes,6,opt,name=new_comment,json=newComment,proto3" json:"new_comment,omitempty"`
+	Breaking      bool                   `protobuf:"varint,7,opt,name=breaking,proto3" json:"breaking,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchemaChange) Reset() {
+	*x = SchemaChange{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchemaChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchemaChange) ProtoMessage() {}
+
+func (x *SchemaChange) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchemaChange.ProtoReflect.Descriptor instead.
+func (*SchemaChange) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SchemaChange) GetColumn() string {
+	if x != nil {
+		return x.Column
+	}
+	return ""
+}
+
+func (x *SchemaChange) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *SchemaChange) GetOldType() string {
+	if x != nil {
+		return x.OldType
+	}
+	return ""
+}
+
+func (x *SchemaChange) GetNewType() string {
+	if x != nil {
+		return x.NewType
+	}
+	return ""
+}
+
+func (x *SchemaChange) GetOldComment() string {
+	if x != nil {
+		return x.OldComment
+	}
+	return ""
+}
+
+func (x *SchemaChange) GetNewComment() string {
+	if x != nil {
+		return x.NewComment
+	}
+	return ""
+}
+
+func (x *SchemaChange) GetBreaking() bool {
+	if x != nil {
+		return x.Breaking
+	}
+	return false
+}
+
+type AssetRevision struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Revision      int32                  `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Author        string                 `protobuf:"bytes,4,opt,name=author,proto3" json:"author,omitempty"`
+	Asset         *Asset                 `protobuf:"bytes,5,opt,name=asset,proto3" json:"asset,omitempty"`
+	SchemaChanges []*SchemaChange        `protobuf:"bytes,6,rep,name=schema_changes,json=schemaChanges,proto3" json:"schema_changes,omitempty"`
+	Impacted      []string               `protobuf:"bytes,7,rep,name=impacted,proto3" json:"impacted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssetRevision) Reset() {
+	*x = AssetRevision{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssetRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetRevision) ProtoMessage() {}
+
+func (x *AssetRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetRevision.ProtoReflect.Descriptor instead.
+func (*AssetRevision) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *AssetRevision) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AssetRevision) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *AssetRevision) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *AssetRevision) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *AssetRevision) GetAsset() *Asset {
+	if x != nil {
+		return x.Asset
+	}
+	return nil
+}
+
+func (x *AssetRevision) GetSchemaChanges() []*SchemaChange {
+	if x != nil {
+		return x.SchemaChanges
+	}
+	return nil
+}
+
+func (x *AssetRevision) GetImpacted() []string {
+	if x != nil {
+		return x.Impacted
+	}
+	return nil
+}
+
+type AssetRevisionList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revisions     []*AssetRevision       `protobuf:"bytes,1,rep,name=revisions,proto3" json:"revisions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssetRevisionList) Reset() {
+	*x = AssetRevisionList{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssetRevisionList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetRevisionList) ProtoMessage() {}
+
+func (x *AssetRevisionList) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetRevisionList.ProtoReflect.Descriptor instead.
+func (*AssetRevisionList) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AssetRevisionList) GetRevisions() []*AssetRevision {
+	if x != nil {
+		return x.Revisions
+	}
+	return nil
+}
+
+type DiffAssetRevisionsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// from ... defaults to the revision before to
+	From int32 `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	// to ... defaults to the latest revision
+	To            int32 `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DiffAssetRevisionsRequest) Reset() {
+	*x = DiffAssetRevisionsRequest{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffAssetRevisionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffAssetRevisionsRequest) ProtoMessage() {}
+
+func (x *DiffAssetRevisionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffAssetRevisionsRequest.ProtoReflect.Descriptor instead.
+func (*DiffAssetRevisionsRequest) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DiffAssetRevisionsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DiffAssetRevisionsRequest) GetFrom() int32 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *DiffAssetRevisionsRequest) GetTo() int32 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+type Change struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Change        string                 `protobuf:"bytes,2,opt,name=change,proto3" json:"change,omitempty"`
+	Old           *structpb.Value        `protobuf:"bytes,3,opt,name=old,proto3" json:"old,omitempty"`
+	New           *structpb.Value        `protobuf:"bytes,4,opt,name=new,proto3" json:"new,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Change) Reset() {
+	*x = Change{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Change) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Change) ProtoMessage() {}
+
+func (x *Change) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Change.ProtoReflect.Descriptor instead.
+func (*Change) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Change) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *Change) GetChange() string {
+	if x != nil {
+		return x.Change
+	}
+	return ""
+}
+
+func (x *Change) GetOld() *structpb.Value {
+	if x != nil {
+		return x.Old
+	}
+	return nil
+}
+
+func (x *Change) GetNew() *structpb.Value {
+	if x != nil {
+		return x.New
+	}
+	return nil
+}
+
+type AssetDiff struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	From          int32                  `protobuf:"varint,2,opt,name=from,proto3" json:"from,omitempty"`
+	To            int32                  `protobuf:"varint,3,opt,name=to,proto3" json:"to,omitempty"`
+	Fields        []*Change              `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty"`
+	Columns       []*Change              `protobuf:"bytes,5,rep,name=columns,proto3" json:"columns,omitempty"`
+	SchemaChanges []*SchemaChange        `protobuf:"bytes,6,rep,name=schema_changes,json=schemaChanges,proto3" json:"schema_changes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssetDiff) Reset() {
+	*x = AssetDiff{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssetDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetDiff) ProtoMessage() {}
+
+func (x *AssetDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetDiff.ProtoReflect.Descriptor instead.
+func (*AssetDiff) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *AssetDiff) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AssetDiff) GetFrom() int32 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *AssetDiff) GetTo() int32 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *AssetDiff) GetFields() []*Change {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *AssetDiff) GetColumns() []*Change {
+	if x != nil {
+		return x.Columns
+	}
+	return nil
+}
+
+func (x *AssetDiff) GetSchemaChanges() []*SchemaChange {
+	if x != nil {
+		return x.SchemaChanges
+	}
+	return nil
+}
+
+type ListSchemaChangesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Breaking      bool                   `protobuf:"varint,2,opt,name=breaking,proto3" json:"breaking,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchemaChangesRequest) Reset() {
+	*x = ListSchemaChangesRequest{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchemaChangesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchemaChangesRequest) ProtoMessage() {}
+
+func (x *ListSchemaChangesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchemaChangesRequest.ProtoReflect.Descriptor instead.
+func (*ListSchemaChangesRequest) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListSchemaChangesRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListSchemaChangesRequest) GetBreaking() bool {
+	if x != nil {
+		return x.Breaking
+	}
+	return false
+}
+
+type SchemaEvolution struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Revision      int32                  `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Author        string                 `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	Changes       []*SchemaChange        `protobuf:"bytes,4,rep,name=changes,proto3" json:"changes,omitempty"`
+	Breaking      bool                   `protobuf:"varint,5,opt,name=breaking,proto3" json:"breaking,omitempty"`
+	Impacted      []string               `protobuf:"bytes,6,rep,name=impacted,proto3" json:"impacted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchemaEvolution) Reset() {
+	*x = SchemaEvolution{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchemaEvolution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchemaEvolution) ProtoMessage() {}
+
+func (x *SchemaEvolution) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchemaEvolution.ProtoReflect.Descriptor instead.
+func (*SchemaEvolution) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SchemaEvolution) GetRevision() int32 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *SchemaEvolution) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *SchemaEvolution) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *SchemaEvolution) GetChanges() []*SchemaChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *SchemaEvolution) GetBreaking() bool {
+	if x != nil {
+		return x.Breaking
+	}
+	return false
+}
+
+func (x *SchemaEvolution) GetImpacted() []string {
+	if x != nil {
+		return x.Impacted
+	}
+	return nil
+}
+
+type SchemaEvolutionList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Evolutions    []*SchemaEvolution     `protobuf:"bytes,1,rep,name=evolutions,proto3" json:"evolutions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SchemaEvolutionList) Reset() {
+	*x = SchemaEvolutionList{}
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SchemaEvolutionList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SchemaEvolutionList) ProtoMessage() {}
+
+func (x *SchemaEvolutionList) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_catalogue_catalogue_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SchemaEvolutionList.ProtoReflect.Descriptor instead.
+func (*SchemaEvolutionList) Descriptor() ([]byte, []int) {
+	return file_mastro_catalogue_catalogue_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *SchemaEvolutionList) GetEvolutions() []*SchemaEvolution {
+	if x != nil {
+		return x.Evolutions
+	}
+	return nil
+}
+
+var File_mastro_catalogue_catalogue_proto protoreflect.FileDescriptor
+
+const file_mastro_catalogue_catalogue_proto_rawDesc = "" +
+	"\n" +
+	" mastro/catalogue/catalogue.proto\x12\x10mastro.catalogue\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1cgoogle/protobuf/struct.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xa6\x05\n" +
+	"\x05Asset\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x1d\n" +
+	"\n" +
+	"depends_on\x18\x04 \x03(\tR\tdependsOn\x12/\n" +
+	"\x06labels\x18\x05 \x01(\v2\x17.google.protobuf.StructR\x06labels\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12\x16\n" +
+	"\x06owners\x18\a \x03(\tR\x06owners\x12\x18\n" +
+	"\asteward\x18\b \x01(\tR\asteward\x12\x16\n" +
+	"\x06domain\x18\t \x01(\tR\x06domain\x12\x12\n" +
+	"\x04team\x18\n" +
+	" \x01(\tR\x04team\x12\x18\n" +
+	"\acontact\x18\v \x01(\tR\acontact\x12&\n" +
+	"\x0eclassification\x18\f \x01(\tR\x0eclassification\x12)\n" +
+	"\x10redacted_columns\x18\r \x01(\x05R\x0fredactedColumns\x12#\n" +
+	"\rdiscovered_by\x18\x0e \x01(\tR\fdiscoveredBy\x12H\n" +
+	"\x12last_discovered_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\x10lastDiscoveredAt\x12=\n" +
+	"\fpublished_on\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\vpublishedOn\x12;\n" +
+	"\vstale_since\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"staleSince\x129\n" +
+	"\n" +
+	"deleted_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\"<\n" +
+	"\tAssetList\x12/\n" +
+	"\x06assets\x18\x01 \x03(\v2\x17.mastro.catalogue.AssetR\x06assets\"%\n" +
+	"\x13GetAssetByIDRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"+\n" +
+	"\x15GetAssetByNameRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"/\n" +
+	"\x19SearchAssetsByTagsRequest\x12\x12\n" +
+	"\x04tags\x18\x01 \x03(\tR\x04tags\"\xa8\x04\n" +
+	"\vAssetSearch\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05types\x18\x02 \x03(\tR\x05types\x12\x12\n" +
+	"\x04tags\x18\x03 \x03(\tR\x04tags\x12A\n" +
+	"\x06labels\x18\x04 \x03(\v2).mastro.catalogue.AssetSearch.LabelsEntryR\x06labels\x12\x1d\n" +
+	"\n" +
+	"depends_on\x18\x05 \x03(\tR\tdependsOn\x12\x14\n" +
+	"\x05owner\x18\x06 \x01(\tR\x05owner\x12\x16\n" +
+	"\x06domain\x18\a \x01(\tR\x06domain\x12(\n" +
+	"\x0fclassifications\x18\b \x03(\tR\x0fclassifications\x12A\n" +
+	"\x0epublished_from\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\rpublishedFrom\x12=\n" +
+	"\fpublished_to\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\vpublishedTo\x12\x19\n" +
+	"\x05stale\x18\v \x01(\bH\x00R\x05stale\x88\x01\x01\x12'\n" +
+	"\x0finclude_deleted\x18\f \x01(\bR\x0eincludeDeleted\x12\x14\n" +
+	"\x05limit\x18\r \x01(\x05R\x05limit\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\b\n" +
+	"\x06_stale\"\xfd\x01\n" +
+	"\vAssetFacets\x12>\n" +
+	"\x05types\x18\x01 \x03(\v2(.mastro.catalogue.AssetFacets.TypesEntryR\x05types\x12;\n" +
+	"\x04tags\x18\x02 \x03(\v2'.mastro.catalogue.AssetFacets.TagsEntryR\x04tags\x1a8\n" +
+	"\n" +
+	"TypesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\x1a7\n" +
+	"\tTagsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\x91\x01\n" +
+	"\x11AssetSearchResult\x12\x14\n" +
+	"\x05total\x18\x01 \x01(\x05R\x05total\x12/\n" +
+	"\x06assets\x18\x02 \x03(\v2\x17.mastro.catalogue.AssetR\x06assets\x125\n" +
+	"\x06facets\x18\x03 \x01(\v2\x1d.mastro.catalogue.AssetFacetsR\x06facets\"F\n" +
+	"\x18ListAssetsByOwnerRequest\x12\x14\n" +
+	"\x05owner\x18\x01 \x01(\tR\x05owner\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"I\n" +
+	"\x19ListAssetsByDomainRequest\x12\x16\n" +
+	"\x06domain\x18\x01 \x01(\tR\x06domain\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"`\n" +
+	"\x16GetAssetLineageRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tdirection\x18\x02 \x01(\tR\tdirection\x12\x14\n" +
+	"\x05depth\x18\x03 \x01(\x05R\x05depth\"\xb3\x01\n" +
+	"\vLineageNode\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x1c\n" +
+	"\tdirection\x18\x03 \x01(\tR\tdirection\x12\x1a\n" +
+	"\bdistance\x18\x04 \x01(\x05R\bdistance\x12\x1a\n" +
+	"\bdangling\x18\x05 \x01(\bR\bdangling\x12&\n" +
+	"\x0eclassification\x18\x06 \x01(\tR\x0eclassification\"1\n" +
+	"\vLineageEdge\x12\x12\n" +
+	"\x04from\x18\x01 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x02 \x01(\tR\x02to\"$\n" +
+	"\fLineageCycle\x12\x14\n" +
+	"\x05names\x18\x01 \x03(\tR\x05names\"\xf6\x01\n" +
+	"\fLineageGraph\x12\x12\n" +
+	"\x04root\x18\x01 \x01(\tR\x04root\x12\x14\n" +
+	"\x05depth\x18\x02 \x01(\x05R\x05depth\x123\n" +
+	"\x05nodes\x18\x03 \x03(\v2\x1d.mastro.catalogue.LineageNodeR\x05nodes\x123\n" +
+	"\x05edges\x18\x04 \x03(\v2\x1d.mastro.catalogue.LineageEdgeR\x05edges\x12\x1a\n" +
+	"\bdangling\x18\x05 \x03(\tR\bdangling\x126\n" +
+	"\x06cycles\x18\x06 \x03(\v2\x1e.mastro.catalogue.LineageCycleR\x06cycles\">\n" +
+	"\x12DeleteAssetRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05purge\x18\x02 \x01(\bR\x05purge\"S\n" +
+	"\x16ReconcileAssetsRequest\x12#\n" +
+	"\rdiscovered_by\x18\x01 \x01(\tR\fdiscoveredBy\x12\x14\n" +
+	"\x05names\x18\x02 \x03(\tR\x05names\"Q\n" +
+	"\x14ReconcileAssetsReply\x12#\n" +
+	"\rdiscovered_by\x18\x01 \x01(\tR\fdiscoveredBy\x12\x14\n" +
+	"\x05stale\x18\x02 \x01(\x05R\x05stale\"\x7f\n" +
+	"\vListRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x12\n" +
+	"\x04sort\x18\x04 \x01(\tR\x04sort\x12\x16\n" +
+	"\x06fields\x18\x05 \x03(\tR\x06fields\"]\n" +
+	"\tAssetPage\x12/\n" +
+	"\x06assets\x18\x01 \x03(\v2\x17.mastro.catalogue.AssetR\x06assets\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"/\n" +
+	"\x19ListAssetRevisionsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\xce\x01\n" +
+	"\fSchemaChange\x12\x16\n" +
+	"\x06column\x18\x01 \x01(\tR\x06column\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x19\n" +
+	"\bold_type\x18\x03 \x01(\tR\aoldType\x12\x19\n" +
+	"\bnew_type\x18\x04 \x01(\tR\anewType\x12\x1f\n" +
+	"\vold_comment\x18\x05 \x01(\tR\n" +
+	"oldComment\x12\x1f\n" +
+	"\vnew_comment\x18\x06 \x01(\tR\n" +
+	"newComment\x12\x1a\n" +
+	"\bbreaking\x18\a \x01(\bR\bbreaking\"\xa4\x02\n" +
+	"\rAssetRevision\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\brevision\x18\x02 \x01(\x05R\brevision\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x16\n" +
+	"\x06author\x18\x04 \x01(\tR\x06author\x12-\n" +
+	"\x05asset\x18\x05 \x01(\v2\x17.mastro.catalogue.AssetR\x05asset\x12E\n" +
+	"\x0eschema_changes\x18\x06 \x03(\v2\x1e.mastro.catalogue.SchemaChangeR\rschemaChanges\x12\x1a\n" +
+	"\bimpacted\x18\a \x03(\tR\bimpacted\"R\n" +
+	"\x11AssetRevisionList\x12=\n" +
+	"\trevisions\x18\x01 \x03(\v2\x1f.mastro.catalogue.AssetRevisionR\trevisions\"S\n" +
+	"\x19DiffAssetRevisionsRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\x05R\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\x05R\x02to\"\x8a\x01\n" +
+	"\x06Change\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x16\n" +
+	"\x06change\x18\x02 \x01(\tR\x06change\x12(\n" +
+	"\x03old\x18\x03 \x01(\v2\x16.google.protobuf.ValueR\x03old\x12(\n" +
+	"\x03new\x18\x04 \x01(\v2\x16.google.protobuf.ValueR\x03new\"\xf0\x01\n" +
+	"\tAssetDiff\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\x05R\x04from\x12\x0e\n" +
+	"\x02to\x18\x03 \x01(\x05R\x02to\x120\n" +
+	"\x06fields\x18\x04 \x03(\v2\x18.mastro.catalogue.ChangeR\x06fields\x122\n" +
+	"\acolumns\x18\x05 \x03(\v2\x18.mastro.catalogue.ChangeR\acolumns\x12E\n" +
+	"\x0eschema_changes\x18\x06 \x03(\v2\x1e.mastro.catalogue.SchemaChangeR\rschemaChanges\"J\n" +
+	"\x18ListSchemaChangesRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bbreaking\x18\x02 \x01(\bR\bbreaking\"\xf2\x01\n" +
+	"\x0fSchemaEvolution\x12\x1a\n" +
+	"\brevision\x18\x01 \x01(\x05R\brevision\x129\n" +
+	"\n" +
+	"created_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x16\n" +
+	"\x06author\x18\x03 \x01(\tR\x06author\x128\n" +
+	"\achanges\x18\x04 \x03(\v2\x1e.mastro.catalogue.SchemaChangeR\achanges\x12\x1a\n" +
+	"\bbreaking\x18\x05 \x01(\bR\bbreaking\x12\x1a\n" +
+	"\bimpacted\x18\x06 \x03(\tR\bimpacted\"X\n" +
+	"\x13SchemaEvolutionList\x12A\n" +
+	"\n" +
+	"evolutions\x18\x01 \x03(\v2!.mastro.catalogue.SchemaEvolutionR\n" +
+	"evolutions2\xd1\n" +
+	"\n" +
+	"\tCatalogue\x12H\n" +
+	"\fUpsertAssets\x12\x1b.mastro.catalogue.AssetList\x1a\x1b.mastro.catalogue.AssetList\x12N\n" +
+	"\fGetAssetByID\x12%.mastro.catalogue.GetAssetByIDRequest\x1a\x17.mastro.catalogue.Asset\x12R\n" +
+	"\x0eGetAssetByName\x12'.mastro.catalogue.GetAssetByNameRequest\x1a\x17.mastro.catalogue.Asset\x12^\n" +
+	"\x12SearchAssetsByTags\x12+.mastro.catalogue.SearchAssetsByTagsRequest\x1a\x1b.mastro.catalogue.AssetList\x12R\n" +
+	"\fSearchAssets\x12\x1d.mastro.catalogue.AssetSearch\x1a#.mastro.catalogue.AssetSearchResult\x12d\n" +
+	"\x11ListAssetsByOwner\x12*.mastro.catalogue.ListAssetsByOwnerRequest\x1a#.mastro.catalogue.AssetSearchResult\x12f\n" +
+	"\x12ListAssetsByDomain\x12+.mastro.catalogue.ListAssetsByDomainRequest\x1a#.mastro.catalogue.AssetSearchResult\x12[\n" +
+	"\x0fGetAssetLineage\x12(.mastro.catalogue.GetAssetLineageRequest\x1a\x1e.mastro.catalogue.LineageGraph\x12K\n" +
+	"\vDeleteAsset\x12$.mastro.catalogue.DeleteAssetRequest\x1a\x16.google.protobuf.Empty\x12c\n" +
+	"\x0fReconcileAssets\x12(.mastro.catalogue.ReconcileAssetsRequest\x1a&.mastro.catalogue.ReconcileAssetsReply\x12K\n" +
+	"\rListAllAssets\x12\x1d.mastro.catalogue.ListRequest\x1a\x1b.mastro.catalogue.AssetPage\x12H\n" +
+	"\fStreamAssets\x12\x1d.mastro.catalogue.ListRequest\x1a\x17.mastro.catalogue.Asset0\x01\x12f\n" +
+	"\x12ListAssetRevisions\x12+.mastro.catalogue.ListAssetRevisionsRequest\x1a#.mastro.catalogue.AssetRevisionList\x12^\n" +
+	"\x12DiffAssetRevisions\x12+.mastro.catalogue.DiffAssetRevisionsRequest\x1a\x1b.mastro.catalogue.AssetDiff\x12f\n" +
+	"\x11ListSchemaChanges\x12*.mastro.catalogue.ListSchemaChangesRequest\x1a%.mastro.catalogue.SchemaEvolutionListB-Z+github.com/pilillo/mastro/proto/cataloguepbb\x06proto3"
+
+var (
+	file_mastro_catalogue_catalogue_proto_rawDescOnce sync.Once
+	file_mastro_catalogue_catalogue_proto_rawDescData []byte
+)
+
+func file_mastro_catalogue_catalogue_proto_rawDescGZIP() []byte {
+	file_mastro_catalogue_catalogue_proto_rawDescOnce.Do(func() {
+		file_mastro_catalogue_catalogue_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_mastro_catalogue_catalogue_proto_rawDesc), len(file_mastro_catalogue_catalogue_proto_rawDesc)))
+	})
+	return file_mastro_catalogue_catalogue_proto_rawDescData
+}
+
+var file_mastro_catalogue_catalogue_proto_msgTypes = make([]protoimpl.MessageInfo, 33)
+var file_mastro_catalogue_catalogue_proto_goTypes = []any{
+	(*Asset)(nil),                     // 0: mastro.catalogue.Asset
+	(*AssetList)(nil),                 // 1: mastro.catalogue.AssetList
+	(*GetAssetByIDRequest)(nil),       // 2: mastro.catalogue.GetAssetByIDRequest
+	(*GetAssetByNameRequest)(nil),     // 3: mastro.catalogue.GetAssetByNameRequest
+	(*SearchAssetsByTagsRequest)(nil), // 4: mastro.catalogue.SearchAssetsByTagsRequest
+	(*AssetSearch)(nil),               // 5: mastro.catalogue.AssetSearch
+	(*AssetFacets)(nil),               // 6: mastro.catalogue.AssetFacets
+	(*AssetSearchResult)(nil),         // 7: mastro.catalogue.AssetSearchResult
+	(*ListAssetsByOwnerRequest)(nil),  // 8: mastro.catalogue.ListAssetsByOwnerRequest
+	(*ListAssetsByDomainRequest)(nil), // 9: mastro.catalogue.ListAssetsByDomainRequest
+	(*GetAssetLineageRequest)(nil),    // 10: mastro.catalogue.GetAssetLineageRequest
+	(*LineageNode)(nil),               // 11: mastro.catalogue.LineageNode
+	(*LineageEdge)(nil),               // 12: mastro.catalogue.LineageEdge
+	(*LineageCycle)(nil),              // 13: mastro.catalogue.LineageCycle
+	(*LineageGraph)(nil),              // 14: mastro.catalogue.LineageGraph
+	(*DeleteAssetRequest)(nil),        // 15: mastro.catalogue.DeleteAssetRequest
+	(*ReconcileAssetsRequest)(nil),    // 16: mastro.catalogue.ReconcileAssetsRequest
+	(*ReconcileAssetsReply)(nil),      // 17: mastro.catalogue.ReconcileAssetsReply
+	(*ListRequest)(nil),               // 18: mastro.catalogue.ListRequest
+	(*AssetPage)(nil),                 // 19: mastro.catalogue.AssetPage
+	(*ListAssetRevisionsRequest)(nil), // 20: mastro.catalogue.ListAssetRevisionsRequest
+	(*SchemaChange)(nil),              // 21: mastro.catalogue.SchemaChange
+	(*AssetRevision)(nil),             // 22: mastro.catalogue.AssetRevision
+	(*AssetRevisionList)(nil),         // 23: mastro.catalogue.AssetRevisionList
+	(*DiffAssetRevisionsRequest)(nil), // 24: mastro.catalogue.DiffAssetRevisionsRequest
+	(*Change)(nil),                    // 25: mastro.catalogue.Change
+	(*AssetDiff)(nil),                 // 26: mastro.catalogue.AssetDiff
+	(*ListSchemaChangesRequest)(nil),  // 27: mastro.catalogue.ListSchemaChangesRequest
+	(*SchemaEvolution)(nil),           // 28: mastro.catalogue.SchemaEvolution
+	(*SchemaEvolutionList)(nil),       // 29: mastro.catalogue.SchemaEvolutionList
+	nil,                               // 30: mastro.catalogue.AssetSearch.LabelsEntry
+	nil,                               // 31: mastro.catalogue.AssetFacets.TypesEntry
+	nil,                               // 32: mastro.catalogue.AssetFacets.TagsEntry
+	(*structpb.Struct)(nil),           // 33: google.protobuf.Struct
+	(*timestamppb.Timestamp)(nil),     // 34: google.protobuf.Timestamp
+	(*structpb.Value)(nil),            // 35: google.protobuf.Value
+	(*emptypb.Empty)(nil),             // 36: google.protobuf.Empty
+}
+var file_mastro_catalogue_catalogue_proto_depIdxs = []int32{
+	33, // 0: mastro.catalogue.Asset.labels:type_name -> google.protobuf.Struct
+	34, // 1: mastro.catalogue.Asset.last_discovered_at:type_name -> google.protobuf.Timestamp
+	34, // 2: mastro.catalogue.Asset.published_on:type_name -> google.protobuf.Timestamp
+	34, // 3: mastro.catalogue.Asset.stale_since:type_name -> google.protobuf.Timestamp
+	34, // 4: mastro.catalogue.Asset.deleted_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: mastro.catalogue.AssetList.assets:type_name -> mastro.catalogue.Asset
+	30, // 6: mastro.catalogue.AssetSearch.labels:type_name -> mastro.catalogue.AssetSearch.LabelsEntry
+	34, // 7: mastro.catalogue.AssetSearch.published_from:type_name -> google.protobuf.Timestamp
+	34, // 8: mastro.catalogue.AssetSearch.published_to:type_name -> google.protobuf.Timestamp
+	31, // 9: mastro.catalogue.AssetFacets.types:type_name -> mastro.catalogue.AssetFacets.TypesEntry
+	32, // 10: mastro.catalogue.AssetFacets.tags:type_name -> mastro.catalogue.AssetFacets.TagsEntry
+	0,  // 11: mastro.catalogue.AssetSearchResult.assets:type_name -> mastro.catalogue.Asset
+	6,  // 12: mastro.catalogue.AssetSearchResult.facets:type_name -> mastro.catalogue.AssetFacets
+	11, // 13: mastro.catalogue.LineageGraph.nodes:type_name -> mastro.catalogue.LineageNode
+	12, // 14: mastro.catalogue.LineageGraph.edges:type_name -> mastro.catalogue.LineageEdge
+	13, // 15: mastro.catalogue.LineageGraph.cycles:type_name -> mastro.catalogue.LineageCycle
+	0,  // 16: mastro.catalogue.AssetPage.assets:type_name -> mastro.catalogue.Asset
+	34, // 17: mastro.catalogue.AssetRevision.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 18: mastro.catalogue.AssetRevision.asset:type_name -> mastro.catalogue.Asset
+	21, // 19: mastro.catalogue.AssetRevision.schema_changes:type_name -> mastro.catalogue.SchemaChange
+	22, // 20: mastro.catalogue.AssetRevisionList.revisions:type_name -> mastro.catalogue.AssetRevision
+	35, // 21: mastro.catalogue.Change.old:type_name -> google.protobuf.Value
+	35, // 22: mastro.catalogue.Change.new:type_name -> google.protobuf.Value
+	25, // 23: mastro.catalogue.AssetDiff.fields:type_name -> mastro.catalogue.Change
+	25, // 24: mastro.catalogue.AssetDiff.columns:type_name -> mastro.catalogue.Change
+	21, // 25: mastro.catalogue.AssetDiff.schema_changes:type_name -> mastro.catalogue.SchemaChange
+	34, // 26: mastro.catalogue.SchemaEvolution.created_at:type_name -> google.protobuf.Timestamp
+	21, // 27: mastro.catalogue.SchemaEvolution.changes:type_name -> mastro.catalogue.SchemaChange
+	28, // 28: mastro.catalogue.SchemaEvolutionList.evolutions:type_name -> mastro.catalogue.SchemaEvolution
+	1,  // 29: mastro.catalogue.Catalogue.UpsertAssets:input_type -> mastro.catalogue.AssetList
+	2,  // 30: mastro.catalogue.Catalogue.GetAssetByID:input_type -> mastro.catalogue.GetAssetByIDRequest
+	3,  // 31: mastro.catalogue.Catalogue.GetAssetByName:input_type -> mastro.catalogue.GetAssetByNameRequest
+	4,  // 32: mastro.catalogue.Catalogue.SearchAssetsByTags:input_type -> mastro.catalogue.SearchAssetsByTagsRequest
+	5,  // 33: mastro.catalogue.Catalogue.SearchAssets:input_type -> mastro.catalogue.AssetSearch
+	8,  // 34: mastro.catalogue.Catalogue.ListAssetsByOwner:input_type -> mastro.catalogue.ListAssetsByOwnerRequest
+	9,  // 35: mastro.catalogue.Catalogue.ListAssetsByDomain:input_type -> mastro.catalogue.ListAssetsByDomainRequest
+	10, // 36: mastro.catalogue.Catalogue.GetAssetLineage:input_type -> mastro.catalogue.GetAssetLineageRequest
+	15, // 37: mastro.catalogue.Catalogue.DeleteAsset:input_type -> mastro.catalogue.DeleteAssetRequest
+	16, // 38: mastro.catalogue.Catalogue.ReconcileAssets:input_type -> mastro.catalogue.ReconcileAssetsRequest
+	18, // 39: mastro.catalogue.Catalogue.ListAllAssets:input_type -> mastro.catalogue.ListRequest
+	18, // 40: mastro.catalogue.Catalogue.StreamAssets:input_type -> mastro.catalogue.ListRequest
+	20, // 41: mastro.catalogue.Catalogue.ListAssetRevisions:input_type -> mastro.catalogue.ListAssetRevisionsRequest
+	24, // 42: mastro.catalogue.Catalogue.DiffAssetRevisions:input_type -> mastro.catalogue.DiffAssetRevisionsRequest
+	27, // 43: mastro.catalogue.Catalogue.ListSchemaChanges:input_type -> mastro.catalogue.ListSchemaChangesRequest
+	1,  // 44: mastro.catalogue.Catalogue.UpsertAssets:output_type -> mastro.catalogue.AssetList
+	0,  // 45: mastro.catalogue.Catalogue.GetAssetByID:output_type -> mastro.catalogue.Asset
+	0,  // 46: mastro.catalogue.Catalogue.GetAssetByName:output_type -> mastro.catalogue.Asset
+	1,  // 47: mastro.catalogue.Catalogue.SearchAssetsByTags:output_type -> mastro.catalogue.AssetList
+	7,  // 48: mastro.catalogue.Catalogue.SearchAssets:output_type -> mastro.catalogue.AssetSearchResult
+	7,  // 49: mastro.catalogue.Catalogue.ListAssetsByOwner:output_type -> mastro.catalogue.AssetSearchResult
+	7,  // 50: mastro.catalogue.Catalogue.ListAssetsByDomain:output_type -> mastro.catalogue.AssetSearchResult
+	14, // 51: mastro.catalogue.Catalogue.GetAssetLineage:output_type -> mastro.catalogue.LineageGraph
+	36, // 52: mastro.catalogue.Catalogue.DeleteAsset:output_type -> google.protobuf.Empty
+	17, // 53: mastro.catalogue.Catalogue.ReconcileAssets:output_type -> mastro.catalogue.ReconcileAssetsReply
+	19, // 54: mastro.catalogue.Catalogue.ListAllAssets:output_type -> mastro.catalogue.AssetPage
+	0,  // 55: mastro.catalogue.Catalogue.StreamAssets:output_type -> mastro.catalogue.Asset
+	23, // 56: mastro.catalogue.Catalogue.ListAssetRevisions:output_type -> mastro.catalogue.AssetRevisionList
+	26, // 57: mastro.catalogue.Catalogue.DiffAssetRevisions:output_type -> mastro.catalogue.AssetDiff
+	29, // 58: mastro.catalogue.Catalogue.ListSchemaChanges:output_type -> mastro.catalogue.SchemaEvolutionList
+	44, // [44:59] is the sub-list for method output_type
+	29, // [29:44] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
+}
+
+func init() { file_mastro_catalogue_catalogue_proto_init() }
+func file_mastro_catalogue_catalogue_proto_init() {
+	if File_mastro_catalogue_catalogue_proto != nil {
+		return
+	}
+	file_mastro_catalogue_catalogue_proto_msgTypes[5].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mastro_catalogue_catalogue_proto_rawDesc), len(file_mastro_catalogue_catalogue_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   33,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_mastro_catalogue_catalogue_proto_goTypes,
+		DependencyIndexes: file_mastro_catalogue_catalogue_proto_depIdxs,
+		MessageInfos:      file_mastro_catalogue_catalogue_proto_msgTypes,
+	}.Build()
+	File_mastro_catalogue_catalogue_proto = out.File
+	file_mastro_catalogue_catalogue_proto_goTypes = nil
+	file_mastro_catalogue_catalogue_proto_depIdxs = nil
+}
@@ -0,0 +1,666 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: mastro/catalogue/catalogue.proto
+
+// the catalogue of data assets, served over gRPC alongside the REST endpoints
+
+package cataloguepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Catalogue_UpsertAssets_FullMethodName       = "/mastro.catalogue.Catalogue/UpsertAssets"
+	Catalogue_GetAssetByID_FullMethodName       = "/mastro.catalogue.Catalogue/GetAssetByID"
+	Catalogue_GetAssetByName_FullMethodName     = "/mastro.catalogue.Catalogue/GetAssetByName"
+	Catalogue_SearchAssetsByTags_FullMethodName = "/mastro.catalogue.Catalogue/SearchAssetsByTags"
+	Catalogue_SearchAssets_FullMethodName       = "/mastro.catalogue.Catalogue/SearchAssets"
+	Catalogue_ListAssetsByOwner_FullMethodName  = "/mastro.catalogue.Catalogue/ListAssetsByOwner"
+	Catalogue_ListAssetsByDomain_FullMethodName = "/mastro.catalogue.Catalogue/ListAssetsByDomain"
+	Catalogue_GetAssetLineage_FullMethodName    = "/mastro.catalogue.Catalogue/GetAssetLineage"
+	Catalogue_DeleteAsset_FullMethodName        = "/mastro.catalogue.Catalogue/DeleteAsset"
+	Catalogue_ReconcileAssets_FullMethodName    = "/mastro.catalogue.Catalogue/ReconcileAssets"
+	Catalogue_ListAllAssets_FullMethodName      = "/mastro.catalogue.Catalogue/ListAllAssets"
+	Catalogue_StreamAssets_FullMethodName       = "/mastro.catalogue.Catalogue/StreamAssets"
+	Catalogue_ListAssetRevisions_FullMethodName = "/mastro.catalogue.Catalogue/ListAssetRevisions"
+	Catalogue_DiffAssetRevisions_FullMethodName = "/mastro.catalogue.Catalogue/DiffAssetRevisions"
+	Catalogue_ListSchemaChanges_FullMethodName  = "/mastro.catalogue.Catalogue/ListSchemaChanges"
+)
+
+// CatalogueClient is the client API for Catalogue service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Catalogue ... the operations of catalogue.Service
+type CatalogueClient interface {
+	UpsertAssets(ctx context.Context, in *AssetList, opts ...grpc.CallOption) (*AssetList, error)
+	GetAssetByID(ctx context.Context, in *GetAssetByIDRequest, opts ...grpc.CallOption) (*Asset, error)
+	GetAssetByName(ctx context.Context, in *GetAssetByNameRequest, opts ...grpc.CallOption) (*Asset, error)
+	SearchAssetsByTags(ctx context.Context, in *SearchAssetsByTagsRequest, opts ...grpc.CallOption) (*AssetList, error)
+	SearchAssets(ctx context.Context, in *AssetSearch, opts ...grpc.CallOption) (*AssetSearchResult, error)
+	ListAssetsByOwner(ctx context.Context, in *ListAssetsByOwnerRequest, opts ...grpc.CallOption) (*AssetSearchResult, error)
+	ListAssetsByDomain(ctx context.Context, in *ListAssetsByDomainRequest, opts ...grpc.CallOption) (*AssetSearchResult, error)
+	GetAssetLineage(ctx context.Context, in *GetAssetLineageRequest, opts ...grpc.CallOption) (*LineageGraph, error)
+	DeleteAsset(ctx context.Context, in *DeleteAssetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ReconcileAssets(ctx context.Context, in *ReconcileAssetsRequest, opts ...grpc.CallOption) (*ReconcileAssetsReply, error)
+	ListAllAssets(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*AssetPage, error)
+	// StreamAssets ... sends all assets one per message, going through the pages of the given size and sort
+	StreamAssets(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Asset], error)
+	ListAssetRevisions(ctx context.Context, in *ListAssetRevisionsRequest, opts ...grpc.CallOption) (*AssetRevisionList, error)
+	DiffAssetRevisions(ctx context.Context, in *DiffAssetRevisionsRequest, opts ...grpc.CallOption) (*AssetDiff, error)
+	ListSchemaChanges(ctx context.Context, in *ListSchemaChangesRequest, opts ...grpc.CallOption) (*SchemaEvolutionList, error)
+}
+
+type catalogueClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCatalogueClient(cc grpc.ClientConnInterface) CatalogueClient {
+	return &catalogueClient{cc}
+}
+
+func (c *catalogueClient) UpsertAssets(ctx context.Context, in *AssetList, opts ...grpc.CallOption) (*AssetList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssetList)
+	err := c.cc.Invoke(ctx, Catalogue_UpsertAssets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogueClient) GetAssetByID(ctx context.Context, in *GetAssetByIDRequest, opts ...grpc.CallOption) (*Asset, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Asset)
+	err := c.cc.Invoke(ctx, Catalogue_GetAssetByID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogueClient) GetAssetByName(ctx context.Context, in *GetAssetByNameRequest, opts ...grpc.CallOption) (*Asset, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Asset)
+	err := c.cc.Invoke(ctx, Catalogue_GetAssetByName_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogueClient) SearchAssetsByTags(ctx context.Context, in *SearchAssetsByTagsRequest, opts ...grpc.CallOption) (*AssetList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssetList)
+	err := c.cc.Invoke(ctx, Catalogue_SearchAssetsByTags_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogueClient) SearchAssets(ctx context.Context, in *AssetSearch, opts ...grpc.CallOption) (*AssetSearchResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssetSearchResult)
+	err := c.cc.Invoke(ctx, Catalogue_SearchAssets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogueClient) ListAssetsByOwner(ctx context.Context, in *ListAssetsByOwnerRequest, opts ...grpc.CallOption) (*AssetSearchResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssetSearchResult)
+	err := c.cc.Invoke(ctx, Catalogue_ListAssetsByOwner_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogueClient) ListAssetsByDomain(ctx context.Context, in *ListAssetsByDomainRequest, opts ...grpc.CallOption) (*AssetSearchResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssetSearchResult)
+	err := c.cc.Invoke(ctx, Catalogue_ListAssetsByDomain_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogueClient) GetAssetLineage(ctx context.Context, in *GetAssetLineageRequest, opts ...grpc.CallOption) (*LineageGraph, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LineageGraph)
+	err := c.cc.Invoke(ctx, Catalogue_GetAssetLineage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogueClient) DeleteAsset(ctx context.Context, in *DeleteAssetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Catalogue_DeleteAsset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogueClient) ReconcileAssets(ctx context.Context, in *ReconcileAssetsRequest, opts ...grpc.CallOption) (*ReconcileAssetsReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReconcileAssetsReply)
+	err := c.cc.Invoke(ctx, Catalogue_ReconcileAssets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogueClient) ListAllAssets(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*AssetPage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssetPage)
+	err := c.cc.Invoke(ctx, Catalogue_ListAllAssets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogueClient) StreamAssets(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Asset], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Catalogue_ServiceDesc.Streams[0], Catalogue_StreamAssets_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListRequest, Asset]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Catalogue_StreamAssetsClient = grpc.ServerStreamingClient[Asset]
+
+func (c *catalogueClient) ListAssetRevisions(ctx context.Context, in *ListAssetRevisionsRequest, opts ...grpc.CallOption) (*AssetRevisionList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssetRevisionList)
+	err := c.cc.Invoke(ctx, Catalogue_ListAssetRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogueClient) DiffAssetRevisions(ctx context.Context, in *DiffAssetRevisionsRequest, opts ...grpc.CallOption) (*AssetDiff, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssetDiff)
+	err := c.cc.Invoke(ctx, Catalogue_DiffAssetRevisions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *catalogueClient) ListSchemaChanges(ctx context.Context, in *ListSchemaChangesRequest, opts ...grpc.CallOption) (*SchemaEvolutionList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SchemaEvolutionList)
+	err := c.cc.Invoke(ctx, Catalogue_ListSchemaChanges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CatalogueServer is the server API for Catalogue service.
+// All implementations must embed UnimplementedCatalogueServer
+// for forward compatibility.
+//
+// Catalogue ... the operations of catalogue.Service
+type CatalogueServer interface {
+	UpsertAssets(context.Context, *AssetList) (*AssetList, error)
+	GetAssetByID(context.Context, *GetAssetByIDRequest) (*Asset, error)
+	GetAssetByName(context.Context, *GetAssetByNameRequest) (*Asset, error)
+	SearchAssetsByTags(context.Context, *SearchAssetsByTagsRequest) (*AssetList, error)
+	SearchAssets(context.Context, *AssetSearch) (*AssetSearchResult, error)
+	ListAssetsByOwner(context.Context, *ListAssetsByOwnerRequest) (*AssetSearchResult, error)
+	ListAssetsByDomain(context.Context, *ListAssetsByDomainRequest) (*AssetSearchResult, error)
+	GetAssetLineage(context.Context, *GetAssetLineageRequest) (*LineageGraph, error)
+	DeleteAsset(context.Context, *DeleteAssetRequest) (*emptypb.Empty, error)
+	ReconcileAssets(context.Context, *ReconcileAssetsRequest) (*ReconcileAssetsReply, error)
+	ListAllAssets(context.Context, *ListRequest) (*AssetPage, error)
+	// StreamAssets ... sends all assets one per message, going through the pages of the given size and sort
+	StreamAssets(*ListRequest, grpc.ServerStreamingServer[Asset]) error
+	ListAssetRevisions(context.Context, *ListAssetRevisionsRequest) (*AssetRevisionList, error)
+	DiffAssetRevisions(context.Context, *DiffAssetRevisionsRequest) (*AssetDiff, error)
+	ListSchemaChanges(context.Context, *ListSchemaChangesRequest) (*SchemaEvolutionList, error)
+	mustEmbedUnimplementedCatalogueServer()
+}
+
+// UnimplementedCatalogueServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedCatalogueServer struct{}
+
+func (UnimplementedCatalogueServer) UpsertAssets(context.Context, *AssetList) (*AssetList, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpsertAssets not implemented")
+}
+func (UnimplementedCatalogueServer) GetAssetByID(context.Context, *GetAssetByIDRequest) (*Asset, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAssetByID not implemented")
+}
+func (UnimplementedCatalogueServer) GetAssetByName(context.Context, *GetAssetByNameRequest) (*Asset, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAssetByName not implemented")
+}
+func (UnimplementedCatalogueServer) SearchAssetsByTags(context.Context, *SearchAssetsByTagsRequest) (*AssetList, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchAssetsByTags not implemented")
+}
+func (UnimplementedCatalogueServer) SearchAssets(context.Context, *AssetSearch) (*AssetSearchResult, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchAssets not implemented")
+}
+func (UnimplementedCatalogueServer) ListAssetsByOwner(context.Context, *ListAssetsByOwnerRequest) (*AssetSearchResult, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAssetsByOwner not implemented")
+}
+func (UnimplementedCatalogueServer) ListAssetsByDomain(context.Context, *ListAssetsByDomainRequest) (*AssetSearchResult, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAssetsByDomain not implemented")
+}
+func (UnimplementedCatalogueServer) GetAssetLineage(context.Context, *GetAssetLineageRequest) (*LineageGraph, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAssetLineage not implemented")
+}
+func (UnimplementedCatalogueServer) DeleteAsset(context.Context, *DeleteAssetRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteAsset not implemented")
+}
+func (UnimplementedCatalogueServer) ReconcileAssets(context.Context, *ReconcileAssetsRequest) (*ReconcileAssetsReply, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReconcileAssets not implemented")
+}
+func (UnimplementedCatalogueServer) ListAllAssets(context.Context, *ListRequest) (*AssetPage, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAllAssets not implemented")
+}
+func (UnimplementedCatalogueServer) StreamAssets(*ListRequest, grpc.ServerStreamingServer[Asset]) error {
+	return status.Error(codes.Unimplemented, "method StreamAssets not implemented")
+}
+func (UnimplementedCatalogueServer) ListAssetRevisions(context.Context, *ListAssetRevisionsRequest) (*AssetRevisionList, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAssetRevisions not implemented")
+}
+func (UnimplementedCatalogueServer) DiffAssetRevisions(context.Context, *DiffAssetRevisionsRequest) (*AssetDiff, error) {
+	return nil, status.Error(codes.Unimplemented, "method DiffAssetRevisions not implemented")
+}
+func (UnimplementedCatalogueServer) ListSchemaChanges(context.Context, *ListSchemaChangesRequest) (*SchemaEvolutionList, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListSchemaChanges not implemented")
+}
+func (UnimplementedCatalogueServer) mustEmbedUnimplementedCatalogueServer() {}
+func (UnimplementedCatalogueServer) testEmbeddedByValue()                   {}
+
+// UnsafeCatalogueServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CatalogueServer will
+// result in compilation errors.
+type UnsafeCatalogueServer interface {
+	mustEmbedUnimplementedCatalogueServer()
+}
+
+func RegisterCatalogueServer(s grpc.ServiceRegistrar, srv CatalogueServer) {
+	// If the following call panics, it indicates UnimplementedCatalogueServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Catalogue_ServiceDesc, srv)
+}
+
+func _Catalogue_UpsertAssets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssetList)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogueServer).UpsertAssets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalogue_UpsertAssets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogueServer).UpsertAssets(ctx, req.(*AssetList))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalogue_GetAssetByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAssetByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogueServer).GetAssetByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalogue_GetAssetByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogueServer).GetAssetByID(ctx, req.(*GetAssetByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalogue_GetAssetByName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAssetByNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogueServer).GetAssetByName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalogue_GetAssetByName_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogueServer).GetAssetByName(ctx, req.(*GetAssetByNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalogue_SearchAssetsByTags_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchAssetsByTagsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogueServer).SearchAssetsByTags(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalogue_SearchAssetsByTags_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogueServer).SearchAssetsByTags(ctx, req.(*SearchAssetsByTagsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalogue_SearchAssets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssetSearch)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogueServer).SearchAssets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalogue_SearchAssets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogueServer).SearchAssets(ctx, req.(*AssetSearch))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalogue_ListAssetsByOwner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAssetsByOwnerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogueServer).ListAssetsByOwner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalogue_ListAssetsByOwner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogueServer).ListAssetsByOwner(ctx, req.(*ListAssetsByOwnerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalogue_ListAssetsByDomain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAssetsByDomainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogueServer).ListAssetsByDomain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalogue_ListAssetsByDomain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogueServer).ListAssetsByDomain(ctx, req.(*ListAssetsByDomainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalogue_GetAssetLineage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAssetLineageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogueServer).GetAssetLineage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalogue_GetAssetLineage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogueServer).GetAssetLineage(ctx, req.(*GetAssetLineageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalogue_DeleteAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteAssetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogueServer).DeleteAsset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalogue_DeleteAsset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogueServer).DeleteAsset(ctx, req.(*DeleteAssetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalogue_ReconcileAssets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileAssetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogueServer).ReconcileAssets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalogue_ReconcileAssets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogueServer).ReconcileAssets(ctx, req.(*ReconcileAssetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalogue_ListAllAssets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogueServer).ListAllAssets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalogue_ListAllAssets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogueServer).ListAllAssets(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalogue_StreamAssets_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CatalogueServer).StreamAssets(m, &grpc.GenericServerStream[ListRequest, Asset]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Catalogue_StreamAssetsServer = grpc.ServerStreamingServer[Asset]
+
+func _Catalogue_ListAssetRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAssetRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogueServer).ListAssetRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalogue_ListAssetRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogueServer).ListAssetRevisions(ctx, req.(*ListAssetRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalogue_DiffAssetRevisions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffAssetRevisionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogueServer).DiffAssetRevisions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalogue_DiffAssetRevisions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogueServer).DiffAssetRevisions(ctx, req.(*DiffAssetRevisionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Catalogue_ListSchemaChanges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSchemaChangesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CatalogueServer).ListSchemaChanges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Catalogue_ListSchemaChanges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CatalogueServer).ListSchemaChanges(ctx, req.(*ListSchemaChangesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Catalogue_ServiceDesc is the grpc.ServiceDesc for Catalogue service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Catalogue_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "mastro.catalogue.Catalogue",
+	HandlerType: (*CatalogueServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "UpsertAssets",
+			Handler:    _Catalogue_UpsertAssets_Handler,
+		},
+		{
+			MethodName: "GetAssetByID",
+			Handler:    _Catalogue_GetAssetByID_Handler,
+		},
+		{
+			MethodName: "GetAssetByName",
+			Handler:    _Catalogue_GetAssetByName_Handler,
+		},
+		{
+			MethodName: "SearchAssetsByTags",
+			Handler:    _Catalogue_SearchAssetsByTags_Handler,
+		},
+		{
+			MethodName: "SearchAssets",
+			Handler:    _Catalogue_SearchAssets_Handler,
+		},
+		{
+			MethodName: "ListAssetsByOwner",
+			Handler:    _Catalogue_ListAssetsByOwner_Handler,
+		},
+		{
+			MethodName: "ListAssetsByDomain",
+			Handler:    _Catalogue_ListAssetsByDomain_Handler,
+		},
+		{
+			MethodName: "GetAssetLineage",
+			Handler:    _Catalogue_GetAssetLineage_Handler,
+		},
+		{
+			MethodName: "DeleteAsset",
+			Handler:    _Catalogue_DeleteAsset_Handler,
+		},
+		{
+			MethodName: "ReconcileAssets",
+			Handler:    _Catalogue_ReconcileAssets_Handler,
+		},
+		{
+			MethodName: "ListAllAssets",
+			Handler:    _Catalogue_ListAllAssets_Handler,
+		},
+		{
+			MethodName: "ListAssetRevisions",
+			Handler:    _Catalogue_ListAssetRevisions_Handler,
+		},
+		{
+			MethodName: "DiffAssetRevisions",
+			Handler:    _Catalogue_DiffAssetRevisions_Handler,
+		},
+		{
+			MethodName: "ListSchemaChanges",
+			Handler:    _Catalogue_ListSchemaChanges_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamAssets",
+			Handler:       _Catalogue_StreamAssets_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "mastro/catalogue/catalogue.proto",
+}
@@ -0,0 +1,1277 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: mastro/featurestore/featurestore.proto
+
+// the feature store of versioned feature sets, served over gRPC alongside the REST endpoints
+
+package featurestorepb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// FeatureValue ... the value of a feature, of one of the data types of the feature store
+type FeatureValue struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Kind:
+	//
+	//	*FeatureValue_BoolValue
+	//	*FeatureValue_IntValue
+	//	*FeatureValue_FloatValue
+	//	*FeatureValue_StringValue
+	Kind          isFeatureValue_Kind `protobuf_oneof:"kind"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeatureValue) Reset() {
+	*x = FeatureValue{}
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeatureValue) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeatureValue) ProtoMessage() {}
+
+func (x *FeatureValue) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeatureValue.ProtoReflect.Descriptor instead.
+func (*FeatureValue) Descriptor() ([]byte, []int) {
+	return file_mastro_featurestore_featurestore_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FeatureValue) GetKind() isFeatureValue_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return nil
+}
+
+func (x *FeatureValue) GetBoolValue() bool {
+	if x != nil {
+		if x, ok := x.Kind.(*FeatureValue_BoolValue); ok {
+			return x.BoolValue
+		}
+	}
+	return false
+}
+
+func (x *FeatureValue) GetIntValue() int64 {
+	if x != nil {
+		if x, ok := x.Kind.(*FeatureValue_IntValue); ok {
+			return x.IntValue
+		}
+	}
+	return 0
+}
+
+func (x *FeatureValue) GetFloatValue() float64 {
+	if x != nil {
+		if x, ok := x.Kind.(*FeatureValue_FloatValue); ok {
+			return x.FloatValue
+		}
+	}
+	return 0
+}
+
+func (x *FeatureValue) GetStringValue() string {
+	if x != nil {
+		if x, ok := x.Kind.(*FeatureValue_StringValue); ok {
+			return x.StringValue
+		}
+	}
+	return ""
+}
+
+type isFeatureValue_Kind interface {
+	isFeatureValue_Kind()
+}
+
+type FeatureValue_BoolValue struct {
+	BoolValue bool `protobuf:"varint,1,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+type FeatureValue_IntValue struct {
+	IntValue int64 `protobuf:"varint,2,opt,name=int_value,json=intValue,proto3,oneof"`
+}
+
+type FeatureValue_FloatValue struct {
+	FloatValue float64 `protobuf:"fixed64,3,opt,name=float_value,json=floatValue,proto3,oneof"`
+}
+
+type FeatureValue_StringValue struct {
+	StringValue string `protobuf:"bytes,4,opt,name=string_value,json=stringValue,proto3,oneof"`
+}
+
+func (*FeatureValue_BoolValue) isFeatureValue_Kind() {}
+
+func (*FeatureValue_IntValue) isFeatureValue_Kind() {}
+
+func (*FeatureValue_FloatValue) isFeatureValue_Kind() {}
+
+func (*FeatureValue_StringValue) isFeatureValue_Kind() {}
+
+type Feature struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value         *FeatureValue          `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	DataType      string                 `protobuf:"bytes,3,opt,name=data_type,json=dataType,proto3" json:"data_type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Feature) Reset() {
+	*x = Feature{}
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Feature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Feature) ProtoMessage() {}
+
+func (x *Feature) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Feature.ProtoReflect.Descriptor instead.
+func (*Feature) Descriptor() ([]byte, []int) {
+	return file_mastro_featurestore_featurestore_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Feature) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Feature) GetValue() *FeatureValue {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *Feature) GetDataType() string {
+	if x != nil {
+		return x.DataType
+	}
+	return ""
+}
+
+// FeatureSet ... a versioned set of features, as abstract.FeatureSet
+type FeatureSet struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Entity        string                 `protobuf:"bytes,3,opt,name=entity,proto3" json:"entity,omitempty"`
+	InsertedAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=inserted_at,json=insertedAt,proto3" json:"inserted_at,omitempty"`
+	Version       string                 `protobuf:"bytes,5,opt,name=version,proto3" json:"version,omitempty"`
+	Features      []*Feature             `protobuf:"bytes,6,rep,name=features,proto3" json:"features,omitempty"`
+	Description   string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	Labels        map[string]string      `protobuf:"bytes,8,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeatureSet) Reset() {
+	*x = FeatureSet{}
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeatureSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeatureSet) ProtoMessage() {}
+
+func (x *FeatureSet) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeatureSet.ProtoReflect.Descriptor instead.
+func (*FeatureSet) Descriptor() ([]byte, []int) {
+	return file_mastro_featurestore_featurestore_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FeatureSet) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FeatureSet) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FeatureSet) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+func (x *FeatureSet) GetInsertedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.InsertedAt
+	}
+	return nil
+}
+
+func (x *FeatureSet) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *FeatureSet) GetFeatures() []*Feature {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+func (x *FeatureSet) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *FeatureSet) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type FeatureSetList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FeatureSets   []*FeatureSet          `protobuf:"bytes,1,rep,name=feature_sets,json=featureSets,proto3" json:"feature_sets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeatureSetList) Reset() {
+	*x = FeatureSetList{}
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeatureSetList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeatureSetList) ProtoMessage() {}
+
+func (x *FeatureSetList) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeatureSetList.ProtoReflect.Descriptor instead.
+func (*FeatureSetList) Descriptor() ([]byte, []int) {
+	return file_mastro_featurestore_featurestore_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *FeatureSetList) GetFeatureSets() []*FeatureSet {
+	if x != nil {
+		return x.FeatureSets
+	}
+	return nil
+}
+
+type GetFeatureSetByIDRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFeatureSetByIDRequest) Reset() {
+	*x = GetFeatureSetByIDRequest{}
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFeatureSetByIDRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFeatureSetByIDRequest) ProtoMessage() {}
+
+func (x *GetFeatureSetByIDRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFeatureSetByIDRequest.ProtoReflect.Descriptor instead.
+func (*GetFeatureSetByIDRequest) Descriptor() ([]byte, []int) {
+	return file_mastro_featurestore_featurestore_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetFeatureSetByIDRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetFeatureSetByNameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFeatureSetByNameRequest) Reset() {
+	*x = GetFeatureSetByNameRequest{}
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFeatureSetByNameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFeatureSetByNameRequest) ProtoMessage() {}
+
+func (x *GetFeatureSetByNameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFeatureSetByNameRequest.ProtoReflect.Descriptor instead.
+func (*GetFeatureSetByNameRequest) Descriptor() ([]byte, []int) {
+	return file_mastro_featurestore_featurestore_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetFeatureSetByNameRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type GetFeatureSetByVersionRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// version ... an exact version, latest or a semver range, e.g. ^1.2
+	Version       string `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Entity        string `protobuf:"bytes,3,opt,name=entity,proto3" json:"entity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFeatureSetByVersionRequest) Reset() {
+	*x = GetFeatureSetByVersionRequest{}
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFeatureSetByVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFeatureSetByVersionRequest) ProtoMessage() {}
+
+func (x *GetFeatureSetByVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFeatureSetByVersionRequest.ProtoReflect.Descriptor instead.
+func (*GetFeatureSetByVersionRequest) Descriptor() ([]byte, []int) {
+	return file_mastro_featurestore_featurestore_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetFeatureSetByVersionRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetFeatureSetByVersionRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *GetFeatureSetByVersionRequest) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+type EntityTimestamp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entity        string                 `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EntityTimestamp) Reset() {
+	*x = EntityTimestamp{}
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EntityTimestamp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EntityTimestamp) ProtoMessage() {}
+
+func (x *EntityTimestamp) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EntityTimestamp.ProtoReflect.Descriptor instead.
+func (*EntityTimestamp) Descriptor() ([]byte, []int) {
+	return file_mastro_featurestore_featurestore_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *EntityTimestamp) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+func (x *EntityTimestamp) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type GetFeatureSetsAtRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Requests      []*EntityTimestamp     `protobuf:"bytes,2,rep,name=requests,proto3" json:"requests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFeatureSetsAtRequest) Reset() {
+	*x = GetFeatureSetsAtRequest{}
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFeatureSetsAtRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFeatureSetsAtRequest) ProtoMessage() {}
+
+func (x *GetFeatureSetsAtRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFeatureSetsAtRequest.ProtoReflect.Descriptor instead.
+func (*GetFeatureSetsAtRequest) Descriptor() ([]byte, []int) {
+	return file_mastro_featurestore_featurestore_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetFeatureSetsAtRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetFeatureSetsAtRequest) GetRequests() []*EntityTimestamp {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+type PointInTimeFeatureSet struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Entity    string                 `protobuf:"bytes,1,opt,name=entity,proto3" json:"entity,omitempty"`
+	Timestamp *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// feature_set ... unset if none was available at that time
+	FeatureSet    *FeatureSet `protobuf:"bytes,3,opt,name=feature_set,json=featureSet,proto3" json:"feature_set,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PointInTimeFeatureSet) Reset() {
+	*x = PointInTimeFeatureSet{}
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PointInTimeFeatureSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PointInTimeFeatureSet) ProtoMessage() {}
+
+func (x *PointInTimeFeatureSet) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PointInTimeFeatureSet.ProtoReflect.Descriptor instead.
+func (*PointInTimeFeatureSet) Descriptor() ([]byte, []int) {
+	return file_mastro_featurestore_featurestore_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PointInTimeFeatureSet) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+func (x *PointInTimeFeatureSet) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *PointInTimeFeatureSet) GetFeatureSet() *FeatureSet {
+	if x != nil {
+		return x.FeatureSet
+	}
+	return nil
+}
+
+type PointInTimeFeatureSetList struct {
+	state         protoimpl.MessageState   `protogen:"open.v1"`
+	FeatureSets   []*PointInTimeFeatureSet `protobuf:"bytes,1,rep,name=feature_sets,json=featureSets,proto3" json:"feature_sets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PointInTimeFeatureSetList) Reset() {
+	*x = PointInTimeFeatureSetList{}
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PointInTimeFeatureSetList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PointInTimeFeatureSetList) ProtoMessage() {}
+
+func (x *PointInTimeFeatureSetList) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PointInTimeFeatureSetList.ProtoReflect.Descriptor instead.
+func (*PointInTimeFeatureSetList) Descriptor() ([]byte, []int) {
+	return file_mastro_featurestore_featurestore_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PointInTimeFeatureSetList) GetFeatureSets() []*PointInTimeFeatureSet {
+	if x != nil {
+		return x.FeatureSets
+	}
+	return nil
+}
+
+type GetOnlineFeatureSetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Entity        string                 `protobuf:"bytes,2,opt,name=entity,proto3" json:"entity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOnlineFeatureSetRequest) Reset() {
+	*x = GetOnlineFeatureSetRequest{}
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOnlineFeatureSetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOnlineFeatureSetRequest) ProtoMessage() {}
+
+func (x *GetOnlineFeatureSetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOnlineFeatureSetRequest.ProtoReflect.Descriptor instead.
+func (*GetOnlineFeatureSetRequest) Descriptor() ([]byte, []int) {
+	return file_mastro_featurestore_featurestore_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetOnlineFeatureSetRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetOnlineFeatureSetRequest) GetEntity() string {
+	if x != nil {
+		return x.Entity
+	}
+	return ""
+}
+
+type FeatureSchema struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	DataType      string                 `protobuf:"bytes,2,opt,name=data_type,json=dataType,proto3" json:"data_type,omitempty"`
+	Nullable      bool                   `protobuf:"varint,3,opt,name=nullable,proto3" json:"nullable,omitempty"`
+	Min           *float64               `protobuf:"fixed64,4,opt,name=min,proto3,oneof" json:"min,omitempty"`
+	Max           *float64               `protobuf:"fixed64,5,opt,name=max,proto3,oneof" json:"max,omitempty"`
+	Enum          []*FeatureValue        `protobuf:"bytes,6,rep,name=enum,proto3" json:"enum,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeatureSchema) Reset() {
+	*x = FeatureSchema{}
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeatureSchema) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeatureSchema) ProtoMessage() {}
+
+func (x *FeatureSchema) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeatureSchema.ProtoReflect.Descriptor instead.
+func (*FeatureSchema) Descriptor() ([]byte, []int) {
+	return file_mastro_featurestore_featurestore_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *FeatureSchema) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FeatureSchema) GetDataType() string {
+	if x != nil {
+		return x.DataType
+	}
+	return ""
+}
+
+func (x *FeatureSchema) GetNullable() bool {
+	if x != nil {
+		return x.Nullable
+	}
+	return false
+}
+
+func (x *FeatureSchema) GetMin() float64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *FeatureSchema) GetMax() float64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
+func (x *FeatureSchema) GetEnum() []*FeatureValue {
+	if x != nil {
+		return x.Enum
+	}
+	return nil
+}
+
+type FeatureSetSchema struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Features      []*FeatureSchema       `protobuf:"bytes,3,rep,name=features,proto3" json:"features,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeatureSetSchema) Reset() {
+	*x = FeatureSetSchema{}
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeatureSetSchema) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeatureSetSchema) ProtoMessage() {}
+
+func (x *FeatureSetSchema) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeatureSetSchema.ProtoReflect.Descriptor instead.
+func (*FeatureSetSchema) Descriptor() ([]byte, []int) {
+	return file_mastro_featurestore_featurestore_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *FeatureSetSchema) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FeatureSetSchema) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *FeatureSetSchema) GetFeatures() []*FeatureSchema {
+	if x != nil {
+		return x.Features
+	}
+	return nil
+}
+
+type GetFeatureSetSchemaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetFeatureSetSchemaRequest) Reset() {
+	*x = GetFeatureSetSchemaRequest{}
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetFeatureSetSchemaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetFeatureSetSchemaRequest) ProtoMessage() {}
+
+func (x *GetFeatureSetSchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetFeatureSetSchemaRequest.ProtoReflect.Descriptor instead.
+func (*GetFeatureSetSchemaRequest) Descriptor() ([]byte, []int) {
+	return file_mastro_featurestore_featurestore_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetFeatureSetSchemaRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// ListRequest ... pagination, sorting and projection of a list
+type ListRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Limit  int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset int32                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	// cursor ... returned with the previous page, takes precedence over the offset
+	Cursor string `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// sort ... sort key, prefixed by - for a descending order
+	Sort string `protobuf:"bytes,4,opt,name=sort,proto3" json:"sort,omitempty"`
+	// fields ... fields of the feature sets to return, all if empty
+	Fields        []string `protobuf:"bytes,5,rep,name=fields,proto3" json:"fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_mastro_featurestore_featurestore_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ListRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListRequest) GetSort() string {
+	if x != nil {
+		return x.Sort
+	}
+	return ""
+}
+
+func (x *ListRequest) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type FeatureSetPage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FeatureSets   []*FeatureSet          `protobuf:"bytes,1,rep,name=feature_sets,json=featureSets,proto3" json:"feature_sets,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FeatureSetPage) Reset() {
+	*x = FeatureSetPage{}
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FeatureSetPage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FeatureSetPage) ProtoMessage() {}
+
+func (x *FeatureSetPage) ProtoReflect() protoreflect.Message {
+	mi := &file_mastro_featurestore_featurestore_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FeatureSetPage.ProtoReflect.Descriptor instead.
+func (*FeatureSetPage) Descriptor() ([]byte, []int) {
+	return file_mastro_featurestore_featurestore_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *FeatureSetPage) GetFeatureSets() []*FeatureSet {
+	if x != nil {
+		return x.FeatureSets
+	}
+	return nil
+}
+
+func (x *FeatureSetPage) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+var File_mastro_featurestore_featurestore_proto protoreflect.FileDescriptor
+
+const file_mastro_featurestore_featurestore_proto_rawDesc = "" +
+	"\n" +
+	"&mastro/featurestore/featurestore.proto\x12\x13mastro.featurestore\x1a\x1fgoogle/protobuf/timestamp.proto\"\x9e\x01\n" +
+	"\fFeatureValue\x12\x1f\n" +
+	"\n" +
+	"bool_value\x18\x01 \x01(\bH\x00R\tboolValue\x12\x1d\n" +
+	"\tint_value\x18\x02 \x01(\x03H\x00R\bintValue\x12!\n" +
+	"\vfloat_value\x18\x03 \x01(\x01H\x00R\n" +
+	"floatValue\x12#\n" +
+	"\fstring_value\x18\x04 \x01(\tH\x00R\vstringValueB\x06\n" +
+	"\x04kind\"s\n" +
+	"\aFeature\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x127\n" +
+	"\x05value\x18\x02 \x01(\v2!.mastro.featurestore.FeatureValueR\x05value\x12\x1b\n" +
+	"\tdata_type\x18\x03 \x01(\tR\bdataType\"\xfb\x02\n" +
+	"\n" +
+	"FeatureSet\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06entity\x18\x03 \x01(\tR\x06entity\x12;\n" +
+	"\vinserted_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"insertedAt\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\tR\aversion\x128\n" +
+	"\bfeatures\x18\x06 \x03(\v2\x1c.mastro.featurestore.FeatureR\bfeatures\x12 \n" +
+	"\vdescription\x18\a \x01(\tR\vdescription\x12C\n" +
+	"\x06labels\x18\b \x03(\v2+.mastro.featurestore.FeatureSet.LabelsEntryR\x06labels\x1a9\n" +
+	"\vLabelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"T\n" +
+	"\x0eFeatureSetList\x12B\n" +
+	"\ffeature_sets\x18\x01 \x03(\v2\x1f.mastro.featurestore.FeatureSetR\vfeatureSets\"*\n" +
+	"\x18GetFeatureSetByIDRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"0\n" +
+	"\x1aGetFeatureSetByNameRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"e\n" +
+	"\x1dGetFeatureSetByVersionRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\x12\x16\n" +
+	"\x06entity\x18\x03 \x01(\tR\x06entity\"c\n" +
+	"\x0fEntityTimestamp\x12\x16\n" +
+	"\x06entity\x18\x01 \x01(\tR\x06entity\x128\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"o\n" +
+	"\x17GetFeatureSetsAtRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12@\n" +
+	"\brequests\x18\x02 \x03(\v2$.mastro.featurestore.EntityTimestampR\brequests\"\xab\x01\n" +
+	"\x15PointInTimeFeatureSet\x12\x16\n" +
+	"\x06entity\x18\x01 \x01(\tR\x06entity\x128\n" +
+	"\ttimestamp\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\x12@\n" +
+	"\vfeature_set\x18\x03 \x01(\v2\x1f.mastro.featurestore.FeatureSetR\n" +
+	"featureSet\"j\n" +
+	"\x19PointInTimeFeatureSetList\x12M\n" +
+	"\ffeature_sets\x18\x01 \x03(\v2*.mastro.featurestore.PointInTimeFeatureSetR\vfeatureSets\"H\n" +
+	"\x1aGetOnlineFeatureSetRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06entity\x18\x02 \x01(\tR\x06entity\"\xd1\x01\n" +
+	"\rFeatureSchema\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tdata_type\x18\x02 \x01(\tR\bdataType\x12\x1a\n" +
+	"\bnullable\x18\x03 \x01(\bR\bnullable\x12\x15\n" +
+	"\x03min\x18\x04 \x01(\x01H\x00R\x03min\x88\x01\x01\x12\x15\n" +
+	"\x03max\x18\x05 \x01(\x01H\x01R\x03max\x88\x01\x01\x125\n" +
+	"\x04enum\x18\x06 \x03(\v2!.mastro.featurestore.FeatureValueR\x04enumB\x06\n" +
+	"\x04_minB\x06\n" +
+	"\x04_max\"\xa1\x01\n" +
+	"\x10FeatureSetSchema\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"updated_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12>\n" +
+	"\bfeatures\x18\x03 \x03(\v2\".mastro.featurestore.FeatureSchemaR\bfeatures\"0\n" +
+	"\x1aGetFeatureSetSchemaRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\x7f\n" +
+	"\vListRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x12\n" +
+	"\x04sort\x18\x04 \x01(\tR\x04sort\x12\x16\n" +
+	"\x06fields\x18\x05 \x03(\tR\x06fields\"u\n" +
+	"\x0eFeatureSetPage\x12B\n" +
+	"\ffeature_sets\x18\x01 \x03(\v2\x1f.mastro.featurestore.FeatureSetR\vfeatureSets\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor2\x8e\b\n" +
+	"\fFeatureStore\x12T\n" +
+	"\x10CreateFeatureSet\x12\x1f.mastro.featurestore.FeatureSet\x1a\x1f.mastro.featurestore.FeatureSet\x12c\n" +
+	"\x11GetFeatureSetByID\x12-.mastro.featurestore.GetFeatureSetByIDRequest\x1a\x1f.mastro.featurestore.FeatureSet\x12k\n" +
+	"\x13GetFeatureSetByName\x12/.mastro.featurestore.GetFeatureSetByNameRequest\x1a#.mastro.featurestore.FeatureSetList\x12m\n" +
+	"\x16GetFeatureSetByVersion\x122.mastro.featurestore.GetFeatureSetByVersionRequest\x1a\x1f.mastro.featurestore.FeatureSet\x12p\n" +
+	"\x10GetFeatureSetsAt\x12,.mastro.featurestore.GetFeatureSetsAtRequest\x1a..mastro.featurestore.PointInTimeFeatureSetList\x12g\n" +
+	"\x13GetOnlineFeatureSet\x12/.mastro.featurestore.GetOnlineFeatureSetRequest\x1a\x1f.mastro.featurestore.FeatureSet\x12f\n" +
+	"\x16UpsertFeatureSetSchema\x12%.mastro.featurestore.FeatureSetSchema\x1a%.mastro.featurestore.FeatureSetSchema\x12m\n" +
+	"\x13GetFeatureSetSchema\x12/.mastro.featurestore.GetFeatureSetSchemaRequest\x1a%.mastro.featurestore.FeatureSetSchema\x12[\n" +
+	"\x12ListAllFeatureSets\x12 .mastro.featurestore.ListRequest\x1a#.mastro.featurestore.FeatureSetPage\x12X\n" +
+	"\x11StreamFeatureSets\x12 .mastro.featurestore.ListRequest\x1a\x1f.mastro.featurestore.FeatureSet0\x01B0Z.github.com/pilillo/mastro/proto/featurestorepbb\x06proto3"
+
+var (
+	file_mastro_featurestore_featurestore_proto_rawDescOnce sync.Once
+	file_mastro_featurestore_featurestore_proto_rawDescData []byte
+)
+
+func file_mastro_featurestore_featurestore_proto_rawDescGZIP() []byte {
+	file_mastro_featurestore_featurestore_proto_rawDescOnce.Do(func() {
+		file_mastro_featurestore_featurestore_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_mastro_featurestore_featurestore_proto_rawDesc), len(file_mastro_featurestore_featurestore_proto_rawDesc)))
+	})
+	return file_mastro_featurestore_featurestore_proto_rawDescData
+}
+
+var file_mastro_featurestore_featurestore_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
+var file_mastro_featurestore_featurestore_proto_goTypes = []any{
+	(*FeatureValue)(nil),                  // 0: mastro.featurestore.FeatureValue
+	(*Feature)(nil),                       // 1: mastro.featurestore.Feature
+	(*FeatureSet)(nil),                    // 2: mastro.featurestore.FeatureSet
+	(*FeatureSetList)(nil),                // 3: mastro.featurestore.FeatureSetList
+	(*GetFeatureSetByIDRequest)(nil),      // 4: mastro.featurestore.GetFeatureSetByIDRequest
+	(*GetFeatureSetByNameRequest)(nil),    // 5: mastro.featurestore.GetFeatureSetByNameRequest
+	(*GetFeatureSetByVersionRequest)(nil), // 6: mastro.featurestore.GetFeatureSetByVersionRequest
+	(*EntityTimestamp)(nil),               // 7: mastro.featurestore.EntityTimestamp
+	(*GetFeatureSetsAtRequest)(nil),       // 8: mastro.featurestore.GetFeatureSetsAtRequest
+	(*PointInTimeFeatureSet)(nil),         // 9: mastro.featurestore.PointInTimeFeatureSet
+	(*PointInTimeFeatureSetList)(nil),     // 10: mastro.featurestore.PointInTimeFeatureSetList
+	(*GetOnlineFeatureSetRequest)(nil),    // 11: mastro.featurestore.GetOnlineFeatureSetRequest
+	(*FeatureSchema)(nil),                 // 12: mastro.featurestore.FeatureSchema
+	(*FeatureSetSchema)(nil),              // 13: mastro.featurestore.FeatureSetSchema
+	(*GetFeatureSetSchemaRequest)(nil),    // 14: mastro.featurestore.GetFeatureSetSchemaRequest
+	(*ListRequest)(nil),                   // 15: mastro.featurestore.ListRequest
+	(*FeatureSetPage)(nil),                // 16: mastro.featurestore.FeatureSetPage
+	nil,                                   // 17: mastro.featurestore.FeatureSet.LabelsEntry
+	(*timestamppb.Timestamp)(nil),         // 18: google.protobuf.Timestamp
+}
+var file_mastro_featurestore_featurestore_proto_depIdxs = []int32{
+	0,  // 0: mastro.featurestore.Feature.value:type_name -> mastro.featurestore.FeatureValue
+	18, // 1: mastro.featurestore.FeatureSet.inserted_at:type_name -> google.protobuf.Timestamp
+	1,  // 2: mastro.featurestore.FeatureSet.features:type_name -> mastro.featurestore.Feature
+	17, // 3: mastro.featurestore.FeatureSet.labels:type_name -> mastro.featurestore.FeatureSet.LabelsEntry
+	2,  // 4: mastro.featurestore.FeatureSetList.feature_sets:type_name -> mastro.featurestore.FeatureSet
+	18, // 5: mastro.featurestore.EntityTimestamp.timestamp:type_name -> google.protobuf.Timestamp
+	7,  // 6: mastro.featurestore.GetFeatureSetsAtRequest.requests:type_name -> mastro.featurestore.EntityTimestamp
+	18, // 7: mastro.featurestore.PointInTimeFeatureSet.timestamp:type_name -> google.protobuf.Timestamp
+	2,  // 8: mastro.featurestore.PointInTimeFeatureSet.feature_set:type_name -> mastro.featurestore.FeatureSet
+	9,  // 9: mastro.featurestore.PointInTimeFeatureSetList.feature_sets:type_name -> mastro.featurestore.PointInTimeFeatureSet
+	0,  // 10: mastro.featurestore.FeatureSchema.enum:type_name -> mastro.featurestore.FeatureValue
+	18, // 11: mastro.featurestore.FeatureSetSchema.updated_at:type_name -> google.protobuf.Timestamp
+	12, // 12: mastro.featurestore.FeatureSetSchema.features:type_name -> mastro.featurestore.FeatureSchema
+	2,  // 13: mastro.featurestore.FeatureSetPage.feature_sets:type_name -> mastro.featurestore.FeatureSet
+	2,  // 14: mastro.featurestore.FeatureStore.CreateFeatureSet:input_type -> mastro.featurestore.FeatureSet
+	4,  // 15: mastro.featurestore.FeatureStore.GetFeatureSetByID:input_type -> mastro.featurestore.GetFeatureSetByIDRequest
+	5,  // 16: mastro.featurestore.FeatureStore.GetFeatureSetByName:input_type -> mastro.featurestore.GetFeatureSetByNameRequest
+	6,  // 17: mastro.featurestore.FeatureStore.GetFeatureSetByVersion:input_type -> mastro.featurestore.GetFeatureSetByVersionRequest
+	8,  // 18: mastro.featurestore.FeatureStore.GetFeatureSetsAt:input_type -> mastro.featurestore.GetFeatureSetsAtRequest
+	11, // 19: mastro.featurestore.FeatureStore.GetOnlineFeatureSet:input_type -> mastro.featurestore.GetOnlineFeatureSetRequest
+	13, // 20: mastro.featurestore.FeatureStore.UpsertFeatureSetSchema:input_type -> mastro.featurestore.FeatureSetSchema
+	14, // 21: mastro.featurestore.FeatureStore.GetFeatureSetSchema:input_type -> mastro.featurestore.GetFeatureSetSchemaRequest
+	15, // 22: mastro.featurestore.FeatureStore.ListAllFeatureSets:input_type -> mastro.featurestore.ListRequest
+	15, // 23: mastro.featurestore.FeatureStore.StreamFeatureSets:input_type -> mastro.featurestore.ListRequest
+	2,  // 24: mastro.featurestore.FeatureStore.CreateFeatureSet:output_type -> mastro.featurestore.FeatureSet
+	2,  // 25: mastro.featurestore.FeatureStore.GetFeatureSetByID:output_type -> mastro.featurestore.FeatureSet
+	3,  // 26: mastro.featurestore.FeatureStore.GetFeatureSetByName:output_type -> mastro.featurestore.FeatureSetList
+	2,  // 27: mastro.featurestore.FeatureStore.GetFeatureSetByVersion:output_type -> mastro.featurestore.FeatureSet
+	10, // 28: mastro.featurestore.FeatureStore.GetFeatureSetsAt:output_type -> mastro.featurestore.PointInTimeFeatureSetList
+	2,  // 29: mastro.featurestore.FeatureStore.GetOnlineFeatureSet:output_type -> mastro.featurestore.FeatureSet
+	13, // 30: mastro.featurestore.FeatureStore.UpsertFeatureSetSchema:output_type -> mastro.featurestore.FeatureSetSchema
+	13, // 31: mastro.featurestore.FeatureStore.GetFeatureSetSchema:output_type -> mastro.featurestore.FeatureSetSchema
+	16, // 32: mastro.featurestore.FeatureStore.ListAllFeatureSets:output_type -> mastro.featurestore.FeatureSetPage
+	2,  // 33: mastro.featurestore.FeatureStore.StreamFeatureSets:output_type -> mastro.featurestore.FeatureSet
+	24, // [24:34] is the sub-list for method output_type
+	14, // [14:24] is the sub-list for method input_type
+	14, // [14:14] is the sub-list for extension type_name
+	14, // [14:14] is the sub-list for extension extendee
+	0,  // [0:14] is the sub-list for field type_name
+}
+
+func init() { file_mastro_featurestore_featurestore_proto_init() }
+func file_mastro_featurestore_featurestore_proto_init() {
+	if File_mastro_featurestore_featurestore_proto != nil {
+		return
+	}
+	file_mastro_featurestore_featurestore_proto_msgTypes[0].OneofWrappers = []any{
+		(*FeatureValue_BoolValue)(nil),
+		(*FeatureValue_IntValue)(nil),
+		(*FeatureValue_FloatValue)(nil),
+		(*FeatureValue_StringValue)(nil),
+	}
+	file_mastro_featurestore_featurestore_proto_msgTypes[12].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_mastro_featurestore_featurestore_proto_rawDesc), len(file_mastro_featurestore_featurestore_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   18,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_mastro_featurestore_featurestore_proto_goTypes,
+		DependencyIndexes: file_mastro_featurestore_featurestore_proto_depIdxs,
+		MessageInfos:      file_mastro_featurestore_featurestore_proto_msgTypes,
+	}.Build()
+	File_mastro_featurestore_featurestore_proto = out.File
+	file_mastro_featurestore_featurestore_proto_goTypes = nil
+	file_mastro_featurestore_featurestore_proto_depIdxs = nil
+}
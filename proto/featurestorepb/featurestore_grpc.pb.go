@@ -0,0 +1,475 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.2
+// - protoc             (unknown)
+// source: mastro/featurestore/featurestore.proto
+
+// the feature store of versioned feature sets, served over gRPC alongside the REST endpoints
+
+package featurestorepb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FeatureStore_CreateFeatureSet_FullMethodName       = "/mastro.featurestore.FeatureStore/CreateFeatureSet"
+	FeatureStore_GetFeatureSetByID_FullMethodName      = "/mastro.featurestore.FeatureStore/GetFeatureSetByID"
+	FeatureStore_GetFeatureSetByName_FullMethodName    = "/mastro.featurestore.FeatureStore/GetFeatureSetByName"
+	FeatureStore_GetFeatureSetByVersion_FullMethodName = "/mastro.featurestore.FeatureStore/GetFeatureSetByVersion"
+	FeatureStore_GetFeatureSetsAt_FullMethodName       = "/mastro.featurestore.FeatureStore/GetFeatureSetsAt"
+	FeatureStore_GetOnlineFeatureSet_FullMethodName    = "/mastro.featurestore.FeatureStore/GetOnlineFeatureSet"
+	FeatureStore_UpsertFeatureSetSchema_FullMethodName = "/mastro.featurestore.FeatureStore/UpsertFeatureSetSchema"
+	FeatureStore_GetFeatureSetSchema_FullMethodName    = "/mastro.featurestore.FeatureStore/GetFeatureSetSchema"
+	FeatureStore_ListAllFeatureSets_FullMethodName     = "/mastro.featurestore.FeatureStore/ListAllFeatureSets"
+	FeatureStore_StreamFeatureSets_FullMethodName      = "/mastro.featurestore.FeatureStore/StreamFeatureSets"
+)
+
+// FeatureStoreClient is the client API for FeatureStore service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// FeatureStore ... the operations of featurestore.Service
+type FeatureStoreClient interface {
+	CreateFeatureSet(ctx context.Context, in *FeatureSet, opts ...grpc.CallOption) (*FeatureSet, error)
+	GetFeatureSetByID(ctx context.Context, in *GetFeatureSetByIDRequest, opts ...grpc.CallOption) (*FeatureSet, error)
+	GetFeatureSetByName(ctx context.Context, in *GetFeatureSetByNameRequest, opts ...grpc.CallOption) (*FeatureSetList, error)
+	GetFeatureSetByVersion(ctx context.Context, in *GetFeatureSetByVersionRequest, opts ...grpc.CallOption) (*FeatureSet, error)
+	GetFeatureSetsAt(ctx context.Context, in *GetFeatureSetsAtRequest, opts ...grpc.CallOption) (*PointInTimeFeatureSetList, error)
+	GetOnlineFeatureSet(ctx context.Context, in *GetOnlineFeatureSetRequest, opts ...grpc.CallOption) (*FeatureSet, error)
+	UpsertFeatureSetSchema(ctx context.Context, in *FeatureSetSchema, opts ...grpc.CallOption) (*FeatureSetSchema, error)
+	GetFeatureSetSchema(ctx context.Context, in *GetFeatureSetSchemaRequest, opts ...grpc.CallOption) (*FeatureSetSchema, error)
+	ListAllFeatureSets(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*FeatureSetPage, error)
+	// StreamFeatureSets ... sends all feature sets one per message, going through the pages of the given size and sort
+	StreamFeatureSets(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FeatureSet], error)
+}
+
+type featureStoreClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFeatureStoreClient(cc grpc.ClientConnInterface) FeatureStoreClient {
+	return &featureStoreClient{cc}
+}
+
+func (c *featureStoreClient) CreateFeatureSet(ctx context.Context, in *FeatureSet, opts ...grpc.CallOption) (*FeatureSet, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeatureSet)
+	err := c.cc.Invoke(ctx, FeatureStore_CreateFeatureSet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featureStoreClient) GetFeatureSetByID(ctx context.Context, in *GetFeatureSetByIDRequest, opts ...grpc.CallOption) (*FeatureSet, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeatureSet)
+	err := c.cc.Invoke(ctx, FeatureStore_GetFeatureSetByID_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featureStoreClient) GetFeatureSetByName(ctx context.Context, in *GetFeatureSetByNameRequest, opts ...grpc.CallOption) (*FeatureSetList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeatureSetList)
+	err := c.cc.Invoke(ctx, FeatureStore_GetFeatureSetByName_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featureStoreClient) GetFeatureSetByVersion(ctx context.Context, in *GetFeatureSetByVersionRequest, opts ...grpc.CallOption) (*FeatureSet, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeatureSet)
+	err := c.cc.Invoke(ctx, FeatureStore_GetFeatureSetByVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featureStoreClient) GetFeatureSetsAt(ctx context.Context, in *GetFeatureSetsAtRequest, opts ...grpc.CallOption) (*PointInTimeFeatureSetList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PointInTimeFeatureSetList)
+	err := c.cc.Invoke(ctx, FeatureStore_GetFeatureSetsAt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featureStoreClient) GetOnlineFeatureSet(ctx context.Context, in *GetOnlineFeatureSetRequest, opts ...grpc.CallOption) (*FeatureSet, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeatureSet)
+	err := c.cc.Invoke(ctx, FeatureStore_GetOnlineFeatureSet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featureStoreClient) UpsertFeatureSetSchema(ctx context.Context, in *FeatureSetSchema, opts ...grpc.CallOption) (*FeatureSetSchema, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeatureSetSchema)
+	err := c.cc.Invoke(ctx, FeatureStore_UpsertFeatureSetSchema_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featureStoreClient) GetFeatureSetSchema(ctx context.Context, in *GetFeatureSetSchemaRequest, opts ...grpc.CallOption) (*FeatureSetSchema, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeatureSetSchema)
+	err := c.cc.Invoke(ctx, FeatureStore_GetFeatureSetSchema_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featureStoreClient) ListAllFeatureSets(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*FeatureSetPage, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FeatureSetPage)
+	err := c.cc.Invoke(ctx, FeatureStore_ListAllFeatureSets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *featureStoreClient) StreamFeatureSets(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FeatureSet], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &FeatureStore_ServiceDesc.Streams[0], FeatureStore_StreamFeatureSets_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListRequest, FeatureSet]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FeatureStore_StreamFeatureSetsClient = grpc.ServerStreamingClient[FeatureSet]
+
+// FeatureStoreServer is the server API for FeatureStore service.
+// All implementations must embed UnimplementedFeatureStoreServer
+// for forward compatibility.
+//
+// FeatureStore ... the operations of featurestore.Service
+type FeatureStoreServer interface {
+	CreateFeatureSet(context.Context, *FeatureSet) (*FeatureSet, error)
+	GetFeatureSetByID(context.Context, *GetFeatureSetByIDRequest) (*FeatureSet, error)
+	GetFeatureSetByName(context.Context, *GetFeatureSetByNameRequest) (*FeatureSetList, error)
+	GetFeatureSetByVersion(context.Context, *GetFeatureSetByVersionRequest) (*FeatureSet, error)
+	GetFeatureSetsAt(context.Context, *GetFeatureSetsAtRequest) (*PointInTimeFeatureSetList, error)
+	GetOnlineFeatureSet(context.Context, *GetOnlineFeatureSetRequest) (*FeatureSet, error)
+	UpsertFeatureSetSchema(context.Context, *FeatureSetSchema) (*FeatureSetSchema, error)
+	GetFeatureSetSchema(context.Context, *GetFeatureSetSchemaRequest) (*FeatureSetSchema, error)
+	ListAllFeatureSets(context.Context, *ListRequest) (*FeatureSetPage, error)
+	// StreamFeatureSets ... sends all feature sets one per message, going through the pages of the given size and sort
+	StreamFeatureSets(*ListRequest, grpc.ServerStreamingServer[FeatureSet]) error
+	mustEmbedUnimplementedFeatureStoreServer()
+}
+
+// UnimplementedFeatureStoreServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFeatureStoreServer struct{}
+
+func (UnimplementedFeatureStoreServer) CreateFeatureSet(context.Context, *FeatureSet) (*FeatureSet, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateFeatureSet not implemented")
+}
+func (UnimplementedFeatureStoreServer) GetFeatureSetByID(context.Context, *GetFeatureSetByIDRequest) (*FeatureSet, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFeatureSetByID not implemented")
+}
+func (UnimplementedFeatureStoreServer) GetFeatureSetByName(context.Context, *GetFeatureSetByNameRequest) (*FeatureSetList, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFeatureSetByName not implemented")
+}
+func (UnimplementedFeatureStoreServer) GetFeatureSetByVersion(context.Context, *GetFeatureSetByVersionRequest) (*FeatureSet, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFeatureSetByVersion not implemented")
+}
+func (UnimplementedFeatureStoreServer) GetFeatureSetsAt(context.Context, *GetFeatureSetsAtRequest) (*PointInTimeFeatureSetList, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFeatureSetsAt not implemented")
+}
+func (UnimplementedFeatureStoreServer) GetOnlineFeatureSet(context.Context, *GetOnlineFeatureSetRequest) (*FeatureSet, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetOnlineFeatureSet not implemented")
+}
+func (UnimplementedFeatureStoreServer) UpsertFeatureSetSchema(context.Context, *FeatureSetSchema) (*FeatureSetSchema, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpsertFeatureSetSchema not implemented")
+}
+func (UnimplementedFeatureStoreServer) GetFeatureSetSchema(context.Context, *GetFeatureSetSchemaRequest) (*FeatureSetSchema, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetFeatureSetSchema not implemented")
+}
+func (UnimplementedFeatureStoreServer) ListAllFeatureSets(context.Context, *ListRequest) (*FeatureSetPage, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListAllFeatureSets not implemented")
+}
+func (UnimplementedFeatureStoreServer) StreamFeatureSets(*ListRequest, grpc.ServerStreamingServer[FeatureSet]) error {
+	return status.Error(codes.Unimplemented, "method StreamFeatureSets not implemented")
+}
+func (UnimplementedFeatureStoreServer) mustEmbedUnimplementedFeatureStoreServer() {}
+func (UnimplementedFeatureStoreServer) testEmbeddedByValue()                      {}
+
+// UnsafeFeatureStoreServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FeatureStoreServer will
+// result in compilation errors.
+type UnsafeFeatureStoreServer interface {
+	mustEmbedUnimplementedFeatureStoreServer()
+}
+
+func RegisterFeatureStoreServer(s grpc.ServiceRegistrar, srv FeatureStoreServer) {
+	// If the following call panics, it indicates UnimplementedFeatureStoreServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FeatureStore_ServiceDesc, srv)
+}
+
+func _FeatureStore_CreateFeatureSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FeatureSet)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureStoreServer).CreateFeatureSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureStore_CreateFeatureSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureStoreServer).CreateFeatureSet(ctx, req.(*FeatureSet))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeatureStore_GetFeatureSetByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFeatureSetByIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureStoreServer).GetFeatureSetByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureStore_GetFeatureSetByID_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureStoreServer).GetFeatureSetByID(ctx, req.(*GetFeatureSetByIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeatureStore_GetFeatureSetByName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFeatureSetByNameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureStoreServer).GetFeatureSetByName(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureStore_GetFeatureSetByName_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureStoreServer).GetFeatureSetByName(ctx, req.(*GetFeatureSetByNameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeatureStore_GetFeatureSetByVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFeatureSetByVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureStoreServer).GetFeatureSetByVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureStore_GetFeatureSetByVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureStoreServer).GetFeatureSetByVersion(ctx, req.(*GetFeatureSetByVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeatureStore_GetFeatureSetsAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFeatureSetsAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureStoreServer).GetFeatureSetsAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureStore_GetFeatureSetsAt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureStoreServer).GetFeatureSetsAt(ctx, req.(*GetFeatureSetsAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeatureStore_GetOnlineFeatureSet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOnlineFeatureSetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureStoreServer).GetOnlineFeatureSet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureStore_GetOnlineFeatureSet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureStoreServer).GetOnlineFeatureSet(ctx, req.(*GetOnlineFeatureSetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeatureStore_UpsertFeatureSetSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FeatureSetSchema)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureStoreServer).UpsertFeatureSetSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureStore_UpsertFeatureSetSchema_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureStoreServer).UpsertFeatureSetSchema(ctx, req.(*FeatureSetSchema))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeatureStore_GetFeatureSetSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFeatureSetSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureStoreServer).GetFeatureSetSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureStore_GetFeatureSetSchema_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureStoreServer).GetFeatureSetSchema(ctx, req.(*GetFeatureSetSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeatureStore_ListAllFeatureSets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FeatureStoreServer).ListAllFeatureSets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FeatureStore_ListAllFeatureSets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FeatureStoreServer).ListAllFeatureSets(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FeatureStore_StreamFeatureSets_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FeatureStoreServer).StreamFeatureSets(m, &grpc.GenericServerStream[ListRequest, FeatureSet]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type FeatureStore_StreamFeatureSetsServer = grpc.ServerStreamingServer[FeatureSet]
+
+// FeatureStore_ServiceDesc is the grpc.ServiceDesc for FeatureStore service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FeatureStore_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "mastro.featurestore.FeatureStore",
+	HandlerType: (*FeatureStoreServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateFeatureSet",
+			Handler:    _FeatureStore_CreateFeatureSet_Handler,
+		},
+		{
+			MethodName: "GetFeatureSetByID",
+			Handler:    _FeatureStore_GetFeatureSetByID_Handler,
+		},
+		{
+			MethodName: "GetFeatureSetByName",
+			Handler:    _FeatureStore_GetFeatureSetByName_Handler,
+		},
+		{
+			MethodName: "GetFeatureSetByVersion",
+			Handler:    _FeatureStore_GetFeatureSetByVersion_Handler,
+		},
+		{
+			MethodName: "GetFeatureSetsAt",
+			Handler:    _FeatureStore_GetFeatureSetsAt_Handler,
+		},
+		{
+			MethodName: "GetOnlineFeatureSet",
+			Handler:    _FeatureStore_GetOnlineFeatureSet_Handler,
+		},
+		{
+			MethodName: "UpsertFeatureSetSchema",
+			Handler:    _FeatureStore_UpsertFeatureSetSchema_Handler,
+		},
+		{
+			MethodName: "GetFeatureSetSchema",
+			Handler:    _FeatureStore_GetFeatureSetSchema_Handler,
+		},
+		{
+			MethodName: "ListAllFeatureSets",
+			Handler:    _FeatureStore_ListAllFeatureSets_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamFeatureSets",
+			Handler:       _FeatureStore_StreamFeatureSets_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "mastro/featurestore/featurestore.proto",
+}
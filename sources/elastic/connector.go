@@ -1,17 +1,30 @@
 package elastic
 
 import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io/ioutil"
-	"log"
+	"net/http"
+	"path/filepath"
+	"sort"
+	"time"
 
 	"strings"
 
 	es7 "github.com/elastic/go-elasticsearch/v7"
+	"github.com/elastic/go-elasticsearch/v7/esapi"
 	"github.com/pilillo/mastro/utils/conf"
+	"github.com/pilillo/mastro/utils/logging"
+	"github.com/pilillo/mastro/utils/retry"
 	stringutils "github.com/pilillo/mastro/utils/strings"
 )
 
+// pingTimeout ... maximum time waited for the backend to reply to a ping
+const pingTimeout = 5 * time.Second
+
 var requiredFields = map[string]string{
 	"esUser":  "username",
 	"esPwd":   "password",
@@ -20,7 +33,8 @@ var requiredFields = map[string]string{
 }
 
 var optionalFields = map[string]string{
-	"cert": "cert",
+	"cert":     "cert",
+	"indexDef": "index-def",
 }
 
 // NewElasticConnector factory
@@ -38,6 +52,7 @@ https://www.elastic.co/blog/the-go-client-for-elasticsearch-working-with-data
 type Connector struct {
 	Client    *es7.Client
 	IndexName string
+	transport *http.Transport
 }
 
 // ValidateDataSourceDefinition ... Validates the input data source definition
@@ -49,53 +64,156 @@ func (c *Connector) ValidateDataSourceDefinition(def *conf.DataSourceDefinition)
 			missingFields = append(missingFields, reqvalue)
 		}
 	}
+	sort.Strings(missingFields)
 
+	// report the missing files along with the missing fields
+	filesErr := conf.CheckFileSettings(def, optionalFields["cert"])
 	if len(missingFields) > 0 {
 		// https://stackoverflow.com/questions/28799110/how-to-join-a-slice-of-strings-into-a-single-string
-		return fmt.Errorf("The following fields are missing from the data source configuration: %s", strings.Join(missingFields, ","))
+		err := fmt.Errorf("The following fields are missing from the data source configuration: %s", strings.Join(missingFields, ","))
+		if filesErr != nil {
+			err = fmt.Errorf("%v; %v", err, filesErr)
+		}
+		return err
+	}
+	if filesErr != nil {
+		return filesErr
 	}
 
-	log.Println("Successfully validated data source definition")
+	logging.Debug("Successfully validated data source definition", "source", def.Name)
 	return nil
 }
 
 // InitConnection ... Starts a connection with Elastic Search
-func (c *Connector) InitConnection(def *conf.DataSourceDefinition) {
-	var err error
+func (c *Connector) InitConnection(def *conf.DataSourceDefinition) error {
 	//c.client, err = es7.NewDefaultClient()
 	elasticHostnames := stringutils.SplitAndTrim(def.Settings[requiredFields["esHosts"]], ",")
 
+	// own the transport, so that its connections can be closed
+	c.transport = http.DefaultTransport.(*http.Transport).Clone()
 	esConfig := es7.Config{
 		Addresses: elasticHostnames,
 		Username:  def.Settings[requiredFields["esUser"]],
 		Password:  def.Settings[requiredFields["esPwd"]],
+		Transport: c.transport,
 	}
 	// if encryption is enabled then set the server certificate
 	if certFile, exist := def.Settings[optionalFields["cert"]]; exist {
 		cert, err := ioutil.ReadFile(certFile)
 		if err != nil {
-			log.Fatal("Error while reading certificate", err)
+			return retry.Permanent(fmt.Errorf("Error while reading certificate %s :: %v", certFile, err))
 		}
-		esConfig.CACert = cert
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(cert) {
+			return retry.Permanent(fmt.Errorf("Invalid certificate %s", certFile))
+		}
+		c.transport.TLSClientConfig = &tls.Config{RootCAs: roots}
 	}
 
-	c.Client, err = es7.NewClient(esConfig)
+	client, err := es7.NewClient(esConfig)
+	if err != nil {
+		return retry.Permanent(fmt.Errorf("Failed creating the client for %s :: %v", def.Name, err))
+	}
+	c.Client = client
 	// set the index for the client
 	c.IndexName = def.Settings[requiredFields["esIndex"]]
 
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+	res, err := c.Client.Info(c.Client.Info.WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("Failed connecting to ES %s :: %v", def.Name, err)
+	}
+	defer res.Body.Close()
+	if res.IsError() {
+		return fmt.Errorf("%s ERROR connecting to ES %s", res.Status(), def.Name)
+	}
+	logging.Info("Successfully connected to ES", "source", def.Name, "status", res.Status())
+	return nil
+}
+
+// IndexExists ... checks whether the index exists
+func (c *Connector) IndexExists(indexName string) (bool, error) {
+	existsReq := esapi.IndicesExistsRequest{
+		Index: []string{indexName},
+	}
+	existsRes, err := existsReq.Do(context.Background(), c.Client)
 	if err != nil {
-		log.Fatal(err)
+		return false, fmt.Errorf("IndicesExistsRequest ERROR: %s", err)
 	}
+	defer existsRes.Body.Close()
+	return existsRes.StatusCode == http.StatusOK, nil
+}
 
-	res, err := c.Client.Info()
+// CreateIndex ... creates the index with the provided definition (settings and mappings)
+func (c *Connector) CreateIndex(indexName string, definition []byte) error {
+	createReq := esapi.IndicesCreateRequest{
+		Index: indexName,
+		Body:  bytes.NewReader(definition),
+	}
+	createRes, err := createReq.Do(context.Background(), c.Client)
 	if err != nil {
-		log.Fatalf("Error getting response: %s", err)
+		return fmt.Errorf("IndicesCreateRequest ERROR: %s", err)
+	}
+	defer createRes.Body.Close()
+
+	if createRes.IsError() {
+		return fmt.Errorf("%s ERROR creating index %s :: %s", createRes.Status(), indexName, createRes.String())
+	}
+
+	logging.Info("Successfully created index", "index", indexName)
+	return nil
+}
+
+// CheckIndex ... creates the index using the definition in the index-def file, unless it exists already
+func (c *Connector) CheckIndex(def *conf.DataSourceDefinition, indexName string) error {
+	// check whether the index already exists
+	exists, err := c.IndexExists(indexName)
+	if err != nil {
+		return err
+	}
+	if exists {
+		logging.Debug("Index already exists", "index", indexName)
+		return nil
+	}
+
+	indexDefFilePath, exist := def.Settings[optionalFields["indexDef"]]
+	if !exist {
+		return fmt.Errorf("Index %s does not exist and no %s was provided to create it", indexName, optionalFields["indexDef"])
+	}
+
+	// if the def is indicated without an actual path (parent is itself and dir is ., or directly as ./file)
+	if filepath.Dir(indexDefFilePath) == "." {
+		// look for the index def in the same location of the application config
+		indexDefFilePath = filepath.Join(filepath.Dir(conf.Args.Config), indexDefFilePath)
+	}
+	logging.Debug("Attempting loading index def file", "path", indexDefFilePath)
+
+	// read definition from file
+	defFile, err := ioutil.ReadFile(indexDefFilePath)
+	if err != nil {
+		return err
+	}
+
+	return c.CreateIndex(indexName, defFile)
+}
+
+// Ping ... checks the cluster is reachable
+func (c *Connector) Ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+	res, err := c.Client.Ping(c.Client.Ping.WithContext(ctx))
+	if err != nil {
+		return err
 	}
 	defer res.Body.Close()
-	log.Println("Successfully connected to ES")
-	log.Println(res)
+	if res.IsError() {
+		return fmt.Errorf("%s ERROR pinging the cluster", res.Status())
+	}
+	return nil
 }
 
+// CloseConnection ... closes the idle connections of the client, which holds no other resource
 func (c *Connector) CloseConnection() {
-	c.CloseConnection()
+	c.transport.CloseIdleConnections()
 }
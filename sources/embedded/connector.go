@@ -0,0 +1,226 @@
+package embedded
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pilillo/mastro/utils/conf"
+	"github.com/pilillo/mastro/utils/logging"
+	"github.com/pilillo/mastro/utils/retry"
+)
+
+var requiredFields = map[string]string{
+	// location of the store file, created if missing
+	"path": "path",
+}
+
+// NewEmbeddedConnector ... Factory
+func NewEmbeddedConnector() *Connector {
+	return &Connector{}
+}
+
+// storeFile ... layout of the store file on disk
+type storeFile struct {
+	Sequences map[string]uint64                     `json:"sequences"`
+	Buckets   map[string]map[string]json.RawMessage `json:"buckets"`
+}
+
+// Connector ... embedded key/value store persisted to a single JSON file
+// the whole store is kept in memory and written atomically on every change
+type Connector struct {
+	path  string
+	mutex sync.RWMutex
+	store storeFile
+}
+
+// ValidateDataSourceDefinition ... validates the provided data source definition
+func (c *Connector) ValidateDataSourceDefinition(def *conf.DataSourceDefinition) error {
+	// check all required fields are available
+	var missingFields []string
+	for _, reqvalue := range requiredFields {
+		if _, exist := def.Settings[reqvalue]; !exist {
+			missingFields = append(missingFields, reqvalue)
+		}
+	}
+	sort.Strings(missingFields)
+
+	if len(missingFields) > 0 {
+		return fmt.Errorf("The following fields are missing from the data source configuration: %s", strings.Join(missingFields, ","))
+	}
+
+	logging.Debug("Successfully validated data source definition", "source", def.Name)
+	return nil
+}
+
+// InitConnection ... loads the store from file, or creates an empty one if the file does not exist yet
+func (c *Connector) InitConnection(def *conf.DataSourceDefinition) error {
+	c.path = def.Settings[requiredFields["path"]]
+	c.store = storeFile{
+		Sequences: make(map[string]uint64),
+		Buckets:   make(map[string]map[string]json.RawMessage),
+	}
+
+	data, err := ioutil.ReadFile(c.path)
+	if os.IsNotExist(err) {
+		logging.Info("Creating new embedded store", "path", c.path)
+		if err := c.persist(); err != nil {
+			return fmt.Errorf("Failed creating the embedded store %s :: %v", c.path, err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Failed reading the embedded store %s :: %v", c.path, err)
+	}
+
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &c.store); err != nil {
+			return retry.Permanent(fmt.Errorf("Error while loading embedded store %s :: %v", c.path, err))
+		}
+	}
+	if c.store.Sequences == nil {
+		c.store.Sequences = make(map[string]uint64)
+	}
+	if c.store.Buckets == nil {
+		c.store.Buckets = make(map[string]map[string]json.RawMessage)
+	}
+	logging.Info("Successfully loaded embedded store", "path", c.path)
+	return nil
+}
+
+// Ping ... checks the directory of the store file is still available, the store itself being in memory
+func (c *Connector) Ping() error {
+	_, err := os.Stat(filepath.Dir(c.path))
+	return err
+}
+
+// CloseConnection ... flushes the store to file
+func (c *Connector) CloseConnection() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if err := c.persist(); err != nil {
+		logging.Error("Failed persisting the embedded store", "path", c.path, "error", err)
+	}
+}
+
+// persist ... writes the store to a temporary file and renames it to the target, to never leave a partial file
+// the caller is expected to hold the lock
+func (c *Connector) persist() error {
+	data, err := json.Marshal(c.store)
+	if err != nil {
+		return err
+	}
+
+	tmpFile, err := ioutil.TempFile(filepath.Dir(c.path), filepath.Base(c.path)+".tmp")
+	if err != nil {
+		return fmt.Errorf("Error while persisting embedded store :: %v", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("Error while persisting embedded store :: %v", err)
+	}
+	if err := tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		return fmt.Errorf("Error while persisting embedded store :: %v", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return fmt.Errorf("Error while persisting embedded store :: %v", err)
+	}
+	return os.Rename(tmpFile.Name(), c.path)
+}
+
+// Put ... stores the value as JSON under bucket/key, replacing any previous value,
+// which is kept if the store cannot be persisted
+func (c *Connector) Put(bucket string, key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, exist := c.store.Buckets[bucket]; !exist {
+		c.store.Buckets[bucket] = make(map[string]json.RawMessage)
+	}
+	previous, existed := c.store.Buckets[bucket][key]
+	c.store.Buckets[bucket][key] = data
+	if err := c.persist(); err != nil {
+		if existed {
+			c.store.Buckets[bucket][key] = previous
+		} else {
+			delete(c.store.Buckets[bucket], key)
+		}
+		return err
+	}
+	return nil
+}
+
+// Get ... decodes the value stored under bucket/key into value, returns false if the key does not exist
+func (c *Connector) Get(bucket string, key string, value interface{}) (bool, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	data, exist := c.store.Buckets[bucket][key]
+	if !exist {
+		return false, nil
+	}
+	return true, json.Unmarshal(data, value)
+}
+
+// Delete ... removes the value stored under bucket/key, returns false if the key does not exist,
+// the value is kept if the store cannot be persisted
+func (c *Connector) Delete(bucket string, key string) (bool, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	previous, exist := c.store.Buckets[bucket][key]
+	if !exist {
+		return false, nil
+	}
+	delete(c.store.Buckets[bucket], key)
+	if err := c.persist(); err != nil {
+		c.store.Buckets[bucket][key] = previous
+		return true, err
+	}
+	return true, nil
+}
+
+// ForEach ... calls fn on every key/value in the bucket, in key order, stopping at the first error
+func (c *Connector) ForEach(bucket string, fn func(key string, value json.RawMessage) error) error {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	keys := make([]string, 0, len(c.store.Buckets[bucket]))
+	for k := range c.store.Buckets[bucket] {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if err := fn(k, c.store.Buckets[bucket][k]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NextSequence ... returns an autoincrementing integer for the bucket
+func (c *Connector) NextSequence(bucket string) (uint64, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.store.Sequences[bucket]++
+	if err := c.persist(); err != nil {
+		c.store.Sequences[bucket]--
+		return 0, err
+	}
+	return c.store.Sequences[bucket], nil
+}
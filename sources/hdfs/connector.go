@@ -2,14 +2,16 @@ package hdfs
 
 import (
 	"fmt"
-	"log"
 	"os"
+	"sort"
 	"strings"
 
 	gohdfs "github.com/colinmarc/hdfs/v2"
 	"github.com/colinmarc/hdfs/v2/hadoopconf"
 	"github.com/pilillo/mastro/utils/conf"
 	"github.com/pilillo/mastro/utils/kerberos"
+	"github.com/pilillo/mastro/utils/logging"
+	"github.com/pilillo/mastro/utils/retry"
 )
 
 // NewHDFSConnector factory
@@ -38,22 +40,23 @@ func (c *Connector) ValidateDataSourceDefinition(def *conf.DataSourceDefinition)
 			missingFields = append(missingFields, reqvalue)
 		}
 	}
+	sort.Strings(missingFields)
 
 	if len(missingFields) > 0 {
 		return fmt.Errorf("The following fields are missing from the data source configuration: %s", strings.Join(missingFields, ","))
 	}
 
-	log.Println("Successfully validated data source definition")
+	logging.Debug("Successfully validated data source definition", "source", def.Name)
 	return nil
 }
 
 // InitConnection ... inits connection
-func (c *Connector) InitConnection(def *conf.DataSourceDefinition) {
+func (c *Connector) InitConnection(def *conf.DataSourceDefinition) error {
 
 	// "HADOOP_CONF_DIR" should be set for this to work
 	_, present := os.LookupEnv("HADOOP_CONF_DIR")
 	if !present {
-		panic("HADOOP_CONF_DIR not set!")
+		return retry.Permanent(fmt.Errorf("HADOOP_CONF_DIR not set"))
 	}
 
 	/*
@@ -63,19 +66,22 @@ func (c *Connector) InitConnection(def *conf.DataSourceDefinition) {
 	*/
 	hadoopConf, err := hadoopconf.LoadFromEnvironment()
 	if err != nil {
-		panic(err)
+		return retry.Permanent(fmt.Errorf("Failed loading the hadoop configuration :: %v", err))
 	}
 
 	// https://godoc.org/github.com/colinmarc/hdfs#ClientOptionsFromConf
 	clientOptions := gohdfs.ClientOptionsFromConf(hadoopConf)
 
 	if clientOptions.KerberosClient != nil {
-		clientOptions.KerberosClient = kerberos.GetKerberosClient(def.KerberosDetails)
+		if clientOptions.KerberosClient, err = kerberos.GetKerberosClient(def.KerberosDetails); err != nil {
+			return retry.Permanent(err)
+		}
 	}
 
 	if c.client, err = gohdfs.NewClient(clientOptions); err != nil {
-		panic(err)
+		return fmt.Errorf("Failed connecting to hdfs %s :: %v", def.Name, err)
 	}
+	return nil
 }
 
 // CloseConnection ... terminates the connection
@@ -3,13 +3,15 @@ package hive
 import (
 	"context"
 	"fmt"
-	"log"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/beltran/gohive"
 	"github.com/pilillo/mastro/abstract"
 	"github.com/pilillo/mastro/utils/conf"
+	"github.com/pilillo/mastro/utils/logging"
+	"github.com/pilillo/mastro/utils/retry"
 )
 
 var requiredFields = map[string]string{
@@ -49,23 +51,22 @@ func (c *Connector) ValidateDataSourceDefinition(def *conf.DataSourceDefinition)
 			missingFields = append(missingFields, reqvalue)
 		}
 	}
+	sort.Strings(missingFields)
 
 	if len(missingFields) > 0 {
 		return fmt.Errorf("The following fields are missing from the data source configuration: %s", strings.Join(missingFields, ","))
 	}
 
-	log.Println("Successfully validated data source definition")
+	logging.Debug("Successfully validated data source definition", "source", def.Name)
 	return nil
 }
 
 // InitConnection ... init connection
-func (c *Connector) InitConnection(def *conf.DataSourceDefinition) {
-	var err error
-
+func (c *Connector) InitConnection(def *conf.DataSourceDefinition) error {
 	host := def.Settings[requiredFields["host"]]
 	port, err := strconv.Atoi(def.Settings[requiredFields["port"]])
 	if err != nil {
-		panic(err)
+		return retry.Permanent(fmt.Errorf("Invalid port %s :: %v", def.Settings[requiredFields["port"]], err))
 	}
 
 	configuration := gohive.NewConnectConfiguration()
@@ -83,12 +84,13 @@ func (c *Connector) InitConnection(def *conf.DataSourceDefinition) {
 	case none:
 		c.connection, err = gohive.Connect(host, port, "NOSASL", configuration)
 	default:
-		log.Panicf("Auth type %s not available!", authType)
+		return retry.Permanent(fmt.Errorf("Auth type %s not available", authType))
 	}
 
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("Failed connecting to hive %s :: %v", def.Name, err)
 	}
+	return nil
 }
 
 // CloseConnection ... close connection
@@ -2,13 +2,15 @@ package impala
 
 import (
 	"fmt"
-	"log"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/koblas/impalathing"
 	"github.com/pilillo/mastro/abstract"
 	"github.com/pilillo/mastro/utils/conf"
+	"github.com/pilillo/mastro/utils/logging"
+	"github.com/pilillo/mastro/utils/retry"
 )
 
 var requiredFields = map[string]string{
@@ -33,22 +35,21 @@ func (c *Connector) ValidateDataSourceDefinition(def *conf.DataSourceDefinition)
 			missingFields = append(missingFields, reqvalue)
 		}
 	}
+	sort.Strings(missingFields)
 
 	if len(missingFields) > 0 {
 		return fmt.Errorf("The following fields are missing from the data source configuration: %s", strings.Join(missingFields, ","))
 	}
 
-	log.Println("Successfully validated data source definition")
+	logging.Debug("Successfully validated data source definition", "source", def.Name)
 	return nil
 }
 
-func (c *Connector) InitConnection(def *conf.DataSourceDefinition) {
-	var err error
-
+func (c *Connector) InitConnection(def *conf.DataSourceDefinition) error {
 	host := def.Settings[requiredFields["host"]]
 	port, err := strconv.Atoi(def.Settings[requiredFields["port"]])
 	if err != nil {
-		panic(err)
+		return retry.Permanent(fmt.Errorf("Invalid port %s :: %v", def.Settings[requiredFields["port"]], err))
 	}
 
 	// todo: convert all settings to map[string]interface{}
@@ -60,8 +61,9 @@ func (c *Connector) InitConnection(def *conf.DataSourceDefinition) {
 	}
 
 	if err != nil {
-		panic(err)
+		return fmt.Errorf("Failed connecting to impala %s :: %v", def.Name, err)
 	}
+	return nil
 }
 
 func (c *Connector) CloseConnection() {
@@ -3,15 +3,20 @@ package mongo
 import (
 	"context"
 	"fmt"
-	"log"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/pilillo/mastro/utils/conf"
+	"github.com/pilillo/mastro/utils/logging"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
+// pingTimeout ... maximum time waited for the backend to reply to a ping
+const pingTimeout = 5 * time.Second
+
 var requiredFields = map[string]string{
 	// surely needed the DB and the target collection
 	"database":   "database",
@@ -50,25 +55,26 @@ func (c *Connector) ValidateDataSourceDefinition(def *conf.DataSourceDefinition)
 			missingFields = append(missingFields, reqvalue)
 		}
 	}
+	sort.Strings(missingFields)
 
 	if len(missingFields) > 0 {
 		return fmt.Errorf("The following %d fields are missing from the data source configuration: %s", len(missingFields), strings.Join(missingFields[:], ","))
 	}
 
-	log.Println("Successfully validated data source definition")
+	logging.Debug("Successfully validated data source definition", "source", def.Name)
 	return nil
 }
 
 // InitConnection ... Instantiate the connection with the remote DB
-func (c *Connector) InitConnection(def *conf.DataSourceDefinition) {
+func (c *Connector) InitConnection(def *conf.DataSourceDefinition) error {
 	var connectionString string
 	var exist bool
 
 	// if connectionString is provided then use it
 	if connectionString, exist = def.Settings[optionalFields["connectionString"]]; exist {
-		log.Println("Using provided connection string")
+		logging.Debug("Using provided connection string", "source", def.Name)
 	} else {
-		log.Println("No connection string, building from mandatory fields")
+		logging.Debug("No connection string, building from mandatory fields", "source", def.Name)
 		// todo: mongo connection string varies a lot, maybe just pass the whole string from a secret rather than composing it here??
 		connectionString = fmt.Sprintf(
 			"mongodb://%s:%s@%s",
@@ -78,25 +84,33 @@ func (c *Connector) InitConnection(def *conf.DataSourceDefinition) {
 		)
 	}
 
-	var err error
-	ctx := context.Background()
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
 	//c.DBCLient, err = mongo.NewClient(options.Client().ApplyURI(connectionString))
 	//err = c.DBCLient.Connect(context.Background())
-	c.Client, err = mongo.Connect(ctx, options.Client().ApplyURI(connectionString))
-
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(connectionString))
 	if err != nil {
-		log.Fatal(err)
-	} else {
-		if err = c.Client.Ping(ctx, readpref.Primary()); err != nil {
-			log.Fatal(err)
-		} else {
-			log.Println("Successfully connected to db")
-		}
+		return fmt.Errorf("Failed connecting to db %s :: %v", def.Name, err)
 	}
+	if err = client.Ping(ctx, readpref.Primary()); err != nil {
+		// release the client, as the connection is attempted again with a new one
+		client.Disconnect(context.Background())
+		return fmt.Errorf("Failed pinging db %s :: %v", def.Name, err)
+	}
+	logging.Info("Successfully connected to db", "source", def.Name)
 
 	// set target db and connections
+	c.Client = client
 	c.Database = c.Client.Database(def.Settings[requiredFields["database"]])
 	c.Collection = c.Database.Collection(def.Settings[requiredFields["collection"]])
+	return nil
+}
+
+// Ping ... checks the primary is reachable
+func (c *Connector) Ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+	return c.Client.Ping(ctx, readpref.Primary())
 }
 
 // CloseConnection ... Disconnects and deallocates resources
@@ -0,0 +1,137 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	// pq also registers the postgres driver for database/sql
+	"github.com/lib/pq"
+	"github.com/pilillo/mastro/utils/conf"
+	"github.com/pilillo/mastro/utils/logging"
+	"github.com/pilillo/mastro/utils/retry"
+)
+
+// pingTimeout ... maximum time waited for the backend to reply to a ping
+const pingTimeout = 5 * time.Second
+
+var requiredFields = map[string]string{
+	"username": "username",
+	"password": "password",
+	"host":     "host",
+}
+
+var optionalFields = map[string]string{
+	// database to connect to, postgres defaults it to the username when missing
+	"database": "database",
+	// schema (namespace) holding the tables, defaults to public
+	"schema": "schema",
+	// ssl mode, e.g. disable, require, verify-full
+	"sslmode": "sslmode",
+}
+
+const (
+	defaultSchema  = "public"
+	defaultSSLMode = "disable"
+)
+
+// NewPostgresConnector ... Factory
+func NewPostgresConnector() *Connector {
+	return &Connector{}
+}
+
+// Connector ... struct containing info on how to connect to a postgres db
+type Connector struct {
+	DB     *sql.DB
+	Schema string
+}
+
+// ValidateDataSourceDefinition ... validates the provided data source definition
+func (c *Connector) ValidateDataSourceDefinition(def *conf.DataSourceDefinition) error {
+	// check all required fields are available
+	var missingFields []string
+	for _, reqvalue := range requiredFields {
+		if _, exist := def.Settings[reqvalue]; !exist {
+			missingFields = append(missingFields, reqvalue)
+		}
+	}
+	sort.Strings(missingFields)
+
+	if len(missingFields) > 0 {
+		return fmt.Errorf("The following fields are missing from the data source configuration: %s", strings.Join(missingFields, ","))
+	}
+
+	logging.Debug("Successfully validated data source definition", "source", def.Name)
+	return nil
+}
+
+// InitConnection ... Instantiate the connection with the remote DB
+func (c *Connector) InitConnection(def *conf.DataSourceDefinition) error {
+	// host is provided as host:port
+	hostPort := strings.SplitN(def.Settings[requiredFields["host"]], ":", 2)
+
+	// https://pkg.go.dev/github.com/lib/pq#hdr-Connection_String_Parameters
+	params := []string{
+		fmt.Sprintf("host=%s", quoteValue(hostPort[0])),
+		fmt.Sprintf("user=%s", quoteValue(def.Settings[requiredFields["username"]])),
+		fmt.Sprintf("password=%s", quoteValue(def.Settings[requiredFields["password"]])),
+	}
+	if len(hostPort) > 1 {
+		params = append(params, fmt.Sprintf("port=%s", quoteValue(hostPort[1])))
+	}
+	if database, exist := def.Settings[optionalFields["database"]]; exist {
+		params = append(params, fmt.Sprintf("dbname=%s", quoteValue(database)))
+	}
+	sslMode := defaultSSLMode
+	if mode, exist := def.Settings[optionalFields["sslmode"]]; exist {
+		sslMode = mode
+	}
+	params = append(params, fmt.Sprintf("sslmode=%s", quoteValue(sslMode)))
+
+	db, err := sql.Open("postgres", strings.Join(params, " "))
+	if err != nil {
+		return retry.Permanent(fmt.Errorf("Failed opening the connection to %s :: %v", def.Name, err))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+	if err = db.PingContext(ctx); err != nil {
+		db.Close()
+		return fmt.Errorf("Failed connecting to db %s :: %v", def.Name, err)
+	}
+	c.DB = db
+	logging.Info("Successfully connected to db", "source", def.Name)
+
+	c.Schema = defaultSchema
+	if schema, exist := def.Settings[optionalFields["schema"]]; exist && len(schema) > 0 {
+		c.Schema = schema
+	}
+	return nil
+}
+
+// Table ... returns the schema qualified and quoted name for the given table
+func (c *Connector) Table(name string) string {
+	return fmt.Sprintf("%s.%s", pq.QuoteIdentifier(c.Schema), pq.QuoteIdentifier(name))
+}
+
+// Ping ... checks the db is reachable
+func (c *Connector) Ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+	return c.DB.PingContext(ctx)
+}
+
+// CloseConnection ... Disconnects and deallocates resources
+func (c *Connector) CloseConnection() {
+	c.DB.Close()
+}
+
+// quoteValue ... quotes a value of the key=value connection string
+func quoteValue(value string) string {
+	value = strings.Replace(value, `\`, `\\`, -1)
+	value = strings.Replace(value, `'`, `\'`, -1)
+	return fmt.Sprintf("'%s'", value)
+}
@@ -1,15 +1,21 @@
 package redis
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-redis/redis/v8"
 	"github.com/pilillo/mastro/utils/conf"
+	"github.com/pilillo/mastro/utils/logging"
 )
 
+// pingTimeout ... maximum time waited for the backend to reply to a ping
+const pingTimeout = 5 * time.Second
+
 var requiredFields = map[string]string{
 	"redisUser": "username",
 	"redisPwd":  "password",
@@ -40,23 +46,33 @@ func (c *Connector) ValidateDataSourceDefinition(def *conf.DataSourceDefinition)
 			missingFields = append(missingFields, reqvalue)
 		}
 	}
+	sort.Strings(missingFields)
 
+	// report the missing files along with the missing fields
+	filesErr := conf.CheckFileSettings(def, optionalFields["cert"])
 	if len(missingFields) > 0 {
 		// https://stackoverflow.com/questions/28799110/how-to-join-a-slice-of-strings-into-a-single-string
-		return fmt.Errorf("The following fields are missing from the data source configuration: %s", strings.Join(missingFields, ","))
+		err := fmt.Errorf("The following fields are missing from the data source configuration: %s", strings.Join(missingFields, ","))
+		if filesErr != nil {
+			err = fmt.Errorf("%v; %v", err, filesErr)
+		}
+		return err
+	}
+	if filesErr != nil {
+		return filesErr
 	}
 
-	_, err := strconv.Atoi(def.Settings[requiredFields["redisDB"]])
+	_, err := strconv.Atoi(def.Settings[requiredFields["redisDb"]])
 	if err != nil {
-		return fmt.Errorf("Impossible to convert redisDB to integer")
+		return fmt.Errorf("Impossible to convert %s to integer", requiredFields["redisDb"])
 	}
 
-	log.Println("Successfully validated data source definition")
+	logging.Debug("Successfully validated data source definition", "source", def.Name)
 	return nil
 }
 
 // InitConnection ... inits connection
-func (c *Connector) InitConnection(def *conf.DataSourceDefinition) {
+func (c *Connector) InitConnection(def *conf.DataSourceDefinition) error {
 	redisConf := &redis.Options{}
 
 	redisHost := def.Settings[requiredFields["redisHost"]]
@@ -69,9 +85,24 @@ func (c *Connector) InitConnection(def *conf.DataSourceDefinition) {
 	redisConf.DB, _ = strconv.Atoi(def.Settings[requiredFields["redisDb"]])
 
 	c.Client = redis.NewClient(redisConf)
+	if err := c.Ping(); err != nil {
+		c.Client.Close()
+		return fmt.Errorf("Failed connecting to redis %s :: %v", def.Name, err)
+	}
+	logging.Info("Successfully connected to redis", "source", def.Name)
+	return nil
+}
+
+// Ping ... checks redis is reachable
+func (c *Connector) Ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), pingTimeout)
+	defer cancel()
+	return c.Client.Ping(ctx).Err()
 }
 
 // CloseConnection ... terminates the connection
 func (c *Connector) CloseConnection() {
-	c.CloseConnection()
+	if err := c.Client.Close(); err != nil {
+		logging.Error("Failed closing the connection", "error", err)
+	}
 }
@@ -2,13 +2,15 @@ package s3
 
 import (
 	"fmt"
-	"log"
+	"sort"
 	"strconv"
 	"strings"
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/pilillo/mastro/utils/conf"
+	"github.com/pilillo/mastro/utils/logging"
+	"github.com/pilillo/mastro/utils/retry"
 )
 
 // NewS3Connector factory
@@ -45,6 +47,7 @@ func (c *Connector) ValidateDataSourceDefinition(def *conf.DataSourceDefinition)
 			missingFields = append(missingFields, reqvalue)
 		}
 	}
+	sort.Strings(missingFields)
 
 	if len(missingFields) > 0 {
 		return fmt.Errorf("The following fields are missing from the data source configuration: %s", strings.Join(missingFields, ","))
@@ -55,12 +58,12 @@ func (c *Connector) ValidateDataSourceDefinition(def *conf.DataSourceDefinition)
 		return fmt.Errorf("Impossible to convert usessl to boolean")
 	}
 
-	log.Println("Successfully validated data source definition")
+	logging.Debug("Successfully validated data source definition", "source", def.Name)
 	return nil
 }
 
 // InitConnection ... inits connection
-func (c *Connector) InitConnection(def *conf.DataSourceDefinition) {
+func (c *Connector) InitConnection(def *conf.DataSourceDefinition) error {
 
 	endpoint := def.Settings[requiredFields["endpoint"]]
 	accessKeyID := def.Settings[requiredFields["accesskey"]]
@@ -76,8 +79,9 @@ func (c *Connector) InitConnection(def *conf.DataSourceDefinition) {
 	c.Bucket = bucket
 
 	if err != nil {
-		log.Panicln(err)
+		return retry.Permanent(fmt.Errorf("Failed creating the client for %s :: %v", def.Name, err))
 	}
+	return nil
 }
 
 // CloseConnection ... terminates the connection
@@ -0,0 +1,251 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/utils/conf"
+	restErrors "github.com/pilillo/mastro/utils/errors"
+	"github.com/pilillo/mastro/utils/logging"
+)
+
+// Permission ... an operation on the endpoints
+type Permission string
+
+const (
+	// Read ... retrieve, list and search resources
+	Read Permission = "read"
+	// Write ... create and update resources
+	Write Permission = "write"
+	// Delete ... remove resources
+	Delete Permission = "delete"
+)
+
+// authentication methods
+const (
+	TokenMethod = "token"
+	JWTMethod   = "jwt"
+	BasicMethod = "basic"
+)
+
+// defaultRoles ... crawlers can only write, analysts can only read and admins can do anything
+var defaultRoles = map[string][]Permission{
+	"crawler": {Write},
+	"analyst": {Read},
+	"admin":   {Read, Write, Delete},
+}
+
+// defaultClearances ... admins can access anything, while others up to internal assets and columns,
+// roles without a clearance can only access public ones
+var defaultClearances = map[string]abstract.Classification{
+	"crawler": abstract.Internal,
+	"analyst": abstract.Internal,
+	"admin":   abstract.PII,
+}
+
+// principalKey ... key of the authenticated principal in the gin context
+const principalKey = "auth.principal"
+
+// ErrNoCredentials ... returned by authenticators when the request has no credentials they recognize
+var ErrNoCredentials = errors.New("No credentials provided")
+
+// Principal ... the authenticated user or service
+type Principal struct {
+	Name  string   `json:"name"`
+	Roles []string `json:"roles"`
+	// Clearance ... highest classification the principal can access, set by the middleware from its roles
+	Clearance abstract.Classification `json:"clearance"`
+}
+
+// Authenticator ... authenticates the credentials of a request
+type Authenticator interface {
+	// Authenticate ... returns ErrNoCredentials if the request has no credentials for the method, any other error if they are invalid
+	Authenticate(r *http.Request) (*Principal, error)
+}
+
+// Middleware ... authenticates requests and checks the permissions of the roles of the principal
+type Middleware struct {
+	authenticators []Authenticator
+	// challenges ... WWW-Authenticate challenges of the methods, sent along 401 responses
+	challenges []string
+	roles      map[string][]Permission
+	clearances map[string]abstract.Classification
+}
+
+// NewMiddleware ... returns a middleware for the given definition, which lets all requests through if the definition is nil
+func NewMiddleware(def *conf.AuthDefinition) (*Middleware, error) {
+	m := &Middleware{roles: make(map[string][]Permission), clearances: make(map[string]abstract.Classification)}
+	if def == nil || len(def.Methods) == 0 {
+		logging.Warn("No authentication method defined, endpoints are not protected")
+		return m, nil
+	}
+
+	for role, permissions := range defaultRoles {
+		m.roles[role] = permissions
+	}
+	for role, permissions := range def.Roles {
+		m.roles[role] = nil
+		for _, p := range permissions {
+			permission := Permission(p)
+			if permission != Read && permission != Write && permission != Delete {
+				return nil, fmt.Errorf("Permission %s of role %s is not one of %s, %s or %s", p, role, Read, Write, Delete)
+			}
+			m.roles[role] = append(m.roles[role], permission)
+		}
+	}
+
+	for role, clearance := range defaultClearances {
+		m.clearances[role] = clearance
+	}
+	for role, c := range def.Clearances {
+		clearance := abstract.Classification(c)
+		if err := clearance.Validate(); err != nil || clearance == "" {
+			return nil, fmt.Errorf("Clearance %s of role %s is not one of %v", c, role, abstract.Classifications)
+		}
+		m.clearances[role] = clearance
+	}
+
+	for _, method := range def.Methods {
+		var authenticator Authenticator
+		var err error
+		switch method {
+		case TokenMethod:
+			authenticator, err = NewTokenAuthenticator(def.TokensFile)
+		case JWTMethod:
+			authenticator, err = NewJWTAuthenticator(def.JWKSFile, def.Issuer, def.Audience, def.RolesClaim, def.AllowNoExpiry)
+		case BasicMethod:
+			authenticator, err = NewBasicAuthenticator(def.HtpasswdFile, def.UserRoles)
+		default:
+			err = fmt.Errorf("Authentication method %s is not one of %s, %s or %s", method, TokenMethod, JWTMethod, BasicMethod)
+		}
+		if err != nil {
+			return nil, err
+		}
+		m.authenticators = append(m.authenticators, authenticator)
+		m.addChallenge(method)
+	}
+	logging.Info("Endpoints protected with authentication methods", "methods", strings.Join(def.Methods, ","))
+	return m, nil
+}
+
+// addChallenge ... adds the challenge of the method, once for the token and jwt methods both using bearer tokens
+func (m *Middleware) addChallenge(method string) {
+	challenge := `Bearer realm="mastro"`
+	if method == BasicMethod {
+		challenge = `Basic realm="mastro"`
+	}
+	for _, c := range m.challenges {
+		if c == challenge {
+			return
+		}
+	}
+	m.challenges = append(m.challenges, challenge)
+}
+
+// Enabled ... returns true if requests are authenticated
+func (m *Middleware) Enabled() bool {
+	return len(m.authenticators) > 0
+}
+
+// authenticate ... tries the authenticators in order, until one recognizes the credentials
+func (m *Middleware) authenticate(r *http.Request) (*Principal, error) {
+	for _, authenticator := range m.authenticators {
+		principal, err := authenticator.Authenticate(r)
+		if err == ErrNoCredentials {
+			continue
+		}
+		return principal, err
+	}
+	return nil, ErrNoCredentials
+}
+
+// Allows ... returns true if any of the roles grants the permission
+func (m *Middleware) Allows(roles []string, permission Permission) bool {
+	for _, role := range roles {
+		for _, p := range m.roles[role] {
+			if p == permission {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Clearance ... returns the highest clearance of the roles, public if none has a clearance
+func (m *Middleware) Clearance(roles []string) abstract.Classification {
+	clearances := []abstract.Classification{abstract.Public}
+	for _, role := range roles {
+		if clearance, exist := m.clearances[role]; exist {
+			clearances = append(clearances, clearance)
+		}
+	}
+	return abstract.Max(clearances...)
+}
+
+// Authorize ... authenticates the request and checks that its principal has the permission, returning a 401 or a 403 error otherwise,
+// the principal is nil if endpoints are not protected
+func (m *Middleware) Authorize(r *http.Request, permission Permission) (*Principal, *restErrors.RestErr) {
+	if !m.Enabled() {
+		return nil, nil
+	}
+	principal, err := m.authenticate(r)
+	if err != nil {
+		return nil, restErrors.GetUnauthorizedError(err.Error())
+	}
+	if !m.Allows(principal.Roles, permission) {
+		return nil, restErrors.GetForbiddenError(fmt.Sprintf("%s is not allowed to %s", principal.Name, permission))
+	}
+	principal.Clearance = m.Clearance(principal.Roles)
+	return principal, nil
+}
+
+// Require ... returns a handler aborting requests not authenticated (401) or whose principal lacks the permission (403)
+func (m *Middleware) Require(permission Permission) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, restErr := m.Authorize(c.Request, permission)
+		if restErr != nil {
+			if restErr.Status == http.StatusUnauthorized {
+				// one challenge for each method, so that clients can pick the one they support
+				for _, challenge := range m.challenges {
+					c.Writer.Header().Add("WWW-Authenticate", challenge)
+				}
+			}
+			logging.ReplyError(c, restErr)
+			return
+		}
+		if principal != nil {
+			c.Set(principalKey, principal)
+		}
+		c.Next()
+	}
+}
+
+// GetPrincipal ... returns the principal authenticated for the request, nil if endpoints are not protected
+func GetPrincipal(c *gin.Context) *Principal {
+	if principal, exist := c.Get(principalKey); exist {
+		return principal.(*Principal)
+	}
+	return nil
+}
+
+// credentials ... returns the credentials of the authorization header for the given scheme, empty if missing
+func credentials(r *http.Request, scheme string) string {
+	header := r.Header.Get("Authorization")
+	if len(header) > len(scheme) && strings.EqualFold(header[:len(scheme)], scheme) && header[len(scheme)] == ' ' {
+		return strings.TrimSpace(header[len(scheme)+1:])
+	}
+	return ""
+}
+
+// CorsConfig ... allows all origins as cors.Default does, as well as the authorization header
+func CorsConfig() cors.Config {
+	config := cors.DefaultConfig()
+	config.AllowAllOrigins = true
+	config.AddAllowHeaders("Authorization")
+	return config
+}
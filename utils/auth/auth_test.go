@@ -0,0 +1,227 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pilillo/mastro/abstract"
+	"github.com/pilillo/mastro/utils/conf"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func writeFile(t *testing.T, name string, data []byte) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := ioutil.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// pad ... left pads with zeros to size bytes
+func pad(b []byte, size int) []byte {
+	return append(make([]byte, size-len(b)), b...)
+}
+
+func encode(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// signJWT ... returns a token with the given claims, signed with RS256 or ES256 depending on the key
+func signJWT(t *testing.T, key crypto.Signer, kid string, claims map[string]interface{}) string {
+	alg := "RS256"
+	if _, isEC := key.(*ecdsa.PrivateKey); isEC {
+		alg = "ES256"
+	}
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := encode(header) + "." + encode(payload)
+	digest := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		var err error
+		if signature, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, digest[:]); err != nil {
+			t.Fatal(err)
+		}
+	case *ecdsa.PrivateKey:
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		signature = append(pad(r.Bytes(), 32), pad(s.Bytes(), 32)...)
+	}
+	return signed + "." + encode(signature)
+}
+
+func newJWTAuthenticator(t *testing.T) (*JWTAuthenticator, *rsa.PrivateKey, *ecdsa.PrivateKey) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwks, _ := json.Marshal(map[string]interface{}{"keys": []map[string]string{
+		{"kty": "RSA", "kid": "rsa", "n": encode(rsaKey.N.Bytes()), "e": encode(big.NewInt(int64(rsaKey.E)).Bytes())},
+		{"kty": "EC", "kid": "ec", "crv": "P-256", "x": encode(pad(ecKey.X.Bytes(), 32)), "y": encode(pad(ecKey.Y.Bytes(), 32))},
+	}})
+	a, err := NewJWTAuthenticator(writeFile(t, "jwks.json", jwks), "https://idp.example.com", "mastro", "realm_access.roles", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return a, rsaKey, ecKey
+}
+
+func bearer(token string) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("Authorization", "Bearer "+token)
+	return r
+}
+
+func TestJWTAuthenticator(t *testing.T) {
+	a, rsaKey, ecKey := newJWTAuthenticator(t)
+	claims := func(changes map[string]interface{}) map[string]interface{} {
+		c := map[string]interface{}{
+			"sub":          "jane",
+			"iss":          "https://idp.example.com",
+			"aud":          []string{"other", "mastro"},
+			"exp":          time.Now().Add(time.Hour).Unix(),
+			"realm_access": map[string]interface{}{"roles": []string{"analyst"}},
+		}
+		for k, v := range changes {
+			c[k] = v
+		}
+		return c
+	}
+
+	for _, key := range []crypto.Signer{rsaKey, ecKey} {
+		kid := "rsa"
+		if key == crypto.Signer(ecKey) {
+			kid = "ec"
+		}
+		principal, err := a.Authenticate(bearer(signJWT(t, key, kid, claims(nil))))
+		if err != nil {
+			t.Fatalf("%s: %v", kid, err)
+		}
+		if principal.Name != "jane" || len(principal.Roles) != 1 || principal.Roles[0] != "analyst" {
+			t.Errorf("%s: unexpected principal %v", kid, principal)
+		}
+	}
+
+	invalid := map[string]string{
+		"expired":        signJWT(t, rsaKey, "rsa", claims(map[string]interface{}{"exp": time.Now().Add(-time.Minute).Unix()})),
+		"wrong issuer":   signJWT(t, rsaKey, "rsa", claims(map[string]interface{}{"iss": "https://evil.example.com"})),
+		"wrong audience": signJWT(t, rsaKey, "rsa", claims(map[string]interface{}{"aud": "other"})),
+		"wrong key":      signJWT(t, ecKey, "rsa", claims(nil)),
+		"no expiration":  signJWT(t, rsaKey, "rsa", claims(map[string]interface{}{"exp": nil})),
+	}
+	tampered := signJWT(t, rsaKey, "rsa", claims(nil))
+	invalid["tampered"] = tampered[:len(tampered)-4] + "AAAA"
+	for name, token := range invalid {
+		if _, err := a.Authenticate(bearer(token)); err == nil || err == ErrNoCredentials {
+			t.Errorf("%s: expected an invalid token, got %v", name, err)
+		}
+	}
+	// tokens without expiration are only accepted when explicitly allowed
+	a.allowNoExpiry = true
+	if _, err := a.Authenticate(bearer(invalid["no expiration"])); err != nil {
+		t.Errorf("expected a token without expiration to be allowed, got %v", err)
+	}
+	if _, err := a.Authenticate(bearer("static-token")); err != ErrNoCredentials {
+		t.Errorf("expected a static token not to be considered, got %v", err)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	def := &conf.AuthDefinition{
+		Methods:      []string{TokenMethod, BasicMethod},
+		TokensFile:   writeFile(t, "tokens.yml", []byte("- name: s3-crawler\n  token: crawler-token\n  roles: [crawler]\n")),
+		HtpasswdFile: writeFile(t, "htpasswd", []byte("admin:"+string(hash)+"\n# comment\nanalyst:{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=\n")),
+		UserRoles:    map[string][]string{"admin": {"admin"}, "analyst": {"analyst"}},
+	}
+	m, err := NewMiddleware(def)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	router := gin.New()
+	router.GET("/asset", m.Require(Read), func(c *gin.Context) { c.String(http.StatusOK, GetPrincipal(c).Name) })
+	router.PUT("/asset", m.Require(Write), func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.DELETE("/asset", m.Require(Delete), func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	cases := []struct {
+		method   string
+		auth     func(r *http.Request)
+		expected int
+	}{
+		{http.MethodGet, func(r *http.Request) {}, http.StatusUnauthorized},
+		{http.MethodGet, func(r *http.Request) { r.Header.Set("Authorization", "Bearer wrong") }, http.StatusUnauthorized},
+		{http.MethodPut, func(r *http.Request) { r.Header.Set("Authorization", "Bearer crawler-token") }, http.StatusOK},
+		{http.MethodGet, func(r *http.Request) { r.Header.Set("Authorization", "Bearer crawler-token") }, http.StatusForbidden},
+		// the sha1 of "password"
+		{http.MethodGet, func(r *http.Request) { r.SetBasicAuth("analyst", "password") }, http.StatusOK},
+		{http.MethodDelete, func(r *http.Request) { r.SetBasicAuth("analyst", "password") }, http.StatusForbidden},
+		{http.MethodDelete, func(r *http.Request) { r.SetBasicAuth("admin", "wrong") }, http.StatusUnauthorized},
+		{http.MethodDelete, func(r *http.Request) { r.SetBasicAuth("nobody", "secret") }, http.StatusUnauthorized},
+		{http.MethodDelete, func(r *http.Request) { r.SetBasicAuth("admin", "secret") }, http.StatusNoContent},
+	}
+	for i, c := range cases {
+		r := httptest.NewRequest(c.method, "/asset", nil)
+		c.auth(r)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		if w.Code != c.expected {
+			t.Errorf("case %d: expected %d, got %d (%s)", i, c.expected, w.Code, w.Body.String())
+		}
+	}
+
+	// a challenge for each method is sent along a 401
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/asset", nil))
+	if challenges := w.Header().Values("WWW-Authenticate"); len(challenges) != 2 ||
+		challenges[0] != `Bearer realm="mastro"` || challenges[1] != `Basic realm="mastro"` {
+		t.Errorf("expected a bearer and a basic challenge, got %v", challenges)
+	}
+	bearer := &Middleware{}
+	bearer.addChallenge(JWTMethod)
+	bearer.addChallenge(TokenMethod)
+	if len(bearer.challenges) != 1 {
+		t.Errorf("expected a single bearer challenge for the jwt and token methods, got %v", bearer.challenges)
+	}
+
+	if clearance := m.Clearance([]string{"analyst", "admin"}); clearance != abstract.PII {
+		t.Errorf("expected the highest clearance of the roles, got %s", clearance)
+	}
+	if clearance := m.Clearance([]string{"unknown"}); clearance != abstract.Public {
+		t.Errorf("expected roles without clearance to access public assets only, got %s", clearance)
+	}
+
+	if _, err := NewMiddleware(&conf.AuthDefinition{Methods: []string{"kerberos"}}); err == nil {
+		t.Error("expected an error on an unknown method")
+	}
+	disabled, err := NewMiddleware(nil)
+	if err != nil || disabled.Enabled() {
+		t.Errorf("expected a disabled middleware, got %v", err)
+	}
+}
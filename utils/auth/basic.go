@@ -0,0 +1,97 @@
+package auth
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// BasicAuthenticator ... authenticates users with HTTP basic against an htpasswd file
+type BasicAuthenticator struct {
+	hashes map[string]string
+	roles  map[string][]string
+	// dummyHash ... bcrypt hash compared for unknown users, so that they take as long as known ones to be rejected
+	dummyHash string
+}
+
+// NewBasicAuthenticator ... loads the users of an htpasswd file, whose passwords are hashed with bcrypt (htpasswd -B) or sha1 (htpasswd -s),
+// roles are assigned to users separately since htpasswd does not have them
+func NewBasicAuthenticator(htpasswdFile string, userRoles map[string][]string) (*BasicAuthenticator, error) {
+	if htpasswdFile == "" {
+		return nil, errors.New("htpasswd-file is required by the basic authentication method")
+	}
+	file, err := os.Open(htpasswdFile)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	a := &BasicAuthenticator{hashes: make(map[string]string), roles: userRoles}
+	// the dummy hash is as costly as the most costly hash of the file
+	dummyCost := bcrypt.MinCost
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		entry := strings.TrimSpace(scanner.Text())
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+		parts := strings.SplitN(entry, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("Invalid entry at line %d of %s", line, htpasswdFile)
+		}
+		if !strings.HasPrefix(parts[1], "$2") && !strings.HasPrefix(parts[1], "{SHA}") {
+			return nil, fmt.Errorf("Unsupported hash for user %s in %s, use bcrypt or sha1", parts[0], htpasswdFile)
+		}
+		a.hashes[parts[0]] = parts[1]
+		if cost, err := bcrypt.Cost([]byte(parts[1])); err == nil && cost > dummyCost {
+			dummyCost = cost
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	dummyHash, err := bcrypt.GenerateFromPassword([]byte("dummy"), dummyCost)
+	if err != nil {
+		return nil, err
+	}
+	a.dummyHash = string(dummyHash)
+	return a, nil
+}
+
+// Authenticate ... checks the basic credentials against the htpasswd hashes
+func (a *BasicAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	if credentials(r, "Basic") == "" {
+		return nil, ErrNoCredentials
+	}
+	user, password, ok := r.BasicAuth()
+	if !ok {
+		return nil, errors.New("Invalid basic credentials")
+	}
+	hash, exist := a.hashes[user]
+	if !exist {
+		matches(a.dummyHash, password)
+		return nil, errors.New("Invalid user or password")
+	}
+	if !matches(hash, password) {
+		return nil, errors.New("Invalid user or password")
+	}
+	return &Principal{Name: user, Roles: a.roles[user]}, nil
+}
+
+// matches ... compares the password with its htpasswd hash
+func matches(hash string, password string) bool {
+	if strings.HasPrefix(hash, "{SHA}") {
+		sum := sha1.Sum([]byte(password))
+		expected := "{SHA}" + base64.StdEncoding.EncodeToString(sum[:])
+		return subtle.ConstantTimeCompare([]byte(hash), []byte(expected)) == 1
+	}
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+}
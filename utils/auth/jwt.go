@@ -0,0 +1,299 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	// hash functions used by the supported algorithms
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+)
+
+// jwk ... a json web key, https://tools.ietf.org/html/rfc7517
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	// rsa
+	N string `json:"n"`
+	E string `json:"e"`
+	// ecdsa
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+	// hmac
+	K string `json:"k"`
+}
+
+// verificationKey ... a parsed key, one of *rsa.PublicKey, *ecdsa.PublicKey or []byte
+type verificationKey struct {
+	kid string
+	alg string
+	key interface{}
+}
+
+// JWTAuthenticator ... authenticates bearers of JWTs signed with a key of the JWKS
+type JWTAuthenticator struct {
+	keys       []verificationKey
+	issuer     string
+	audience   string
+	rolesClaim []string
+	// allowNoExpiry ... accepts tokens without exp claim, which never expire
+	allowNoExpiry bool
+	// now ... current time, replaced in tests
+	now func() time.Time
+}
+
+// algorithms ... supported signature algorithms, the none algorithm is never accepted
+var algorithms = map[string]crypto.Hash{
+	"RS256": crypto.SHA256, "RS384": crypto.SHA384, "RS512": crypto.SHA512,
+	"ES256": crypto.SHA256, "ES384": crypto.SHA384, "ES512": crypto.SHA512,
+	"HS256": crypto.SHA256, "HS384": crypto.SHA384, "HS512": crypto.SHA512,
+}
+
+// NewJWTAuthenticator ... loads the keys of a JWKS file (RSA, EC and oct keys) to verify JWTs,
+// checking the issuer and the audience when set and reading the roles from the given claim,
+// tokens without expiration are rejected unless allowNoExpiry is set
+func NewJWTAuthenticator(jwksFile string, issuer string, audience string, rolesClaim string, allowNoExpiry bool) (*JWTAuthenticator, error) {
+	if jwksFile == "" {
+		return nil, errors.New("jwks-file is required by the jwt authentication method")
+	}
+	data, err := ioutil.ReadFile(jwksFile)
+	if err != nil {
+		return nil, err
+	}
+	set := struct {
+		Keys []jwk `json:"keys"`
+	}{}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("Invalid JWKS file %s :: %v", jwksFile, err)
+	}
+	if rolesClaim == "" {
+		rolesClaim = "roles"
+	}
+
+	a := &JWTAuthenticator{issuer: issuer, audience: audience, rolesClaim: strings.Split(rolesClaim, "."), allowNoExpiry: allowNoExpiry, now: time.Now}
+	for i, k := range set.Keys {
+		key, err := k.parse()
+		if err != nil {
+			return nil, fmt.Errorf("Invalid key %d of %s :: %v", i, jwksFile, err)
+		}
+		a.keys = append(a.keys, verificationKey{kid: k.Kid, alg: k.Alg, key: key})
+	}
+	if len(a.keys) == 0 {
+		return nil, fmt.Errorf("No keys in JWKS file %s", jwksFile)
+	}
+	return a, nil
+}
+
+func decodeSegment(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(s, "="))
+}
+
+func decodeInt(s string) (*big.Int, error) {
+	b, err := decodeSegment(s)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(b), nil
+}
+
+// parse ... returns the public key (or secret) of the jwk
+func (k *jwk) parse() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		curves := map[string]elliptic.Curve{"P-256": elliptic.P256(), "P-384": elliptic.P384(), "P-521": elliptic.P521()}
+		curve, exist := curves[k.Crv]
+		if !exist {
+			return nil, fmt.Errorf("Unsupported curve %s", k.Crv)
+		}
+		x, err := decodeInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	case "oct":
+		return decodeSegment(k.K)
+	}
+	return nil, fmt.Errorf("Unsupported key type %s", k.Kty)
+}
+
+// Authenticate ... verifies the signature and claims of the bearer JWT
+func (a *JWTAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	token := credentials(r, "Bearer")
+	if token == "" || strings.Count(token, ".") != 2 {
+		// not a JWT, possibly a static token
+		return nil, ErrNoCredentials
+	}
+	claims, err := a.verify(token)
+	if err != nil {
+		return nil, err
+	}
+	subject, _ := claims["sub"].(string)
+	if subject == "" {
+		return nil, errors.New("Token has no subject")
+	}
+	return &Principal{Name: subject, Roles: a.roles(claims)}, nil
+}
+
+// verify ... checks the signature of the token and returns its claims if valid
+func (a *JWTAuthenticator) verify(token string) (map[string]interface{}, error) {
+	parts := strings.Split(token, ".")
+	headerJSON, err := decodeSegment(parts[0])
+	if err != nil {
+		return nil, errors.New("Invalid token header")
+	}
+	header := struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}{}
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return nil, errors.New("Invalid token header")
+	}
+	hash, supported := algorithms[header.Alg]
+	if !supported {
+		return nil, fmt.Errorf("Unsupported token algorithm %s", header.Alg)
+	}
+	signature, err := decodeSegment(parts[2])
+	if err != nil {
+		return nil, errors.New("Invalid token signature")
+	}
+
+	signed := []byte(parts[0] + "." + parts[1])
+	verified := false
+	for _, k := range a.keys {
+		if (header.Kid != "" && k.kid != "" && k.kid != header.Kid) || (k.alg != "" && k.alg != header.Alg) {
+			continue
+		}
+		if verifySignature(header.Alg, hash, k.key, signed, signature) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, errors.New("Invalid token signature")
+	}
+
+	claimsJSON, err := decodeSegment(parts[1])
+	if err != nil {
+		return nil, errors.New("Invalid token claims")
+	}
+	claims := make(map[string]interface{})
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		return nil, errors.New("Invalid token claims")
+	}
+	if err := a.validateClaims(claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// verifySignature ... verifies the signature with the key, if it has the type required by the algorithm
+func verifySignature(alg string, hash crypto.Hash, key interface{}, signed []byte, signature []byte) bool {
+	h := hash.New()
+	h.Write(signed)
+	digest := h.Sum(nil)
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return strings.HasPrefix(alg, "RS") && rsa.VerifyPKCS1v15(k, hash, digest, signature) == nil
+	case *ecdsa.PublicKey:
+		// the signature is the concatenation of r and s, https://tools.ietf.org/html/rfc7518#section-3.4
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if !strings.HasPrefix(alg, "ES") || len(signature) != 2*size {
+			return false
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		return ecdsa.Verify(k, digest, r, s)
+	case []byte:
+		if !strings.HasPrefix(alg, "HS") {
+			return false
+		}
+		mac := hmac.New(hash.New, k)
+		mac.Write(signed)
+		return hmac.Equal(mac.Sum(nil), signature)
+	}
+	return false
+}
+
+// validateClaims ... checks expiration, not before, issuer and audience
+func (a *JWTAuthenticator) validateClaims(claims map[string]interface{}) error {
+	now := a.now().Unix()
+	exp, isNumber := claims["exp"].(float64)
+	if !isNumber && (claims["exp"] != nil || !a.allowNoExpiry) {
+		return errors.New("Token has no expiration")
+	}
+	if isNumber && now >= int64(exp) {
+		return errors.New("Token is expired")
+	}
+	if nbf, isNumber := claims["nbf"].(float64); isNumber && now < int64(nbf) {
+		return errors.New("Token is not valid yet")
+	}
+	if a.issuer != "" && claims["iss"] != a.issuer {
+		return errors.New("Token issuer is not accepted")
+	}
+	if a.audience != "" {
+		// the audience is either a string or a list of strings
+		found := claims["aud"] == a.audience
+		if audiences, isList := claims["aud"].([]interface{}); isList {
+			for _, aud := range audiences {
+				found = found || aud == a.audience
+			}
+		}
+		if !found {
+			return errors.New("Token audience is not accepted")
+		}
+	}
+	return nil
+}
+
+// roles ... returns the roles in the roles claim, either a list or a space separated string
+func (a *JWTAuthenticator) roles(claims map[string]interface{}) []string {
+	var value interface{} = claims
+	for _, key := range a.rolesClaim {
+		object, isObject := value.(map[string]interface{})
+		if !isObject {
+			return nil
+		}
+		value = object[key]
+	}
+	var roles []string
+	switch v := value.(type) {
+	case string:
+		roles = strings.Fields(v)
+	case []interface{}:
+		for _, role := range v {
+			if s, isString := role.(string); isString {
+				roles = append(roles, s)
+			}
+		}
+	}
+	return roles
+}
@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// tokenEntry ... a static API token in the tokens file
+type tokenEntry struct {
+	Name  string   `yaml:"name"`
+	Token string   `yaml:"token"`
+	Roles []string `yaml:"roles"`
+}
+
+// hashedToken ... the sha256 of a token, so that tokens are not kept in memory and compared in constant time
+type hashedToken struct {
+	hash      [sha256.Size]byte
+	principal Principal
+}
+
+// TokenAuthenticator ... authenticates bearers of static API tokens
+type TokenAuthenticator struct {
+	tokens []hashedToken
+}
+
+// NewTokenAuthenticator ... loads the tokens from a yaml file, e.g.
+//   - name: s3-crawler
+//     token: a-long-random-string
+//     roles: [crawler]
+func NewTokenAuthenticator(tokensFile string) (*TokenAuthenticator, error) {
+	if tokensFile == "" {
+		return nil, errors.New("tokens-file is required by the token authentication method")
+	}
+	data, err := ioutil.ReadFile(tokensFile)
+	if err != nil {
+		return nil, err
+	}
+	var entries []tokenEntry
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("Invalid tokens file %s :: %v", tokensFile, err)
+	}
+
+	a := &TokenAuthenticator{}
+	for i, e := range entries {
+		if strings.TrimSpace(e.Name) == "" || strings.TrimSpace(e.Token) == "" {
+			return nil, fmt.Errorf("Token %d of %s has no name or token", i, tokensFile)
+		}
+		a.tokens = append(a.tokens, hashedToken{
+			hash:      sha256.Sum256([]byte(e.Token)),
+			principal: Principal{Name: e.Name, Roles: e.Roles},
+		})
+	}
+	return a, nil
+}
+
+// Authenticate ... checks the bearer token against the known ones
+func (a *TokenAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	token := credentials(r, "Bearer")
+	if token == "" {
+		return nil, ErrNoCredentials
+	}
+	hash := sha256.Sum256([]byte(token))
+	for i := range a.tokens {
+		if subtle.ConstantTimeCompare(hash[:], a.tokens[i].hash[:]) == 1 {
+			principal := a.tokens[i].principal
+			return &principal, nil
+		}
+	}
+	// a JWT is also sent as bearer, let the next authenticator try it
+	if strings.Count(token, ".") == 2 {
+		return nil, ErrNoCredentials
+	}
+	return nil, errors.New("Invalid token")
+}
@@ -0,0 +1,27 @@
+package conf
+
+// AuthDefinition ... authentication methods and role-based authorization of the REST endpoints,
+// the endpoints are not protected if no method is defined
+type AuthDefinition struct {
+	// Methods ... any of token, jwt and basic, tried in order until one recognizes the request credentials
+	Methods []string `yaml:"methods"`
+	// TokensFile ... yaml list of static API tokens, with the name and roles of their bearer
+	TokensFile string `yaml:"tokens-file,omitempty"`
+	// JWKSFile ... json web key set used to verify the signature of JWTs
+	JWKSFile string `yaml:"jwks-file,omitempty"`
+	// Issuer and Audience ... expected iss and aud claims of JWTs, not checked if empty
+	Issuer   string `yaml:"issuer,omitempty"`
+	Audience string `yaml:"audience,omitempty"`
+	// RolesClaim ... claim of JWTs listing the roles, possibly nested as in realm_access.roles, roles by default
+	RolesClaim string `yaml:"roles-claim,omitempty"`
+	// AllowNoExpiry ... accepts JWTs without exp claim, rejected by default
+	AllowNoExpiry bool `yaml:"allow-no-expiry,omitempty"`
+	// HtpasswdFile ... users and password hashes (bcrypt or sha1) for HTTP basic authentication
+	HtpasswdFile string `yaml:"htpasswd-file,omitempty"`
+	// UserRoles ... roles of the users authenticated with HTTP basic
+	UserRoles map[string][]string `yaml:"user-roles,omitempty"`
+	// Roles ... permissions (read, write, delete) granted to each role, in addition to the default roles
+	Roles map[string][]string `yaml:"roles,omitempty"`
+	// Clearances ... highest classification (public, internal, confidential, pii) each role can access, in addition to the default clearances
+	Clearances map[string]string `yaml:"clearances,omitempty"`
+}
@@ -1,23 +1,37 @@
 package conf
 
 import (
+	"fmt"
 	"io/ioutil"
-	"log"
 	"os"
 
+	"github.com/pilillo/mastro/utils/logging"
 	"gopkg.in/yaml.v2"
 )
 
 // Args ... Arguments provided either as env vars or string args
 var Args struct {
-	Config string `required:"true" arg:"-c,required"`
+	Config string `required:"true" arg:"-c,required,env:MASTRO_CONFIG"`
+	// Validate ... only validates the configuration, as mastro validate -c file
+	Validate *ValidateCommand `arg:"subcommand:validate" ignored:"true"`
 }
 
+// ValidateCommand ... arguments of the validate subcommand, i.e. none besides the configuration
+type ValidateCommand struct{}
+
 // Config ... Defines a model for the input config files
 type Config struct {
 	ConfigType           ConfigType           `yaml:"type"`
 	Details              map[string]string    `yaml:"details,omitempty"`
 	DataSourceDefinition DataSourceDefinition `yaml:"backend"`
+	// optional online store, used by the featurestore to serve the latest feature sets
+	OnlineStoreDefinition *DataSourceDefinition `yaml:"online-store,omitempty"`
+	// optional authentication and authorization of the service endpoints
+	AuthDefinition *AuthDefinition `yaml:"auth,omitempty"`
+	// optional export of the traces
+	TracingDefinition *TracingDefinition `yaml:"tracing,omitempty"`
+	// optional level and format of the logs
+	LoggingDefinition *LoggingDefinition `yaml:"logging,omitempty"`
 }
 
 // ConfigType ... config type
@@ -43,35 +57,77 @@ func fileExists(filename string) bool {
 func parseCfg(data []byte) (*Config, error) {
 	cfg := &Config{}
 
-	err := yaml.Unmarshal(data, &cfg)
-	log.Println("Successfully loaded config", cfg.ConfigType, cfg.DataSourceDefinition.Name)
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	logging.Debug("Successfully parsed config", "type", cfg.ConfigType, "name", cfg.DataSourceDefinition.Name)
 
-	return cfg, err
+	return cfg, nil
 }
 
 func validateCfg(cfg *Config) (*Config, error) {
-	// todo add validation of input config
+	if err := Validate(cfg); err != nil {
+		return nil, err
+	}
 	return cfg, nil
 }
 
-// Load ... load configuration from file path
-func Load(filename string) *Config {
+// Read ... reads, parses and validates the configuration file, the error being a ValidationError if the configuration is invalid
+func Read(filename string) (*Config, error) {
 	if !fileExists(filename) {
-		log.Fatalf("Configuration file %s does not exist (or is a directory)", filename)
+		return nil, fmt.Errorf("Configuration file %s does not exist (or is a directory)", filename)
 	}
 
 	data, err := ioutil.ReadFile(filename)
 	if err != nil {
-		log.Fatalf("Error - %v", err)
+		return nil, fmt.Errorf("Failed reading the configuration %s :: %v", filename, err)
 	}
 
 	config, err := parseCfg(data)
 	if err != nil {
-		log.Fatalf("Error - %v", err)
+		return nil, fmt.Errorf("Failed parsing the configuration %s :: %v", filename, err)
+	}
+	return validateCfg(config)
+}
+
+// ValidateFile ... prints the problems of the configuration file, if any, returning the exit code of the validate command,
+// i.e. 1 if the file is invalid and 0 otherwise
+func ValidateFile(filename string) int {
+	_, err := Read(filename)
+	if validationErr, isInvalid := err.(*ValidationError); isInvalid {
+		for _, problem := range validationErr.Problems {
+			fmt.Println(problem)
+		}
+		fmt.Printf("%s is invalid, %d problems found\n", filename, len(validationErr.Problems))
+		return 1
 	}
-	config, err = validateCfg(config)
 	if err != nil {
-		log.Fatalf("Error - %v", err)
+		fmt.Println(err)
+		return 1
+	}
+	fmt.Printf("%s is valid\n", filename)
+	return 0
+}
+
+// Load ... load configuration from file path, exiting if it is invalid
+func Load(filename string) *Config {
+	config, err := Read(filename)
+	if validationErr, isInvalid := err.(*ValidationError); isInvalid {
+		// report each problem on its own
+		for _, problem := range validationErr.Problems {
+			logging.Error("Invalid configuration", "file", filename, "path", problem.Path, "problem", problem.Message)
+		}
+		logging.Fatal("Invalid configuration", "file", filename, "problems", len(validationErr.Problems))
+	}
+	if err != nil {
+		logging.Fatal("Failed loading the configuration", "error", err)
+	}
+	logging.Info("Successfully loaded config", "type", config.ConfigType, "name", config.DataSourceDefinition.Name)
+	// log as configured from now on
+	if def := config.LoggingDefinition; def != nil {
+		if err := logging.Init(def.Level, def.Format); err != nil {
+			logging.Fatal("Invalid logging configuration", "file", filename, "error", err)
+		}
 	}
 
 	return config
@@ -8,6 +8,12 @@ type CrawlerDefinition struct {
 	ScheduleValue     uint64 `yaml:"schedule-value"`
 	StartNow          bool   `yaml:"start-now"`
 	CatalogueEndpoint string `yaml:"catalogue-endpoint"`
+	// ReconcileEndpoint ... if set, assets of the crawler not found in a walk are marked as stale through this endpoint
+	ReconcileEndpoint string `yaml:"reconcile-endpoint,omitempty"`
+	// AuthToken ... API token sent as bearer to the catalogue, when its endpoints are protected
+	AuthToken string `yaml:"auth-token,omitempty"`
+	// MetricsPort ... if set, the metrics of the crawler runs are served at /metrics on this port
+	MetricsPort string `yaml:"metrics-port,omitempty"`
 }
 
 // Period ... time period to schedule the crawler for
@@ -40,3 +46,6 @@ const (
 	// Sunday = "sunday"
 	Sunday = "sunday"
 )
+
+// periods ... all the periods a crawler can be scheduled for
+var periods = []Period{Seconds, Minutes, Hours, Days, Weeks, Monday, Tuesday, Wednesday, Thursday, Friday, Saturday, Sunday}
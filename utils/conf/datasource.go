@@ -10,6 +10,18 @@ type DataSourceDefinition struct {
 	KerberosDetails *KerberosDetails `yaml:"kerberos"`
 	// optional tls section
 	TLSDetails *TLSDetails `yaml:"tls"`
+	// optional retries of the connection
+	RetryDefinition *RetryDefinition `yaml:"retry,omitempty"`
+}
+
+// RetryDefinition ... retries of the connection to a data source, waiting an exponential backoff between the attempts
+type RetryDefinition struct {
+	// Attempts ... connection attempts before starting without the data source, which keeps being connected in the background, 5 by default
+	Attempts int `yaml:"attempts,omitempty"`
+	// InitialInterval ... wait after the first failed attempt, e.g. 1s, doubled after each further one, 1s by default
+	InitialInterval string `yaml:"initial-interval,omitempty"`
+	// MaxInterval ... maximum wait between two attempts, 30s by default
+	MaxInterval string `yaml:"max-interval,omitempty"`
 }
 
 // KerberosDetails ... Connection details for Kerberos
@@ -0,0 +1,9 @@
+package conf
+
+// LoggingDefinition ... level and format of the logs of the service or crawler
+type LoggingDefinition struct {
+	// Level ... debug, info, warn or error, info by default
+	Level string `yaml:"level,omitempty"`
+	// Format ... logfmt or json, logfmt by default
+	Format string `yaml:"format,omitempty"`
+}
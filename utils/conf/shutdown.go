@@ -0,0 +1,37 @@
+package conf
+
+import (
+	"fmt"
+	"time"
+)
+
+// ShutdownTimeoutKey ... setting of the details with the time given to the running requests or crawler run to end on shutdown
+const ShutdownTimeoutKey = "shutdown-timeout"
+
+// DefaultShutdownTimeout ... time given to the running requests or crawler run to end on shutdown, unless set in the details
+const DefaultShutdownTimeout = 30 * time.Second
+
+// ShutdownTimeout ... returns the shutdown timeout set in the details as a duration, e.g. 45s, or the default one
+func (cfg *Config) ShutdownTimeout() (time.Duration, error) {
+	value, exist := cfg.Details[ShutdownTimeoutKey]
+	if !exist || value == "" {
+		return DefaultShutdownTimeout, nil
+	}
+	timeout, err := parseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %s, expected a positive duration such as 30s", ShutdownTimeoutKey, value)
+	}
+	return timeout, nil
+}
+
+// parseDuration ... parses a positive duration, e.g. 30s
+func parseDuration(value string) (time.Duration, error) {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if duration <= 0 {
+		return 0, fmt.Errorf("%s is not positive", value)
+	}
+	return duration, nil
+}
@@ -0,0 +1,18 @@
+package conf
+
+// TracingDefinition ... export of the traces of the service or crawler, which are not recorded if undefined
+type TracingDefinition struct {
+	// Exporter ... otlp, to send the spans to an OTLP/HTTP receiver such as the OpenTelemetry collector, file or stdout
+	Exporter string `yaml:"exporter"`
+	// Endpoint ... base url of the OTLP/HTTP receiver, e.g. http://localhost:4318, the spans being sent to its /v1/traces
+	Endpoint string `yaml:"endpoint,omitempty"`
+	// Headers ... sent along the exported spans, e.g. to authenticate to the receiver
+	Headers map[string]string `yaml:"headers,omitempty"`
+	// Path ... file the spans are appended to by the file exporter
+	Path string `yaml:"path,omitempty"`
+	// ServiceName ... name of the traced service, mastro-<type> by default
+	ServiceName string `yaml:"service-name,omitempty"`
+	// SampleRatio ... fraction of the traces started by the service which are recorded, from 0 to 1, 1 by default,
+	// while traces started by a caller are recorded if sampled by the caller
+	SampleRatio *float64 `yaml:"sample-ratio,omitempty"`
+}
@@ -0,0 +1,276 @@
+package conf
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pilillo/mastro/utils/logging"
+)
+
+const (
+	// BackendSection ... yaml path of the data source definition of the backend
+	BackendSection = "backend"
+	// OnlineStoreSection ... yaml path of the data source definition of the online store
+	OnlineStoreSection = "online-store"
+)
+
+// SettingsValidator ... checks the settings of a data source definition, e.g. that the required ones are present
+type SettingsValidator func(def *DataSourceDefinition) error
+
+// dataSourceTypes ... the data source types available in each section of each config type, with the validator of their settings
+var dataSourceTypes = map[ConfigType]map[string]map[string]SettingsValidator{}
+
+// RegisterDataSource ... makes a data source type available in a section of a config type, e.g. mongo as backend of the catalogue,
+// its settings being checked by validate if not nil
+func RegisterDataSource(configType ConfigType, section string, dataSourceType string, validate SettingsValidator) {
+	if dataSourceTypes[configType] == nil {
+		dataSourceTypes[configType] = map[string]map[string]SettingsValidator{}
+	}
+	if dataSourceTypes[configType][section] == nil {
+		dataSourceTypes[configType][section] = map[string]SettingsValidator{}
+	}
+	dataSourceTypes[configType][section][dataSourceType] = validate
+}
+
+// Problem ... an invalid value of the configuration, at its yaml path, e.g. backend.crawler.schedule-period
+type Problem struct {
+	Path    string
+	Message string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s: %s", p.Path, p.Message)
+}
+
+// ValidationError ... all the problems found in a configuration
+type ValidationError struct {
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	problems := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		problems[i] = problem.String()
+	}
+	return fmt.Sprintf("%d problems in the configuration :: %s", len(problems), strings.Join(problems, "; "))
+}
+
+// validator ... collects the problems of a configuration
+type validator struct {
+	problems []Problem
+}
+
+func (v *validator) add(path string, format string, args ...interface{}) {
+	v.problems = append(v.problems, Problem{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// Validate ... checks the configuration, returning a ValidationError with all the problems found, nil if none
+func Validate(cfg *Config) error {
+	v := &validator{}
+	switch cfg.ConfigType {
+	case Crawler, Catalogue, FeatureStore:
+	case "":
+		v.add("type", "required, use one of crawler, catalogue or featurestore")
+	default:
+		v.add("type", "unknown type %s, use one of crawler, catalogue or featurestore", cfg.ConfigType)
+	}
+
+	// the services need a port to serve their endpoints on
+	if cfg.ConfigType == Catalogue || cfg.ConfigType == FeatureStore {
+		if port := cfg.Details["port"]; port == "" {
+			v.add("details.port", "required")
+		} else if number, err := strconv.Atoi(port); err != nil || number < 1 || number > 65535 {
+			v.add("details.port", "%s is not a port number, use one from 1 to 65535", port)
+		}
+	}
+	if _, err := cfg.ShutdownTimeout(); err != nil {
+		v.add(fmt.Sprintf("details.%s", ShutdownTimeoutKey), "%v", err)
+	}
+
+	v.dataSource(cfg.ConfigType, BackendSection, &cfg.DataSourceDefinition)
+	if cfg.OnlineStoreDefinition != nil {
+		if cfg.ConfigType != FeatureStore {
+			v.add(OnlineStoreSection, "only used by the featurestore")
+		} else {
+			v.dataSource(cfg.ConfigType, OnlineStoreSection, cfg.OnlineStoreDefinition)
+		}
+	}
+	if cfg.ConfigType == Crawler {
+		v.crawler(fmt.Sprintf("%s.crawler", BackendSection), &cfg.DataSourceDefinition.CrawlerDefinition)
+	}
+
+	if def := cfg.LoggingDefinition; def != nil {
+		if def.Level != "" {
+			if _, err := logging.ParseLevel(def.Level); err != nil {
+				v.add("logging.level", "%v", err)
+			}
+		}
+		if def.Format != "" && def.Format != logging.FormatLogfmt && def.Format != logging.FormatJSON {
+			v.add("logging.format", "unknown log format %s, use one of %s or %s", def.Format, logging.FormatLogfmt, logging.FormatJSON)
+		}
+	}
+
+	if def := cfg.AuthDefinition; def != nil {
+		v.auth(def)
+	}
+	if def := cfg.TracingDefinition; def != nil {
+		v.tracing(def)
+	}
+
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+	return nil
+}
+
+// dataSource ... checks the data source definition at the section
+func (v *validator) dataSource(configType ConfigType, section string, def *DataSourceDefinition) {
+	if def.Name == "" {
+		v.add(fmt.Sprintf("%s.name", section), "required")
+	}
+	// the types are only known for the config types of the binary
+	if types, known := dataSourceTypes[configType][section]; known {
+		validate, exist := types[def.Type]
+		switch {
+		case def.Type == "":
+			v.add(fmt.Sprintf("%s.type", section), "required, use one of %s", typeNames(types))
+		case !exist:
+			v.add(fmt.Sprintf("%s.type", section), "unknown type %s for the %s, use one of %s", def.Type, configType, typeNames(types))
+		case validate != nil:
+			if err := validate(def); err != nil {
+				v.add(fmt.Sprintf("%s.settings", section), "%v", err)
+			}
+		}
+	}
+
+	if tls := def.TLSDetails; tls != nil {
+		v.file(fmt.Sprintf("%s.tls.client-cert-file", section), tls.ClientCertFile)
+		v.file(fmt.Sprintf("%s.tls.client-key-file", section), tls.ClientKeyFile)
+		v.file(fmt.Sprintf("%s.tls.ca-cert-file", section), tls.CaCertFile)
+	}
+	if kerberos := def.KerberosDetails; kerberos != nil {
+		v.file(fmt.Sprintf("%s.kerberos.krb-config-path", section), kerberos.KrbConfigPath)
+		v.file(fmt.Sprintf("%s.kerberos.keytab-path", section), kerberos.KeytabPath)
+	}
+
+	if retry := def.RetryDefinition; retry != nil {
+		if retry.Attempts < 0 {
+			v.add(fmt.Sprintf("%s.retry.attempts", section), "%d is not a positive number", retry.Attempts)
+		}
+		if retry.InitialInterval != "" {
+			if _, err := parseDuration(retry.InitialInterval); err != nil {
+				v.add(fmt.Sprintf("%s.retry.initial-interval", section), "invalid duration :: %v", err)
+			}
+		}
+		if retry.MaxInterval != "" {
+			if _, err := parseDuration(retry.MaxInterval); err != nil {
+				v.add(fmt.Sprintf("%s.retry.max-interval", section), "invalid duration :: %v", err)
+			}
+		}
+	}
+}
+
+// crawler ... checks the schedule and the endpoint of the crawler definition
+func (v *validator) crawler(path string, def *CrawlerDefinition) {
+	valid := false
+	names := make([]string, len(periods))
+	for i, period := range periods {
+		valid = valid || def.ScheduleEvery == period
+		names[i] = string(period)
+	}
+	if def.ScheduleEvery == "" {
+		v.add(fmt.Sprintf("%s.schedule-period", path), "required, use one of %s", strings.Join(names, ", "))
+	} else if !valid {
+		v.add(fmt.Sprintf("%s.schedule-period", path), "unknown period %s, use one of %s", def.ScheduleEvery, strings.Join(names, ", "))
+	}
+	if def.ScheduleValue == 0 {
+		v.add(fmt.Sprintf("%s.schedule-value", path), "required, e.g. 1 to run every period")
+	}
+	if def.CatalogueEndpoint == "" {
+		v.add(fmt.Sprintf("%s.catalogue-endpoint", path), "required")
+	}
+}
+
+// authMethodFiles ... the authentication methods, with the setting of the file each one requires
+var authMethodFiles = map[string]string{
+	"token": "tokens-file",
+	"jwt":   "jwks-file",
+	"basic": "htpasswd-file",
+}
+
+// auth ... checks the authentication methods and the files they require
+func (v *validator) auth(def *AuthDefinition) {
+	files := map[string]string{
+		"tokens-file":   def.TokensFile,
+		"jwks-file":     def.JWKSFile,
+		"htpasswd-file": def.HtpasswdFile,
+	}
+	for i, method := range def.Methods {
+		setting, known := authMethodFiles[method]
+		if !known {
+			v.add(fmt.Sprintf("auth.methods[%d]", i), "unknown method %s, use one of basic, jwt or token", method)
+			continue
+		}
+		if files[setting] == "" {
+			v.add(fmt.Sprintf("auth.%s", setting), "required by the %s method", method)
+		} else {
+			v.file(fmt.Sprintf("auth.%s", setting), files[setting])
+		}
+	}
+}
+
+// tracing ... checks the exporter of the traces and the settings it requires
+func (v *validator) tracing(def *TracingDefinition) {
+	switch def.Exporter {
+	case "otlp":
+		if def.Endpoint == "" {
+			v.add("tracing.endpoint", "required by the otlp exporter")
+		}
+	case "file":
+		if def.Path == "" {
+			v.add("tracing.path", "required by the file exporter")
+		}
+	case "stdout":
+	case "":
+		v.add("tracing.exporter", "required, use one of file, otlp or stdout")
+	default:
+		v.add("tracing.exporter", "unknown exporter %s, use one of file, otlp or stdout", def.Exporter)
+	}
+	if ratio := def.SampleRatio; ratio != nil && (*ratio < 0 || *ratio > 1) {
+		v.add("tracing.sample-ratio", "%v is not between 0 and 1", *ratio)
+	}
+}
+
+// file ... checks the file exists, if the path is set
+func (v *validator) file(path string, filename string) {
+	if filename != "" && !fileExists(filename) {
+		v.add(path, "file %s does not exist", filename)
+	}
+}
+
+// CheckFileSettings ... checks the files at the given settings of the data source definition exist, if set,
+// returning an error listing the missing ones, for the settings validators of the connectors
+func CheckFileSettings(def *DataSourceDefinition, settings ...string) error {
+	var missing []string
+	for _, setting := range settings {
+		if filename := def.Settings[setting]; filename != "" && !fileExists(filename) {
+			missing = append(missing, fmt.Sprintf("%s (%s)", setting, filename))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("The following files of the data source configuration do not exist: %s", strings.Join(missing, ","))
+	}
+	return nil
+}
+
+// typeNames ... returns the sorted names of the types
+func typeNames(types map[string]SettingsValidator) string {
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
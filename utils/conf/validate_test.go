@@ -0,0 +1,135 @@
+package conf
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	RegisterDataSource(Catalogue, BackendSection, "test", func(def *DataSourceDefinition) error {
+		if def.Settings["host"] == "" {
+			return errors.New("missing host")
+		}
+		return nil
+	})
+
+	valid := &Config{
+		ConfigType:           Catalogue,
+		Details:              map[string]string{"port": "8085"},
+		DataSourceDefinition: DataSourceDefinition{Name: "test", Type: "test", Settings: map[string]string{"host": "localhost"}},
+	}
+	if err := Validate(valid); err != nil {
+		t.Fatalf("expected a valid configuration, got %v", err)
+	}
+
+	invalid := &Config{
+		ConfigType: Catalogue,
+		DataSourceDefinition: DataSourceDefinition{
+			Type:            "test",
+			TLSDetails:      &TLSDetails{CaCertFile: filepath.Join(t.TempDir(), "ca.pem")},
+			RetryDefinition: &RetryDefinition{MaxInterval: "often"},
+		},
+		OnlineStoreDefinition: &DataSourceDefinition{Name: "online", Type: "redis"},
+		LoggingDefinition:     &LoggingDefinition{Format: "xml"},
+	}
+	err := Validate(invalid)
+	validationErr, isInvalid := err.(*ValidationError)
+	if !isInvalid {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	// all the problems are reported at once, by yaml path
+	expected := []string{"details.port", "backend.name", "backend.settings", "backend.tls.ca-cert-file", "backend.retry.max-interval", "online-store", "logging.format"}
+	if len(validationErr.Problems) != len(expected) {
+		t.Fatalf("expected %d problems, got %v", len(expected), validationErr.Problems)
+	}
+	for i, problem := range validationErr.Problems {
+		if problem.Path != expected[i] {
+			t.Errorf("expected a problem at %s, got %s", expected[i], problem)
+		}
+	}
+
+	crawler := &Config{
+		ConfigType:           Crawler,
+		DataSourceDefinition: DataSourceDefinition{Name: "test", Type: "local", CrawlerDefinition: CrawlerDefinition{ScheduleEvery: "fortnights", ScheduleValue: 1}},
+	}
+	err = Validate(crawler)
+	if validationErr, isInvalid = err.(*ValidationError); !isInvalid || len(validationErr.Problems) != 2 ||
+		validationErr.Problems[0].Path != "backend.crawler.schedule-period" || validationErr.Problems[1].Path != "backend.crawler.catalogue-endpoint" {
+		t.Errorf("expected the schedule period and the catalogue endpoint to be reported, got %v", err)
+	}
+	if err := Validate(&Config{ConfigType: "agent"}); err == nil {
+		t.Error("expected the unknown type to be reported")
+	}
+}
+
+func TestValidateSections(t *testing.T) {
+	RegisterDataSource(Catalogue, BackendSection, "test-files", func(def *DataSourceDefinition) error {
+		return CheckFileSettings(def, "cert")
+	})
+	dir := t.TempDir()
+	jwks := filepath.Join(dir, "jwks.json")
+	if err := os.WriteFile(jwks, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	ratio := 1.5
+
+	cfg := &Config{
+		ConfigType: Catalogue,
+		Details:    map[string]string{"port": "abc"},
+		DataSourceDefinition: DataSourceDefinition{
+			Name: "test", Type: "test-files", Settings: map[string]string{"cert": filepath.Join(dir, "ca.pem")},
+		},
+		AuthDefinition: &AuthDefinition{
+			Methods:      []string{"jwt", "oauth", "token", "basic"},
+			JWKSFile:     jwks,
+			HtpasswdFile: filepath.Join(dir, "htpasswd"),
+		},
+		TracingDefinition: &TracingDefinition{Exporter: "otlp", SampleRatio: &ratio},
+	}
+	err := Validate(cfg)
+	validationErr, isInvalid := err.(*ValidationError)
+	if !isInvalid {
+		t.Fatalf("expected a validation error, got %v", err)
+	}
+	// all the problems are reported at once, by yaml path
+	expected := []string{"details.port", "backend.settings", "auth.methods[1]", "auth.tokens-file", "auth.htpasswd-file",
+		"tracing.endpoint", "tracing.sample-ratio"}
+	if len(validationErr.Problems) != len(expected) {
+		t.Fatalf("expected %d problems, got %v", len(expected), validationErr.Problems)
+	}
+	for i, problem := range validationErr.Problems {
+		if problem.Path != expected[i] {
+			t.Errorf("expected a problem at %s, got %s", expected[i], problem)
+		}
+	}
+
+	if err := Validate(&Config{ConfigType: Crawler, TracingDefinition: &TracingDefinition{Exporter: "zipkin"}}); err == nil ||
+		!strings.Contains(err.Error(), "tracing.exporter: unknown exporter zipkin") {
+		t.Errorf("expected the unknown exporter to be reported, got %v", err)
+	}
+}
+
+func TestValidateFile(t *testing.T) {
+	RegisterDataSource(Catalogue, BackendSection, "test-file", nil)
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.yml")
+	if err := os.WriteFile(valid, []byte("type: catalogue\ndetails:\n  port: 8085\nbackend:\n  name: test\n  type: test-file\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if code := ValidateFile(valid); code != 0 {
+		t.Errorf("expected the exit code 0 for a valid file, got %d", code)
+	}
+	invalid := filepath.Join(dir, "invalid.yml")
+	if err := os.WriteFile(invalid, []byte("type: catalogue\nbackend:\n  type: unknown\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if code := ValidateFile(invalid); code != 1 {
+		t.Errorf("expected the exit code 1 for an invalid file, got %d", code)
+	}
+	if code := ValidateFile(filepath.Join(dir, "missing.yml")); code != 1 {
+		t.Errorf("expected the exit code 1 for a missing file, got %d", code)
+	}
+}
@@ -8,6 +8,8 @@ type RestErr struct {
 	Message string `json:"message"`
 	Status  int    `json:"status"`
 	Error   string `json:"error"`
+	// RequestID ... id of the failed request, as logged by the service
+	RequestID string `json:"request-id,omitempty"`
 }
 
 func GetBadRequestError(message string) *RestErr {
@@ -26,6 +28,14 @@ func GetNotFoundError(message string) *RestErr {
 	}
 }
 
+func GetConflictError(message string) *RestErr {
+	return &RestErr{
+		Message: message,
+		Status:  http.StatusConflict,
+		Error:   "conflict",
+	}
+}
+
 func GetInternalServerError(message string) *RestErr {
 	return &RestErr{
 		Message: message,
@@ -33,3 +43,35 @@ func GetInternalServerError(message string) *RestErr {
 		Error:   "internal_server_error",
 	}
 }
+
+func GetNotImplementedError(message string) *RestErr {
+	return &RestErr{
+		Message: message,
+		Status:  http.StatusNotImplemented,
+		Error:   "not_implemented",
+	}
+}
+
+func GetUnauthorizedError(message string) *RestErr {
+	return &RestErr{
+		Message: message,
+		Status:  http.StatusUnauthorized,
+		Error:   "unauthorized",
+	}
+}
+
+func GetForbiddenError(message string) *RestErr {
+	return &RestErr{
+		Message: message,
+		Status:  http.StatusForbidden,
+		Error:   "forbidden",
+	}
+}
+
+func GetServiceUnavailableError(message string) *RestErr {
+	return &RestErr{
+		Message: message,
+		Status:  http.StatusServiceUnavailable,
+		Error:   "service_unavailable",
+	}
+}
@@ -0,0 +1,30 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Dial ... returns a connection to the server at the address, e.g. localhost:9090, over cleartext HTTP/2,
+// authenticated with the bearer token if not empty, to be used with the generated clients, e.g. cataloguepb.NewCatalogueClient
+func Dial(addr string, token string) (*grpc.ClientConn, error) {
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(bearerToken(token)))
+	}
+	return grpc.NewClient(addr, opts...)
+}
+
+// bearerToken ... sends the API token in the authorization metadata of each call
+type bearerToken string
+
+func (t bearerToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+// RequireTransportSecurity ... the server is served over cleartext as the REST endpoints
+func (t bearerToken) RequireTransportSecurity() bool {
+	return false
+}
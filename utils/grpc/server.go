@@ -0,0 +1,181 @@
+// Package grpc ... serves the services generated from the protos in proto/ with grpc-go,
+// authenticating, tracing and logging the calls as the REST endpoints do for requests
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pilillo/mastro/utils/auth"
+	"github.com/pilillo/mastro/utils/lifecycle"
+	"github.com/pilillo/mastro/utils/logging"
+	"github.com/pilillo/mastro/utils/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Server ... the gRPC services, authorized with the middleware of the REST endpoints
+type Server struct {
+	server *grpc.Server
+	authz  *auth.Middleware
+	// permissions ... permission required by each method, e.g. /mastro.catalogue.Catalogue/GetAssetByName
+	permissions map[string]auth.Permission
+}
+
+// NewServer ... returns a server without services
+func NewServer(authz *auth.Middleware) *Server {
+	s := &Server{authz: authz, permissions: make(map[string]auth.Permission)}
+	s.server = grpc.NewServer(grpc.UnaryInterceptor(s.unary), grpc.StreamInterceptor(s.stream))
+	return s
+}
+
+// Register ... registers the implementation of a generated service, with the permission required by each of its methods
+func (s *Server) Register(desc *grpc.ServiceDesc, impl interface{}, permissions map[string]auth.Permission) error {
+	var names []string
+	for _, m := range desc.Methods {
+		names = append(names, m.MethodName)
+	}
+	for _, m := range desc.Streams {
+		names = append(names, m.StreamName)
+	}
+	for _, name := range names {
+		permission, exist := permissions[name]
+		if !exist {
+			return fmt.Errorf("No permission defined for method %s of %s", name, desc.ServiceName)
+		}
+		s.permissions[fmt.Sprintf("/%s/%s", desc.ServiceName, name)] = permission
+	}
+	s.server.RegisterService(desc, impl)
+	return nil
+}
+
+type principalKey struct{}
+
+// GetPrincipal ... returns the principal authenticated for the call, nil if the methods are not protected
+func GetPrincipal(ctx context.Context) *auth.Principal {
+	principal, _ := ctx.Value(principalKey{}).(*auth.Principal)
+	return principal
+}
+
+func (s *Server) unary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	var reply interface{}
+	err := s.call(ctx, info.FullMethod, func(ctx context.Context) error {
+		var err error
+		reply, err = handler(ctx, req)
+		return err
+	})
+	return reply, err
+}
+
+func (s *Server) stream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return s.call(ss.Context(), info.FullMethod, func(ctx context.Context) error {
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	})
+}
+
+// contextStream ... a server stream whose context carries the principal, request id and span of the call
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// call ... handles a call as the REST middlewares handle a request, the metadata of the call being its headers
+func (s *Server) call(ctx context.Context, method string, handle func(ctx context.Context) error) error {
+	start := time.Now()
+	md, _ := metadata.FromIncomingContext(ctx)
+	header := http.Header{}
+	for key, values := range md {
+		for _, v := range values {
+			header.Add(key, v)
+		}
+	}
+	// identify the call as the REST requests, so that its logs can be correlated
+	requestID := logging.RequestIDFrom(header)
+	grpc.SetHeader(ctx, metadata.Pairs(logging.RequestIDHeader, requestID))
+
+	permission, exist := s.permissions[method]
+	if !exist {
+		return status.Errorf(codes.Unimplemented, "unknown method %s", method)
+	}
+	principal, restErr := s.authz.Authorize(&http.Request{Header: header}, permission)
+	if restErr != nil {
+		return FromRestErr(restErr)
+	}
+	// as the REST endpoints, reply unavailable while the backends are not connected
+	if err := lifecycle.Unavailable(); err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+
+	// trace the call, as a child of the span of the caller if any
+	ctx, span := tracing.StartWithKind(tracing.Extract(ctx, header), trace.SpanKindServer, strings.TrimPrefix(method, "/"),
+		attribute.String("rpc.system", "grpc"))
+	defer span.End()
+	ctx = logging.WithRequestID(ctx, requestID)
+	if principal != nil {
+		ctx = context.WithValue(ctx, principalKey{}, principal)
+	}
+
+	err := handle(ctx)
+	code := status.Code(err)
+	span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(code)))
+	if code != codes.OK {
+		span.SetStatus(otelcodes.Error, status.Convert(err).Message())
+	}
+	logger := logging.FromContext(ctx)
+	if code == codes.Internal || code == codes.Unknown {
+		logger.Error("Handled call", "method", method, "code", code, "duration", time.Since(start), "error", status.Convert(err).Message())
+	} else {
+		logger.Info("Handled call", "method", method, "code", code, "duration", time.Since(start))
+	}
+	return err
+}
+
+// Serve ... serves the services on the listener in the background
+func (s *Server) Serve(listener net.Listener) {
+	go func() {
+		if err := s.server.Serve(listener); err != nil {
+			logging.Fatal("gRPC endpoint failed", "address", listener.Addr().String(), "error", err)
+		}
+	}()
+	logging.Info("Serving gRPC methods", "methods", len(s.permissions), "address", listener.Addr().String())
+}
+
+// Listen ... listens on the address, e.g. :9090, and serves the services in the background
+func (s *Server) Listen(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	s.Serve(listener)
+	return nil
+}
+
+// Shutdown ... stops accepting connections and calls and waits for the running ones to end,
+// or for the context to be done, in which case they are canceled
+func (s *Server) Shutdown(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
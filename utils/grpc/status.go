@@ -0,0 +1,33 @@
+package grpc
+
+import (
+	"net/http"
+
+	"github.com/pilillo/mastro/utils/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// statusCodes ... gRPC status codes of the http statuses of RestErr
+var statusCodes = map[int]codes.Code{
+	http.StatusBadRequest:          codes.InvalidArgument,
+	http.StatusUnauthorized:        codes.Unauthenticated,
+	http.StatusForbidden:           codes.PermissionDenied,
+	http.StatusNotFound:            codes.NotFound,
+	http.StatusConflict:            codes.AlreadyExists,
+	http.StatusNotImplemented:      codes.Unimplemented,
+	http.StatusServiceUnavailable:  codes.Unavailable,
+	http.StatusInternalServerError: codes.Internal,
+}
+
+// FromRestErr ... converts the RestErr of a service to a status error, nil if the RestErr is nil
+func FromRestErr(restErr *errors.RestErr) error {
+	if restErr == nil {
+		return nil
+	}
+	code, exist := statusCodes[restErr.Status]
+	if !exist {
+		code = codes.Unknown
+	}
+	return status.Error(code, restErr.Message)
+}
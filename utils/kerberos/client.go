@@ -1,6 +1,8 @@
 package kerberos
 
 import (
+	"fmt"
+
 	"github.com/pilillo/mastro/utils/conf"
 
 	krbClient "github.com/jcmturner/gokrb5/v8/client"
@@ -9,12 +11,15 @@ import (
 )
 
 // GetKerberosClient ... returns a gokrb5 kerberos client
-func GetKerberosClient(details *conf.KerberosDetails) *krbClient.Client {
+func GetKerberosClient(details *conf.KerberosDetails) (*krbClient.Client, error) {
+	if details == nil {
+		return nil, fmt.Errorf("kerberos is enabled in the hadoop configuration but no kerberos section is defined")
+	}
 	// https://github.com/jcmturner/gokrb5/blob/master/v8/USAGE.md
 	// Replace with a valid credentialed client.
 	cfg, err := config.Load(details.KrbConfigPath)
 	if err != nil {
-		panic(err)
+		return nil, fmt.Errorf("Failed loading the kerberos configuration %s :: %v", details.KrbConfigPath, err)
 	}
 
 	var krb5Client *krbClient.Client
@@ -22,7 +27,7 @@ func GetKerberosClient(details *conf.KerberosDetails) *krbClient.Client {
 	if len(details.KeytabPath) > 0 {
 		kt, err := keytab.Load(details.KeytabPath)
 		if err != nil {
-			panic(err)
+			return nil, fmt.Errorf("Failed loading the keytab %s :: %v", details.KeytabPath, err)
 		}
 		krb5Client = krbClient.NewWithKeytab(
 			details.Username,
@@ -37,5 +42,5 @@ func GetKerberosClient(details *conf.KerberosDetails) *krbClient.Client {
 		)
 	}
 
-	return krb5Client
+	return krb5Client, nil
 }
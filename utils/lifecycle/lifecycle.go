@@ -0,0 +1,231 @@
+// Package lifecycle ... connects the backends and serves the endpoints until shut down, and reports through the probes whether
+// the process is alive and ready to handle requests, i.e. its backends are reachable and it is not shutting down
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pilillo/mastro/utils/errors"
+	"github.com/pilillo/mastro/utils/logging"
+	"github.com/pilillo/mastro/utils/retry"
+)
+
+const (
+	// LivenessRoute ... replies 200 as long as the process serves requests
+	LivenessRoute = "/livez"
+	// ReadinessRoute ... replies 200 if all checks pass and the process is not shutting down, 503 otherwise
+	ReadinessRoute = "/readyz"
+	// checkTimeout ... maximum time waited for the checks of a readiness probe
+	checkTimeout = 5 * time.Second
+)
+
+// Check ... returns an error if a dependency of the process, e.g. its backend, is not available
+type Check func() error
+
+var (
+	mu       sync.RWMutex
+	checks   = map[string]Check{}
+	draining bool
+	// pending ... the backends not connected yet, with the error of their last attempt
+	pending = map[string]error{}
+	// connecting ... context of the connections in the background, cancelled by Drain
+	connecting, stopConnecting = context.WithCancel(context.Background())
+)
+
+// RegisterCheck ... adds a check to the readiness probe, replacing any check with the same name
+func RegisterCheck(name string, check Check) {
+	mu.Lock()
+	defer mu.Unlock()
+	checks[name] = check
+}
+
+// Drain ... makes the readiness probe fail, so that no new requests are routed to the process while it shuts down,
+// and stops connecting the pending backends
+func Drain() {
+	mu.Lock()
+	defer mu.Unlock()
+	draining = true
+	stopConnecting()
+}
+
+// Connect ... connects a backend, retrying with the backoff, and checks it with ping once connected;
+// a permanent error, e.g. an invalid definition, is returned, while if the attempts are exhausted the process starts in degraded mode:
+// the backend keeps being connected in the background, reported by its readiness check and by Unavailable meanwhile
+func Connect(name string, backoff *retry.Backoff, connect func() error, ping Check) error {
+	err := backoff.Do(connecting, name, connect)
+	if err == nil {
+		connected(name, ping)
+		return nil
+	}
+	if retry.IsPermanent(err) {
+		return err
+	}
+	logging.Error("Backend not reachable, starting in degraded mode", "backend", name, "error", err)
+	mu.Lock()
+	pending[name] = err
+	mu.Unlock()
+	RegisterCheck(name, func() error {
+		mu.RLock()
+		defer mu.RUnlock()
+		return fmt.Errorf("not connected :: %v", pending[name])
+	})
+	go func() {
+		err := backoff.Unlimited().Do(connecting, name, func() error {
+			err := connect()
+			if err != nil {
+				mu.Lock()
+				pending[name] = err
+				mu.Unlock()
+			}
+			return err
+		})
+		if err == nil {
+			connected(name, ping)
+			logging.Info("Backend connected, leaving degraded mode", "backend", name)
+		}
+	}()
+	return nil
+}
+
+// connected ... marks the backend as connected, checked with ping from now on
+func connected(name string, ping Check) {
+	if ping == nil {
+		ping = func() error { return nil }
+	}
+	mu.Lock()
+	delete(pending, name)
+	mu.Unlock()
+	RegisterCheck(name, ping)
+}
+
+// Connected ... returns whether the backend was connected
+func Connected(name string) bool {
+	mu.RLock()
+	defer mu.RUnlock()
+	_, isPending := pending[name]
+	return !isPending
+}
+
+// Unavailable ... returns an error naming the backends not connected yet, nil if all are
+func Unavailable() error {
+	mu.RLock()
+	defer mu.RUnlock()
+	if len(pending) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(pending))
+	for name := range pending {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return fmt.Errorf("not connected to %s yet, retrying", strings.Join(names, ", "))
+}
+
+// Available ... replies 503 to the requests while a backend is not connected
+func Available(c *gin.Context) {
+	if err := Unavailable(); err != nil {
+		logging.ReplyError(c, errors.GetServiceUnavailableError(err.Error()))
+		return
+	}
+	c.Next()
+}
+
+// Readiness ... the reply of the readiness probe, with the outcome of each check
+type Readiness struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// Live ... handles the liveness probe
+func Live(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+}
+
+// Ready ... handles the readiness probe, by running all checks concurrently
+func Ready(c *gin.Context) {
+	readiness, ready := Probe()
+	status := http.StatusOK
+	if !ready {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, readiness)
+}
+
+// Probe ... runs all checks, returning their outcome and whether the process is ready
+func Probe() (Readiness, bool) {
+	mu.RLock()
+	isDraining := draining
+	names := make([]string, 0, len(checks))
+	for name := range checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	toRun := make([]Check, len(names))
+	for i, name := range names {
+		toRun[i] = checks[name]
+	}
+	mu.RUnlock()
+
+	outcomes := make([]chan error, len(toRun))
+	for i, check := range toRun {
+		outcomes[i] = make(chan error, 1)
+		go func(check Check, outcome chan error) {
+			outcome <- check()
+		}(check, outcomes[i])
+	}
+	readiness := Readiness{Status: "ready", Checks: map[string]string{}}
+	ready := !isDraining
+	timeout := time.After(checkTimeout)
+	for i, name := range names {
+		var err error
+		select {
+		case err = <-outcomes[i]:
+		case <-timeout:
+			err = context.DeadlineExceeded
+		}
+		if err != nil {
+			ready = false
+			readiness.Checks[name] = err.Error()
+		} else {
+			readiness.Checks[name] = "ok"
+		}
+	}
+	if isDraining {
+		readiness.Status = "shutting down"
+	} else if !ready {
+		readiness.Status = "unavailable"
+	}
+	return readiness, ready
+}
+
+// Serve ... listens on the address and serves the handler in the background, returning an error if it cannot listen
+func Serve(addr string, handler http.Handler) (*http.Server, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	server := &http.Server{Addr: addr, Handler: handler}
+	go func() {
+		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			logging.Fatal("Endpoint failed", "address", addr, "error", err)
+		}
+	}()
+	logging.Info("Serving endpoint", "address", listener.Addr().String())
+	return server, nil
+}
+
+// Shutdown ... stops the server from accepting requests and waits for the running ones to end, or for the context to be done
+func Shutdown(ctx context.Context, server *http.Server) error {
+	if server == nil {
+		return nil
+	}
+	return server.Shutdown(ctx)
+}
@@ -0,0 +1,128 @@
+package lifecycle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pilillo/mastro/utils/retry"
+)
+
+// reset ... forgets the checks and backends of the previous test, and stops draining
+func reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	checks = map[string]Check{}
+	pending = map[string]error{}
+	draining = false
+	connecting, stopConnecting = context.WithCancel(context.Background())
+}
+
+func probe(t *testing.T, engine *gin.Engine) (int, Readiness) {
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, ReadinessRoute, nil))
+	readiness := Readiness{}
+	if err := json.Unmarshal(w.Body.Bytes(), &readiness); err != nil {
+		t.Fatalf("invalid readiness %s :: %v", w.Body.String(), err)
+	}
+	return w.Code, readiness
+}
+
+func TestProbes(t *testing.T) {
+	reset()
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET(LivenessRoute, Live)
+	engine.GET(ReadinessRoute, Ready)
+
+	reachable := errors.New("connection refused")
+	RegisterCheck("backend", func() error { return reachable })
+	RegisterCheck("online-store", func() error { return nil })
+	if code, readiness := probe(t, engine); code != http.StatusServiceUnavailable || readiness.Status != "unavailable" ||
+		readiness.Checks["backend"] != "connection refused" || readiness.Checks["online-store"] != "ok" {
+		t.Errorf("expected the unreachable backend to be reported, got %d %+v", code, readiness)
+	}
+
+	// the backend becomes reachable, e.g. once it started
+	reachable = nil
+	if code, readiness := probe(t, engine); code != http.StatusOK || readiness.Status != "ready" {
+		t.Errorf("expected ready, got %d %+v", code, readiness)
+	}
+
+	Drain()
+	if code, readiness := probe(t, engine); code != http.StatusServiceUnavailable || readiness.Status != "shutting down" {
+		t.Errorf("expected shutting down, got %d %+v", code, readiness)
+	}
+	// the process is still alive while draining
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, LivenessRoute, nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected alive, got %d", w.Code)
+	}
+}
+
+func TestConnect(t *testing.T) {
+	reset()
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET(ReadinessRoute, Ready)
+	engine.Use(Available)
+	engine.GET("/assets", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	// the backend is reachable after a few attempts, more than those at startup
+	var lock sync.Mutex
+	attempts := 0
+	connect := func() error {
+		lock.Lock()
+		defer lock.Unlock()
+		if attempts++; attempts < 5 {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+	backoff := &retry.Backoff{Attempts: 2, InitialInterval: 10 * time.Millisecond, MaxInterval: 10 * time.Millisecond}
+	if err := Connect("backend", backoff, connect, nil); err != nil {
+		t.Fatalf("expected to start in degraded mode, got %v", err)
+	}
+	if Connected("backend") {
+		t.Fatal("expected the backend not to be connected yet")
+	}
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/assets", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 while the backend is not connected, got %d", w.Code)
+	}
+	if code, readiness := probe(t, engine); code != http.StatusServiceUnavailable || !strings.Contains(readiness.Checks["backend"], "connection refused") {
+		t.Errorf("expected the backend to be reported, got %d %+v", code, readiness)
+	}
+
+	// the backend keeps being connected in the background
+	deadline := time.Now().Add(5 * time.Second)
+	for !Connected("backend") && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !Connected("backend") {
+		t.Fatal("expected the backend to be connected in the background")
+	}
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/assets", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected 200 once the backend is connected, got %d", w.Code)
+	}
+	if code, readiness := probe(t, engine); code != http.StatusOK {
+		t.Errorf("expected ready, got %d %+v", code, readiness)
+	}
+
+	// an invalid definition fails the startup
+	invalid := func() error { return retry.Permanent(errors.New("missing host")) }
+	if err := Connect("online-store", backoff, invalid, nil); err == nil {
+		t.Error("expected the invalid definition to be returned")
+	}
+}
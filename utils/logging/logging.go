@@ -0,0 +1,289 @@
+// Package logging ... leveled and structured logging, each entry being a message with key-value fields,
+// written as logfmt or json lines so that the logs can be parsed and correlated by request
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level ... severity of a log entry, entries below the configured level are discarded
+type Level int
+
+const (
+	// LevelDebug ... details useful when troubleshooting, e.g. each backend operation
+	LevelDebug Level = iota
+	// LevelInfo ... normal operations, e.g. handled requests and crawler runs
+	LevelInfo
+	// LevelWarn ... failures recovered from, e.g. an asset skipped by a crawler
+	LevelWarn
+	// LevelError ... failures of an operation, e.g. an unreachable backend
+	LevelError
+)
+
+var levelNames = map[Level]string{LevelDebug: "debug", LevelInfo: "info", LevelWarn: "warn", LevelError: "error"}
+
+// String ... returns the name of the level
+func (l Level) String() string {
+	return levelNames[l]
+}
+
+// ParseLevel ... returns the level of the name, i.e. debug, info, warn or error
+func ParseLevel(name string) (Level, error) {
+	for level, levelName := range levelNames {
+		if strings.EqualFold(name, levelName) {
+			return level, nil
+		}
+	}
+	return LevelInfo, fmt.Errorf("unknown log level %s, use one of debug, info, warn or error", name)
+}
+
+const (
+	// FormatLogfmt ... entries written as key=value pairs, the default
+	FormatLogfmt = "logfmt"
+	// FormatJSON ... entries written as json objects
+	FormatJSON = "json"
+)
+
+// output ... the destination and format shared by all loggers
+type output struct {
+	mu     sync.Mutex
+	level  Level
+	format string
+	writer io.Writer
+}
+
+var out = &output{level: LevelInfo, format: FormatLogfmt, writer: os.Stderr}
+
+// Init ... sets the minimum level and the format of the logs, each defaulting to info and logfmt if empty
+func Init(level string, format string) error {
+	l := LevelInfo
+	if level != "" {
+		var err error
+		if l, err = ParseLevel(level); err != nil {
+			return err
+		}
+	}
+	if format == "" {
+		format = FormatLogfmt
+	}
+	if format != FormatLogfmt && format != FormatJSON {
+		return fmt.Errorf("unknown log format %s, use one of %s or %s", format, FormatLogfmt, FormatJSON)
+	}
+	out.mu.Lock()
+	defer out.mu.Unlock()
+	out.level, out.format = l, format
+	return nil
+}
+
+// SetOutput ... sets the writer of the logs, os.Stderr by default
+func SetOutput(w io.Writer) {
+	out.mu.Lock()
+	defer out.mu.Unlock()
+	out.writer = w
+}
+
+func init() {
+	// libraries using the standard logger write in the same format
+	log.SetFlags(0)
+	log.SetOutput(stdWriter{})
+}
+
+// stdWriter ... writes the lines of the standard logger as info entries
+type stdWriter struct{}
+
+func (stdWriter) Write(p []byte) (int, error) {
+	root.log(LevelInfo, strings.TrimSuffix(string(p), "\n"), nil)
+	return len(p), nil
+}
+
+// Logger ... writes entries with a set of fields, e.g. the id of the request being handled
+type Logger struct {
+	fields []interface{}
+}
+
+var root = &Logger{}
+
+// Default ... returns the logger without fields
+func Default() *Logger {
+	return root
+}
+
+// With ... returns a logger adding the key-value pairs to all its entries
+func (l *Logger) With(keyvals ...interface{}) *Logger {
+	fields := make([]interface{}, 0, len(l.fields)+len(keyvals))
+	return &Logger{fields: append(append(fields, l.fields...), keyvals...)}
+}
+
+// Enabled ... returns true if entries of the level are written
+func (l *Logger) Enabled(level Level) bool {
+	out.mu.Lock()
+	defer out.mu.Unlock()
+	return level >= out.level
+}
+
+// Debug ... writes a debug entry with the message and the key-value pairs
+func (l *Logger) Debug(msg string, keyvals ...interface{}) {
+	l.log(LevelDebug, msg, keyvals)
+}
+
+// Info ... writes an info entry with the message and the key-value pairs
+func (l *Logger) Info(msg string, keyvals ...interface{}) {
+	l.log(LevelInfo, msg, keyvals)
+}
+
+// Warn ... writes a warn entry with the message and the key-value pairs
+func (l *Logger) Warn(msg string, keyvals ...interface{}) {
+	l.log(LevelWarn, msg, keyvals)
+}
+
+// Error ... writes an error entry with the message and the key-value pairs
+func (l *Logger) Error(msg string, keyvals ...interface{}) {
+	l.log(LevelError, msg, keyvals)
+}
+
+// Panic ... writes an error entry and panics with the message and the key-value pairs
+func (l *Logger) Panic(msg string, keyvals ...interface{}) {
+	l.log(LevelError, msg, keyvals)
+	panic(strings.TrimSpace(msg + " " + string(encodeLogfmt(nil, keyvals))))
+}
+
+// Fatal ... writes an error entry and exits the process
+func (l *Logger) Fatal(msg string, keyvals ...interface{}) {
+	l.log(LevelError, msg, keyvals)
+	os.Exit(1)
+}
+
+func (l *Logger) log(level Level, msg string, keyvals []interface{}) {
+	out.mu.Lock()
+	defer out.mu.Unlock()
+	if level < out.level {
+		return
+	}
+	entry := []interface{}{"time", time.Now().UTC().Format(time.RFC3339Nano), "level", level.String(), "msg", msg}
+	entry = append(append(entry, l.fields...), keyvals...)
+	var line []byte
+	if out.format == FormatJSON {
+		line = encodeJSON(entry)
+	} else {
+		line = encodeLogfmt(nil, entry)
+	}
+	out.writer.Write(append(line, '\n'))
+}
+
+// Debug ... writes a debug entry with the default logger
+func Debug(msg string, keyvals ...interface{}) {
+	root.log(LevelDebug, msg, keyvals)
+}
+
+// Info ... writes an info entry with the default logger
+func Info(msg string, keyvals ...interface{}) {
+	root.log(LevelInfo, msg, keyvals)
+}
+
+// Warn ... writes a warn entry with the default logger
+func Warn(msg string, keyvals ...interface{}) {
+	root.log(LevelWarn, msg, keyvals)
+}
+
+// Error ... writes an error entry with the default logger
+func Error(msg string, keyvals ...interface{}) {
+	root.log(LevelError, msg, keyvals)
+}
+
+// Panic ... writes an error entry and panics, with the default logger
+func Panic(msg string, keyvals ...interface{}) {
+	root.Panic(msg, keyvals...)
+}
+
+// Fatal ... writes an error entry and exits the process, with the default logger
+func Fatal(msg string, keyvals ...interface{}) {
+	root.Fatal(msg, keyvals...)
+}
+
+type loggerKey struct{}
+
+// NewContext ... returns a context holding the logger
+func NewContext(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// FromContext ... returns the logger of the context, e.g. with the id of the request, the default one if none
+func FromContext(ctx context.Context) *Logger {
+	if ctx != nil {
+		if l, isLogger := ctx.Value(loggerKey{}).(*Logger); isLogger {
+			return l
+		}
+	}
+	return root
+}
+
+// value ... returns the value of a field as written in the entries
+func value(v interface{}) interface{} {
+	switch value := v.(type) {
+	case error:
+		return value.Error()
+	case time.Duration:
+		return value.String()
+	case fmt.Stringer:
+		return value.String()
+	}
+	return v
+}
+
+// pairs ... iterates the key-value pairs, a missing last value being written as such
+func pairs(keyvals []interface{}, f func(key string, value interface{})) {
+	for i := 0; i < len(keyvals); i += 2 {
+		key := fmt.Sprint(keyvals[i])
+		if i+1 < len(keyvals) {
+			f(key, value(keyvals[i+1]))
+		} else {
+			f(key, "(missing)")
+		}
+	}
+}
+
+func encodeLogfmt(buf []byte, keyvals []interface{}) []byte {
+	b := bytes.NewBuffer(buf)
+	pairs(keyvals, func(key string, v interface{}) {
+		if b.Len() > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(key)
+		b.WriteByte('=')
+		s := fmt.Sprint(v)
+		if s == "" || strings.ContainsAny(s, " =\"\t\n\r") {
+			s = fmt.Sprintf("%q", s)
+		}
+		b.WriteString(s)
+	})
+	return b.Bytes()
+}
+
+func encodeJSON(keyvals []interface{}) []byte {
+	b := &bytes.Buffer{}
+	b.WriteByte('{')
+	pairs(keyvals, func(key string, v interface{}) {
+		if b.Len() > 1 {
+			b.WriteByte(',')
+		}
+		k, _ := json.Marshal(key)
+		b.Write(k)
+		b.WriteByte(':')
+		encoded, err := json.Marshal(v)
+		if err != nil {
+			encoded, _ = json.Marshal(fmt.Sprint(v))
+		}
+		b.Write(encoded)
+	})
+	b.WriteByte('}')
+	return b.Bytes()
+}
@@ -0,0 +1,116 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	resterrors "github.com/pilillo/mastro/utils/errors"
+)
+
+// capture ... returns the buffer the logs are written to, with the given level and format, until the end of the test
+func capture(t *testing.T, level string, format string) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	if err := Init(level, format); err != nil {
+		t.Fatal(err)
+	}
+	SetOutput(buf)
+	t.Cleanup(func() {
+		Init("", "")
+		SetOutput(os.Stderr)
+	})
+	return buf
+}
+
+func TestLogfmt(t *testing.T) {
+	buf := capture(t, "info", FormatLogfmt)
+	logger := Default().With("request-id", "abc")
+	logger.Debug("hidden")
+	logger.Info("Upserted asset", "name", "my db", "assets", 2, "error", errors.New(`no "such" asset`))
+
+	line := buf.String()
+	if strings.Contains(line, "hidden") {
+		t.Errorf("expected debug entries to be discarded, got %s", line)
+	}
+	for _, expected := range []string{` level=info msg="Upserted asset" request-id=abc name="my db" assets=2 error="no \"such\" asset"`, "time="} {
+		if !strings.Contains(line, expected) {
+			t.Errorf("expected %s in %s", expected, line)
+		}
+	}
+}
+
+func TestJSON(t *testing.T) {
+	buf := capture(t, "warn", FormatJSON)
+	Info("hidden")
+	Warn("Skipping table", "table", "mydb.t", "retries", 3)
+
+	entry := map[string]interface{}{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected a single json entry, got %s", buf.String())
+	}
+	if entry["level"] != "warn" || entry["msg"] != "Skipping table" || entry["table"] != "mydb.t" || entry["retries"] != 3.0 {
+		t.Errorf("unexpected entry %v", entry)
+	}
+}
+
+func TestInvalidSettings(t *testing.T) {
+	if err := Init("verbose", ""); err == nil {
+		t.Error("expected an unknown level to be rejected")
+	}
+	if err := Init("", "xml"); err == nil {
+		t.Error("expected an unknown format to be rejected")
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	buf := capture(t, "info", FormatLogfmt)
+	engine := gin.New()
+	engine.Use(Middleware())
+	engine.GET("/fail", func(c *gin.Context) {
+		FromContext(c.Request.Context()).Info("Handling")
+		ReplyError(c, resterrors.GetNotFoundError("missing"))
+	})
+	engine.GET("/panic", func(c *gin.Context) {
+		panic("unexpected")
+	})
+
+	// the id of the caller is kept, and returned along the error
+	req := httptest.NewRequest(http.MethodGet, "/fail", nil)
+	req.Header.Set(RequestIDHeader, "support-42")
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	restErr := resterrors.RestErr{}
+	if err := json.Unmarshal(w.Body.Bytes(), &restErr); err != nil {
+		t.Fatal(err)
+	}
+	if w.Header().Get(RequestIDHeader) != "support-42" || restErr.RequestID != "support-42" || restErr.Status != http.StatusNotFound {
+		t.Errorf("expected the error to carry the request id, got %v", restErr)
+	}
+	if strings.Count(buf.String(), "request-id=support-42") != 2 {
+		t.Errorf("expected the entries of the request to carry its id, got %s", buf.String())
+	}
+
+	// an invalid id is replaced, and panics are replied as internal errors
+	req = httptest.NewRequest(http.MethodGet, "/panic", nil)
+	req.Header.Set(RequestIDHeader, "bad id\n")
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	restErr = resterrors.RestErr{}
+	if err := json.Unmarshal(w.Body.Bytes(), &restErr); err != nil {
+		t.Fatal(err)
+	}
+	id := w.Header().Get(RequestIDHeader)
+	if id == "" || id == "bad id\n" || restErr.RequestID != id || w.Code != http.StatusInternalServerError {
+		t.Errorf("expected an internal error with a new request id, got %d %v", w.Code, restErr)
+	}
+	if !strings.Contains(buf.String(), "level=error msg=\"Recovered from a panic while handling the request\" request-id="+id) {
+		t.Errorf("expected the panic to be logged, got %s", buf.String())
+	}
+}
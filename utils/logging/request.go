@@ -0,0 +1,111 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pilillo/mastro/utils/errors"
+)
+
+// RequestIDHeader ... header carrying the id of a request, taken from the caller if set and returned in the reply
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength ... longer ids sent by callers are replaced, to keep the logs readable
+const maxRequestIDLength = 128
+
+type requestIDKey struct{}
+
+// NewRequestID ... returns a random request id
+func NewRequestID() string {
+	id := make([]byte, 12)
+	if _, err := rand.Read(id); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(id)
+}
+
+// WithRequestID ... returns a context holding the request id, whose logger adds it to all entries
+func WithRequestID(ctx context.Context, id string) context.Context {
+	ctx = context.WithValue(ctx, requestIDKey{}, id)
+	return NewContext(ctx, FromContext(ctx).With("request-id", id))
+}
+
+// RequestID ... returns the request id of the context, empty if none
+func RequestID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// RequestIDFrom ... returns the id set by the caller in the header if valid, a new one otherwise
+func RequestIDFrom(header http.Header) string {
+	if id := header.Get(RequestIDHeader); isValidRequestID(id) {
+		return id
+	}
+	return NewRequestID()
+}
+
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, r := range id {
+		// printable ascii without spaces, so that it is never quoted nor injects log lines
+		if r <= ' ' || r > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// Middleware ... assigns an id to each request, returned in the X-Request-ID header and available to the handlers
+// in the logger of the request context, logs the handled requests and recovers from the panics of the handlers
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		id := RequestIDFrom(c.Request.Header)
+		c.Header(RequestIDHeader, id)
+		ctx := WithRequestID(c.Request.Context(), id)
+		c.Request = c.Request.WithContext(ctx)
+		logger := FromContext(ctx)
+
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("Recovered from a panic while handling the request", "panic", fmt.Sprint(r))
+				ReplyError(c, errors.GetInternalServerError("unexpected failure while handling the request"))
+				c.Abort()
+			}
+			status := c.Writer.Status()
+			level := LevelInfo
+			if status >= http.StatusInternalServerError {
+				level = LevelError
+			}
+			logger.log(level, "Handled request", []interface{}{
+				"method", c.Request.Method,
+				"path", c.Request.URL.Path,
+				"route", c.FullPath(),
+				"status", status,
+				"duration", time.Since(start),
+				"client", c.ClientIP(),
+			})
+		}()
+		c.Next()
+	}
+}
+
+// ReplyError ... replies the error along with the id of the request, so that the caller can refer to it
+func ReplyError(c *gin.Context, restErr *errors.RestErr) {
+	replied := *restErr
+	replied.RequestID = RequestID(c.Request.Context())
+	if replied.Status >= http.StatusInternalServerError {
+		FromContext(c.Request.Context()).Error("Request failed", "status", replied.Status, "error", replied.Message)
+	}
+	c.AbortWithStatusJSON(replied.Status, &replied)
+}
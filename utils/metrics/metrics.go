@@ -0,0 +1,135 @@
+// Package metrics ... Prometheus metrics of the services and of the crawlers, exposed at /metrics
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Route ... route serving the metrics
+const Route = "/metrics"
+
+// namespace ... prefix of all metric names
+const namespace = "mastro"
+
+var (
+	httpRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Number of handled requests by route, method and status code.",
+	}, []string{"route", "method", "status"})
+	httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of the handled requests by route, method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "status"})
+
+	daoDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "dao_operation_duration_seconds",
+		Help:      "Latency of the operations on the backends by backend type and operation.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"backend", "operation"})
+	daoErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "dao_operation_errors_total",
+		Help:      "Number of failed operations on the backends by backend type and operation.",
+	}, []string{"backend", "operation"})
+
+	crawlerRunDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "crawler_run_duration_seconds",
+		Help:      "Duration of the crawler runs by source.",
+		Buckets:   []float64{1, 5, 15, 30, 60, 120, 300, 600, 1800, 3600},
+	}, []string{"source", "type"})
+	crawlerAssetsFound = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "crawler_assets_found_total",
+		Help:      "Number of assets found by the crawler runs by source.",
+	}, []string{"source", "type"})
+	crawlerAssetsPushed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "crawler_assets_pushed_total",
+		Help:      "Number of assets upserted in the catalogue by the crawler runs by source.",
+	}, []string{"source", "type"})
+	crawlerFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "crawler_failures_total",
+		Help:      "Number of failed crawler runs by source and failed stage, i.e. walk, upsert or reconcile.",
+	}, []string{"source", "type", "stage"})
+	crawlerLastSuccess = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "crawler_last_success_timestamp_seconds",
+		Help:      "Unix time of the last successful crawler run by source.",
+	}, []string{"source", "type"})
+)
+
+// Handler ... serves the metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// Middleware ... counts and times the requests by route, i.e. the route pattern rather than the requested path
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		route := c.FullPath()
+		if route == "" {
+			// not matching any route, keep a bounded set of label values
+			route = "unmatched"
+		}
+		status := strconv.Itoa(c.Writer.Status())
+		httpRequests.WithLabelValues(route, c.Request.Method, status).Inc()
+		httpDuration.WithLabelValues(route, c.Request.Method, status).Observe(time.Since(start).Seconds())
+	}
+}
+
+// ObserveDAO ... records the latency of an operation on a backend, started at start, and whether it failed
+func ObserveDAO(backend string, operation string, start time.Time, err error) {
+	daoDuration.WithLabelValues(backend, operation).Observe(time.Since(start).Seconds())
+	if err != nil {
+		daoErrors.WithLabelValues(backend, operation).Inc()
+	}
+}
+
+// CrawlerRun ... metrics of a crawler run, to be ended with Succeeded or Failed
+type CrawlerRun struct {
+	source     string
+	sourceType string
+	start      time.Time
+}
+
+// StartCrawlerRun ... starts timing a run of the crawler of the data source with the given name and type
+func StartCrawlerRun(source string, sourceType string) *CrawlerRun {
+	return &CrawlerRun{source: source, sourceType: sourceType, start: time.Now()}
+}
+
+// Found ... records the number of assets found by the walk
+func (r *CrawlerRun) Found(assets int) {
+	crawlerAssetsFound.WithLabelValues(r.source, r.sourceType).Add(float64(assets))
+}
+
+// Pushed ... records the number of assets upserted in the catalogue
+func (r *CrawlerRun) Pushed(assets int) {
+	crawlerAssetsPushed.WithLabelValues(r.source, r.sourceType).Add(float64(assets))
+}
+
+// Failed ... ends the run as failed at the stage, i.e. walk, upsert or reconcile
+func (r *CrawlerRun) Failed(stage string) {
+	crawlerRunDuration.WithLabelValues(r.source, r.sourceType).Observe(time.Since(r.start).Seconds())
+	crawlerFailures.WithLabelValues(r.source, r.sourceType, stage).Inc()
+}
+
+// Succeeded ... ends the run as successful
+func (r *CrawlerRun) Succeeded() {
+	crawlerRunDuration.WithLabelValues(r.source, r.sourceType).Observe(time.Since(r.start).Seconds())
+	crawlerLastSuccess.WithLabelValues(r.source, r.sourceType).SetToCurrentTime()
+}
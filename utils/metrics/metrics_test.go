@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(Middleware())
+	engine.GET("/asset/name/:asset_name", func(c *gin.Context) { c.Status(http.StatusNotFound) })
+
+	for _, path := range []string{"/asset/name/a", "/asset/name/b", "/missing"} {
+		engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	// requests are counted by route pattern, rather than by path
+	if n := testutil.ToFloat64(httpRequests.WithLabelValues("/asset/name/:asset_name", http.MethodGet, "404")); n != 2 {
+		t.Errorf("expected 2 requests on the route, got %v", n)
+	}
+	if n := testutil.ToFloat64(httpRequests.WithLabelValues("unmatched", http.MethodGet, "404")); n != 1 {
+		t.Errorf("expected 1 unmatched request, got %v", n)
+	}
+}
+
+func TestCrawlerRun(t *testing.T) {
+	run := StartCrawlerRun("test-crawler", "local")
+	run.Found(3)
+	run.Failed("upsert")
+	run = StartCrawlerRun("test-crawler", "local")
+	run.Found(3)
+	run.Pushed(3)
+	run.Succeeded()
+
+	if n := testutil.ToFloat64(crawlerAssetsFound.WithLabelValues("test-crawler", "local")); n != 6 {
+		t.Errorf("expected 6 found assets, got %v", n)
+	}
+	if n := testutil.ToFloat64(crawlerAssetsPushed.WithLabelValues("test-crawler", "local")); n != 3 {
+		t.Errorf("expected 3 pushed assets, got %v", n)
+	}
+	if n := testutil.ToFloat64(crawlerFailures.WithLabelValues("test-crawler", "local", "upsert")); n != 1 {
+		t.Errorf("expected 1 failure, got %v", n)
+	}
+	if ts := testutil.ToFloat64(crawlerLastSuccess.WithLabelValues("test-crawler", "local")); ts == 0 {
+		t.Error("expected the time of the last success to be set")
+	}
+}
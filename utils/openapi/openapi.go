@@ -0,0 +1,304 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/pilillo/mastro/utils/errors"
+)
+
+// Version ... version of the OpenAPI specification the documents follow
+const Version = "3.0.3"
+
+// Document ... an OpenAPI 3 document, whose schemas are derived from the go types exchanged by the endpoints
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Paths      map[string]map[string]Operation `json:"paths"`
+	Components Components                      `json:"components"`
+	Security   []map[string][]string           `json:"security,omitempty"`
+}
+
+// Info ... metadata of the API
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Components ... reusable schemas and security schemes
+type Components struct {
+	Schemas         map[string]*Schema        `json:"schemas"`
+	SecuritySchemes map[string]SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+// SecurityScheme ... an authentication method of the endpoints
+type SecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme,omitempty"`
+}
+
+// Operation ... an endpoint, i.e. a method on a path
+type Operation struct {
+	OperationID string       `json:"operationId"`
+	Summary     string       `json:"summary"`
+	Tags        []string     `json:"tags,omitempty"`
+	Parameters  []Parameter  `json:"parameters,omitempty"`
+	RequestBody *RequestBody `json:"requestBody,omitempty"`
+	// Responses ... by status code
+	Responses map[int]Response `json:"responses"`
+	// Security ... overrides the security of the document if not nil, e.g. an empty list for public operations
+	Security *[]map[string][]string `json:"security,omitempty"`
+}
+
+// Parameter ... a path or query parameter
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody ... the body of a request
+type RequestBody struct {
+	Required bool                 `json:"required"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response ... a response of an operation, with no content if the media types are empty
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType ... the schema of a content type
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema ... a subset of the JSON schema supported by OpenAPI, enough to describe go types
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []interface{}      `json:"enum,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// security schemes of the utils/auth methods
+const (
+	bearerAuth = "bearerAuth"
+	basicAuth  = "basicAuth"
+)
+
+// New ... returns an empty document, whose endpoints accept the bearer (token and jwt) and basic authentication methods
+func New(title string, description string, version string) *Document {
+	return &Document{
+		OpenAPI: Version,
+		Info:    Info{Title: title, Description: description, Version: version},
+		Paths:   make(map[string]map[string]Operation),
+		Components: Components{
+			Schemas: make(map[string]*Schema),
+			SecuritySchemes: map[string]SecurityScheme{
+				bearerAuth: {Type: "http", Scheme: "bearer"},
+				basicAuth:  {Type: "http", Scheme: "basic"},
+			},
+		},
+		Security: []map[string][]string{{bearerAuth: {}}, {basicAuth: {}}},
+	}
+}
+
+// ginParam ... a gin path parameter, e.g. :asset_name
+var ginParam = regexp.MustCompile(`:([^/]+)`)
+
+// Path ... converts a gin route to an OpenAPI path, e.g. asset/name/:asset_name to /asset/name/{asset_name}
+func Path(route string) string {
+	return "/" + strings.TrimPrefix(ginParam.ReplaceAllString(route, "{$1}"), "/")
+}
+
+// Add ... adds an operation for the gin route, its path parameters are described as strings unless already given
+// and the RestErr responses are added for the given error statuses, as well as for 401 and 403 unless the operation is public
+func (d *Document) Add(method string, route string, op Operation, errorStatuses ...int) {
+	if op.Security == nil {
+		errorStatuses = append(errorStatuses, http.StatusUnauthorized, http.StatusForbidden)
+	}
+	for _, match := range ginParam.FindAllStringSubmatch(route, -1) {
+		described := false
+		for _, p := range op.Parameters {
+			described = described || (p.In == "path" && p.Name == match[1])
+		}
+		if !described {
+			op.Parameters = append([]Parameter{PathParam(match[1], "")}, op.Parameters...)
+		}
+	}
+	if op.Responses == nil {
+		op.Responses = make(map[int]Response)
+	}
+	for _, status := range errorStatuses {
+		op.Responses[status] = Response{
+			Description: http.StatusText(status),
+			Content:     map[string]MediaType{gin.MIMEJSON: {Schema: d.Schema(errors.RestErr{})}},
+		}
+	}
+	path := Path(route)
+	if d.Paths[path] == nil {
+		d.Paths[path] = make(map[string]Operation)
+	}
+	d.Paths[path][strings.ToLower(method)] = op
+}
+
+// Public ... marks the operation as not requiring authentication, e.g. healthchecks
+func Public(op Operation) Operation {
+	op.Security = &[]map[string][]string{}
+	return op
+}
+
+// Methods ... returns the methods of each path, upper case and sorted, e.g. to check that all routes are described
+func (d *Document) Methods() map[string][]string {
+	methods := make(map[string][]string)
+	for path, ops := range d.Paths {
+		for method := range ops {
+			methods[path] = append(methods[path], strings.ToUpper(method))
+		}
+		sort.Strings(methods[path])
+	}
+	return methods
+}
+
+// PathParam ... a required string path parameter
+func PathParam(name string, description string) Parameter {
+	return Parameter{Name: name, In: "path", Description: description, Required: true, Schema: &Schema{Type: "string"}}
+}
+
+// QueryParam ... an optional query parameter of the given type (e.g. string, integer or boolean)
+func QueryParam(name string, schemaType string, description string) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: &Schema{Type: schemaType}}
+}
+
+// ListParams ... the query parameters of the list endpoints, see queries.List
+func ListParams(sorts ...string) []Parameter {
+	sortSchema := &Schema{Type: "string"}
+	for _, s := range sorts {
+		sortSchema.Enum = append(sortSchema.Enum, s, "-"+s)
+	}
+	return []Parameter{
+		QueryParam("limit", "integer", "maximum number of items of the page"),
+		QueryParam("offset", "integer", "number of items to skip, ignored if a cursor is given"),
+		QueryParam("cursor", "string", "cursor to the next page, as returned with the previous one"),
+		{Name: "sort", In: "query", Description: "sort key, prefixed by - for a descending order", Schema: sortSchema},
+		QueryParam("fields", "string", "comma separated list of the fields to return"),
+	}
+}
+
+// JSONBody ... a required json request body of the type of v
+func (d *Document) JSONBody(v interface{}) *RequestBody {
+	return &RequestBody{Required: true, Content: map[string]MediaType{gin.MIMEJSON: {Schema: d.Schema(v)}}}
+}
+
+// JSON ... a response with a json body of the type of v
+func (d *Document) JSON(description string, v interface{}) Response {
+	return Response{Description: description, Content: map[string]MediaType{gin.MIMEJSON: {Schema: d.Schema(v)}}}
+}
+
+// Text ... a response with a text body of the given media type
+func Text(description string, mediaType string) Response {
+	return Response{Description: description, Content: map[string]MediaType{mediaType: {Schema: &Schema{Type: "string"}}}}
+}
+
+// Schema ... returns the schema of the type of v, named structs are added to the components and referenced
+func (d *Document) Schema(v interface{}) *Schema {
+	return d.schemaOf(reflect.TypeOf(v))
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func (d *Document) schemaOf(t reflect.Type) *Schema {
+	if t == nil {
+		// any value, e.g. interface{}
+		return &Schema{}
+	}
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		s := d.schemaOf(t.Elem())
+		if s.Ref != "" {
+			return s
+		}
+		s.Nullable = true
+		return s
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: d.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: d.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return d.structSchema(t)
+		}
+		if _, exist := d.Components.Schemas[t.Name()]; !exist {
+			// reserve the name first, as structs may refer to themselves
+			d.Components.Schemas[t.Name()] = &Schema{}
+			*d.Components.Schemas[t.Name()] = *d.structSchema(t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + t.Name()}
+	}
+	return &Schema{}
+}
+
+// structSchema ... an object with the json fields of the struct, including those of embedded structs
+func (d *Document) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			// unexported
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct {
+			for n, p := range d.structSchema(field.Type).Properties {
+				s.Properties[n] = p
+			}
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		s.Properties[name] = d.schemaOf(field.Type)
+	}
+	return s
+}
+
+// Handler ... serves the document as json
+func Handler(d *Document) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, d)
+	}
+}
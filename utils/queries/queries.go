@@ -1,5 +1,65 @@
 package queries
 
+import (
+	"strings"
+
+	"github.com/pilillo/mastro/abstract"
+	stringutils "github.com/pilillo/mastro/utils/strings"
+)
+
 type ByTags struct {
 	Tags []string `json:"tags,omitempty"`
 }
+
+// Reconcile ... names of the assets found by a crawler in its last walk
+type Reconcile struct {
+	DiscoveredBy string   `json:"discovered-by,omitempty"`
+	Names        []string `json:"names"`
+}
+
+// ReconcileResult ... number of assets of the crawler marked as stale by a reconciliation
+type ReconcileResult struct {
+	DiscoveredBy string `json:"discovered-by"`
+	Stale        int    `json:"stale"`
+}
+
+// List ... query parameters of the list endpoints
+type List struct {
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+	Cursor string `form:"cursor"`
+	// Sort ... sort key, prefixed by - for a descending order
+	Sort string `form:"sort"`
+	// Fields ... comma separated list of fields to return
+	Fields string `form:"fields"`
+}
+
+// ToListOptions ... converts the query parameters to list options
+func (q *List) ToListOptions() *abstract.ListOptions {
+	opts := &abstract.ListOptions{
+		Limit:      q.Limit,
+		Offset:     q.Offset,
+		Cursor:     q.Cursor,
+		SortBy:     strings.TrimPrefix(q.Sort, "-"),
+		Descending: strings.HasPrefix(q.Sort, "-"),
+	}
+	if q.Fields != "" {
+		opts.Fields = stringutils.SplitAndTrim(q.Fields, ",")
+	}
+	return opts
+}
+
+// Lineage ... query parameters of the lineage endpoint
+type Lineage struct {
+	// Direction ... upstream, downstream or both (default)
+	Direction string `form:"direction"`
+	Depth     int    `form:"depth"`
+	// Format ... json (default) or dot
+	Format string `form:"format"`
+}
+
+// Diff ... query parameters of the revision diff endpoint, to defaults to the latest revision and from to the previous one
+type Diff struct {
+	From int `form:"from"`
+	To   int `form:"to"`
+}
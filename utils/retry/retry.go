@@ -0,0 +1,128 @@
+// Package retry ... retries an operation, e.g. the connection to a backend, waiting an exponential backoff between the attempts
+package retry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/pilillo/mastro/utils/conf"
+	"github.com/pilillo/mastro/utils/logging"
+)
+
+const (
+	// DefaultAttempts ... attempts of an operation, unless set in the retry definition
+	DefaultAttempts = 5
+	// DefaultInitialInterval ... wait after the first failed attempt, unless set in the retry definition
+	DefaultInitialInterval = time.Second
+	// DefaultMaxInterval ... maximum wait between two attempts, unless set in the retry definition
+	DefaultMaxInterval = 30 * time.Second
+)
+
+// Backoff ... waits twice as long after each failed attempt, up to a maximum interval
+type Backoff struct {
+	// Attempts ... maximum number of attempts, 0 to retry until the operation succeeds
+	Attempts        int
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+}
+
+// NewBackoff ... returns the backoff of the retry definition, the default one if nil
+func NewBackoff(def *conf.RetryDefinition) (*Backoff, error) {
+	backoff := &Backoff{Attempts: DefaultAttempts, InitialInterval: DefaultInitialInterval, MaxInterval: DefaultMaxInterval}
+	if def == nil {
+		return backoff, nil
+	}
+	if def.Attempts < 0 {
+		return nil, fmt.Errorf("invalid retry attempts %d, expected a positive number", def.Attempts)
+	}
+	if def.Attempts > 0 {
+		backoff.Attempts = def.Attempts
+	}
+	var err error
+	if backoff.InitialInterval, err = parseInterval("initial-interval", def.InitialInterval, DefaultInitialInterval); err != nil {
+		return nil, err
+	}
+	if backoff.MaxInterval, err = parseInterval("max-interval", def.MaxInterval, DefaultMaxInterval); err != nil {
+		return nil, err
+	}
+	if backoff.MaxInterval < backoff.InitialInterval {
+		return nil, fmt.Errorf("retry max-interval %s is shorter than the initial-interval %s", backoff.MaxInterval, backoff.InitialInterval)
+	}
+	return backoff, nil
+}
+
+// parseInterval ... parses a positive duration of the retry definition, the default if empty
+func parseInterval(name string, value string, defaultValue time.Duration) (time.Duration, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval <= 0 {
+		return 0, fmt.Errorf("invalid retry %s %s, expected a positive duration such as 1s", name, value)
+	}
+	return interval, nil
+}
+
+// Unlimited ... returns the same backoff, retrying until the operation succeeds
+func (b *Backoff) Unlimited() *Backoff {
+	return &Backoff{InitialInterval: b.InitialInterval, MaxInterval: b.MaxInterval}
+}
+
+// Interval ... returns the wait after the given failed attempt, counted from 1
+func (b *Backoff) Interval(attempt int) time.Duration {
+	interval := b.InitialInterval
+	for i := 1; i < attempt && interval < b.MaxInterval; i++ {
+		interval *= 2
+	}
+	if interval > b.MaxInterval {
+		return b.MaxInterval
+	}
+	return interval
+}
+
+// permanentError ... an error which retrying does not solve, e.g. an invalid definition
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// Permanent ... marks an error as not worth retrying, nil if the error is nil
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent ... returns whether the error was marked as not worth retrying
+func IsPermanent(err error) bool {
+	var permanent *permanentError
+	return errors.As(err, &permanent)
+}
+
+// Do ... calls the operation until it succeeds, it returns a permanent error, the attempts are exhausted or the context is done,
+// returning the error of the last attempt
+func (b *Backoff) Do(ctx context.Context, name string, operation func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := operation()
+		if err == nil || IsPermanent(err) || (b.Attempts > 0 && attempt >= b.Attempts) {
+			return err
+		}
+		interval := b.Interval(attempt)
+		logging.Warn("Attempt failed, retrying", "operation", name, "attempt", attempt, "retry-in", interval, "error", err)
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
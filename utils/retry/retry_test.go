@@ -0,0 +1,62 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pilillo/mastro/utils/conf"
+)
+
+func TestInterval(t *testing.T) {
+	backoff, err := NewBackoff(&conf.RetryDefinition{InitialInterval: "1s", MaxInterval: "5s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for attempt, expected := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		if interval := backoff.Interval(attempt + 1); interval != expected {
+			t.Errorf("expected %s after attempt %d, got %s", expected, attempt+1, interval)
+		}
+	}
+	for _, def := range []*conf.RetryDefinition{{Attempts: -1}, {InitialInterval: "soon"}, {InitialInterval: "10s", MaxInterval: "1s"}} {
+		if _, err := NewBackoff(def); err == nil {
+			t.Errorf("expected %+v to be invalid", def)
+		}
+	}
+}
+
+func TestDo(t *testing.T) {
+	backoff := &Backoff{Attempts: 3, InitialInterval: time.Millisecond, MaxInterval: time.Millisecond}
+	refused := errors.New("connection refused")
+
+	calls := 0
+	err := backoff.Do(context.Background(), "connect", func() error {
+		calls++
+		return refused
+	})
+	if err != refused || calls != 3 {
+		t.Errorf("expected 3 failed attempts, got %d and %v", calls, err)
+	}
+
+	calls = 0
+	err = backoff.Unlimited().Do(context.Background(), "connect", func() error {
+		if calls++; calls < 5 {
+			return refused
+		}
+		return nil
+	})
+	if err != nil || calls != 5 {
+		t.Errorf("expected success at the 5th attempt, got %d and %v", calls, err)
+	}
+
+	// an invalid definition is not retried
+	calls = 0
+	err = backoff.Do(context.Background(), "connect", func() error {
+		calls++
+		return Permanent(refused)
+	})
+	if !IsPermanent(err) || !errors.Is(err, refused) || calls != 1 {
+		t.Errorf("expected a single attempt, got %d and %v", calls, err)
+	}
+}
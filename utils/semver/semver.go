@@ -0,0 +1,247 @@
+package semver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version ... a semantic version major.minor.patch with an optional pre-release, build metadata is ignored
+type Version struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	PreRelease []string
+}
+
+// Parse ... parses a version such as 1.2.3, v1.2.3-rc.1 or 1.2, with missing minor and patch set to 0
+func Parse(input string) (*Version, error) {
+	v, _, err := parsePartial(input)
+	return v, err
+}
+
+// parsePartial ... parses a version returning also the number of provided numeric parts (1 to 3)
+func parsePartial(input string) (*Version, int, error) {
+	s := strings.TrimPrefix(strings.TrimSpace(input), "v")
+	if i := strings.Index(s, "+"); i >= 0 {
+		s = s[:i]
+	}
+
+	v := &Version{}
+	if i := strings.Index(s, "-"); i >= 0 {
+		v.PreRelease = strings.Split(s[i+1:], ".")
+		for _, id := range v.PreRelease {
+			if id == "" {
+				return nil, 0, fmt.Errorf("Invalid pre-release in version %s", input)
+			}
+		}
+		s = s[:i]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) > 3 {
+		return nil, 0, fmt.Errorf("Invalid version %s", input)
+	}
+	numbers := []*uint64{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return nil, 0, fmt.Errorf("Invalid version %s", input)
+		}
+		*numbers[i] = n
+	}
+	return v, len(parts), nil
+}
+
+// String ... formats the version as major.minor.patch[-pre-release]
+func (v *Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if len(v.PreRelease) > 0 {
+		s += "-" + strings.Join(v.PreRelease, ".")
+	}
+	return s
+}
+
+// Compare ... returns -1, 0 or 1 if the version is lower, equal or greater than the other one,
+// a pre-release has a lower precedence than the associated normal version
+func (v *Version) Compare(other *Version) int {
+	if c := compareUint(v.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Patch, other.Patch); c != 0 {
+		return c
+	}
+	return comparePreRelease(v.PreRelease, other.PreRelease)
+}
+
+func compareUint(a uint64, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// comparePreRelease ... compares identifiers one by one, numeric ones numerically and lower than alphanumeric ones
+func comparePreRelease(a []string, b []string) int {
+	switch {
+	case len(a) == 0 && len(b) == 0:
+		return 0
+	case len(a) == 0:
+		return 1
+	case len(b) == 0:
+		return -1
+	}
+
+	for i := 0; i < len(a) && i < len(b); i++ {
+		na, errA := strconv.ParseUint(a[i], 10, 64)
+		nb, errB := strconv.ParseUint(b[i], 10, 64)
+		switch {
+		case errA == nil && errB == nil:
+			if c := compareUint(na, nb); c != 0 {
+				return c
+			}
+		case errA == nil:
+			return -1
+		case errB == nil:
+			return 1
+		default:
+			if c := strings.Compare(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+	}
+	return compareUint(uint64(len(a)), uint64(len(b)))
+}
+
+// comparator ... a single condition on a version, e.g. >=1.2.0
+type comparator struct {
+	operator string
+	version  *Version
+}
+
+func (c *comparator) check(v *Version) bool {
+	cmp := v.Compare(c.version)
+	switch c.operator {
+	case "=":
+		return cmp == 0
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+	return false
+}
+
+// Constraint ... a set of alternative ranges, satisfied if any range is satisfied
+type Constraint struct {
+	ranges [][]comparator
+}
+
+// ParseConstraint ... parses a constraint such as ^1.2, ~1.2.3, 1.2.3, >=1.0.0 <2.0.0 or ^1.0 || ^2.0,
+// with space separated conditions all to be satisfied and || separating alternatives
+func ParseConstraint(input string) (*Constraint, error) {
+	c := &Constraint{}
+	for _, alternative := range strings.Split(input, "||") {
+		fields := strings.Fields(alternative)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("Invalid constraint %s", input)
+		}
+		var comparators []comparator
+		for _, field := range fields {
+			parsed, err := parseComparators(field)
+			if err != nil {
+				return nil, fmt.Errorf("Invalid constraint %s :: %v", input, err)
+			}
+			comparators = append(comparators, parsed...)
+		}
+		c.ranges = append(c.ranges, comparators)
+	}
+	return c, nil
+}
+
+// parseComparators ... translates a single condition to one or more comparators
+func parseComparators(field string) ([]comparator, error) {
+	for _, operator := range []string{">=", "<=", ">", "<", "="} {
+		if strings.HasPrefix(field, operator) {
+			v, err := Parse(field[len(operator):])
+			if err != nil {
+				return nil, err
+			}
+			return []comparator{{operator, v}}, nil
+		}
+	}
+
+	switch {
+	case strings.HasPrefix(field, "^"):
+		// ^1.2.3 := >=1.2.3 <2.0.0, ^0.2.3 := >=0.2.3 <0.3.0, ^0.0.3 := >=0.0.3 <0.0.4
+		v, n, err := parsePartial(field[1:])
+		if err != nil {
+			return nil, err
+		}
+		upper := &Version{Major: v.Major + 1}
+		if v.Major == 0 && n > 1 {
+			upper = &Version{Minor: v.Minor + 1}
+			if v.Minor == 0 && n > 2 {
+				upper = &Version{Patch: v.Patch + 1}
+			}
+		}
+		return []comparator{{">=", v}, {"<", upper}}, nil
+	case strings.HasPrefix(field, "~"):
+		// ~1.2.3 := >=1.2.3 <1.3.0, ~1 := >=1.0.0 <2.0.0
+		v, n, err := parsePartial(field[1:])
+		if err != nil {
+			return nil, err
+		}
+		upper := &Version{Major: v.Major, Minor: v.Minor + 1}
+		if n == 1 {
+			upper = &Version{Major: v.Major + 1}
+		}
+		return []comparator{{">=", v}, {"<", upper}}, nil
+	}
+
+	// a partial version matches all versions with the same prefix, e.g. 1.2 := >=1.2.0 <1.3.0
+	v, n, err := parsePartial(field)
+	if err != nil {
+		return nil, err
+	}
+	switch n {
+	case 1:
+		return []comparator{{">=", v}, {"<", &Version{Major: v.Major + 1}}}, nil
+	case 2:
+		return []comparator{{">=", v}, {"<", &Version{Major: v.Major, Minor: v.Minor + 1}}}, nil
+	}
+	return []comparator{{"=", v}}, nil
+}
+
+// Check ... returns true if the version satisfies the constraint,
+// pre-releases only satisfy ranges explicitly referring to a pre-release of the same major.minor.patch
+func (c *Constraint) Check(v *Version) bool {
+	for _, r := range c.ranges {
+		satisfied := true
+		allowsPreRelease := len(v.PreRelease) == 0
+		for _, comp := range r {
+			if !comp.check(v) {
+				satisfied = false
+				break
+			}
+			if len(comp.version.PreRelease) > 0 && comp.version.Major == v.Major &&
+				comp.version.Minor == v.Minor && comp.version.Patch == v.Patch {
+				allowsPreRelease = true
+			}
+		}
+		if satisfied && allowsPreRelease {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package semver
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	cases := map[string]string{
+		"1.2.3":          "1.2.3",
+		"v1.2.3":         "1.2.3",
+		"1.2":            "1.2.0",
+		"2":              "2.0.0",
+		"1.0.0-rc.1":     "1.0.0-rc.1",
+		"1.0.0+build.42": "1.0.0",
+	}
+	for input, expected := range cases {
+		v, err := Parse(input)
+		if err != nil {
+			t.Errorf("expected %s to parse, got %v", input, err)
+			continue
+		}
+		if v.String() != expected {
+			t.Errorf("expected %s to be %s, got %s", input, expected, v)
+		}
+	}
+
+	for _, input := range []string{"", "test-v1.0", "1.2.3.4", "1.x", "1.0.0-"} {
+		if _, err := Parse(input); err == nil {
+			t.Errorf("expected %q not to parse", input)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	// in increasing order of precedence
+	ordered := []string{"0.9.9", "1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0", "1.2.0", "1.10.0"}
+	for i := 0; i < len(ordered)-1; i++ {
+		a, _ := Parse(ordered[i])
+		b, _ := Parse(ordered[i+1])
+		if a.Compare(b) != -1 || b.Compare(a) != 1 {
+			t.Errorf("expected %s < %s", a, b)
+		}
+	}
+	a, _ := Parse("v1.2")
+	b, _ := Parse("1.2.0")
+	if a.Compare(b) != 0 {
+		t.Errorf("expected %s = %s", a, b)
+	}
+}
+
+func TestConstraint(t *testing.T) {
+	cases := []struct {
+		constraint string
+		matching   []string
+		other      []string
+	}{
+		{"^1.2", []string{"1.2.0", "1.9.9"}, []string{"1.1.9", "2.0.0", "1.3.0-rc.1"}},
+		{"^0.2.3", []string{"0.2.3", "0.2.9"}, []string{"0.3.0", "0.2.2"}},
+		{"^0.0.3", []string{"0.0.3"}, []string{"0.0.4"}},
+		{"~1.2.3", []string{"1.2.3", "1.2.9"}, []string{"1.3.0", "1.2.2"}},
+		{"~1", []string{"1.0.0", "1.9.0"}, []string{"2.0.0"}},
+		{"1.2", []string{"1.2.0", "1.2.7"}, []string{"1.3.0"}},
+		{"1.2.3", []string{"1.2.3", "v1.2.3"}, []string{"1.2.4"}},
+		{">=1.0.0 <2.0.0", []string{"1.0.0", "1.5.0"}, []string{"0.9.0", "2.0.0"}},
+		{"^1.0 || ^3.0", []string{"1.1.0", "3.2.0"}, []string{"2.0.0"}},
+		{">=1.0.0-rc.1", []string{"1.0.0-rc.2", "1.0.0", "2.0.0"}, []string{"1.0.0-beta", "2.0.0-rc.1"}},
+	}
+	for _, c := range cases {
+		constraint, err := ParseConstraint(c.constraint)
+		if err != nil {
+			t.Errorf("expected %s to parse, got %v", c.constraint, err)
+			continue
+		}
+		for _, input := range c.matching {
+			v, _ := Parse(input)
+			if !constraint.Check(v) {
+				t.Errorf("expected %s to satisfy %s", input, c.constraint)
+			}
+		}
+		for _, input := range c.other {
+			v, _ := Parse(input)
+			if constraint.Check(v) {
+				t.Errorf("expected %s not to satisfy %s", input, c.constraint)
+			}
+		}
+	}
+
+	for _, input := range []string{"", "^", ">=x", "1.0 ||"} {
+		if _, err := ParseConstraint(input); err == nil {
+			t.Errorf("expected %q not to parse", input)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Inject ... sets the context of the current span in the headers of an outgoing request
+func Inject(ctx context.Context, header http.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// Extract ... returns a context whose spans are children of the span propagated in the headers of an incoming request, if any
+func Extract(ctx context.Context, header http.Header) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// Middleware ... traces the handling of each request in a server span named after the route, child of the caller span if any,
+// the span being available to the handlers in the context of the request
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		ctx, span := StartWithKind(Extract(c.Request.Context(), c.Request.Header), trace.SpanKindServer,
+			fmt.Sprintf("%s %s", c.Request.Method, route),
+			attribute.String("http.method", c.Request.Method),
+			attribute.String("http.route", route),
+			attribute.String("http.target", c.Request.URL.RequestURI()),
+		)
+		defer span.End()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+		status := c.Writer.Status()
+		span.SetAttributes(attribute.Int("http.status_code", status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
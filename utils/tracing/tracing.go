@@ -0,0 +1,170 @@
+// Package tracing ... traces the services and crawlers with OpenTelemetry, i.e. spans propagated with the W3C trace context
+// and exported with the OTLP/HTTP exporter or the stdout exporter, so that they can be collected and browsed
+// with the OpenTelemetry collector and any tracing backend it exports to
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/pilillo/mastro/utils/conf"
+	"github.com/pilillo/mastro/utils/logging"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// instrumentationName ... name of the instrumentation recording the spans
+const instrumentationName = "github.com/pilillo/mastro"
+
+// exporters ... the available exporters by name, built from the definition
+var exporters = map[string]func(def *conf.TracingDefinition) (sdktrace.SpanExporter, error){
+	"otlp":   newOTLPExporter,
+	"file":   newFileExporter,
+	"stdout": newStdoutExporter,
+}
+
+func newOTLPExporter(def *conf.TracingDefinition) (sdktrace.SpanExporter, error) {
+	if def.Endpoint == "" {
+		return nil, fmt.Errorf("the otlp trace exporter requires an endpoint")
+	}
+	endpoint := strings.TrimSuffix(def.Endpoint, "/")
+	if !strings.HasSuffix(endpoint, "/v1/traces") {
+		endpoint += "/v1/traces"
+	}
+	return otlptracehttp.New(context.Background(), otlptracehttp.WithEndpointURL(endpoint), otlptracehttp.WithHeaders(def.Headers))
+}
+
+func newFileExporter(def *conf.TracingDefinition) (sdktrace.SpanExporter, error) {
+	if def.Path == "" {
+		return nil, fmt.Errorf("the file trace exporter requires a path")
+	}
+	file, err := os.OpenFile(def.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &closingExporter{SpanExporter: exporter, closer: file}, nil
+}
+
+func newStdoutExporter(def *conf.TracingDefinition) (sdktrace.SpanExporter, error) {
+	return stdouttrace.New()
+}
+
+// closingExporter ... closes the file written by the exporter on shutdown
+type closingExporter struct {
+	sdktrace.SpanExporter
+	closer io.Closer
+}
+
+func (e *closingExporter) Shutdown(ctx context.Context) error {
+	err := e.SpanExporter.Shutdown(ctx)
+	if closeErr := e.closer.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+var (
+	providerMu     sync.Mutex
+	activeProvider *sdktrace.TracerProvider
+)
+
+func init() {
+	// propagate the span context with the W3C traceparent header, even when tracing is disabled, so that traces are not broken
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+}
+
+// Init ... starts recording traces as defined, named after the default service name unless set; tracing stays disabled if
+// the definition is nil
+func Init(def *conf.TracingDefinition, defaultServiceName string) error {
+	if def == nil {
+		return nil
+	}
+	newExporter, exist := exporters[def.Exporter]
+	if !exist {
+		return fmt.Errorf("Impossible to find specified trace exporter %s", def.Exporter)
+	}
+	ratio := 1.0
+	if def.SampleRatio != nil {
+		ratio = *def.SampleRatio
+	}
+	if ratio < 0 || ratio > 1 {
+		return fmt.Errorf("the sample ratio of the traces should be between 0 and 1, got %v", ratio)
+	}
+	exporter, err := newExporter(def)
+	if err != nil {
+		return err
+	}
+	serviceName := def.ServiceName
+	if serviceName == "" {
+		serviceName = defaultServiceName
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+		// children follow the sampling decision of their parent, so that traces are complete
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+	Shutdown()
+	providerMu.Lock()
+	activeProvider = provider
+	providerMu.Unlock()
+	otel.SetTracerProvider(provider)
+	logging.Info("Exporting traces", "service", serviceName, "exporter", def.Exporter)
+	return nil
+}
+
+// Shutdown ... exports the ended spans and stops recording traces
+func Shutdown() {
+	providerMu.Lock()
+	provider := activeProvider
+	activeProvider = nil
+	providerMu.Unlock()
+	if provider == nil {
+		return
+	}
+	otel.SetTracerProvider(noop.NewTracerProvider())
+	if err := provider.Shutdown(context.Background()); err != nil {
+		logging.Error("Failed shutting down the trace exporter", "error", err)
+	}
+}
+
+// Start ... starts an internal span, child of the current span of the context if any, and returns the context holding it;
+// the span does not record anything if tracing is disabled
+func Start(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	return StartWithKind(ctx, trace.SpanKindInternal, name, attributes...)
+}
+
+// StartWithKind ... starts a span of the given kind, see Start
+func StartWithKind(ctx context.Context, kind trace.SpanKind, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attributes...))
+}
+
+// RecordError ... records the error in the span and marks it as failed, if the error is not nil
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
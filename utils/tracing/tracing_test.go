@@ -0,0 +1,116 @@
+package tracing
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pilillo/mastro/utils/conf"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// exportedSpan ... the fields of the spans written by the stdout exporter which are checked by the tests
+type exportedSpan struct {
+	Name        string
+	SpanContext struct {
+		TraceID string
+		SpanID  string
+	}
+	Parent struct {
+		SpanID string
+	}
+	SpanKind   trace.SpanKind
+	Attributes []struct {
+		Key   string
+		Value struct {
+			Value interface{}
+		}
+	}
+	Status struct {
+		Code string
+	}
+}
+
+// readSpans ... returns the spans exported to the file, by name
+func readSpans(t *testing.T, path string) map[string]exportedSpan {
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	spans := make(map[string]exportedSpan)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		span := exportedSpan{}
+		if err := json.Unmarshal(scanner.Bytes(), &span); err != nil {
+			t.Fatal(err)
+		}
+		spans[span.Name] = span
+	}
+	return spans
+}
+
+func TestFileExporter(t *testing.T) {
+	if _, span := Start(context.Background(), "disabled"); span.IsRecording() {
+		t.Fatal("expected no recording span when tracing is disabled")
+	}
+
+	path := filepath.Join(t.TempDir(), "traces.json")
+	if err := Init(&conf.TracingDefinition{Exporter: "file", Path: path}, "test"); err != nil {
+		t.Fatal(err)
+	}
+	ctx, parent := Start(context.Background(), "parent")
+	// propagate the parent to another service
+	header := http.Header{}
+	Inject(ctx, header)
+	if header.Get("traceparent") == "" {
+		t.Fatal("expected the span context to be propagated in the traceparent header")
+	}
+	_, child := StartWithKind(Extract(context.Background(), header), trace.SpanKindServer, "child", attribute.String("key", "value"))
+	child.SetStatus(codes.Error, "failed")
+	child.End()
+	parent.End()
+	Shutdown()
+
+	spans := readSpans(t, path)
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %v", spans)
+	}
+	if spans["child"].SpanContext.TraceID != spans["parent"].SpanContext.TraceID || spans["child"].Parent.SpanID != spans["parent"].SpanContext.SpanID {
+		t.Errorf("expected the child span to be in the trace of its parent, got %v", spans)
+	}
+	exported := spans["child"]
+	if exported.Status.Code != "Error" || exported.SpanKind != trace.SpanKindServer || exported.Attributes[0].Value.Value != "value" {
+		t.Errorf("unexpected child span %v", exported)
+	}
+}
+
+func TestSampling(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.json")
+	never := 0.0
+	if err := Init(&conf.TracingDefinition{Exporter: "file", Path: path, SampleRatio: &never}, "test"); err != nil {
+		t.Fatal(err)
+	}
+	_, span := Start(context.Background(), "unsampled")
+	span.End()
+	// a span sampled by the caller is recorded regardless of the ratio
+	header := http.Header{}
+	header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	_, span = Start(Extract(context.Background(), header), "sampled")
+	span.End()
+	Shutdown()
+
+	spans := readSpans(t, path)
+	if _, exist := spans["unsampled"]; exist || len(spans) != 1 {
+		t.Errorf("expected only the sampled span, got %v", spans)
+	}
+	if spans["sampled"].SpanContext.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("expected the span in the trace of the caller, got %v", spans["sampled"])
+	}
+}